@@ -0,0 +1,100 @@
+// Copyright 2022 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// swagger:route GET /audit audit ListAuditEntries
+//
+// List audit log entries, oldest first.
+//
+//	Parameters:
+//	  + name: limit
+//	    description: Maximum number of results to return. If not set, all results are returned.
+//	    type: integer
+//	    in: query
+//	    required: false
+//
+//	  + name: cursor
+//	    description: Cursor returned in the X-Next-Cursor header of a previous response. Results start after the last item of that page.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: userID
+//	    description: Only return changes made by this user.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: action
+//	    description: Only return entries with this action (create, update, delete or quarantine).
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: entityType
+//	    description: Only return changes made to this type of entity.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: entityID
+//	    description: Only return changes made to the entity with this ID.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: createdAfter
+//	    description: Only return entries recorded at or after this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: createdBefore
+//	    description: Only return entries recorded before this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	Responses:
+//	  200: AuditEntriesPage
+//	  400: APIErrorResponse
+func (a *APIController) ListAuditEntriesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, err := auditFilterFromQuery(r.URL.Query())
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	entries, next, err := a.r.ListAuditEntries(ctx, filter)
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	setNextCursor(w, next)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
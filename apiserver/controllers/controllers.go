@@ -202,6 +202,41 @@ func (a *APIController) WSHandler(writer http.ResponseWriter, req *http.Request)
 	client.Go()
 }
 
+// EventsHandler streams database change events over a websocket. The events
+// can be filtered with the entityType, operation and entityID query parameters.
+func (a *APIController) EventsHandler(writer http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	if !auth.IsAdmin(ctx) {
+		writer.WriteHeader(http.StatusForbidden)
+		if _, err := writer.Write([]byte("you need admin level access to view events")); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+		}
+		return
+	}
+
+	filters, err := eventFiltersFromQuery(req.URL.Query())
+	if err != nil {
+		handleError(ctx, writer, err)
+		return
+	}
+
+	conn, err := a.upgrader.Upgrade(writer, req, nil)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "error upgrading to websockets")
+		return
+	}
+
+	client, err := wsWriter.NewEventClient(ctx, conn, filters...)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to create new event client")
+		conn.Close()
+		return
+	}
+	// The request context is canceled when the handler returns, so we
+	// block until the client goes away.
+	client.Run()
+}
+
 // NotFoundHandler is returned when an invalid URL is acccessed
 func (a *APIController) NotFoundHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -354,23 +389,194 @@ func (a *APIController) ListProviders(w http.ResponseWriter, r *http.Request) {
 //
 // List all jobs.
 //
+//	Parameters:
+//	  + name: limit
+//	    description: Maximum number of results to return. If not set, all results are returned.
+//	    type: integer
+//	    in: query
+//	    required: false
+//
+//	  + name: cursor
+//	    description: Cursor returned in the X-Next-Cursor header of a previous response. Results start after the last item of that page.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: status
+//	    description: Only return jobs with this status.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: poolID
+//	    description: Only return jobs picked up by a runner from this pool.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: providerName
+//	    description: Only return jobs picked up by a runner from this provider.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: label
+//	    description: Only return jobs that requested this label.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: createdAfter
+//	    description: Only return results created at or after this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: createdBefore
+//	    description: Only return results created before this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: updatedAfter
+//	    description: Only return results updated at or after this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: updatedBefore
+//	    description: Only return results updated before this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
 //	Responses:
-//	  200: Jobs
+//	  200: JobsPage
 //	  400: APIErrorResponse
 func (a *APIController) ListAllJobs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	jobs, err := a.r.ListAllJobs(ctx)
+	filter, err := jobFilterFromQuery(r.URL.Query())
 	if err != nil {
 		handleError(ctx, w, err)
 		return
 	}
 
+	jobs, next, err := a.r.ListJobs(ctx, filter)
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	setNextCursor(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(jobs); err != nil {
 		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
 	}
 }
 
+// swagger:route GET /jobs/history jobs ListJobHistory
+//
+// List the job history.
+//
+//	Parameters:
+//	  + name: limit
+//	    description: Maximum number of results to return. If not set, all results are returned.
+//	    type: integer
+//	    in: query
+//	    required: false
+//
+//	  + name: cursor
+//	    description: Cursor returned in the X-Next-Cursor header of a previous response. Results start after the last item of that page.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: status
+//	    description: Only return jobs with this status.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: conclusion
+//	    description: Only return jobs with this conclusion.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: poolID
+//	    description: Only return jobs picked up by a runner from this pool.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: providerName
+//	    description: Only return jobs picked up by a runner from this provider.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: label
+//	    description: Only return jobs that requested this label.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: createdAfter
+//	    description: Only return jobs first recorded at or after this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: createdBefore
+//	    description: Only return jobs first recorded before this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: updatedAfter
+//	    description: Only return results updated at or after this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: updatedBefore
+//	    description: Only return results updated before this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	Responses:
+//	  200: JobHistoryPage
+//	  400: APIErrorResponse
+func (a *APIController) ListJobHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, err := jobHistoryFilterFromQuery(r.URL.Query())
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	history, next, err := a.r.ListJobHistory(ctx, filter)
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	setNextCursor(w, next)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
 // swagger:route GET /controller-info controllerInfo ControllerInfo
 //
 // Get controller info.
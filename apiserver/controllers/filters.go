@@ -0,0 +1,256 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package controllers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	gErrors "github.com/cloudbase/garm-provider-common/errors"
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/database/watcher"
+	runnerParams "github.com/cloudbase/garm/params"
+)
+
+// nextCursorHeader is set on paginated list responses when another page
+// is available. Its value must be passed as the cursor query parameter
+// to fetch the next page.
+const nextCursorHeader = "X-Next-Cursor"
+
+func setNextCursor(w http.ResponseWriter, next string) {
+	if next != "" {
+		w.Header().Set(nextCursorHeader, next)
+	}
+}
+
+func listParamsFromQuery(q url.Values) (runnerParams.ListParams, error) {
+	ret := runnerParams.ListParams{
+		Cursor: q.Get("cursor"),
+	}
+	if limit := q.Get("limit"); limit != "" {
+		val, err := strconv.ParseUint(limit, 10, 32)
+		if err != nil {
+			return runnerParams.ListParams{}, gErrors.NewBadRequestError("invalid limit: %q", limit)
+		}
+		ret.Limit = uint(val)
+	}
+	return ret, nil
+}
+
+func timeFromQuery(q url.Values, name string) (*time.Time, error) {
+	val := q.Get(name)
+	if val == "" {
+		return nil, nil
+	}
+	ret, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, gErrors.NewBadRequestError("invalid %s (expected RFC3339): %q", name, val)
+	}
+	return &ret, nil
+}
+
+func timeRangeFromQuery(q url.Values) (runnerParams.TimeRangeFilter, error) {
+	var ret runnerParams.TimeRangeFilter
+	var err error
+	for name, dst := range map[string]**time.Time{
+		"createdAfter":  &ret.CreatedAfter,
+		"createdBefore": &ret.CreatedBefore,
+		"updatedAfter":  &ret.UpdatedAfter,
+		"updatedBefore": &ret.UpdatedBefore,
+	} {
+		if *dst, err = timeFromQuery(q, name); err != nil {
+			return runnerParams.TimeRangeFilter{}, err
+		}
+	}
+	return ret, nil
+}
+
+func instanceFilterFromQuery(q url.Values) (runnerParams.InstanceFilter, error) {
+	listParams, err := listParamsFromQuery(q)
+	if err != nil {
+		return runnerParams.InstanceFilter{}, err
+	}
+	timeRange, err := timeRangeFromQuery(q)
+	if err != nil {
+		return runnerParams.InstanceFilter{}, err
+	}
+	return runnerParams.InstanceFilter{
+		ListParams:      listParams,
+		TimeRangeFilter: timeRange,
+		Status:          commonParams.InstanceStatus(q.Get("status")),
+		RunnerStatus:    runnerParams.RunnerStatus(q.Get("runnerStatus")),
+		PoolID:          q.Get("poolID"),
+		ProviderName:    q.Get("providerName"),
+		Label:           q.Get("label"),
+	}, nil
+}
+
+func poolFilterFromQuery(q url.Values) (runnerParams.PoolFilter, error) {
+	listParams, err := listParamsFromQuery(q)
+	if err != nil {
+		return runnerParams.PoolFilter{}, err
+	}
+	timeRange, err := timeRangeFromQuery(q)
+	if err != nil {
+		return runnerParams.PoolFilter{}, err
+	}
+	ret := runnerParams.PoolFilter{
+		ListParams:      listParams,
+		TimeRangeFilter: timeRange,
+		ProviderName:    q.Get("providerName"),
+		Label:           q.Get("label"),
+	}
+	if enabled := q.Get("enabled"); enabled != "" {
+		val, err := strconv.ParseBool(enabled)
+		if err != nil {
+			return runnerParams.PoolFilter{}, gErrors.NewBadRequestError("invalid enabled: %q", enabled)
+		}
+		ret.Enabled = &val
+	}
+	return ret, nil
+}
+
+func jobHistoryFilterFromQuery(q url.Values) (runnerParams.JobHistoryFilter, error) {
+	listParams, err := listParamsFromQuery(q)
+	if err != nil {
+		return runnerParams.JobHistoryFilter{}, err
+	}
+	timeRange, err := timeRangeFromQuery(q)
+	if err != nil {
+		return runnerParams.JobHistoryFilter{}, err
+	}
+	return runnerParams.JobHistoryFilter{
+		ListParams:      listParams,
+		TimeRangeFilter: timeRange,
+		Status:          runnerParams.JobStatus(q.Get("status")),
+		Conclusion:      q.Get("conclusion"),
+		PoolID:          q.Get("poolID"),
+		ProviderName:    q.Get("providerName"),
+		Label:           q.Get("label"),
+	}, nil
+}
+
+func auditFilterFromQuery(q url.Values) (runnerParams.AuditFilter, error) {
+	listParams, err := listParamsFromQuery(q)
+	if err != nil {
+		return runnerParams.AuditFilter{}, err
+	}
+	timeRange, err := timeRangeFromQuery(q)
+	if err != nil {
+		return runnerParams.AuditFilter{}, err
+	}
+	return runnerParams.AuditFilter{
+		ListParams:      listParams,
+		TimeRangeFilter: timeRange,
+		UserID:          q.Get("userID"),
+		Action:          runnerParams.AuditAction(q.Get("action")),
+		EntityType:      q.Get("entityType"),
+		EntityID:        q.Get("entityID"),
+	}, nil
+}
+
+func jobFilterFromQuery(q url.Values) (runnerParams.JobFilter, error) {
+	listParams, err := listParamsFromQuery(q)
+	if err != nil {
+		return runnerParams.JobFilter{}, err
+	}
+	timeRange, err := timeRangeFromQuery(q)
+	if err != nil {
+		return runnerParams.JobFilter{}, err
+	}
+	return runnerParams.JobFilter{
+		ListParams:      listParams,
+		TimeRangeFilter: timeRange,
+		Status:          runnerParams.JobStatus(q.Get("status")),
+		PoolID:          q.Get("poolID"),
+		ProviderName:    q.Get("providerName"),
+		Label:           q.Get("label"),
+	}, nil
+}
+
+var eventEntityTypes = map[dbCommon.DatabaseEntityType]bool{
+	dbCommon.RepositoryEntityType:        true,
+	dbCommon.OrganizationEntityType:      true,
+	dbCommon.EnterpriseEntityType:        true,
+	dbCommon.PoolEntityType:              true,
+	dbCommon.UserEntityType:              true,
+	dbCommon.InstanceEntityType:          true,
+	dbCommon.JobEntityType:               true,
+	dbCommon.ControllerEntityType:        true,
+	dbCommon.GithubCredentialsEntityType: true,
+	dbCommon.GithubEndpointEntityType:    true,
+	dbCommon.NotificationSinkEntityType:  true,
+	dbCommon.NotificationEventEntityType: true,
+}
+
+var eventOperations = map[dbCommon.OperationType]bool{
+	dbCommon.CreateOperation: true,
+	dbCommon.UpdateOperation: true,
+	dbCommon.DeleteOperation: true,
+}
+
+// queryList returns the values of a query parameter that may be repeated,
+// or hold a comma separated list, or both.
+func queryList(q url.Values, name string) []string {
+	var ret []string
+	for _, val := range q[name] {
+		for _, item := range strings.Split(val, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				ret = append(ret, item)
+			}
+		}
+	}
+	return ret
+}
+
+// eventFiltersFromQuery returns the watcher filters for the event stream. An
+// event must match one of the entity types, one of the operations and the
+// entity ID, if set.
+func eventFiltersFromQuery(q url.Values) ([]dbCommon.PayloadFilterFunc, error) {
+	var filters []dbCommon.PayloadFilterFunc
+
+	if entityTypes := queryList(q, "entityType"); len(entityTypes) > 0 {
+		typeFilters := make([]dbCommon.PayloadFilterFunc, 0, len(entityTypes))
+		for _, val := range entityTypes {
+			entityType := dbCommon.DatabaseEntityType(val)
+			if !eventEntityTypes[entityType] {
+				return nil, gErrors.NewBadRequestError("invalid entityType: %q", val)
+			}
+			typeFilters = append(typeFilters, watcher.WithEntityTypeFilter(entityType))
+		}
+		filters = append(filters, watcher.WithAny(typeFilters...))
+	}
+
+	if operations := queryList(q, "operation"); len(operations) > 0 {
+		opFilters := make([]dbCommon.PayloadFilterFunc, 0, len(operations))
+		for _, val := range operations {
+			operation := dbCommon.OperationType(val)
+			if !eventOperations[operation] {
+				return nil, gErrors.NewBadRequestError("invalid operation: %q", val)
+			}
+			opFilters = append(opFilters, watcher.WithOperationTypeFilter(operation))
+		}
+		filters = append(filters, watcher.WithAny(opFilters...))
+	}
+
+	if entityID := q.Get("entityID"); entityID != "" {
+		filters = append(filters, watcher.WithEntityIDFilter(entityID))
+	}
+	return filters, nil
+}
@@ -294,19 +294,97 @@ func (a *APIController) ListEnterpriseInstancesHandler(w http.ResponseWriter, r
 //
 // Get all runners' instances.
 //
+//	Parameters:
+//	  + name: limit
+//	    description: Maximum number of results to return. If not set, all results are returned.
+//	    type: integer
+//	    in: query
+//	    required: false
+//
+//	  + name: cursor
+//	    description: Cursor returned in the X-Next-Cursor header of a previous response. Results start after the last item of that page.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: status
+//	    description: Only return instances with this status.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: runnerStatus
+//	    description: Only return instances with this runner status.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: poolID
+//	    description: Only return instances belonging to this pool.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: providerName
+//	    description: Only return instances created by this provider.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: label
+//	    description: Only return instances belonging to pools that have this label.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: createdAfter
+//	    description: Only return results created at or after this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: createdBefore
+//	    description: Only return results created before this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: updatedAfter
+//	    description: Only return results updated at or after this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: updatedBefore
+//	    description: Only return results updated before this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
 //	Responses:
-//	  200: Instances
+//	  200: InstancesPage
 //	  default: APIErrorResponse
 func (a *APIController) ListAllInstancesHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	instances, err := a.r.ListAllInstances(ctx)
+	filter, err := instanceFilterFromQuery(r.URL.Query())
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	instances, next, err := a.r.ListInstances(ctx, filter)
 	if err != nil {
 		slog.With(slog.Any("error", err)).ErrorContext(ctx, "listing instances")
 		handleError(ctx, w, err)
 		return
 	}
 
+	setNextCursor(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(instances); err != nil {
 		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
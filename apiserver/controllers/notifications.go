@@ -0,0 +1,240 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	gErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/params"
+)
+
+// sinkIDFromRequest returns the notification sink ID from the request path.
+func sinkIDFromRequest(r *http.Request) (uint, error) {
+	idParam, ok := mux.Vars(r)["sinkID"]
+	if !ok {
+		return 0, gErrors.NewBadRequestError("missing sink ID")
+	}
+	id, err := strconv.ParseUint(idParam, 10, strconv.IntSize)
+	if err != nil {
+		return 0, gErrors.NewBadRequestError("invalid sink ID: %q", idParam)
+	}
+	return uint(id), nil
+}
+
+// swagger:route GET /notifications/sinks notifications ListNotificationSinks
+//
+// List notification sinks. Sinks defined in the config file are listed first.
+//
+//	Responses:
+//	  200: NotificationSinks
+//	  400: APIErrorResponse
+func (a *APIController) ListNotificationSinksHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sinks, err := a.r.ListNotificationSinks(ctx)
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sinks); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route POST /notifications/sinks notifications CreateNotificationSink
+//
+// Create a notification sink.
+//
+//	Parameters:
+//	  + name: Body
+//	    description: Parameters used when creating a notification sink.
+//	    type: CreateNotificationSinkParams
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  200: NotificationSink
+//	  400: APIErrorResponse
+func (a *APIController) CreateNotificationSinkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var param params.CreateNotificationSinkParams
+	if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	sink, err := a.r.CreateNotificationSink(ctx, param)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to create notification sink")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sink); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route GET /notifications/sinks/{sinkID} notifications GetNotificationSink
+//
+// Get a notification sink.
+//
+//	Parameters:
+//	  + name: sinkID
+//	    description: ID of the notification sink.
+//	    type: integer
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  200: NotificationSink
+//	  400: APIErrorResponse
+func (a *APIController) GetNotificationSinkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sinkID, err := sinkIDFromRequest(r)
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	sink, err := a.r.GetNotificationSink(ctx, sinkID)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to get notification sink")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sink); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route PUT /notifications/sinks/{sinkID} notifications UpdateNotificationSink
+//
+// Update a notification sink.
+//
+//	Parameters:
+//	  + name: sinkID
+//	    description: ID of the notification sink.
+//	    type: integer
+//	    in: path
+//	    required: true
+//	  + name: Body
+//	    description: Parameters used when updating a notification sink.
+//	    type: UpdateNotificationSinkParams
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  200: NotificationSink
+//	  400: APIErrorResponse
+func (a *APIController) UpdateNotificationSinkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sinkID, err := sinkIDFromRequest(r)
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	var param params.UpdateNotificationSinkParams
+	if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	sink, err := a.r.UpdateNotificationSink(ctx, sinkID, param)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to update notification sink")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sink); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route DELETE /notifications/sinks/{sinkID} notifications DeleteNotificationSink
+//
+// Delete a notification sink.
+//
+//	Parameters:
+//	  + name: sinkID
+//	    description: ID of the notification sink.
+//	    type: integer
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  default: APIErrorResponse
+func (a *APIController) DeleteNotificationSinkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sinkID, err := sinkIDFromRequest(r)
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	if err := a.r.DeleteNotificationSink(ctx, sinkID); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to delete notification sink")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// swagger:route POST /notifications/sinks/{sinkID}/test notifications TestNotificationSink
+//
+// Send a test notification to a sink.
+//
+//	Parameters:
+//	  + name: sinkID
+//	    description: ID of the notification sink.
+//	    type: integer
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  default: APIErrorResponse
+func (a *APIController) TestNotificationSinkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sinkID, err := sinkIDFromRequest(r)
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	if err := a.r.TestNotificationSink(ctx, sinkID); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to send test notification")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -30,19 +30,85 @@ import (
 //
 // List all pools.
 //
+//	Parameters:
+//	  + name: limit
+//	    description: Maximum number of results to return. If not set, all results are returned.
+//	    type: integer
+//	    in: query
+//	    required: false
+//
+//	  + name: cursor
+//	    description: Cursor returned in the X-Next-Cursor header of a previous response. Results start after the last item of that page.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: providerName
+//	    description: Only return pools using this provider.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: label
+//	    description: Only return pools that have this label.
+//	    type: string
+//	    in: query
+//	    required: false
+//
+//	  + name: enabled
+//	    description: Only return enabled (true) or disabled (false) pools.
+//	    type: boolean
+//	    in: query
+//	    required: false
+//
+//	  + name: createdAfter
+//	    description: Only return results created at or after this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: createdBefore
+//	    description: Only return results created before this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: updatedAfter
+//	    description: Only return results updated at or after this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
+//	  + name: updatedBefore
+//	    description: Only return results updated before this time (RFC3339).
+//	    type: string
+//	    format: date-time
+//	    in: query
+//	    required: false
+//
 //	Responses:
-//	  200: Pools
+//	  200: PoolsPage
 //	  default: APIErrorResponse
 func (a *APIController) ListAllPoolsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	pools, err := a.r.ListAllPools(ctx)
+	filter, err := poolFilterFromQuery(r.URL.Query())
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	pools, next, err := a.r.ListPools(ctx, filter)
 	if err != nil {
 		slog.With(slog.Any("error", err)).ErrorContext(ctx, "listing pools")
 		handleError(ctx, w, err)
 		return
 	}
 
+	setNextCursor(w, next)
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(pools); err != nil {
 		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
@@ -188,3 +254,46 @@ func (a *APIController) UpdatePoolByIDHandler(w http.ResponseWriter, r *http.Req
 		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
 	}
 }
+
+// swagger:route DELETE /pools/{poolID}/quarantine pools ReleasePoolQuarantine
+//
+// Release a pool from quarantine and enable it again.
+//
+//	Parameters:
+//	  + name: poolID
+//	    description: ID of the quarantined pool.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  200: Pool
+//	  default: APIErrorResponse
+func (a *APIController) ReleasePoolQuarantineHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	poolID, ok := vars["poolID"]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		if err := json.NewEncoder(w).Encode(params.APIErrorResponse{
+			Error:   "Bad Request",
+			Details: "No pool ID specified",
+		}); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+		}
+		return
+	}
+
+	pool, err := a.r.ReleasePoolQuarantine(ctx, poolID)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "releasing pool quarantine")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(pool); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
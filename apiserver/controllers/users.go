@@ -0,0 +1,184 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	gErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/params"
+)
+
+// swagger:route GET /users users ListUsers
+//
+// List users.
+//
+//	Responses:
+//	  200: Users
+//	  400: APIErrorResponse
+func (a *APIController) ListUsersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	users, err := a.r.ListUsers(ctx)
+	if err != nil {
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(users); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route POST /users users CreateUser
+//
+// Create a user.
+//
+//	Parameters:
+//	  + name: Body
+//	    description: Parameters used when creating a user.
+//	    type: NewUserParams
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  200: User
+//	  400: APIErrorResponse
+func (a *APIController) CreateUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var param params.NewUserParams
+	if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	user, err := a.r.CreateUser(ctx, param)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to create user")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route GET /users/{username} users GetUser
+//
+// Get a user.
+//
+//	Parameters:
+//	  + name: username
+//	    description: Username or email of the user.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  200: User
+//	  400: APIErrorResponse
+func (a *APIController) GetUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	username := mux.Vars(r)["username"]
+
+	user, err := a.r.GetUser(ctx, username)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to get user")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route PUT /users/{username} users UpdateUser
+//
+// Update a user.
+//
+//	Parameters:
+//	  + name: username
+//	    description: Username or email of the user.
+//	    type: string
+//	    in: path
+//	    required: true
+//	  + name: Body
+//	    description: Parameters used when updating a user.
+//	    type: UpdateUserParams
+//	    in: body
+//	    required: true
+//
+//	Responses:
+//	  200: User
+//	  400: APIErrorResponse
+func (a *APIController) UpdateUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	username := mux.Vars(r)["username"]
+
+	var param params.UpdateUserParams
+	if err := json.NewDecoder(r.Body).Decode(&param); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to decode request")
+		handleError(ctx, w, gErrors.ErrBadRequest)
+		return
+	}
+
+	user, err := a.r.UpdateUser(ctx, username, param)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to update user")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+	}
+}
+
+// swagger:route DELETE /users/{username} users DeleteUser
+//
+// Delete a user.
+//
+//	Parameters:
+//	  + name: username
+//	    description: Username or email of the user.
+//	    type: string
+//	    in: path
+//	    required: true
+//
+//	Responses:
+//	  default: APIErrorResponse
+func (a *APIController) DeleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	username := mux.Vars(r)["username"]
+
+	if err := a.r.DeleteUser(ctx, username); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to delete user")
+		handleError(ctx, w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
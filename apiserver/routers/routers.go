@@ -57,6 +57,7 @@ import (
 
 	"github.com/cloudbase/garm/apiserver/controllers"
 	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/params"
 )
 
 func WithMetricsRouter(parentRouter *mux.Router, disableAuth bool, metricsMiddlerware auth.Middleware) *mux.Router {
@@ -100,9 +101,16 @@ func requestLogger(h http.Handler) http.Handler {
 	})
 }
 
+// withRole returns a handler that only serves requests from users with a role
+// that includes the given role.
+func withRole(role params.UserRole, handler http.HandlerFunc) http.Handler {
+	return auth.RoleRequiredMiddleware(role)(handler)
+}
+
 func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware, urlsRequiredMiddleware, instanceMiddleware auth.Middleware, manageWebhooks bool) *mux.Router {
 	router := mux.NewRouter()
 	router.Use(requestLogger)
+	router.Use(auth.SourceIPMiddleware)
 
 	// Handles github webhooks
 	webhookRouter := router.PathPrefix("/webhooks").Subrouter()
@@ -161,13 +169,13 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 	// updating the URLs.
 	controllerRouter.Use(initMiddleware.Middleware)
 	controllerRouter.Use(authMiddleware.Middleware)
-	controllerRouter.Use(auth.AdminRequiredMiddleware)
+	controllerRouter.Use(auth.RoleRequiredMiddleware(params.UserRoleViewer))
 	// Get controller info
-	controllerRouter.Handle("/", http.HandlerFunc(han.ControllerInfoHandler)).Methods("GET", "OPTIONS")
-	controllerRouter.Handle("", http.HandlerFunc(han.ControllerInfoHandler)).Methods("GET", "OPTIONS")
+	controllerRouter.Handle("/", withRole(params.UserRoleViewer, han.ControllerInfoHandler)).Methods("GET", "OPTIONS")
+	controllerRouter.Handle("", withRole(params.UserRoleViewer, han.ControllerInfoHandler)).Methods("GET", "OPTIONS")
 	// Update controller
-	controllerRouter.Handle("/", http.HandlerFunc(han.UpdateControllerHandler)).Methods("PUT", "OPTIONS")
-	controllerRouter.Handle("", http.HandlerFunc(han.UpdateControllerHandler)).Methods("PUT", "OPTIONS")
+	controllerRouter.Handle("/", withRole(params.UserRoleAdmin, han.UpdateControllerHandler)).Methods("PUT", "OPTIONS")
+	controllerRouter.Handle("", withRole(params.UserRoleAdmin, han.UpdateControllerHandler)).Methods("PUT", "OPTIONS")
 
 	////////////////////////////////////
 	// API router for everything else //
@@ -178,241 +186,297 @@ func NewAPIRouter(han *controllers.APIController, authMiddleware, initMiddleware
 	// if the required metadata, callback and webhook URLs are not set.
 	apiRouter.Use(urlsRequiredMiddleware.Middleware)
 	apiRouter.Use(authMiddleware.Middleware)
-	apiRouter.Use(auth.AdminRequiredMiddleware)
+	// All users need at least the viewer role. The role needed by each
+	// route is set on the route itself.
+	apiRouter.Use(auth.RoleRequiredMiddleware(params.UserRoleViewer))
 
 	// Legacy controller path
-	apiRouter.Handle("/controller-info/", http.HandlerFunc(han.ControllerInfoHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/controller-info", http.HandlerFunc(han.ControllerInfoHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/controller-info/", withRole(params.UserRoleViewer, han.ControllerInfoHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/controller-info", withRole(params.UserRoleViewer, han.ControllerInfoHandler)).Methods("GET", "OPTIONS")
 
 	// Metrics Token
-	apiRouter.Handle("/metrics-token/", http.HandlerFunc(han.MetricsTokenHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/metrics-token", http.HandlerFunc(han.MetricsTokenHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/metrics-token/", withRole(params.UserRoleAdmin, han.MetricsTokenHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/metrics-token", withRole(params.UserRoleAdmin, han.MetricsTokenHandler)).Methods("GET", "OPTIONS")
 
 	//////////
 	// Jobs //
 	//////////
+	// List audit log entries
+	apiRouter.Handle("/audit/", withRole(params.UserRoleAdmin, han.ListAuditEntriesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/audit", withRole(params.UserRoleAdmin, han.ListAuditEntriesHandler)).Methods("GET", "OPTIONS")
+
+	////////////////////////
+	// Notification sinks //
+	////////////////////////
+	// List notification sinks
+	apiRouter.Handle("/notifications/sinks/", withRole(params.UserRoleAdmin, han.ListNotificationSinksHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/notifications/sinks", withRole(params.UserRoleAdmin, han.ListNotificationSinksHandler)).Methods("GET", "OPTIONS")
+	// Create notification sink
+	apiRouter.Handle("/notifications/sinks/", withRole(params.UserRoleAdmin, han.CreateNotificationSinkHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/notifications/sinks", withRole(params.UserRoleAdmin, han.CreateNotificationSinkHandler)).Methods("POST", "OPTIONS")
+	// Get notification sink
+	apiRouter.Handle("/notifications/sinks/{sinkID}/", withRole(params.UserRoleAdmin, han.GetNotificationSinkHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/notifications/sinks/{sinkID}", withRole(params.UserRoleAdmin, han.GetNotificationSinkHandler)).Methods("GET", "OPTIONS")
+	// Update notification sink
+	apiRouter.Handle("/notifications/sinks/{sinkID}/", withRole(params.UserRoleAdmin, han.UpdateNotificationSinkHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/notifications/sinks/{sinkID}", withRole(params.UserRoleAdmin, han.UpdateNotificationSinkHandler)).Methods("PUT", "OPTIONS")
+	// Delete notification sink
+	apiRouter.Handle("/notifications/sinks/{sinkID}/", withRole(params.UserRoleAdmin, han.DeleteNotificationSinkHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/notifications/sinks/{sinkID}", withRole(params.UserRoleAdmin, han.DeleteNotificationSinkHandler)).Methods("DELETE", "OPTIONS")
+	// Send a test notification
+	apiRouter.Handle("/notifications/sinks/{sinkID}/test/", withRole(params.UserRoleAdmin, han.TestNotificationSinkHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/notifications/sinks/{sinkID}/test", withRole(params.UserRoleAdmin, han.TestNotificationSinkHandler)).Methods("POST", "OPTIONS")
+
+	// List job history
+	apiRouter.Handle("/jobs/history/", withRole(params.UserRoleViewer, han.ListJobHistoryHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/jobs/history", withRole(params.UserRoleViewer, han.ListJobHistoryHandler)).Methods("GET", "OPTIONS")
 	// List all jobs
-	apiRouter.Handle("/jobs/", http.HandlerFunc(han.ListAllJobs)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/jobs", http.HandlerFunc(han.ListAllJobs)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/jobs/", withRole(params.UserRoleViewer, han.ListAllJobs)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/jobs", withRole(params.UserRoleViewer, han.ListAllJobs)).Methods("GET", "OPTIONS")
 
 	///////////
 	// Pools //
 	///////////
 	// List all pools
-	apiRouter.Handle("/pools/", http.HandlerFunc(han.ListAllPoolsHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/pools", http.HandlerFunc(han.ListAllPoolsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/pools/", withRole(params.UserRoleViewer, han.ListAllPoolsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/pools", withRole(params.UserRoleViewer, han.ListAllPoolsHandler)).Methods("GET", "OPTIONS")
 	// Get one pool
-	apiRouter.Handle("/pools/{poolID}/", http.HandlerFunc(han.GetPoolByIDHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/pools/{poolID}", http.HandlerFunc(han.GetPoolByIDHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}/", withRole(params.UserRoleViewer, han.GetPoolByIDHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}", withRole(params.UserRoleViewer, han.GetPoolByIDHandler)).Methods("GET", "OPTIONS")
 	// Delete one pool
-	apiRouter.Handle("/pools/{poolID}/", http.HandlerFunc(han.DeletePoolByIDHandler)).Methods("DELETE", "OPTIONS")
-	apiRouter.Handle("/pools/{poolID}", http.HandlerFunc(han.DeletePoolByIDHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}/", withRole(params.UserRoleAdmin, han.DeletePoolByIDHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}", withRole(params.UserRoleAdmin, han.DeletePoolByIDHandler)).Methods("DELETE", "OPTIONS")
 	// Update one pool
-	apiRouter.Handle("/pools/{poolID}/", http.HandlerFunc(han.UpdatePoolByIDHandler)).Methods("PUT", "OPTIONS")
-	apiRouter.Handle("/pools/{poolID}", http.HandlerFunc(han.UpdatePoolByIDHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}/", withRole(params.UserRoleOperator, han.UpdatePoolByIDHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}", withRole(params.UserRoleOperator, han.UpdatePoolByIDHandler)).Methods("PUT", "OPTIONS")
+	// Release pool quarantine
+	apiRouter.Handle("/pools/{poolID}/quarantine/", withRole(params.UserRoleOperator, han.ReleasePoolQuarantineHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}/quarantine", withRole(params.UserRoleOperator, han.ReleasePoolQuarantineHandler)).Methods("DELETE", "OPTIONS")
 	// List pool instances
-	apiRouter.Handle("/pools/{poolID}/instances/", http.HandlerFunc(han.ListPoolInstancesHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/pools/{poolID}/instances", http.HandlerFunc(han.ListPoolInstancesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}/instances/", withRole(params.UserRoleViewer, han.ListPoolInstancesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/pools/{poolID}/instances", withRole(params.UserRoleViewer, han.ListPoolInstancesHandler)).Methods("GET", "OPTIONS")
 
 	/////////////
 	// Runners //
 	/////////////
 	// Get instance
-	apiRouter.Handle("/instances/{instanceName}/", http.HandlerFunc(han.GetInstanceHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/instances/{instanceName}", http.HandlerFunc(han.GetInstanceHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/instances/{instanceName}/", withRole(params.UserRoleViewer, han.GetInstanceHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/instances/{instanceName}", withRole(params.UserRoleViewer, han.GetInstanceHandler)).Methods("GET", "OPTIONS")
 	// Delete runner
-	apiRouter.Handle("/instances/{instanceName}/", http.HandlerFunc(han.DeleteInstanceHandler)).Methods("DELETE", "OPTIONS")
-	apiRouter.Handle("/instances/{instanceName}", http.HandlerFunc(han.DeleteInstanceHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/instances/{instanceName}/", withRole(params.UserRoleOperator, han.DeleteInstanceHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/instances/{instanceName}", withRole(params.UserRoleOperator, han.DeleteInstanceHandler)).Methods("DELETE", "OPTIONS")
 	// List runners
-	apiRouter.Handle("/instances/", http.HandlerFunc(han.ListAllInstancesHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/instances", http.HandlerFunc(han.ListAllInstancesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/instances/", withRole(params.UserRoleViewer, han.ListAllInstancesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/instances", withRole(params.UserRoleViewer, han.ListAllInstancesHandler)).Methods("GET", "OPTIONS")
 
 	/////////////////////
 	// Repos and pools //
 	/////////////////////
 	// Get pool
-	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}/", http.HandlerFunc(han.GetRepoPoolHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}", http.HandlerFunc(han.GetRepoPoolHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}/", withRole(params.UserRoleViewer, han.GetRepoPoolHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}", withRole(params.UserRoleViewer, han.GetRepoPoolHandler)).Methods("GET", "OPTIONS")
 	// Delete pool
-	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}/", http.HandlerFunc(han.DeleteRepoPoolHandler)).Methods("DELETE", "OPTIONS")
-	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}", http.HandlerFunc(han.DeleteRepoPoolHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}/", withRole(params.UserRoleAdmin, han.DeleteRepoPoolHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}", withRole(params.UserRoleAdmin, han.DeleteRepoPoolHandler)).Methods("DELETE", "OPTIONS")
 	// Update pool
-	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}/", http.HandlerFunc(han.UpdateRepoPoolHandler)).Methods("PUT", "OPTIONS")
-	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}", http.HandlerFunc(han.UpdateRepoPoolHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}/", withRole(params.UserRoleOperator, han.UpdateRepoPoolHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/pools/{poolID}", withRole(params.UserRoleOperator, han.UpdateRepoPoolHandler)).Methods("PUT", "OPTIONS")
 	// List pools
-	apiRouter.Handle("/repositories/{repoID}/pools/", http.HandlerFunc(han.ListRepoPoolsHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/repositories/{repoID}/pools", http.HandlerFunc(han.ListRepoPoolsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/pools/", withRole(params.UserRoleViewer, han.ListRepoPoolsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/pools", withRole(params.UserRoleViewer, han.ListRepoPoolsHandler)).Methods("GET", "OPTIONS")
 	// Create pool
-	apiRouter.Handle("/repositories/{repoID}/pools/", http.HandlerFunc(han.CreateRepoPoolHandler)).Methods("POST", "OPTIONS")
-	apiRouter.Handle("/repositories/{repoID}/pools", http.HandlerFunc(han.CreateRepoPoolHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/pools/", withRole(params.UserRoleAdmin, han.CreateRepoPoolHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/pools", withRole(params.UserRoleAdmin, han.CreateRepoPoolHandler)).Methods("POST", "OPTIONS")
 
 	// Repo instances list
-	apiRouter.Handle("/repositories/{repoID}/instances/", http.HandlerFunc(han.ListRepoInstancesHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/repositories/{repoID}/instances", http.HandlerFunc(han.ListRepoInstancesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/instances/", withRole(params.UserRoleViewer, han.ListRepoInstancesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/instances", withRole(params.UserRoleViewer, han.ListRepoInstancesHandler)).Methods("GET", "OPTIONS")
 
 	// Get repo
-	apiRouter.Handle("/repositories/{repoID}/", http.HandlerFunc(han.GetRepoByIDHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/repositories/{repoID}", http.HandlerFunc(han.GetRepoByIDHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/", withRole(params.UserRoleViewer, han.GetRepoByIDHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}", withRole(params.UserRoleViewer, han.GetRepoByIDHandler)).Methods("GET", "OPTIONS")
 	// Update repo
-	apiRouter.Handle("/repositories/{repoID}/", http.HandlerFunc(han.UpdateRepoHandler)).Methods("PUT", "OPTIONS")
-	apiRouter.Handle("/repositories/{repoID}", http.HandlerFunc(han.UpdateRepoHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/", withRole(params.UserRoleAdmin, han.UpdateRepoHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}", withRole(params.UserRoleAdmin, han.UpdateRepoHandler)).Methods("PUT", "OPTIONS")
 	// Delete repo
-	apiRouter.Handle("/repositories/{repoID}/", http.HandlerFunc(han.DeleteRepoHandler)).Methods("DELETE", "OPTIONS")
-	apiRouter.Handle("/repositories/{repoID}", http.HandlerFunc(han.DeleteRepoHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}/", withRole(params.UserRoleAdmin, han.DeleteRepoHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/repositories/{repoID}", withRole(params.UserRoleAdmin, han.DeleteRepoHandler)).Methods("DELETE", "OPTIONS")
 	// List repos
-	apiRouter.Handle("/repositories/", http.HandlerFunc(han.ListReposHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/repositories", http.HandlerFunc(han.ListReposHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/repositories/", withRole(params.UserRoleViewer, han.ListReposHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/repositories", withRole(params.UserRoleViewer, han.ListReposHandler)).Methods("GET", "OPTIONS")
 	// Create repo
-	apiRouter.Handle("/repositories/", http.HandlerFunc(han.CreateRepoHandler)).Methods("POST", "OPTIONS")
-	apiRouter.Handle("/repositories", http.HandlerFunc(han.CreateRepoHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/repositories/", withRole(params.UserRoleAdmin, han.CreateRepoHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/repositories", withRole(params.UserRoleAdmin, han.CreateRepoHandler)).Methods("POST", "OPTIONS")
 
 	if manageWebhooks {
 		// Install Webhook
-		apiRouter.Handle("/repositories/{repoID}/webhook/", http.HandlerFunc(han.InstallRepoWebhookHandler)).Methods("POST", "OPTIONS")
-		apiRouter.Handle("/repositories/{repoID}/webhook", http.HandlerFunc(han.InstallRepoWebhookHandler)).Methods("POST", "OPTIONS")
+		apiRouter.Handle("/repositories/{repoID}/webhook/", withRole(params.UserRoleAdmin, han.InstallRepoWebhookHandler)).Methods("POST", "OPTIONS")
+		apiRouter.Handle("/repositories/{repoID}/webhook", withRole(params.UserRoleAdmin, han.InstallRepoWebhookHandler)).Methods("POST", "OPTIONS")
 		// Uninstall Webhook
-		apiRouter.Handle("/repositories/{repoID}/webhook/", http.HandlerFunc(han.UninstallRepoWebhookHandler)).Methods("DELETE", "OPTIONS")
-		apiRouter.Handle("/repositories/{repoID}/webhook", http.HandlerFunc(han.UninstallRepoWebhookHandler)).Methods("DELETE", "OPTIONS")
+		apiRouter.Handle("/repositories/{repoID}/webhook/", withRole(params.UserRoleAdmin, han.UninstallRepoWebhookHandler)).Methods("DELETE", "OPTIONS")
+		apiRouter.Handle("/repositories/{repoID}/webhook", withRole(params.UserRoleAdmin, han.UninstallRepoWebhookHandler)).Methods("DELETE", "OPTIONS")
 		// Get webhook info
-		apiRouter.Handle("/repositories/{repoID}/webhook/", http.HandlerFunc(han.GetRepoWebhookInfoHandler)).Methods("GET", "OPTIONS")
-		apiRouter.Handle("/repositories/{repoID}/webhook", http.HandlerFunc(han.GetRepoWebhookInfoHandler)).Methods("GET", "OPTIONS")
+		apiRouter.Handle("/repositories/{repoID}/webhook/", withRole(params.UserRoleViewer, han.GetRepoWebhookInfoHandler)).Methods("GET", "OPTIONS")
+		apiRouter.Handle("/repositories/{repoID}/webhook", withRole(params.UserRoleViewer, han.GetRepoWebhookInfoHandler)).Methods("GET", "OPTIONS")
 	}
 	/////////////////////////////
 	// Organizations and pools //
 	/////////////////////////////
 	// Get pool
-	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}/", http.HandlerFunc(han.GetOrgPoolHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}", http.HandlerFunc(han.GetOrgPoolHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}/", withRole(params.UserRoleViewer, han.GetOrgPoolHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}", withRole(params.UserRoleViewer, han.GetOrgPoolHandler)).Methods("GET", "OPTIONS")
 	// Delete pool
-	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}/", http.HandlerFunc(han.DeleteOrgPoolHandler)).Methods("DELETE", "OPTIONS")
-	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}", http.HandlerFunc(han.DeleteOrgPoolHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}/", withRole(params.UserRoleAdmin, han.DeleteOrgPoolHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}", withRole(params.UserRoleAdmin, han.DeleteOrgPoolHandler)).Methods("DELETE", "OPTIONS")
 	// Update pool
-	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}/", http.HandlerFunc(han.UpdateOrgPoolHandler)).Methods("PUT", "OPTIONS")
-	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}", http.HandlerFunc(han.UpdateOrgPoolHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}/", withRole(params.UserRoleOperator, han.UpdateOrgPoolHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/pools/{poolID}", withRole(params.UserRoleOperator, han.UpdateOrgPoolHandler)).Methods("PUT", "OPTIONS")
 	// List pools
-	apiRouter.Handle("/organizations/{orgID}/pools/", http.HandlerFunc(han.ListOrgPoolsHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/organizations/{orgID}/pools", http.HandlerFunc(han.ListOrgPoolsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/pools/", withRole(params.UserRoleViewer, han.ListOrgPoolsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/pools", withRole(params.UserRoleViewer, han.ListOrgPoolsHandler)).Methods("GET", "OPTIONS")
 	// Create pool
-	apiRouter.Handle("/organizations/{orgID}/pools/", http.HandlerFunc(han.CreateOrgPoolHandler)).Methods("POST", "OPTIONS")
-	apiRouter.Handle("/organizations/{orgID}/pools", http.HandlerFunc(han.CreateOrgPoolHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/pools/", withRole(params.UserRoleAdmin, han.CreateOrgPoolHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/pools", withRole(params.UserRoleAdmin, han.CreateOrgPoolHandler)).Methods("POST", "OPTIONS")
 
 	// Org instances list
-	apiRouter.Handle("/organizations/{orgID}/instances/", http.HandlerFunc(han.ListOrgInstancesHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/organizations/{orgID}/instances", http.HandlerFunc(han.ListOrgInstancesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/instances/", withRole(params.UserRoleViewer, han.ListOrgInstancesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/instances", withRole(params.UserRoleViewer, han.ListOrgInstancesHandler)).Methods("GET", "OPTIONS")
 
 	// Get org
-	apiRouter.Handle("/organizations/{orgID}/", http.HandlerFunc(han.GetOrgByIDHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/organizations/{orgID}", http.HandlerFunc(han.GetOrgByIDHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/", withRole(params.UserRoleViewer, han.GetOrgByIDHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}", withRole(params.UserRoleViewer, han.GetOrgByIDHandler)).Methods("GET", "OPTIONS")
 	// Update org
-	apiRouter.Handle("/organizations/{orgID}/", http.HandlerFunc(han.UpdateOrgHandler)).Methods("PUT", "OPTIONS")
-	apiRouter.Handle("/organizations/{orgID}", http.HandlerFunc(han.UpdateOrgHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/", withRole(params.UserRoleAdmin, han.UpdateOrgHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}", withRole(params.UserRoleAdmin, han.UpdateOrgHandler)).Methods("PUT", "OPTIONS")
 	// Delete org
-	apiRouter.Handle("/organizations/{orgID}/", http.HandlerFunc(han.DeleteOrgHandler)).Methods("DELETE", "OPTIONS")
-	apiRouter.Handle("/organizations/{orgID}", http.HandlerFunc(han.DeleteOrgHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}/", withRole(params.UserRoleAdmin, han.DeleteOrgHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/organizations/{orgID}", withRole(params.UserRoleAdmin, han.DeleteOrgHandler)).Methods("DELETE", "OPTIONS")
 	// List orgs
-	apiRouter.Handle("/organizations/", http.HandlerFunc(han.ListOrgsHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/organizations", http.HandlerFunc(han.ListOrgsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/organizations/", withRole(params.UserRoleViewer, han.ListOrgsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/organizations", withRole(params.UserRoleViewer, han.ListOrgsHandler)).Methods("GET", "OPTIONS")
 	// Create org
-	apiRouter.Handle("/organizations/", http.HandlerFunc(han.CreateOrgHandler)).Methods("POST", "OPTIONS")
-	apiRouter.Handle("/organizations", http.HandlerFunc(han.CreateOrgHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/organizations/", withRole(params.UserRoleAdmin, han.CreateOrgHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/organizations", withRole(params.UserRoleAdmin, han.CreateOrgHandler)).Methods("POST", "OPTIONS")
 
 	if manageWebhooks {
 		// Install Webhook
-		apiRouter.Handle("/organizations/{orgID}/webhook/", http.HandlerFunc(han.InstallOrgWebhookHandler)).Methods("POST", "OPTIONS")
-		apiRouter.Handle("/organizations/{orgID}/webhook", http.HandlerFunc(han.InstallOrgWebhookHandler)).Methods("POST", "OPTIONS")
+		apiRouter.Handle("/organizations/{orgID}/webhook/", withRole(params.UserRoleAdmin, han.InstallOrgWebhookHandler)).Methods("POST", "OPTIONS")
+		apiRouter.Handle("/organizations/{orgID}/webhook", withRole(params.UserRoleAdmin, han.InstallOrgWebhookHandler)).Methods("POST", "OPTIONS")
 		// Uninstall Webhook
-		apiRouter.Handle("/organizations/{orgID}/webhook/", http.HandlerFunc(han.UninstallOrgWebhookHandler)).Methods("DELETE", "OPTIONS")
-		apiRouter.Handle("/organizations/{orgID}/webhook", http.HandlerFunc(han.UninstallOrgWebhookHandler)).Methods("DELETE", "OPTIONS")
+		apiRouter.Handle("/organizations/{orgID}/webhook/", withRole(params.UserRoleAdmin, han.UninstallOrgWebhookHandler)).Methods("DELETE", "OPTIONS")
+		apiRouter.Handle("/organizations/{orgID}/webhook", withRole(params.UserRoleAdmin, han.UninstallOrgWebhookHandler)).Methods("DELETE", "OPTIONS")
 		// Get webhook info
-		apiRouter.Handle("/organizations/{orgID}/webhook/", http.HandlerFunc(han.GetOrgWebhookInfoHandler)).Methods("GET", "OPTIONS")
-		apiRouter.Handle("/organizations/{orgID}/webhook", http.HandlerFunc(han.GetOrgWebhookInfoHandler)).Methods("GET", "OPTIONS")
+		apiRouter.Handle("/organizations/{orgID}/webhook/", withRole(params.UserRoleViewer, han.GetOrgWebhookInfoHandler)).Methods("GET", "OPTIONS")
+		apiRouter.Handle("/organizations/{orgID}/webhook", withRole(params.UserRoleViewer, han.GetOrgWebhookInfoHandler)).Methods("GET", "OPTIONS")
 	}
 	/////////////////////////////
 	//  Enterprises and pools  //
 	/////////////////////////////
 	// Get pool
-	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}/", http.HandlerFunc(han.GetEnterprisePoolHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}", http.HandlerFunc(han.GetEnterprisePoolHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}/", withRole(params.UserRoleViewer, han.GetEnterprisePoolHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}", withRole(params.UserRoleViewer, han.GetEnterprisePoolHandler)).Methods("GET", "OPTIONS")
 	// Delete pool
-	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}/", http.HandlerFunc(han.DeleteEnterprisePoolHandler)).Methods("DELETE", "OPTIONS")
-	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}", http.HandlerFunc(han.DeleteEnterprisePoolHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}/", withRole(params.UserRoleAdmin, han.DeleteEnterprisePoolHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}", withRole(params.UserRoleAdmin, han.DeleteEnterprisePoolHandler)).Methods("DELETE", "OPTIONS")
 	// Update pool
-	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}/", http.HandlerFunc(han.UpdateEnterprisePoolHandler)).Methods("PUT", "OPTIONS")
-	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}", http.HandlerFunc(han.UpdateEnterprisePoolHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}/", withRole(params.UserRoleOperator, han.UpdateEnterprisePoolHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/pools/{poolID}", withRole(params.UserRoleOperator, han.UpdateEnterprisePoolHandler)).Methods("PUT", "OPTIONS")
 	// List pools
-	apiRouter.Handle("/enterprises/{enterpriseID}/pools/", http.HandlerFunc(han.ListEnterprisePoolsHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/enterprises/{enterpriseID}/pools", http.HandlerFunc(han.ListEnterprisePoolsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/pools/", withRole(params.UserRoleViewer, han.ListEnterprisePoolsHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/pools", withRole(params.UserRoleViewer, han.ListEnterprisePoolsHandler)).Methods("GET", "OPTIONS")
 	// Create pool
-	apiRouter.Handle("/enterprises/{enterpriseID}/pools/", http.HandlerFunc(han.CreateEnterprisePoolHandler)).Methods("POST", "OPTIONS")
-	apiRouter.Handle("/enterprises/{enterpriseID}/pools", http.HandlerFunc(han.CreateEnterprisePoolHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/pools/", withRole(params.UserRoleAdmin, han.CreateEnterprisePoolHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/pools", withRole(params.UserRoleAdmin, han.CreateEnterprisePoolHandler)).Methods("POST", "OPTIONS")
 
 	// Enterprise instances list
-	apiRouter.Handle("/enterprises/{enterpriseID}/instances/", http.HandlerFunc(han.ListEnterpriseInstancesHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/enterprises/{enterpriseID}/instances", http.HandlerFunc(han.ListEnterpriseInstancesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/instances/", withRole(params.UserRoleViewer, han.ListEnterpriseInstancesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/instances", withRole(params.UserRoleViewer, han.ListEnterpriseInstancesHandler)).Methods("GET", "OPTIONS")
 
 	// Get enterprise
-	apiRouter.Handle("/enterprises/{enterpriseID}/", http.HandlerFunc(han.GetEnterpriseByIDHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/enterprises/{enterpriseID}", http.HandlerFunc(han.GetEnterpriseByIDHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/", withRole(params.UserRoleViewer, han.GetEnterpriseByIDHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}", withRole(params.UserRoleViewer, han.GetEnterpriseByIDHandler)).Methods("GET", "OPTIONS")
 	// Update enterprise
-	apiRouter.Handle("/enterprises/{enterpriseID}/", http.HandlerFunc(han.UpdateEnterpriseHandler)).Methods("PUT", "OPTIONS")
-	apiRouter.Handle("/enterprises/{enterpriseID}", http.HandlerFunc(han.UpdateEnterpriseHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/", withRole(params.UserRoleAdmin, han.UpdateEnterpriseHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}", withRole(params.UserRoleAdmin, han.UpdateEnterpriseHandler)).Methods("PUT", "OPTIONS")
 	// Delete enterprise
-	apiRouter.Handle("/enterprises/{enterpriseID}/", http.HandlerFunc(han.DeleteEnterpriseHandler)).Methods("DELETE", "OPTIONS")
-	apiRouter.Handle("/enterprises/{enterpriseID}", http.HandlerFunc(han.DeleteEnterpriseHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}/", withRole(params.UserRoleAdmin, han.DeleteEnterpriseHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/enterprises/{enterpriseID}", withRole(params.UserRoleAdmin, han.DeleteEnterpriseHandler)).Methods("DELETE", "OPTIONS")
 	// List enterprises
-	apiRouter.Handle("/enterprises/", http.HandlerFunc(han.ListEnterprisesHandler)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/enterprises", http.HandlerFunc(han.ListEnterprisesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/enterprises/", withRole(params.UserRoleViewer, han.ListEnterprisesHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/enterprises", withRole(params.UserRoleViewer, han.ListEnterprisesHandler)).Methods("GET", "OPTIONS")
 	// Create enterprise
-	apiRouter.Handle("/enterprises/", http.HandlerFunc(han.CreateEnterpriseHandler)).Methods("POST", "OPTIONS")
-	apiRouter.Handle("/enterprises", http.HandlerFunc(han.CreateEnterpriseHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/enterprises/", withRole(params.UserRoleAdmin, han.CreateEnterpriseHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/enterprises", withRole(params.UserRoleAdmin, han.CreateEnterpriseHandler)).Methods("POST", "OPTIONS")
 
 	// Providers
-	apiRouter.Handle("/providers/", http.HandlerFunc(han.ListProviders)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/providers", http.HandlerFunc(han.ListProviders)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/providers/", withRole(params.UserRoleViewer, han.ListProviders)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/providers", withRole(params.UserRoleViewer, han.ListProviders)).Methods("GET", "OPTIONS")
 
 	//////////////////////
 	// Github Endpoints //
 	//////////////////////
 	// Create Github Endpoint
-	apiRouter.Handle("/github/endpoints/", http.HandlerFunc(han.CreateGithubEndpoint)).Methods("POST", "OPTIONS")
-	apiRouter.Handle("/github/endpoints", http.HandlerFunc(han.CreateGithubEndpoint)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/github/endpoints/", withRole(params.UserRoleAdmin, han.CreateGithubEndpoint)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/github/endpoints", withRole(params.UserRoleAdmin, han.CreateGithubEndpoint)).Methods("POST", "OPTIONS")
 	// List Github Endpoints
-	apiRouter.Handle("/github/endpoints/", http.HandlerFunc(han.ListGithubEndpoints)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/github/endpoints", http.HandlerFunc(han.ListGithubEndpoints)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/github/endpoints/", withRole(params.UserRoleViewer, han.ListGithubEndpoints)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/github/endpoints", withRole(params.UserRoleViewer, han.ListGithubEndpoints)).Methods("GET", "OPTIONS")
 	// Get Github Endpoint
-	apiRouter.Handle("/github/endpoints/{name}/", http.HandlerFunc(han.GetGithubEndpoint)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/github/endpoints/{name}", http.HandlerFunc(han.GetGithubEndpoint)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/github/endpoints/{name}/", withRole(params.UserRoleViewer, han.GetGithubEndpoint)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/github/endpoints/{name}", withRole(params.UserRoleViewer, han.GetGithubEndpoint)).Methods("GET", "OPTIONS")
 	// Delete Github Endpoint
-	apiRouter.Handle("/github/endpoints/{name}/", http.HandlerFunc(han.DeleteGithubEndpoint)).Methods("DELETE", "OPTIONS")
-	apiRouter.Handle("/github/endpoints/{name}", http.HandlerFunc(han.DeleteGithubEndpoint)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/github/endpoints/{name}/", withRole(params.UserRoleAdmin, han.DeleteGithubEndpoint)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/github/endpoints/{name}", withRole(params.UserRoleAdmin, han.DeleteGithubEndpoint)).Methods("DELETE", "OPTIONS")
 	// Update Github Endpoint
-	apiRouter.Handle("/github/endpoints/{name}/", http.HandlerFunc(han.UpdateGithubEndpoint)).Methods("PUT", "OPTIONS")
-	apiRouter.Handle("/github/endpoints/{name}", http.HandlerFunc(han.UpdateGithubEndpoint)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/github/endpoints/{name}/", withRole(params.UserRoleAdmin, han.UpdateGithubEndpoint)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/github/endpoints/{name}", withRole(params.UserRoleAdmin, han.UpdateGithubEndpoint)).Methods("PUT", "OPTIONS")
 
 	////////////////////////
 	// Github credentials //
 	////////////////////////
 	// Legacy credentials path
-	apiRouter.Handle("/credentials/", http.HandlerFunc(han.ListCredentials)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/credentials", http.HandlerFunc(han.ListCredentials)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/credentials/", withRole(params.UserRoleAdmin, han.ListCredentials)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/credentials", withRole(params.UserRoleAdmin, han.ListCredentials)).Methods("GET", "OPTIONS")
 	// List Github Credentials
-	apiRouter.Handle("/github/credentials/", http.HandlerFunc(han.ListCredentials)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/github/credentials", http.HandlerFunc(han.ListCredentials)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/github/credentials/", withRole(params.UserRoleAdmin, han.ListCredentials)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/github/credentials", withRole(params.UserRoleAdmin, han.ListCredentials)).Methods("GET", "OPTIONS")
 	// Create Github Credentials
-	apiRouter.Handle("/github/credentials/", http.HandlerFunc(han.CreateGithubCredential)).Methods("POST", "OPTIONS")
-	apiRouter.Handle("/github/credentials", http.HandlerFunc(han.CreateGithubCredential)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/github/credentials/", withRole(params.UserRoleAdmin, han.CreateGithubCredential)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/github/credentials", withRole(params.UserRoleAdmin, han.CreateGithubCredential)).Methods("POST", "OPTIONS")
 	// Get Github Credential
-	apiRouter.Handle("/github/credentials/{id}/", http.HandlerFunc(han.GetGithubCredential)).Methods("GET", "OPTIONS")
-	apiRouter.Handle("/github/credentials/{id}", http.HandlerFunc(han.GetGithubCredential)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/github/credentials/{id}/", withRole(params.UserRoleAdmin, han.GetGithubCredential)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/github/credentials/{id}", withRole(params.UserRoleAdmin, han.GetGithubCredential)).Methods("GET", "OPTIONS")
 	// Delete Github Credential
-	apiRouter.Handle("/github/credentials/{id}/", http.HandlerFunc(han.DeleteGithubCredential)).Methods("DELETE", "OPTIONS")
-	apiRouter.Handle("/github/credentials/{id}", http.HandlerFunc(han.DeleteGithubCredential)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/github/credentials/{id}/", withRole(params.UserRoleAdmin, han.DeleteGithubCredential)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/github/credentials/{id}", withRole(params.UserRoleAdmin, han.DeleteGithubCredential)).Methods("DELETE", "OPTIONS")
 	// Update Github Credential
-	apiRouter.Handle("/github/credentials/{id}/", http.HandlerFunc(han.UpdateGithubCredential)).Methods("PUT", "OPTIONS")
-	apiRouter.Handle("/github/credentials/{id}", http.HandlerFunc(han.UpdateGithubCredential)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/github/credentials/{id}/", withRole(params.UserRoleAdmin, han.UpdateGithubCredential)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/github/credentials/{id}", withRole(params.UserRoleAdmin, han.UpdateGithubCredential)).Methods("PUT", "OPTIONS")
+
+	///////////
+	// Users //
+	///////////
+	// List users
+	apiRouter.Handle("/users/", withRole(params.UserRoleAdmin, han.ListUsersHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/users", withRole(params.UserRoleAdmin, han.ListUsersHandler)).Methods("GET", "OPTIONS")
+	// Create user
+	apiRouter.Handle("/users/", withRole(params.UserRoleAdmin, han.CreateUserHandler)).Methods("POST", "OPTIONS")
+	apiRouter.Handle("/users", withRole(params.UserRoleAdmin, han.CreateUserHandler)).Methods("POST", "OPTIONS")
+	// Get user
+	apiRouter.Handle("/users/{username}/", withRole(params.UserRoleAdmin, han.GetUserHandler)).Methods("GET", "OPTIONS")
+	apiRouter.Handle("/users/{username}", withRole(params.UserRoleAdmin, han.GetUserHandler)).Methods("GET", "OPTIONS")
+	// Update user
+	apiRouter.Handle("/users/{username}/", withRole(params.UserRoleAdmin, han.UpdateUserHandler)).Methods("PUT", "OPTIONS")
+	apiRouter.Handle("/users/{username}", withRole(params.UserRoleAdmin, han.UpdateUserHandler)).Methods("PUT", "OPTIONS")
+	// Delete user
+	apiRouter.Handle("/users/{username}/", withRole(params.UserRoleAdmin, han.DeleteUserHandler)).Methods("DELETE", "OPTIONS")
+	apiRouter.Handle("/users/{username}", withRole(params.UserRoleAdmin, han.DeleteUserHandler)).Methods("DELETE", "OPTIONS")
 
 	// Websocket log writer
-	apiRouter.Handle("/{ws:ws\\/?}", http.HandlerFunc(han.WSHandler)).Methods("GET")
+	apiRouter.Handle("/{ws:ws\\/?}", withRole(params.UserRoleAdmin, han.WSHandler)).Methods("GET")
+	// Websocket database events
+	apiRouter.Handle("/ws/events/", withRole(params.UserRoleAdmin, han.EventsHandler)).Methods("GET")
+	apiRouter.Handle("/ws/events", withRole(params.UserRoleAdmin, han.EventsHandler)).Methods("GET")
 
 	// NotFound handler
 	apiRouter.PathPrefix("/").HandlerFunc(han.NotFoundHandler).Methods("GET", "POST", "PUT", "DELETE", "OPTIONS")
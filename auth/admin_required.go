@@ -1,14 +1,38 @@
 package auth
 
-import "net/http"
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	apiParams "github.com/cloudbase/garm/apiserver/params"
+	"github.com/cloudbase/garm/params"
+)
 
 func AdminRequiredMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-		if !IsAdmin(ctx) {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-		next.ServeHTTP(w, r)
-	})
+	return RoleRequiredMiddleware(params.UserRoleAdmin)(next)
+}
+
+// RoleRequiredMiddleware returns a middleware that only allows requests from
+// users that have a role which includes the given role.
+func RoleRequiredMiddleware(role params.UserRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if !HasRole(ctx, role) {
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				if err := json.NewEncoder(w).Encode(
+					apiParams.APIErrorResponse{
+						Error:   "Forbidden",
+						Details: fmt.Sprintf("this operation requires the %s role", role),
+					}); err != nil {
+					slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to encode response")
+				}
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
 }
@@ -126,27 +126,13 @@ func (a *Authenticator) InitController(ctx context.Context, param params.NewUser
 		return params.User{}, runnerErrors.ErrNotFound
 	}
 
-	if param.Email == "" || param.Username == "" {
-		return params.User{}, runnerErrors.NewBadRequestError("missing username or email")
-	}
-
-	if !util.IsValidEmail(param.Email) {
-		return params.User{}, runnerErrors.NewBadRequestError("invalid email address")
-	}
-
-	// username is varchar(64)
-	if len(param.Username) > 64 || !util.IsAlphanumeric(param.Username) {
-		return params.User{}, runnerErrors.NewBadRequestError("invalid username")
+	if err := ValidateNewUser(param); err != nil {
+		return params.User{}, err
 	}
 
 	param.IsAdmin = true
 	param.Enabled = true
 
-	passwordStenght := zxcvbn.PasswordStrength(param.Password, nil)
-	if passwordStenght.Score < 4 {
-		return params.User{}, runnerErrors.NewBadRequestError("password is too weak")
-	}
-
 	hashed, err := util.PaswsordToBcrypt(param.Password)
 	if err != nil {
 		return params.User{}, errors.Wrap(err, "creating user")
@@ -157,6 +143,33 @@ func (a *Authenticator) InitController(ctx context.Context, param params.NewUser
 	return a.store.CreateUser(ctx, param)
 }
 
+// ValidateNewUser checks the username, email and password of a new user.
+func ValidateNewUser(param params.NewUserParams) error {
+	if param.Email == "" || param.Username == "" {
+		return runnerErrors.NewBadRequestError("missing username or email")
+	}
+
+	if !util.IsValidEmail(param.Email) {
+		return runnerErrors.NewBadRequestError("invalid email address")
+	}
+
+	// username is varchar(64)
+	if len(param.Username) > 64 || !util.IsAlphanumeric(param.Username) {
+		return runnerErrors.NewBadRequestError("invalid username")
+	}
+
+	return ValidatePassword(param.Password)
+}
+
+// ValidatePassword returns an error if the password is too weak.
+func ValidatePassword(password string) error {
+	passwordStenght := zxcvbn.PasswordStrength(password, nil)
+	if passwordStenght.Score < 4 {
+		return runnerErrors.NewBadRequestError("password is too weak")
+	}
+	return nil
+}
+
 func (a *Authenticator) AuthenticateUser(ctx context.Context, info params.PasswordLoginParams) (context.Context, error) {
 	if info.Username == "" || info.Password == "" {
 		return ctx, runnerErrors.ErrUnauthorized
@@ -25,12 +25,14 @@ type contextFlags string
 
 const (
 	isAdminKey     contextFlags = "is_admin"
+	roleKey        contextFlags = "role"
 	fullNameKey    contextFlags = "full_name"
 	readMetricsKey contextFlags = "read_metrics"
 	// UserIDFlag is the User ID flag we set in the context
 	UserIDFlag    contextFlags = "user_id"
 	isEnabledFlag contextFlags = "is_enabled"
 	jwtTokenFlag  contextFlags = "jwt_token"
+	sourceIPKey   contextFlags = "source_ip"
 
 	instanceIDKey        contextFlags = "id"
 	instanceNameKey      contextFlags = "name"
@@ -172,6 +174,7 @@ func PopulateInstanceContext(ctx context.Context, instance params.Instance) cont
 func PopulateContext(ctx context.Context, user params.User) context.Context {
 	ctx = SetUserID(ctx, user.ID)
 	ctx = SetAdmin(ctx, user.IsAdmin)
+	ctx = SetRole(ctx, user.Role)
 	ctx = SetIsEnabled(ctx, user.Enabled)
 	ctx = SetFullName(ctx, user.FullName)
 	return ctx
@@ -222,6 +225,30 @@ func IsAdmin(ctx context.Context) bool {
 	return elem.(bool)
 }
 
+// SetRole sets the user role in the context
+func SetRole(ctx context.Context, role params.UserRole) context.Context {
+	return context.WithValue(ctx, roleKey, role)
+}
+
+// Role returns the role of the user from the context. Contexts that
+// only have the admin flag set are treated as having the admin role.
+func Role(ctx context.Context) params.UserRole {
+	if IsAdmin(ctx) {
+		return params.UserRoleAdmin
+	}
+	elem := ctx.Value(roleKey)
+	if elem == nil {
+		return ""
+	}
+	return elem.(params.UserRole)
+}
+
+// HasRole returns a boolean indicating whether or not the user in
+// the context has a role that includes the given role.
+func HasRole(ctx context.Context, role params.UserRole) bool {
+	return Role(ctx).Includes(role)
+}
+
 // SetUserID sets the userID in the context
 func SetUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, UserIDFlag, userID)
@@ -236,6 +263,21 @@ func UserID(ctx context.Context) string {
 	return userID.(string)
 }
 
+// SetSourceIP sets the address the request came from in the context
+func SetSourceIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, sourceIPKey, ip)
+}
+
+// SourceIP returns the address the request came from. It is empty for
+// operations that did not originate from an API request.
+func SourceIP(ctx context.Context) string {
+	ip := ctx.Value(sourceIPKey)
+	if ip == nil {
+		return ""
+	}
+	return ip.(string)
+}
+
 // GetAdminContext will return an admin context. This can be used internally
 // when fetching users.
 func GetAdminContext(ctx context.Context) context.Context {
@@ -244,6 +286,7 @@ func GetAdminContext(ctx context.Context) context.Context {
 	}
 	ctx = SetUserID(ctx, "")
 	ctx = SetAdmin(ctx, true)
+	ctx = SetRole(ctx, params.UserRoleAdmin)
 	ctx = SetIsEnabled(ctx, true)
 	return ctx
 }
@@ -0,0 +1,406 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package audit
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewListAuditEntriesParams creates a new ListAuditEntriesParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewListAuditEntriesParams() *ListAuditEntriesParams {
+	return &ListAuditEntriesParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListAuditEntriesParamsWithTimeout creates a new ListAuditEntriesParams object
+// with the ability to set a timeout on a request.
+func NewListAuditEntriesParamsWithTimeout(timeout time.Duration) *ListAuditEntriesParams {
+	return &ListAuditEntriesParams{
+		timeout: timeout,
+	}
+}
+
+// NewListAuditEntriesParamsWithContext creates a new ListAuditEntriesParams object
+// with the ability to set a context for a request.
+func NewListAuditEntriesParamsWithContext(ctx context.Context) *ListAuditEntriesParams {
+	return &ListAuditEntriesParams{
+		Context: ctx,
+	}
+}
+
+// NewListAuditEntriesParamsWithHTTPClient creates a new ListAuditEntriesParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewListAuditEntriesParamsWithHTTPClient(client *http.Client) *ListAuditEntriesParams {
+	return &ListAuditEntriesParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+ListAuditEntriesParams contains all the parameters to send to the API endpoint
+
+	for the list audit entries operation.
+
+	Typically these are written to a http.Request.
+*/
+type ListAuditEntriesParams struct {
+
+	/* Action.
+
+	   Only return entries with this action (create, update, delete or quarantine).
+	*/
+	Action *string
+
+	/* CreatedAfter.
+
+	   Only return entries recorded at or after this time (RFC3339).
+
+	   Format: date-time
+	*/
+	CreatedAfter *strfmt.DateTime
+
+	/* CreatedBefore.
+
+	   Only return entries recorded before this time (RFC3339).
+
+	   Format: date-time
+	*/
+	CreatedBefore *strfmt.DateTime
+
+	/* Cursor.
+
+	   Cursor returned in the X-Next-Cursor header of a previous response. Results start after the last item of that page.
+	*/
+	Cursor *string
+
+	/* EntityID.
+
+	   Only return changes made to the entity with this ID.
+	*/
+	EntityID *string
+
+	/* EntityType.
+
+	   Only return changes made to this type of entity.
+	*/
+	EntityType *string
+
+	/* Limit.
+
+	   Maximum number of results to return. If not set, all results are returned.
+	*/
+	Limit *int64
+
+	/* UserID.
+
+	   Only return changes made by this user.
+	*/
+	UserID *string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the list audit entries params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ListAuditEntriesParams) WithDefaults() *ListAuditEntriesParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the list audit entries params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ListAuditEntriesParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the list audit entries params
+func (o *ListAuditEntriesParams) WithTimeout(timeout time.Duration) *ListAuditEntriesParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list audit entries params
+func (o *ListAuditEntriesParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list audit entries params
+func (o *ListAuditEntriesParams) WithContext(ctx context.Context) *ListAuditEntriesParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list audit entries params
+func (o *ListAuditEntriesParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list audit entries params
+func (o *ListAuditEntriesParams) WithHTTPClient(client *http.Client) *ListAuditEntriesParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list audit entries params
+func (o *ListAuditEntriesParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithAction adds the action to the list audit entries params
+func (o *ListAuditEntriesParams) WithAction(action *string) *ListAuditEntriesParams {
+	o.SetAction(action)
+	return o
+}
+
+// SetAction adds the action to the list audit entries params
+func (o *ListAuditEntriesParams) SetAction(action *string) {
+	o.Action = action
+}
+
+// WithCreatedAfter adds the createdAfter to the list audit entries params
+func (o *ListAuditEntriesParams) WithCreatedAfter(createdAfter *strfmt.DateTime) *ListAuditEntriesParams {
+	o.SetCreatedAfter(createdAfter)
+	return o
+}
+
+// SetCreatedAfter adds the createdAfter to the list audit entries params
+func (o *ListAuditEntriesParams) SetCreatedAfter(createdAfter *strfmt.DateTime) {
+	o.CreatedAfter = createdAfter
+}
+
+// WithCreatedBefore adds the createdBefore to the list audit entries params
+func (o *ListAuditEntriesParams) WithCreatedBefore(createdBefore *strfmt.DateTime) *ListAuditEntriesParams {
+	o.SetCreatedBefore(createdBefore)
+	return o
+}
+
+// SetCreatedBefore adds the createdBefore to the list audit entries params
+func (o *ListAuditEntriesParams) SetCreatedBefore(createdBefore *strfmt.DateTime) {
+	o.CreatedBefore = createdBefore
+}
+
+// WithCursor adds the cursor to the list audit entries params
+func (o *ListAuditEntriesParams) WithCursor(cursor *string) *ListAuditEntriesParams {
+	o.SetCursor(cursor)
+	return o
+}
+
+// SetCursor adds the cursor to the list audit entries params
+func (o *ListAuditEntriesParams) SetCursor(cursor *string) {
+	o.Cursor = cursor
+}
+
+// WithEntityID adds the entityID to the list audit entries params
+func (o *ListAuditEntriesParams) WithEntityID(entityID *string) *ListAuditEntriesParams {
+	o.SetEntityID(entityID)
+	return o
+}
+
+// SetEntityID adds the entityId to the list audit entries params
+func (o *ListAuditEntriesParams) SetEntityID(entityID *string) {
+	o.EntityID = entityID
+}
+
+// WithEntityType adds the entityType to the list audit entries params
+func (o *ListAuditEntriesParams) WithEntityType(entityType *string) *ListAuditEntriesParams {
+	o.SetEntityType(entityType)
+	return o
+}
+
+// SetEntityType adds the entityType to the list audit entries params
+func (o *ListAuditEntriesParams) SetEntityType(entityType *string) {
+	o.EntityType = entityType
+}
+
+// WithLimit adds the limit to the list audit entries params
+func (o *ListAuditEntriesParams) WithLimit(limit *int64) *ListAuditEntriesParams {
+	o.SetLimit(limit)
+	return o
+}
+
+// SetLimit adds the limit to the list audit entries params
+func (o *ListAuditEntriesParams) SetLimit(limit *int64) {
+	o.Limit = limit
+}
+
+// WithUserID adds the userID to the list audit entries params
+func (o *ListAuditEntriesParams) WithUserID(userID *string) *ListAuditEntriesParams {
+	o.SetUserID(userID)
+	return o
+}
+
+// SetUserID adds the userId to the list audit entries params
+func (o *ListAuditEntriesParams) SetUserID(userID *string) {
+	o.UserID = userID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListAuditEntriesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Action != nil {
+
+		// query param action
+		var qrAction string
+
+		if o.Action != nil {
+			qrAction = *o.Action
+		}
+		qAction := qrAction
+		if qAction != "" {
+
+			if err := r.SetQueryParam("action", qAction); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.CreatedAfter != nil {
+
+		// query param createdAfter
+		var qrCreatedAfter strfmt.DateTime
+
+		if o.CreatedAfter != nil {
+			qrCreatedAfter = *o.CreatedAfter
+		}
+		qCreatedAfter := qrCreatedAfter.String()
+		if qCreatedAfter != "" {
+
+			if err := r.SetQueryParam("createdAfter", qCreatedAfter); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.CreatedBefore != nil {
+
+		// query param createdBefore
+		var qrCreatedBefore strfmt.DateTime
+
+		if o.CreatedBefore != nil {
+			qrCreatedBefore = *o.CreatedBefore
+		}
+		qCreatedBefore := qrCreatedBefore.String()
+		if qCreatedBefore != "" {
+
+			if err := r.SetQueryParam("createdBefore", qCreatedBefore); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Cursor != nil {
+
+		// query param cursor
+		var qrCursor string
+
+		if o.Cursor != nil {
+			qrCursor = *o.Cursor
+		}
+		qCursor := qrCursor
+		if qCursor != "" {
+
+			if err := r.SetQueryParam("cursor", qCursor); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.EntityID != nil {
+
+		// query param entityID
+		var qrEntityID string
+
+		if o.EntityID != nil {
+			qrEntityID = *o.EntityID
+		}
+		qEntityID := qrEntityID
+		if qEntityID != "" {
+
+			if err := r.SetQueryParam("entityID", qEntityID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.EntityType != nil {
+
+		// query param entityType
+		var qrEntityType string
+
+		if o.EntityType != nil {
+			qrEntityType = *o.EntityType
+		}
+		qEntityType := qrEntityType
+		if qEntityType != "" {
+
+			if err := r.SetQueryParam("entityType", qEntityType); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Limit != nil {
+
+		// query param limit
+		var qrLimit int64
+
+		if o.Limit != nil {
+			qrLimit = *o.Limit
+		}
+		qLimit := swag.FormatInt64(qrLimit)
+		if qLimit != "" {
+
+			if err := r.SetQueryParam("limit", qLimit); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.UserID != nil {
+
+		// query param userID
+		var qrUserID string
+
+		if o.UserID != nil {
+			qrUserID = *o.UserID
+		}
+		qUserID := qrUserID
+		if qUserID != "" {
+
+			if err := r.SetQueryParam("userID", qUserID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package audit
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// ListAuditEntriesReader is a Reader for the ListAuditEntries structure.
+type ListAuditEntriesReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListAuditEntriesReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListAuditEntriesOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewListAuditEntriesBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("[GET /audit] ListAuditEntries", response, response.Code())
+	}
+}
+
+// NewListAuditEntriesOK creates a ListAuditEntriesOK with default headers values
+func NewListAuditEntriesOK() *ListAuditEntriesOK {
+	return &ListAuditEntriesOK{}
+}
+
+/*
+ListAuditEntriesOK describes a response with status code 200, with default header values.
+
+A page of audit log entries.
+*/
+type ListAuditEntriesOK struct {
+
+	/* Cursor of the next page. Not set on the last page.
+	 */
+	XNextCursor string
+
+	Payload garm_params.AuditEntries
+}
+
+// IsSuccess returns true when this list audit entries o k response has a 2xx status code
+func (o *ListAuditEntriesOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this list audit entries o k response has a 3xx status code
+func (o *ListAuditEntriesOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this list audit entries o k response has a 4xx status code
+func (o *ListAuditEntriesOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this list audit entries o k response has a 5xx status code
+func (o *ListAuditEntriesOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this list audit entries o k response a status code equal to that given
+func (o *ListAuditEntriesOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the list audit entries o k response
+func (o *ListAuditEntriesOK) Code() int {
+	return 200
+}
+
+func (o *ListAuditEntriesOK) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /audit][%d] listAuditEntriesOK %s", 200, payload)
+}
+
+func (o *ListAuditEntriesOK) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /audit][%d] listAuditEntriesOK %s", 200, payload)
+}
+
+func (o *ListAuditEntriesOK) GetPayload() garm_params.AuditEntries {
+	return o.Payload
+}
+
+func (o *ListAuditEntriesOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// hydrates response header X-Next-Cursor
+	hdrXNextCursor := response.GetHeader("X-Next-Cursor")
+
+	if hdrXNextCursor != "" {
+		o.XNextCursor = hdrXNextCursor
+	}
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListAuditEntriesBadRequest creates a ListAuditEntriesBadRequest with default headers values
+func NewListAuditEntriesBadRequest() *ListAuditEntriesBadRequest {
+	return &ListAuditEntriesBadRequest{}
+}
+
+/*
+ListAuditEntriesBadRequest describes a response with status code 400, with default header values.
+
+APIErrorResponse
+*/
+type ListAuditEntriesBadRequest struct {
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this list audit entries bad request response has a 2xx status code
+func (o *ListAuditEntriesBadRequest) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this list audit entries bad request response has a 3xx status code
+func (o *ListAuditEntriesBadRequest) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this list audit entries bad request response has a 4xx status code
+func (o *ListAuditEntriesBadRequest) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this list audit entries bad request response has a 5xx status code
+func (o *ListAuditEntriesBadRequest) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this list audit entries bad request response a status code equal to that given
+func (o *ListAuditEntriesBadRequest) IsCode(code int) bool {
+	return code == 400
+}
+
+// Code gets the status code for the list audit entries bad request response
+func (o *ListAuditEntriesBadRequest) Code() int {
+	return 400
+}
+
+func (o *ListAuditEntriesBadRequest) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /audit][%d] listAuditEntriesBadRequest %s", 400, payload)
+}
+
+func (o *ListAuditEntriesBadRequest) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /audit][%d] listAuditEntriesBadRequest %s", 400, payload)
+}
+
+func (o *ListAuditEntriesBadRequest) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *ListAuditEntriesBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 
+	"github.com/cloudbase/garm/client/audit"
 	"github.com/cloudbase/garm/client/controller"
 	"github.com/cloudbase/garm/client/controller_info"
 	"github.com/cloudbase/garm/client/credentials"
@@ -20,10 +21,12 @@ import (
 	"github.com/cloudbase/garm/client/jobs"
 	"github.com/cloudbase/garm/client/login"
 	"github.com/cloudbase/garm/client/metrics_token"
+	"github.com/cloudbase/garm/client/notifications"
 	"github.com/cloudbase/garm/client/organizations"
 	"github.com/cloudbase/garm/client/pools"
 	"github.com/cloudbase/garm/client/providers"
 	"github.com/cloudbase/garm/client/repositories"
+	"github.com/cloudbase/garm/client/users"
 )
 
 // Default garm API HTTP client.
@@ -68,6 +71,7 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *GarmAPI {
 
 	cli := new(GarmAPI)
 	cli.Transport = transport
+	cli.Audit = audit.New(transport, formats)
 	cli.Controller = controller.New(transport, formats)
 	cli.ControllerInfo = controller_info.New(transport, formats)
 	cli.Credentials = credentials.New(transport, formats)
@@ -78,10 +82,12 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *GarmAPI {
 	cli.Jobs = jobs.New(transport, formats)
 	cli.Login = login.New(transport, formats)
 	cli.MetricsToken = metrics_token.New(transport, formats)
+	cli.Notifications = notifications.New(transport, formats)
 	cli.Organizations = organizations.New(transport, formats)
 	cli.Pools = pools.New(transport, formats)
 	cli.Providers = providers.New(transport, formats)
 	cli.Repositories = repositories.New(transport, formats)
+	cli.Users = users.New(transport, formats)
 	return cli
 }
 
@@ -126,6 +132,8 @@ func (cfg *TransportConfig) WithSchemes(schemes []string) *TransportConfig {
 
 // GarmAPI is a client for garm API
 type GarmAPI struct {
+	Audit audit.ClientService
+
 	Controller controller.ClientService
 
 	ControllerInfo controller_info.ClientService
@@ -146,6 +154,8 @@ type GarmAPI struct {
 
 	MetricsToken metrics_token.ClientService
 
+	Notifications notifications.ClientService
+
 	Organizations organizations.ClientService
 
 	Pools pools.ClientService
@@ -154,12 +164,15 @@ type GarmAPI struct {
 
 	Repositories repositories.ClientService
 
+	Users users.ClientService
+
 	Transport runtime.ClientTransport
 }
 
 // SetTransport changes the transport on the client and all its subresources
 func (c *GarmAPI) SetTransport(transport runtime.ClientTransport) {
 	c.Transport = transport
+	c.Audit.SetTransport(transport)
 	c.Controller.SetTransport(transport)
 	c.ControllerInfo.SetTransport(transport)
 	c.Credentials.SetTransport(transport)
@@ -170,8 +183,10 @@ func (c *GarmAPI) SetTransport(transport runtime.ClientTransport) {
 	c.Jobs.SetTransport(transport)
 	c.Login.SetTransport(transport)
 	c.MetricsToken.SetTransport(transport)
+	c.Notifications.SetTransport(transport)
 	c.Organizations.SetTransport(transport)
 	c.Pools.SetTransport(transport)
 	c.Providers.SetTransport(transport)
 	c.Repositories.SetTransport(transport)
+	c.Users.SetTransport(transport)
 }
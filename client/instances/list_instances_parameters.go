@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewListInstancesParams creates a new ListInstancesParams object,
@@ -60,6 +61,81 @@ ListInstancesParams contains all the parameters to send to the API endpoint
 	Typically these are written to a http.Request.
 */
 type ListInstancesParams struct {
+
+	/* CreatedAfter.
+
+	   Only return results created at or after this time (RFC3339).
+
+	   Format: date-time
+	*/
+	CreatedAfter *strfmt.DateTime
+
+	/* CreatedBefore.
+
+	   Only return results created before this time (RFC3339).
+
+	   Format: date-time
+	*/
+	CreatedBefore *strfmt.DateTime
+
+	/* Cursor.
+
+	   Cursor returned in the X-Next-Cursor header of a previous response. Results start after the last item of that page.
+	*/
+	Cursor *string
+
+	/* Label.
+
+	   Only return instances belonging to pools that have this label.
+	*/
+	Label *string
+
+	/* Limit.
+
+	   Maximum number of results to return. If not set, all results are returned.
+	*/
+	Limit *int64
+
+	/* PoolID.
+
+	   Only return instances belonging to this pool.
+	*/
+	PoolID *string
+
+	/* ProviderName.
+
+	   Only return instances created by this provider.
+	*/
+	ProviderName *string
+
+	/* RunnerStatus.
+
+	   Only return instances with this runner status.
+	*/
+	RunnerStatus *string
+
+	/* Status.
+
+	   Only return instances with this status.
+	*/
+	Status *string
+
+	/* UpdatedAfter.
+
+	   Only return results updated at or after this time (RFC3339).
+
+	   Format: date-time
+	*/
+	UpdatedAfter *strfmt.DateTime
+
+	/* UpdatedBefore.
+
+	   Only return results updated before this time (RFC3339).
+
+	   Format: date-time
+	*/
+	UpdatedBefore *strfmt.DateTime
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -113,6 +189,127 @@ func (o *ListInstancesParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithCreatedAfter adds the createdAfter to the list instances params
+func (o *ListInstancesParams) WithCreatedAfter(createdAfter *strfmt.DateTime) *ListInstancesParams {
+	o.SetCreatedAfter(createdAfter)
+	return o
+}
+
+// SetCreatedAfter adds the createdAfter to the list instances params
+func (o *ListInstancesParams) SetCreatedAfter(createdAfter *strfmt.DateTime) {
+	o.CreatedAfter = createdAfter
+}
+
+// WithCreatedBefore adds the createdBefore to the list instances params
+func (o *ListInstancesParams) WithCreatedBefore(createdBefore *strfmt.DateTime) *ListInstancesParams {
+	o.SetCreatedBefore(createdBefore)
+	return o
+}
+
+// SetCreatedBefore adds the createdBefore to the list instances params
+func (o *ListInstancesParams) SetCreatedBefore(createdBefore *strfmt.DateTime) {
+	o.CreatedBefore = createdBefore
+}
+
+// WithCursor adds the cursor to the list instances params
+func (o *ListInstancesParams) WithCursor(cursor *string) *ListInstancesParams {
+	o.SetCursor(cursor)
+	return o
+}
+
+// SetCursor adds the cursor to the list instances params
+func (o *ListInstancesParams) SetCursor(cursor *string) {
+	o.Cursor = cursor
+}
+
+// WithLabel adds the label to the list instances params
+func (o *ListInstancesParams) WithLabel(label *string) *ListInstancesParams {
+	o.SetLabel(label)
+	return o
+}
+
+// SetLabel adds the label to the list instances params
+func (o *ListInstancesParams) SetLabel(label *string) {
+	o.Label = label
+}
+
+// WithLimit adds the limit to the list instances params
+func (o *ListInstancesParams) WithLimit(limit *int64) *ListInstancesParams {
+	o.SetLimit(limit)
+	return o
+}
+
+// SetLimit adds the limit to the list instances params
+func (o *ListInstancesParams) SetLimit(limit *int64) {
+	o.Limit = limit
+}
+
+// WithPoolID adds the poolID to the list instances params
+func (o *ListInstancesParams) WithPoolID(poolID *string) *ListInstancesParams {
+	o.SetPoolID(poolID)
+	return o
+}
+
+// SetPoolID adds the poolId to the list instances params
+func (o *ListInstancesParams) SetPoolID(poolID *string) {
+	o.PoolID = poolID
+}
+
+// WithProviderName adds the providerName to the list instances params
+func (o *ListInstancesParams) WithProviderName(providerName *string) *ListInstancesParams {
+	o.SetProviderName(providerName)
+	return o
+}
+
+// SetProviderName adds the providerName to the list instances params
+func (o *ListInstancesParams) SetProviderName(providerName *string) {
+	o.ProviderName = providerName
+}
+
+// WithRunnerStatus adds the runnerStatus to the list instances params
+func (o *ListInstancesParams) WithRunnerStatus(runnerStatus *string) *ListInstancesParams {
+	o.SetRunnerStatus(runnerStatus)
+	return o
+}
+
+// SetRunnerStatus adds the runnerStatus to the list instances params
+func (o *ListInstancesParams) SetRunnerStatus(runnerStatus *string) {
+	o.RunnerStatus = runnerStatus
+}
+
+// WithStatus adds the status to the list instances params
+func (o *ListInstancesParams) WithStatus(status *string) *ListInstancesParams {
+	o.SetStatus(status)
+	return o
+}
+
+// SetStatus adds the status to the list instances params
+func (o *ListInstancesParams) SetStatus(status *string) {
+	o.Status = status
+}
+
+// WithUpdatedAfter adds the updatedAfter to the list instances params
+func (o *ListInstancesParams) WithUpdatedAfter(updatedAfter *strfmt.DateTime) *ListInstancesParams {
+	o.SetUpdatedAfter(updatedAfter)
+	return o
+}
+
+// SetUpdatedAfter adds the updatedAfter to the list instances params
+func (o *ListInstancesParams) SetUpdatedAfter(updatedAfter *strfmt.DateTime) {
+	o.UpdatedAfter = updatedAfter
+}
+
+// WithUpdatedBefore adds the updatedBefore to the list instances params
+func (o *ListInstancesParams) WithUpdatedBefore(updatedBefore *strfmt.DateTime) *ListInstancesParams {
+	o.SetUpdatedBefore(updatedBefore)
+	return o
+}
+
+// SetUpdatedBefore adds the updatedBefore to the list instances params
+func (o *ListInstancesParams) SetUpdatedBefore(updatedBefore *strfmt.DateTime) {
+	o.UpdatedBefore = updatedBefore
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *ListInstancesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -121,6 +318,193 @@ func (o *ListInstancesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt
 	}
 	var res []error
 
+	if o.CreatedAfter != nil {
+
+		// query param createdAfter
+		var qrCreatedAfter strfmt.DateTime
+
+		if o.CreatedAfter != nil {
+			qrCreatedAfter = *o.CreatedAfter
+		}
+		qCreatedAfter := qrCreatedAfter.String()
+		if qCreatedAfter != "" {
+
+			if err := r.SetQueryParam("createdAfter", qCreatedAfter); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.CreatedBefore != nil {
+
+		// query param createdBefore
+		var qrCreatedBefore strfmt.DateTime
+
+		if o.CreatedBefore != nil {
+			qrCreatedBefore = *o.CreatedBefore
+		}
+		qCreatedBefore := qrCreatedBefore.String()
+		if qCreatedBefore != "" {
+
+			if err := r.SetQueryParam("createdBefore", qCreatedBefore); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Cursor != nil {
+
+		// query param cursor
+		var qrCursor string
+
+		if o.Cursor != nil {
+			qrCursor = *o.Cursor
+		}
+		qCursor := qrCursor
+		if qCursor != "" {
+
+			if err := r.SetQueryParam("cursor", qCursor); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Label != nil {
+
+		// query param label
+		var qrLabel string
+
+		if o.Label != nil {
+			qrLabel = *o.Label
+		}
+		qLabel := qrLabel
+		if qLabel != "" {
+
+			if err := r.SetQueryParam("label", qLabel); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Limit != nil {
+
+		// query param limit
+		var qrLimit int64
+
+		if o.Limit != nil {
+			qrLimit = *o.Limit
+		}
+		qLimit := swag.FormatInt64(qrLimit)
+		if qLimit != "" {
+
+			if err := r.SetQueryParam("limit", qLimit); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.PoolID != nil {
+
+		// query param poolID
+		var qrPoolID string
+
+		if o.PoolID != nil {
+			qrPoolID = *o.PoolID
+		}
+		qPoolID := qrPoolID
+		if qPoolID != "" {
+
+			if err := r.SetQueryParam("poolID", qPoolID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.ProviderName != nil {
+
+		// query param providerName
+		var qrProviderName string
+
+		if o.ProviderName != nil {
+			qrProviderName = *o.ProviderName
+		}
+		qProviderName := qrProviderName
+		if qProviderName != "" {
+
+			if err := r.SetQueryParam("providerName", qProviderName); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.RunnerStatus != nil {
+
+		// query param runnerStatus
+		var qrRunnerStatus string
+
+		if o.RunnerStatus != nil {
+			qrRunnerStatus = *o.RunnerStatus
+		}
+		qRunnerStatus := qrRunnerStatus
+		if qRunnerStatus != "" {
+
+			if err := r.SetQueryParam("runnerStatus", qRunnerStatus); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Status != nil {
+
+		// query param status
+		var qrStatus string
+
+		if o.Status != nil {
+			qrStatus = *o.Status
+		}
+		qStatus := qrStatus
+		if qStatus != "" {
+
+			if err := r.SetQueryParam("status", qStatus); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.UpdatedAfter != nil {
+
+		// query param updatedAfter
+		var qrUpdatedAfter strfmt.DateTime
+
+		if o.UpdatedAfter != nil {
+			qrUpdatedAfter = *o.UpdatedAfter
+		}
+		qUpdatedAfter := qrUpdatedAfter.String()
+		if qUpdatedAfter != "" {
+
+			if err := r.SetQueryParam("updatedAfter", qUpdatedAfter); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.UpdatedBefore != nil {
+
+		// query param updatedBefore
+		var qrUpdatedBefore strfmt.DateTime
+
+		if o.UpdatedBefore != nil {
+			qrUpdatedBefore = *o.UpdatedBefore
+		}
+		qUpdatedBefore := qrUpdatedBefore.String()
+		if qUpdatedBefore != "" {
+
+			if err := r.SetQueryParam("updatedBefore", qUpdatedBefore); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
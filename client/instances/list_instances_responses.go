@@ -51,9 +51,14 @@ func NewListInstancesOK() *ListInstancesOK {
 /*
 ListInstancesOK describes a response with status code 200, with default header values.
 
-Instances
+A page of runner instances.
 */
 type ListInstancesOK struct {
+
+	/* Cursor of the next page. Not set on the last page.
+	 */
+	XNextCursor string
+
 	Payload garm_params.Instances
 }
 
@@ -103,6 +108,13 @@ func (o *ListInstancesOK) GetPayload() garm_params.Instances {
 
 func (o *ListInstancesOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// hydrates response header X-Next-Cursor
+	hdrXNextCursor := response.GetHeader("X-Next-Cursor")
+
+	if hdrXNextCursor != "" {
+		o.XNextCursor = hdrXNextCursor
+	}
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
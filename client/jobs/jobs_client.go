@@ -56,11 +56,52 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	ListJobHistory(params *ListJobHistoryParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListJobHistoryOK, error)
+
 	ListJobs(params *ListJobsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListJobsOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+ListJobHistory lists the job history
+*/
+func (a *Client) ListJobHistory(params *ListJobHistoryParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListJobHistoryOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListJobHistoryParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ListJobHistory",
+		Method:             "GET",
+		PathPattern:        "/jobs/history",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ListJobHistoryReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListJobHistoryOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListJobHistory: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 ListJobs lists all jobs
 */
@@ -0,0 +1,512 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package jobs
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewListJobHistoryParams creates a new ListJobHistoryParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewListJobHistoryParams() *ListJobHistoryParams {
+	return &ListJobHistoryParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListJobHistoryParamsWithTimeout creates a new ListJobHistoryParams object
+// with the ability to set a timeout on a request.
+func NewListJobHistoryParamsWithTimeout(timeout time.Duration) *ListJobHistoryParams {
+	return &ListJobHistoryParams{
+		timeout: timeout,
+	}
+}
+
+// NewListJobHistoryParamsWithContext creates a new ListJobHistoryParams object
+// with the ability to set a context for a request.
+func NewListJobHistoryParamsWithContext(ctx context.Context) *ListJobHistoryParams {
+	return &ListJobHistoryParams{
+		Context: ctx,
+	}
+}
+
+// NewListJobHistoryParamsWithHTTPClient creates a new ListJobHistoryParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewListJobHistoryParamsWithHTTPClient(client *http.Client) *ListJobHistoryParams {
+	return &ListJobHistoryParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+ListJobHistoryParams contains all the parameters to send to the API endpoint
+
+	for the list job history operation.
+
+	Typically these are written to a http.Request.
+*/
+type ListJobHistoryParams struct {
+
+	/* Conclusion.
+
+	   Only return jobs with this conclusion.
+	*/
+	Conclusion *string
+
+	/* CreatedAfter.
+
+	   Only return jobs first recorded at or after this time (RFC3339).
+
+	   Format: date-time
+	*/
+	CreatedAfter *strfmt.DateTime
+
+	/* CreatedBefore.
+
+	   Only return jobs first recorded before this time (RFC3339).
+
+	   Format: date-time
+	*/
+	CreatedBefore *strfmt.DateTime
+
+	/* Cursor.
+
+	   Cursor returned in the X-Next-Cursor header of a previous response. Results start after the last item of that page.
+	*/
+	Cursor *string
+
+	/* Label.
+
+	   Only return jobs that requested this label.
+	*/
+	Label *string
+
+	/* Limit.
+
+	   Maximum number of results to return. If not set, all results are returned.
+	*/
+	Limit *int64
+
+	/* PoolID.
+
+	   Only return jobs picked up by a runner from this pool.
+	*/
+	PoolID *string
+
+	/* ProviderName.
+
+	   Only return jobs picked up by a runner from this provider.
+	*/
+	ProviderName *string
+
+	/* Status.
+
+	   Only return jobs with this status.
+	*/
+	Status *string
+
+	/* UpdatedAfter.
+
+	   Only return results updated at or after this time (RFC3339).
+
+	   Format: date-time
+	*/
+	UpdatedAfter *strfmt.DateTime
+
+	/* UpdatedBefore.
+
+	   Only return results updated before this time (RFC3339).
+
+	   Format: date-time
+	*/
+	UpdatedBefore *strfmt.DateTime
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the list job history params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ListJobHistoryParams) WithDefaults() *ListJobHistoryParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the list job history params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ListJobHistoryParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the list job history params
+func (o *ListJobHistoryParams) WithTimeout(timeout time.Duration) *ListJobHistoryParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list job history params
+func (o *ListJobHistoryParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list job history params
+func (o *ListJobHistoryParams) WithContext(ctx context.Context) *ListJobHistoryParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list job history params
+func (o *ListJobHistoryParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list job history params
+func (o *ListJobHistoryParams) WithHTTPClient(client *http.Client) *ListJobHistoryParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list job history params
+func (o *ListJobHistoryParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithConclusion adds the conclusion to the list job history params
+func (o *ListJobHistoryParams) WithConclusion(conclusion *string) *ListJobHistoryParams {
+	o.SetConclusion(conclusion)
+	return o
+}
+
+// SetConclusion adds the conclusion to the list job history params
+func (o *ListJobHistoryParams) SetConclusion(conclusion *string) {
+	o.Conclusion = conclusion
+}
+
+// WithCreatedAfter adds the createdAfter to the list job history params
+func (o *ListJobHistoryParams) WithCreatedAfter(createdAfter *strfmt.DateTime) *ListJobHistoryParams {
+	o.SetCreatedAfter(createdAfter)
+	return o
+}
+
+// SetCreatedAfter adds the createdAfter to the list job history params
+func (o *ListJobHistoryParams) SetCreatedAfter(createdAfter *strfmt.DateTime) {
+	o.CreatedAfter = createdAfter
+}
+
+// WithCreatedBefore adds the createdBefore to the list job history params
+func (o *ListJobHistoryParams) WithCreatedBefore(createdBefore *strfmt.DateTime) *ListJobHistoryParams {
+	o.SetCreatedBefore(createdBefore)
+	return o
+}
+
+// SetCreatedBefore adds the createdBefore to the list job history params
+func (o *ListJobHistoryParams) SetCreatedBefore(createdBefore *strfmt.DateTime) {
+	o.CreatedBefore = createdBefore
+}
+
+// WithCursor adds the cursor to the list job history params
+func (o *ListJobHistoryParams) WithCursor(cursor *string) *ListJobHistoryParams {
+	o.SetCursor(cursor)
+	return o
+}
+
+// SetCursor adds the cursor to the list job history params
+func (o *ListJobHistoryParams) SetCursor(cursor *string) {
+	o.Cursor = cursor
+}
+
+// WithLabel adds the label to the list job history params
+func (o *ListJobHistoryParams) WithLabel(label *string) *ListJobHistoryParams {
+	o.SetLabel(label)
+	return o
+}
+
+// SetLabel adds the label to the list job history params
+func (o *ListJobHistoryParams) SetLabel(label *string) {
+	o.Label = label
+}
+
+// WithLimit adds the limit to the list job history params
+func (o *ListJobHistoryParams) WithLimit(limit *int64) *ListJobHistoryParams {
+	o.SetLimit(limit)
+	return o
+}
+
+// SetLimit adds the limit to the list job history params
+func (o *ListJobHistoryParams) SetLimit(limit *int64) {
+	o.Limit = limit
+}
+
+// WithPoolID adds the poolID to the list job history params
+func (o *ListJobHistoryParams) WithPoolID(poolID *string) *ListJobHistoryParams {
+	o.SetPoolID(poolID)
+	return o
+}
+
+// SetPoolID adds the poolId to the list job history params
+func (o *ListJobHistoryParams) SetPoolID(poolID *string) {
+	o.PoolID = poolID
+}
+
+// WithProviderName adds the providerName to the list job history params
+func (o *ListJobHistoryParams) WithProviderName(providerName *string) *ListJobHistoryParams {
+	o.SetProviderName(providerName)
+	return o
+}
+
+// SetProviderName adds the providerName to the list job history params
+func (o *ListJobHistoryParams) SetProviderName(providerName *string) {
+	o.ProviderName = providerName
+}
+
+// WithStatus adds the status to the list job history params
+func (o *ListJobHistoryParams) WithStatus(status *string) *ListJobHistoryParams {
+	o.SetStatus(status)
+	return o
+}
+
+// SetStatus adds the status to the list job history params
+func (o *ListJobHistoryParams) SetStatus(status *string) {
+	o.Status = status
+}
+
+// WithUpdatedAfter adds the updatedAfter to the list job history params
+func (o *ListJobHistoryParams) WithUpdatedAfter(updatedAfter *strfmt.DateTime) *ListJobHistoryParams {
+	o.SetUpdatedAfter(updatedAfter)
+	return o
+}
+
+// SetUpdatedAfter adds the updatedAfter to the list job history params
+func (o *ListJobHistoryParams) SetUpdatedAfter(updatedAfter *strfmt.DateTime) {
+	o.UpdatedAfter = updatedAfter
+}
+
+// WithUpdatedBefore adds the updatedBefore to the list job history params
+func (o *ListJobHistoryParams) WithUpdatedBefore(updatedBefore *strfmt.DateTime) *ListJobHistoryParams {
+	o.SetUpdatedBefore(updatedBefore)
+	return o
+}
+
+// SetUpdatedBefore adds the updatedBefore to the list job history params
+func (o *ListJobHistoryParams) SetUpdatedBefore(updatedBefore *strfmt.DateTime) {
+	o.UpdatedBefore = updatedBefore
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListJobHistoryParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if o.Conclusion != nil {
+
+		// query param conclusion
+		var qrConclusion string
+
+		if o.Conclusion != nil {
+			qrConclusion = *o.Conclusion
+		}
+		qConclusion := qrConclusion
+		if qConclusion != "" {
+
+			if err := r.SetQueryParam("conclusion", qConclusion); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.CreatedAfter != nil {
+
+		// query param createdAfter
+		var qrCreatedAfter strfmt.DateTime
+
+		if o.CreatedAfter != nil {
+			qrCreatedAfter = *o.CreatedAfter
+		}
+		qCreatedAfter := qrCreatedAfter.String()
+		if qCreatedAfter != "" {
+
+			if err := r.SetQueryParam("createdAfter", qCreatedAfter); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.CreatedBefore != nil {
+
+		// query param createdBefore
+		var qrCreatedBefore strfmt.DateTime
+
+		if o.CreatedBefore != nil {
+			qrCreatedBefore = *o.CreatedBefore
+		}
+		qCreatedBefore := qrCreatedBefore.String()
+		if qCreatedBefore != "" {
+
+			if err := r.SetQueryParam("createdBefore", qCreatedBefore); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Cursor != nil {
+
+		// query param cursor
+		var qrCursor string
+
+		if o.Cursor != nil {
+			qrCursor = *o.Cursor
+		}
+		qCursor := qrCursor
+		if qCursor != "" {
+
+			if err := r.SetQueryParam("cursor", qCursor); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Label != nil {
+
+		// query param label
+		var qrLabel string
+
+		if o.Label != nil {
+			qrLabel = *o.Label
+		}
+		qLabel := qrLabel
+		if qLabel != "" {
+
+			if err := r.SetQueryParam("label", qLabel); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Limit != nil {
+
+		// query param limit
+		var qrLimit int64
+
+		if o.Limit != nil {
+			qrLimit = *o.Limit
+		}
+		qLimit := swag.FormatInt64(qrLimit)
+		if qLimit != "" {
+
+			if err := r.SetQueryParam("limit", qLimit); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.PoolID != nil {
+
+		// query param poolID
+		var qrPoolID string
+
+		if o.PoolID != nil {
+			qrPoolID = *o.PoolID
+		}
+		qPoolID := qrPoolID
+		if qPoolID != "" {
+
+			if err := r.SetQueryParam("poolID", qPoolID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.ProviderName != nil {
+
+		// query param providerName
+		var qrProviderName string
+
+		if o.ProviderName != nil {
+			qrProviderName = *o.ProviderName
+		}
+		qProviderName := qrProviderName
+		if qProviderName != "" {
+
+			if err := r.SetQueryParam("providerName", qProviderName); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Status != nil {
+
+		// query param status
+		var qrStatus string
+
+		if o.Status != nil {
+			qrStatus = *o.Status
+		}
+		qStatus := qrStatus
+		if qStatus != "" {
+
+			if err := r.SetQueryParam("status", qStatus); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.UpdatedAfter != nil {
+
+		// query param updatedAfter
+		var qrUpdatedAfter strfmt.DateTime
+
+		if o.UpdatedAfter != nil {
+			qrUpdatedAfter = *o.UpdatedAfter
+		}
+		qUpdatedAfter := qrUpdatedAfter.String()
+		if qUpdatedAfter != "" {
+
+			if err := r.SetQueryParam("updatedAfter", qUpdatedAfter); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.UpdatedBefore != nil {
+
+		// query param updatedBefore
+		var qrUpdatedBefore strfmt.DateTime
+
+		if o.UpdatedBefore != nil {
+			qrUpdatedBefore = *o.UpdatedBefore
+		}
+		qUpdatedBefore := qrUpdatedBefore.String()
+		if qUpdatedBefore != "" {
+
+			if err := r.SetQueryParam("updatedBefore", qUpdatedBefore); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
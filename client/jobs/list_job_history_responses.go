@@ -0,0 +1,191 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package jobs
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// ListJobHistoryReader is a Reader for the ListJobHistory structure.
+type ListJobHistoryReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListJobHistoryReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListJobHistoryOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewListJobHistoryBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("[GET /jobs/history] ListJobHistory", response, response.Code())
+	}
+}
+
+// NewListJobHistoryOK creates a ListJobHistoryOK with default headers values
+func NewListJobHistoryOK() *ListJobHistoryOK {
+	return &ListJobHistoryOK{}
+}
+
+/*
+ListJobHistoryOK describes a response with status code 200, with default header values.
+
+A page of job history entries.
+*/
+type ListJobHistoryOK struct {
+
+	/* Cursor of the next page. Not set on the last page.
+	 */
+	XNextCursor string
+
+	Payload garm_params.JobHistories
+}
+
+// IsSuccess returns true when this list job history o k response has a 2xx status code
+func (o *ListJobHistoryOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this list job history o k response has a 3xx status code
+func (o *ListJobHistoryOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this list job history o k response has a 4xx status code
+func (o *ListJobHistoryOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this list job history o k response has a 5xx status code
+func (o *ListJobHistoryOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this list job history o k response a status code equal to that given
+func (o *ListJobHistoryOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the list job history o k response
+func (o *ListJobHistoryOK) Code() int {
+	return 200
+}
+
+func (o *ListJobHistoryOK) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /jobs/history][%d] listJobHistoryOK %s", 200, payload)
+}
+
+func (o *ListJobHistoryOK) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /jobs/history][%d] listJobHistoryOK %s", 200, payload)
+}
+
+func (o *ListJobHistoryOK) GetPayload() garm_params.JobHistories {
+	return o.Payload
+}
+
+func (o *ListJobHistoryOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// hydrates response header X-Next-Cursor
+	hdrXNextCursor := response.GetHeader("X-Next-Cursor")
+
+	if hdrXNextCursor != "" {
+		o.XNextCursor = hdrXNextCursor
+	}
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListJobHistoryBadRequest creates a ListJobHistoryBadRequest with default headers values
+func NewListJobHistoryBadRequest() *ListJobHistoryBadRequest {
+	return &ListJobHistoryBadRequest{}
+}
+
+/*
+ListJobHistoryBadRequest describes a response with status code 400, with default header values.
+
+APIErrorResponse
+*/
+type ListJobHistoryBadRequest struct {
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this list job history bad request response has a 2xx status code
+func (o *ListJobHistoryBadRequest) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this list job history bad request response has a 3xx status code
+func (o *ListJobHistoryBadRequest) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this list job history bad request response has a 4xx status code
+func (o *ListJobHistoryBadRequest) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this list job history bad request response has a 5xx status code
+func (o *ListJobHistoryBadRequest) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this list job history bad request response a status code equal to that given
+func (o *ListJobHistoryBadRequest) IsCode(code int) bool {
+	return code == 400
+}
+
+// Code gets the status code for the list job history bad request response
+func (o *ListJobHistoryBadRequest) Code() int {
+	return 400
+}
+
+func (o *ListJobHistoryBadRequest) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /jobs/history][%d] listJobHistoryBadRequest %s", 400, payload)
+}
+
+func (o *ListJobHistoryBadRequest) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /jobs/history][%d] listJobHistoryBadRequest %s", 400, payload)
+}
+
+func (o *ListJobHistoryBadRequest) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *ListJobHistoryBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
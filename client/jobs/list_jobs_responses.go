@@ -50,9 +50,14 @@ func NewListJobsOK() *ListJobsOK {
 /*
 ListJobsOK describes a response with status code 200, with default header values.
 
-Jobs
+A page of jobs.
 */
 type ListJobsOK struct {
+
+	/* Cursor of the next page. Not set on the last page.
+	 */
+	XNextCursor string
+
 	Payload garm_params.Jobs
 }
 
@@ -102,6 +107,13 @@ func (o *ListJobsOK) GetPayload() garm_params.Jobs {
 
 func (o *ListJobsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// hydrates response header X-Next-Cursor
+	hdrXNextCursor := response.GetHeader("X-Next-Cursor")
+
+	if hdrXNextCursor != "" {
+		o.XNextCursor = hdrXNextCursor
+	}
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
@@ -0,0 +1,151 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// NewCreateNotificationSinkParams creates a new CreateNotificationSinkParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewCreateNotificationSinkParams() *CreateNotificationSinkParams {
+	return &CreateNotificationSinkParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewCreateNotificationSinkParamsWithTimeout creates a new CreateNotificationSinkParams object
+// with the ability to set a timeout on a request.
+func NewCreateNotificationSinkParamsWithTimeout(timeout time.Duration) *CreateNotificationSinkParams {
+	return &CreateNotificationSinkParams{
+		timeout: timeout,
+	}
+}
+
+// NewCreateNotificationSinkParamsWithContext creates a new CreateNotificationSinkParams object
+// with the ability to set a context for a request.
+func NewCreateNotificationSinkParamsWithContext(ctx context.Context) *CreateNotificationSinkParams {
+	return &CreateNotificationSinkParams{
+		Context: ctx,
+	}
+}
+
+// NewCreateNotificationSinkParamsWithHTTPClient creates a new CreateNotificationSinkParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewCreateNotificationSinkParamsWithHTTPClient(client *http.Client) *CreateNotificationSinkParams {
+	return &CreateNotificationSinkParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+CreateNotificationSinkParams contains all the parameters to send to the API endpoint
+
+	for the create notification sink operation.
+
+	Typically these are written to a http.Request.
+*/
+type CreateNotificationSinkParams struct {
+
+	/* Body.
+
+	   Parameters used when creating a notification sink.
+	*/
+	Body garm_params.CreateNotificationSinkParams
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the create notification sink params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *CreateNotificationSinkParams) WithDefaults() *CreateNotificationSinkParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the create notification sink params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *CreateNotificationSinkParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the create notification sink params
+func (o *CreateNotificationSinkParams) WithTimeout(timeout time.Duration) *CreateNotificationSinkParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the create notification sink params
+func (o *CreateNotificationSinkParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the create notification sink params
+func (o *CreateNotificationSinkParams) WithContext(ctx context.Context) *CreateNotificationSinkParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the create notification sink params
+func (o *CreateNotificationSinkParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the create notification sink params
+func (o *CreateNotificationSinkParams) WithHTTPClient(client *http.Client) *CreateNotificationSinkParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the create notification sink params
+func (o *CreateNotificationSinkParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the create notification sink params
+func (o *CreateNotificationSinkParams) WithBody(body garm_params.CreateNotificationSinkParams) *CreateNotificationSinkParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the create notification sink params
+func (o *CreateNotificationSinkParams) SetBody(body garm_params.CreateNotificationSinkParams) {
+	o.Body = body
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *CreateNotificationSinkParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if err := r.SetBodyParam(o.Body); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
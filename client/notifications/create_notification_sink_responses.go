@@ -0,0 +1,179 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// CreateNotificationSinkReader is a Reader for the CreateNotificationSink structure.
+type CreateNotificationSinkReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *CreateNotificationSinkReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewCreateNotificationSinkOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewCreateNotificationSinkBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("[POST /notifications/sinks] CreateNotificationSink", response, response.Code())
+	}
+}
+
+// NewCreateNotificationSinkOK creates a CreateNotificationSinkOK with default headers values
+func NewCreateNotificationSinkOK() *CreateNotificationSinkOK {
+	return &CreateNotificationSinkOK{}
+}
+
+/*
+CreateNotificationSinkOK describes a response with status code 200, with default header values.
+
+NotificationSink
+*/
+type CreateNotificationSinkOK struct {
+	Payload garm_params.NotificationSink
+}
+
+// IsSuccess returns true when this create notification sink o k response has a 2xx status code
+func (o *CreateNotificationSinkOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this create notification sink o k response has a 3xx status code
+func (o *CreateNotificationSinkOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this create notification sink o k response has a 4xx status code
+func (o *CreateNotificationSinkOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this create notification sink o k response has a 5xx status code
+func (o *CreateNotificationSinkOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this create notification sink o k response a status code equal to that given
+func (o *CreateNotificationSinkOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the create notification sink o k response
+func (o *CreateNotificationSinkOK) Code() int {
+	return 200
+}
+
+func (o *CreateNotificationSinkOK) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[POST /notifications/sinks][%d] createNotificationSinkOK %s", 200, payload)
+}
+
+func (o *CreateNotificationSinkOK) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[POST /notifications/sinks][%d] createNotificationSinkOK %s", 200, payload)
+}
+
+func (o *CreateNotificationSinkOK) GetPayload() garm_params.NotificationSink {
+	return o.Payload
+}
+
+func (o *CreateNotificationSinkOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateNotificationSinkBadRequest creates a CreateNotificationSinkBadRequest with default headers values
+func NewCreateNotificationSinkBadRequest() *CreateNotificationSinkBadRequest {
+	return &CreateNotificationSinkBadRequest{}
+}
+
+/*
+CreateNotificationSinkBadRequest describes a response with status code 400, with default header values.
+
+APIErrorResponse
+*/
+type CreateNotificationSinkBadRequest struct {
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this create notification sink bad request response has a 2xx status code
+func (o *CreateNotificationSinkBadRequest) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this create notification sink bad request response has a 3xx status code
+func (o *CreateNotificationSinkBadRequest) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this create notification sink bad request response has a 4xx status code
+func (o *CreateNotificationSinkBadRequest) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this create notification sink bad request response has a 5xx status code
+func (o *CreateNotificationSinkBadRequest) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this create notification sink bad request response a status code equal to that given
+func (o *CreateNotificationSinkBadRequest) IsCode(code int) bool {
+	return code == 400
+}
+
+// Code gets the status code for the create notification sink bad request response
+func (o *CreateNotificationSinkBadRequest) Code() int {
+	return 400
+}
+
+func (o *CreateNotificationSinkBadRequest) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[POST /notifications/sinks][%d] createNotificationSinkBadRequest %s", 400, payload)
+}
+
+func (o *CreateNotificationSinkBadRequest) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[POST /notifications/sinks][%d] createNotificationSinkBadRequest %s", 400, payload)
+}
+
+func (o *CreateNotificationSinkBadRequest) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *CreateNotificationSinkBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewDeleteNotificationSinkParams creates a new DeleteNotificationSinkParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewDeleteNotificationSinkParams() *DeleteNotificationSinkParams {
+	return &DeleteNotificationSinkParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewDeleteNotificationSinkParamsWithTimeout creates a new DeleteNotificationSinkParams object
+// with the ability to set a timeout on a request.
+func NewDeleteNotificationSinkParamsWithTimeout(timeout time.Duration) *DeleteNotificationSinkParams {
+	return &DeleteNotificationSinkParams{
+		timeout: timeout,
+	}
+}
+
+// NewDeleteNotificationSinkParamsWithContext creates a new DeleteNotificationSinkParams object
+// with the ability to set a context for a request.
+func NewDeleteNotificationSinkParamsWithContext(ctx context.Context) *DeleteNotificationSinkParams {
+	return &DeleteNotificationSinkParams{
+		Context: ctx,
+	}
+}
+
+// NewDeleteNotificationSinkParamsWithHTTPClient creates a new DeleteNotificationSinkParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewDeleteNotificationSinkParamsWithHTTPClient(client *http.Client) *DeleteNotificationSinkParams {
+	return &DeleteNotificationSinkParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+DeleteNotificationSinkParams contains all the parameters to send to the API endpoint
+
+	for the delete notification sink operation.
+
+	Typically these are written to a http.Request.
+*/
+type DeleteNotificationSinkParams struct {
+
+	/* SinkID.
+
+	   ID of the notification sink.
+	*/
+	SinkID int64
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the delete notification sink params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *DeleteNotificationSinkParams) WithDefaults() *DeleteNotificationSinkParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the delete notification sink params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *DeleteNotificationSinkParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the delete notification sink params
+func (o *DeleteNotificationSinkParams) WithTimeout(timeout time.Duration) *DeleteNotificationSinkParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the delete notification sink params
+func (o *DeleteNotificationSinkParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the delete notification sink params
+func (o *DeleteNotificationSinkParams) WithContext(ctx context.Context) *DeleteNotificationSinkParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the delete notification sink params
+func (o *DeleteNotificationSinkParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the delete notification sink params
+func (o *DeleteNotificationSinkParams) WithHTTPClient(client *http.Client) *DeleteNotificationSinkParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the delete notification sink params
+func (o *DeleteNotificationSinkParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithSinkID adds the sinkID to the delete notification sink params
+func (o *DeleteNotificationSinkParams) WithSinkID(sinkID int64) *DeleteNotificationSinkParams {
+	o.SetSinkID(sinkID)
+	return o
+}
+
+// SetSinkID adds the sinkId to the delete notification sink params
+func (o *DeleteNotificationSinkParams) SetSinkID(sinkID int64) {
+	o.SinkID = sinkID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *DeleteNotificationSinkParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param sinkID
+	if err := r.SetPathParam("sinkID", swag.FormatInt64(o.SinkID)); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+)
+
+// DeleteNotificationSinkReader is a Reader for the DeleteNotificationSink structure.
+type DeleteNotificationSinkReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *DeleteNotificationSinkReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	result := NewDeleteNotificationSinkDefault(response.Code())
+	if err := result.readResponse(response, consumer, o.formats); err != nil {
+		return nil, err
+	}
+	if response.Code()/100 == 2 {
+		return result, nil
+	}
+	return nil, result
+}
+
+// NewDeleteNotificationSinkDefault creates a DeleteNotificationSinkDefault with default headers values
+func NewDeleteNotificationSinkDefault(code int) *DeleteNotificationSinkDefault {
+	return &DeleteNotificationSinkDefault{
+		_statusCode: code,
+	}
+}
+
+/*
+DeleteNotificationSinkDefault describes a response with status code -1, with default header values.
+
+APIErrorResponse
+*/
+type DeleteNotificationSinkDefault struct {
+	_statusCode int
+
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this delete notification sink default response has a 2xx status code
+func (o *DeleteNotificationSinkDefault) IsSuccess() bool {
+	return o._statusCode/100 == 2
+}
+
+// IsRedirect returns true when this delete notification sink default response has a 3xx status code
+func (o *DeleteNotificationSinkDefault) IsRedirect() bool {
+	return o._statusCode/100 == 3
+}
+
+// IsClientError returns true when this delete notification sink default response has a 4xx status code
+func (o *DeleteNotificationSinkDefault) IsClientError() bool {
+	return o._statusCode/100 == 4
+}
+
+// IsServerError returns true when this delete notification sink default response has a 5xx status code
+func (o *DeleteNotificationSinkDefault) IsServerError() bool {
+	return o._statusCode/100 == 5
+}
+
+// IsCode returns true when this delete notification sink default response a status code equal to that given
+func (o *DeleteNotificationSinkDefault) IsCode(code int) bool {
+	return o._statusCode == code
+}
+
+// Code gets the status code for the delete notification sink default response
+func (o *DeleteNotificationSinkDefault) Code() int {
+	return o._statusCode
+}
+
+func (o *DeleteNotificationSinkDefault) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[DELETE /notifications/sinks/{sinkID}][%d] DeleteNotificationSink default %s", o._statusCode, payload)
+}
+
+func (o *DeleteNotificationSinkDefault) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[DELETE /notifications/sinks/{sinkID}][%d] DeleteNotificationSink default %s", o._statusCode, payload)
+}
+
+func (o *DeleteNotificationSinkDefault) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *DeleteNotificationSinkDefault) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
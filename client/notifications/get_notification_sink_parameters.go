@@ -0,0 +1,152 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewGetNotificationSinkParams creates a new GetNotificationSinkParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewGetNotificationSinkParams() *GetNotificationSinkParams {
+	return &GetNotificationSinkParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewGetNotificationSinkParamsWithTimeout creates a new GetNotificationSinkParams object
+// with the ability to set a timeout on a request.
+func NewGetNotificationSinkParamsWithTimeout(timeout time.Duration) *GetNotificationSinkParams {
+	return &GetNotificationSinkParams{
+		timeout: timeout,
+	}
+}
+
+// NewGetNotificationSinkParamsWithContext creates a new GetNotificationSinkParams object
+// with the ability to set a context for a request.
+func NewGetNotificationSinkParamsWithContext(ctx context.Context) *GetNotificationSinkParams {
+	return &GetNotificationSinkParams{
+		Context: ctx,
+	}
+}
+
+// NewGetNotificationSinkParamsWithHTTPClient creates a new GetNotificationSinkParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewGetNotificationSinkParamsWithHTTPClient(client *http.Client) *GetNotificationSinkParams {
+	return &GetNotificationSinkParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+GetNotificationSinkParams contains all the parameters to send to the API endpoint
+
+	for the get notification sink operation.
+
+	Typically these are written to a http.Request.
+*/
+type GetNotificationSinkParams struct {
+
+	/* SinkID.
+
+	   ID of the notification sink.
+	*/
+	SinkID int64
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the get notification sink params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *GetNotificationSinkParams) WithDefaults() *GetNotificationSinkParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the get notification sink params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *GetNotificationSinkParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the get notification sink params
+func (o *GetNotificationSinkParams) WithTimeout(timeout time.Duration) *GetNotificationSinkParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the get notification sink params
+func (o *GetNotificationSinkParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the get notification sink params
+func (o *GetNotificationSinkParams) WithContext(ctx context.Context) *GetNotificationSinkParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the get notification sink params
+func (o *GetNotificationSinkParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the get notification sink params
+func (o *GetNotificationSinkParams) WithHTTPClient(client *http.Client) *GetNotificationSinkParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the get notification sink params
+func (o *GetNotificationSinkParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithSinkID adds the sinkID to the get notification sink params
+func (o *GetNotificationSinkParams) WithSinkID(sinkID int64) *GetNotificationSinkParams {
+	o.SetSinkID(sinkID)
+	return o
+}
+
+// SetSinkID adds the sinkId to the get notification sink params
+func (o *GetNotificationSinkParams) SetSinkID(sinkID int64) {
+	o.SinkID = sinkID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *GetNotificationSinkParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param sinkID
+	if err := r.SetPathParam("sinkID", swag.FormatInt64(o.SinkID)); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// GetNotificationSinkReader is a Reader for the GetNotificationSink structure.
+type GetNotificationSinkReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetNotificationSinkReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetNotificationSinkOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewGetNotificationSinkBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("[GET /notifications/sinks/{sinkID}] GetNotificationSink", response, response.Code())
+	}
+}
+
+// NewGetNotificationSinkOK creates a GetNotificationSinkOK with default headers values
+func NewGetNotificationSinkOK() *GetNotificationSinkOK {
+	return &GetNotificationSinkOK{}
+}
+
+/*
+GetNotificationSinkOK describes a response with status code 200, with default header values.
+
+NotificationSink
+*/
+type GetNotificationSinkOK struct {
+	Payload garm_params.NotificationSink
+}
+
+// IsSuccess returns true when this get notification sink o k response has a 2xx status code
+func (o *GetNotificationSinkOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this get notification sink o k response has a 3xx status code
+func (o *GetNotificationSinkOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this get notification sink o k response has a 4xx status code
+func (o *GetNotificationSinkOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this get notification sink o k response has a 5xx status code
+func (o *GetNotificationSinkOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this get notification sink o k response a status code equal to that given
+func (o *GetNotificationSinkOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the get notification sink o k response
+func (o *GetNotificationSinkOK) Code() int {
+	return 200
+}
+
+func (o *GetNotificationSinkOK) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /notifications/sinks/{sinkID}][%d] getNotificationSinkOK %s", 200, payload)
+}
+
+func (o *GetNotificationSinkOK) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /notifications/sinks/{sinkID}][%d] getNotificationSinkOK %s", 200, payload)
+}
+
+func (o *GetNotificationSinkOK) GetPayload() garm_params.NotificationSink {
+	return o.Payload
+}
+
+func (o *GetNotificationSinkOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetNotificationSinkBadRequest creates a GetNotificationSinkBadRequest with default headers values
+func NewGetNotificationSinkBadRequest() *GetNotificationSinkBadRequest {
+	return &GetNotificationSinkBadRequest{}
+}
+
+/*
+GetNotificationSinkBadRequest describes a response with status code 400, with default header values.
+
+APIErrorResponse
+*/
+type GetNotificationSinkBadRequest struct {
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this get notification sink bad request response has a 2xx status code
+func (o *GetNotificationSinkBadRequest) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this get notification sink bad request response has a 3xx status code
+func (o *GetNotificationSinkBadRequest) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this get notification sink bad request response has a 4xx status code
+func (o *GetNotificationSinkBadRequest) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this get notification sink bad request response has a 5xx status code
+func (o *GetNotificationSinkBadRequest) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this get notification sink bad request response a status code equal to that given
+func (o *GetNotificationSinkBadRequest) IsCode(code int) bool {
+	return code == 400
+}
+
+// Code gets the status code for the get notification sink bad request response
+func (o *GetNotificationSinkBadRequest) Code() int {
+	return 400
+}
+
+func (o *GetNotificationSinkBadRequest) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /notifications/sinks/{sinkID}][%d] getNotificationSinkBadRequest %s", 400, payload)
+}
+
+func (o *GetNotificationSinkBadRequest) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /notifications/sinks/{sinkID}][%d] getNotificationSinkBadRequest %s", 400, payload)
+}
+
+func (o *GetNotificationSinkBadRequest) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *GetNotificationSinkBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
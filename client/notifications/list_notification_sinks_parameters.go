@@ -0,0 +1,128 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewListNotificationSinksParams creates a new ListNotificationSinksParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewListNotificationSinksParams() *ListNotificationSinksParams {
+	return &ListNotificationSinksParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewListNotificationSinksParamsWithTimeout creates a new ListNotificationSinksParams object
+// with the ability to set a timeout on a request.
+func NewListNotificationSinksParamsWithTimeout(timeout time.Duration) *ListNotificationSinksParams {
+	return &ListNotificationSinksParams{
+		timeout: timeout,
+	}
+}
+
+// NewListNotificationSinksParamsWithContext creates a new ListNotificationSinksParams object
+// with the ability to set a context for a request.
+func NewListNotificationSinksParamsWithContext(ctx context.Context) *ListNotificationSinksParams {
+	return &ListNotificationSinksParams{
+		Context: ctx,
+	}
+}
+
+// NewListNotificationSinksParamsWithHTTPClient creates a new ListNotificationSinksParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewListNotificationSinksParamsWithHTTPClient(client *http.Client) *ListNotificationSinksParams {
+	return &ListNotificationSinksParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+ListNotificationSinksParams contains all the parameters to send to the API endpoint
+
+	for the list notification sinks operation.
+
+	Typically these are written to a http.Request.
+*/
+type ListNotificationSinksParams struct {
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the list notification sinks params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ListNotificationSinksParams) WithDefaults() *ListNotificationSinksParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the list notification sinks params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ListNotificationSinksParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the list notification sinks params
+func (o *ListNotificationSinksParams) WithTimeout(timeout time.Duration) *ListNotificationSinksParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the list notification sinks params
+func (o *ListNotificationSinksParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the list notification sinks params
+func (o *ListNotificationSinksParams) WithContext(ctx context.Context) *ListNotificationSinksParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the list notification sinks params
+func (o *ListNotificationSinksParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the list notification sinks params
+func (o *ListNotificationSinksParams) WithHTTPClient(client *http.Client) *ListNotificationSinksParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the list notification sinks params
+func (o *ListNotificationSinksParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ListNotificationSinksParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
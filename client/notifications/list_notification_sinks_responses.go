@@ -0,0 +1,179 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// ListNotificationSinksReader is a Reader for the ListNotificationSinks structure.
+type ListNotificationSinksReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListNotificationSinksReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListNotificationSinksOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewListNotificationSinksBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("[GET /notifications/sinks] ListNotificationSinks", response, response.Code())
+	}
+}
+
+// NewListNotificationSinksOK creates a ListNotificationSinksOK with default headers values
+func NewListNotificationSinksOK() *ListNotificationSinksOK {
+	return &ListNotificationSinksOK{}
+}
+
+/*
+ListNotificationSinksOK describes a response with status code 200, with default header values.
+
+NotificationSinks
+*/
+type ListNotificationSinksOK struct {
+	Payload garm_params.NotificationSinks
+}
+
+// IsSuccess returns true when this list notification sinks o k response has a 2xx status code
+func (o *ListNotificationSinksOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this list notification sinks o k response has a 3xx status code
+func (o *ListNotificationSinksOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this list notification sinks o k response has a 4xx status code
+func (o *ListNotificationSinksOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this list notification sinks o k response has a 5xx status code
+func (o *ListNotificationSinksOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this list notification sinks o k response a status code equal to that given
+func (o *ListNotificationSinksOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the list notification sinks o k response
+func (o *ListNotificationSinksOK) Code() int {
+	return 200
+}
+
+func (o *ListNotificationSinksOK) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /notifications/sinks][%d] listNotificationSinksOK %s", 200, payload)
+}
+
+func (o *ListNotificationSinksOK) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /notifications/sinks][%d] listNotificationSinksOK %s", 200, payload)
+}
+
+func (o *ListNotificationSinksOK) GetPayload() garm_params.NotificationSinks {
+	return o.Payload
+}
+
+func (o *ListNotificationSinksOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListNotificationSinksBadRequest creates a ListNotificationSinksBadRequest with default headers values
+func NewListNotificationSinksBadRequest() *ListNotificationSinksBadRequest {
+	return &ListNotificationSinksBadRequest{}
+}
+
+/*
+ListNotificationSinksBadRequest describes a response with status code 400, with default header values.
+
+APIErrorResponse
+*/
+type ListNotificationSinksBadRequest struct {
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this list notification sinks bad request response has a 2xx status code
+func (o *ListNotificationSinksBadRequest) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this list notification sinks bad request response has a 3xx status code
+func (o *ListNotificationSinksBadRequest) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this list notification sinks bad request response has a 4xx status code
+func (o *ListNotificationSinksBadRequest) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this list notification sinks bad request response has a 5xx status code
+func (o *ListNotificationSinksBadRequest) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this list notification sinks bad request response a status code equal to that given
+func (o *ListNotificationSinksBadRequest) IsCode(code int) bool {
+	return code == 400
+}
+
+// Code gets the status code for the list notification sinks bad request response
+func (o *ListNotificationSinksBadRequest) Code() int {
+	return 400
+}
+
+func (o *ListNotificationSinksBadRequest) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /notifications/sinks][%d] listNotificationSinksBadRequest %s", 400, payload)
+}
+
+func (o *ListNotificationSinksBadRequest) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /notifications/sinks][%d] listNotificationSinksBadRequest %s", 400, payload)
+}
+
+func (o *ListNotificationSinksBadRequest) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *ListNotificationSinksBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
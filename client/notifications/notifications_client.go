@@ -0,0 +1,297 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// New creates a new notifications API client.
+func New(transport runtime.ClientTransport, formats strfmt.Registry) ClientService {
+	return &Client{transport: transport, formats: formats}
+}
+
+// New creates a new notifications API client with basic auth credentials.
+// It takes the following parameters:
+// - host: http host (github.com).
+// - basePath: any base path for the API client ("/v1", "/v3").
+// - scheme: http scheme ("http", "https").
+// - user: user for basic authentication header.
+// - password: password for basic authentication header.
+func NewClientWithBasicAuth(host, basePath, scheme, user, password string) ClientService {
+	transport := httptransport.New(host, basePath, []string{scheme})
+	transport.DefaultAuthentication = httptransport.BasicAuth(user, password)
+	return &Client{transport: transport, formats: strfmt.Default}
+}
+
+// New creates a new notifications API client with a bearer token for authentication.
+// It takes the following parameters:
+// - host: http host (github.com).
+// - basePath: any base path for the API client ("/v1", "/v3").
+// - scheme: http scheme ("http", "https").
+// - bearerToken: bearer token for Bearer authentication header.
+func NewClientWithBearerToken(host, basePath, scheme, bearerToken string) ClientService {
+	transport := httptransport.New(host, basePath, []string{scheme})
+	transport.DefaultAuthentication = httptransport.BearerToken(bearerToken)
+	return &Client{transport: transport, formats: strfmt.Default}
+}
+
+/*
+Client for notifications API
+*/
+type Client struct {
+	transport runtime.ClientTransport
+	formats   strfmt.Registry
+}
+
+// ClientOption may be used to customize the behavior of Client methods.
+type ClientOption func(*runtime.ClientOperation)
+
+// ClientService is the interface for Client methods
+type ClientService interface {
+	CreateNotificationSink(params *CreateNotificationSinkParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*CreateNotificationSinkOK, error)
+
+	DeleteNotificationSink(params *DeleteNotificationSinkParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) error
+
+	GetNotificationSink(params *GetNotificationSinkParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetNotificationSinkOK, error)
+
+	ListNotificationSinks(params *ListNotificationSinksParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListNotificationSinksOK, error)
+
+	TestNotificationSink(params *TestNotificationSinkParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) error
+
+	UpdateNotificationSink(params *UpdateNotificationSinkParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*UpdateNotificationSinkOK, error)
+
+	SetTransport(transport runtime.ClientTransport)
+}
+
+/*
+CreateNotificationSink creates a notification sink
+*/
+func (a *Client) CreateNotificationSink(params *CreateNotificationSinkParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*CreateNotificationSinkOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateNotificationSinkParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "CreateNotificationSink",
+		Method:             "POST",
+		PathPattern:        "/notifications/sinks",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &CreateNotificationSinkReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateNotificationSinkOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for CreateNotificationSink: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+DeleteNotificationSink deletes a notification sink
+*/
+func (a *Client) DeleteNotificationSink(params *DeleteNotificationSinkParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) error {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeleteNotificationSinkParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "DeleteNotificationSink",
+		Method:             "DELETE",
+		PathPattern:        "/notifications/sinks/{sinkID}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &DeleteNotificationSinkReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	_, err := a.transport.Submit(op)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+/*
+GetNotificationSink gets a notification sink
+*/
+func (a *Client) GetNotificationSink(params *GetNotificationSinkParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetNotificationSinkOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetNotificationSinkParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetNotificationSink",
+		Method:             "GET",
+		PathPattern:        "/notifications/sinks/{sinkID}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetNotificationSinkReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetNotificationSinkOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetNotificationSink: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+ListNotificationSinks lists notification sinks sinks defined in the config file are listed first
+*/
+func (a *Client) ListNotificationSinks(params *ListNotificationSinksParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListNotificationSinksOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListNotificationSinksParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ListNotificationSinks",
+		Method:             "GET",
+		PathPattern:        "/notifications/sinks",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ListNotificationSinksReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListNotificationSinksOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for ListNotificationSinks: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+TestNotificationSink sends a test notification to a sink
+*/
+func (a *Client) TestNotificationSink(params *TestNotificationSinkParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) error {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewTestNotificationSinkParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "TestNotificationSink",
+		Method:             "POST",
+		PathPattern:        "/notifications/sinks/{sinkID}/test",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &TestNotificationSinkReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	_, err := a.transport.Submit(op)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+/*
+UpdateNotificationSink updates a notification sink
+*/
+func (a *Client) UpdateNotificationSink(params *UpdateNotificationSinkParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*UpdateNotificationSinkOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewUpdateNotificationSinkParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "UpdateNotificationSink",
+		Method:             "PUT",
+		PathPattern:        "/notifications/sinks/{sinkID}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &UpdateNotificationSinkReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*UpdateNotificationSinkOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for UpdateNotificationSink: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+// SetTransport changes the transport on the client
+func (a *Client) SetTransport(transport runtime.ClientTransport) {
+	a.transport = transport
+}
@@ -0,0 +1,152 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewTestNotificationSinkParams creates a new TestNotificationSinkParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewTestNotificationSinkParams() *TestNotificationSinkParams {
+	return &TestNotificationSinkParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewTestNotificationSinkParamsWithTimeout creates a new TestNotificationSinkParams object
+// with the ability to set a timeout on a request.
+func NewTestNotificationSinkParamsWithTimeout(timeout time.Duration) *TestNotificationSinkParams {
+	return &TestNotificationSinkParams{
+		timeout: timeout,
+	}
+}
+
+// NewTestNotificationSinkParamsWithContext creates a new TestNotificationSinkParams object
+// with the ability to set a context for a request.
+func NewTestNotificationSinkParamsWithContext(ctx context.Context) *TestNotificationSinkParams {
+	return &TestNotificationSinkParams{
+		Context: ctx,
+	}
+}
+
+// NewTestNotificationSinkParamsWithHTTPClient creates a new TestNotificationSinkParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewTestNotificationSinkParamsWithHTTPClient(client *http.Client) *TestNotificationSinkParams {
+	return &TestNotificationSinkParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+TestNotificationSinkParams contains all the parameters to send to the API endpoint
+
+	for the test notification sink operation.
+
+	Typically these are written to a http.Request.
+*/
+type TestNotificationSinkParams struct {
+
+	/* SinkID.
+
+	   ID of the notification sink.
+	*/
+	SinkID int64
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the test notification sink params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *TestNotificationSinkParams) WithDefaults() *TestNotificationSinkParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the test notification sink params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *TestNotificationSinkParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the test notification sink params
+func (o *TestNotificationSinkParams) WithTimeout(timeout time.Duration) *TestNotificationSinkParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the test notification sink params
+func (o *TestNotificationSinkParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the test notification sink params
+func (o *TestNotificationSinkParams) WithContext(ctx context.Context) *TestNotificationSinkParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the test notification sink params
+func (o *TestNotificationSinkParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the test notification sink params
+func (o *TestNotificationSinkParams) WithHTTPClient(client *http.Client) *TestNotificationSinkParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the test notification sink params
+func (o *TestNotificationSinkParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithSinkID adds the sinkID to the test notification sink params
+func (o *TestNotificationSinkParams) WithSinkID(sinkID int64) *TestNotificationSinkParams {
+	o.SetSinkID(sinkID)
+	return o
+}
+
+// SetSinkID adds the sinkId to the test notification sink params
+func (o *TestNotificationSinkParams) SetSinkID(sinkID int64) {
+	o.SinkID = sinkID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *TestNotificationSinkParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param sinkID
+	if err := r.SetPathParam("sinkID", swag.FormatInt64(o.SinkID)); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+)
+
+// TestNotificationSinkReader is a Reader for the TestNotificationSink structure.
+type TestNotificationSinkReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *TestNotificationSinkReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	result := NewTestNotificationSinkDefault(response.Code())
+	if err := result.readResponse(response, consumer, o.formats); err != nil {
+		return nil, err
+	}
+	if response.Code()/100 == 2 {
+		return result, nil
+	}
+	return nil, result
+}
+
+// NewTestNotificationSinkDefault creates a TestNotificationSinkDefault with default headers values
+func NewTestNotificationSinkDefault(code int) *TestNotificationSinkDefault {
+	return &TestNotificationSinkDefault{
+		_statusCode: code,
+	}
+}
+
+/*
+TestNotificationSinkDefault describes a response with status code -1, with default header values.
+
+APIErrorResponse
+*/
+type TestNotificationSinkDefault struct {
+	_statusCode int
+
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this test notification sink default response has a 2xx status code
+func (o *TestNotificationSinkDefault) IsSuccess() bool {
+	return o._statusCode/100 == 2
+}
+
+// IsRedirect returns true when this test notification sink default response has a 3xx status code
+func (o *TestNotificationSinkDefault) IsRedirect() bool {
+	return o._statusCode/100 == 3
+}
+
+// IsClientError returns true when this test notification sink default response has a 4xx status code
+func (o *TestNotificationSinkDefault) IsClientError() bool {
+	return o._statusCode/100 == 4
+}
+
+// IsServerError returns true when this test notification sink default response has a 5xx status code
+func (o *TestNotificationSinkDefault) IsServerError() bool {
+	return o._statusCode/100 == 5
+}
+
+// IsCode returns true when this test notification sink default response a status code equal to that given
+func (o *TestNotificationSinkDefault) IsCode(code int) bool {
+	return o._statusCode == code
+}
+
+// Code gets the status code for the test notification sink default response
+func (o *TestNotificationSinkDefault) Code() int {
+	return o._statusCode
+}
+
+func (o *TestNotificationSinkDefault) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[POST /notifications/sinks/{sinkID}/test][%d] TestNotificationSink default %s", o._statusCode, payload)
+}
+
+func (o *TestNotificationSinkDefault) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[POST /notifications/sinks/{sinkID}/test][%d] TestNotificationSink default %s", o._statusCode, payload)
+}
+
+func (o *TestNotificationSinkDefault) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *TestNotificationSinkDefault) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
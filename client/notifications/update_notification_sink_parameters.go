@@ -0,0 +1,174 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// NewUpdateNotificationSinkParams creates a new UpdateNotificationSinkParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewUpdateNotificationSinkParams() *UpdateNotificationSinkParams {
+	return &UpdateNotificationSinkParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewUpdateNotificationSinkParamsWithTimeout creates a new UpdateNotificationSinkParams object
+// with the ability to set a timeout on a request.
+func NewUpdateNotificationSinkParamsWithTimeout(timeout time.Duration) *UpdateNotificationSinkParams {
+	return &UpdateNotificationSinkParams{
+		timeout: timeout,
+	}
+}
+
+// NewUpdateNotificationSinkParamsWithContext creates a new UpdateNotificationSinkParams object
+// with the ability to set a context for a request.
+func NewUpdateNotificationSinkParamsWithContext(ctx context.Context) *UpdateNotificationSinkParams {
+	return &UpdateNotificationSinkParams{
+		Context: ctx,
+	}
+}
+
+// NewUpdateNotificationSinkParamsWithHTTPClient creates a new UpdateNotificationSinkParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewUpdateNotificationSinkParamsWithHTTPClient(client *http.Client) *UpdateNotificationSinkParams {
+	return &UpdateNotificationSinkParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+UpdateNotificationSinkParams contains all the parameters to send to the API endpoint
+
+	for the update notification sink operation.
+
+	Typically these are written to a http.Request.
+*/
+type UpdateNotificationSinkParams struct {
+
+	/* Body.
+
+	   Parameters used when updating a notification sink.
+	*/
+	Body garm_params.UpdateNotificationSinkParams
+
+	/* SinkID.
+
+	   ID of the notification sink.
+	*/
+	SinkID int64
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the update notification sink params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *UpdateNotificationSinkParams) WithDefaults() *UpdateNotificationSinkParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the update notification sink params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *UpdateNotificationSinkParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the update notification sink params
+func (o *UpdateNotificationSinkParams) WithTimeout(timeout time.Duration) *UpdateNotificationSinkParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the update notification sink params
+func (o *UpdateNotificationSinkParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the update notification sink params
+func (o *UpdateNotificationSinkParams) WithContext(ctx context.Context) *UpdateNotificationSinkParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the update notification sink params
+func (o *UpdateNotificationSinkParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the update notification sink params
+func (o *UpdateNotificationSinkParams) WithHTTPClient(client *http.Client) *UpdateNotificationSinkParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the update notification sink params
+func (o *UpdateNotificationSinkParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the update notification sink params
+func (o *UpdateNotificationSinkParams) WithBody(body garm_params.UpdateNotificationSinkParams) *UpdateNotificationSinkParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the update notification sink params
+func (o *UpdateNotificationSinkParams) SetBody(body garm_params.UpdateNotificationSinkParams) {
+	o.Body = body
+}
+
+// WithSinkID adds the sinkID to the update notification sink params
+func (o *UpdateNotificationSinkParams) WithSinkID(sinkID int64) *UpdateNotificationSinkParams {
+	o.SetSinkID(sinkID)
+	return o
+}
+
+// SetSinkID adds the sinkId to the update notification sink params
+func (o *UpdateNotificationSinkParams) SetSinkID(sinkID int64) {
+	o.SinkID = sinkID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *UpdateNotificationSinkParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if err := r.SetBodyParam(o.Body); err != nil {
+		return err
+	}
+
+	// path param sinkID
+	if err := r.SetPathParam("sinkID", swag.FormatInt64(o.SinkID)); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
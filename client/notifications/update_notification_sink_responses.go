@@ -0,0 +1,179 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package notifications
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// UpdateNotificationSinkReader is a Reader for the UpdateNotificationSink structure.
+type UpdateNotificationSinkReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *UpdateNotificationSinkReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewUpdateNotificationSinkOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewUpdateNotificationSinkBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("[PUT /notifications/sinks/{sinkID}] UpdateNotificationSink", response, response.Code())
+	}
+}
+
+// NewUpdateNotificationSinkOK creates a UpdateNotificationSinkOK with default headers values
+func NewUpdateNotificationSinkOK() *UpdateNotificationSinkOK {
+	return &UpdateNotificationSinkOK{}
+}
+
+/*
+UpdateNotificationSinkOK describes a response with status code 200, with default header values.
+
+NotificationSink
+*/
+type UpdateNotificationSinkOK struct {
+	Payload garm_params.NotificationSink
+}
+
+// IsSuccess returns true when this update notification sink o k response has a 2xx status code
+func (o *UpdateNotificationSinkOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this update notification sink o k response has a 3xx status code
+func (o *UpdateNotificationSinkOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this update notification sink o k response has a 4xx status code
+func (o *UpdateNotificationSinkOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this update notification sink o k response has a 5xx status code
+func (o *UpdateNotificationSinkOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this update notification sink o k response a status code equal to that given
+func (o *UpdateNotificationSinkOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the update notification sink o k response
+func (o *UpdateNotificationSinkOK) Code() int {
+	return 200
+}
+
+func (o *UpdateNotificationSinkOK) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[PUT /notifications/sinks/{sinkID}][%d] updateNotificationSinkOK %s", 200, payload)
+}
+
+func (o *UpdateNotificationSinkOK) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[PUT /notifications/sinks/{sinkID}][%d] updateNotificationSinkOK %s", 200, payload)
+}
+
+func (o *UpdateNotificationSinkOK) GetPayload() garm_params.NotificationSink {
+	return o.Payload
+}
+
+func (o *UpdateNotificationSinkOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewUpdateNotificationSinkBadRequest creates a UpdateNotificationSinkBadRequest with default headers values
+func NewUpdateNotificationSinkBadRequest() *UpdateNotificationSinkBadRequest {
+	return &UpdateNotificationSinkBadRequest{}
+}
+
+/*
+UpdateNotificationSinkBadRequest describes a response with status code 400, with default header values.
+
+APIErrorResponse
+*/
+type UpdateNotificationSinkBadRequest struct {
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this update notification sink bad request response has a 2xx status code
+func (o *UpdateNotificationSinkBadRequest) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this update notification sink bad request response has a 3xx status code
+func (o *UpdateNotificationSinkBadRequest) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this update notification sink bad request response has a 4xx status code
+func (o *UpdateNotificationSinkBadRequest) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this update notification sink bad request response has a 5xx status code
+func (o *UpdateNotificationSinkBadRequest) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this update notification sink bad request response a status code equal to that given
+func (o *UpdateNotificationSinkBadRequest) IsCode(code int) bool {
+	return code == 400
+}
+
+// Code gets the status code for the update notification sink bad request response
+func (o *UpdateNotificationSinkBadRequest) Code() int {
+	return 400
+}
+
+func (o *UpdateNotificationSinkBadRequest) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[PUT /notifications/sinks/{sinkID}][%d] updateNotificationSinkBadRequest %s", 400, payload)
+}
+
+func (o *UpdateNotificationSinkBadRequest) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[PUT /notifications/sinks/{sinkID}][%d] updateNotificationSinkBadRequest %s", 400, payload)
+}
+
+func (o *UpdateNotificationSinkBadRequest) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *UpdateNotificationSinkBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewListPoolsParams creates a new ListPoolsParams object,
@@ -60,6 +61,69 @@ ListPoolsParams contains all the parameters to send to the API endpoint
 	Typically these are written to a http.Request.
 */
 type ListPoolsParams struct {
+
+	/* CreatedAfter.
+
+	   Only return results created at or after this time (RFC3339).
+
+	   Format: date-time
+	*/
+	CreatedAfter *strfmt.DateTime
+
+	/* CreatedBefore.
+
+	   Only return results created before this time (RFC3339).
+
+	   Format: date-time
+	*/
+	CreatedBefore *strfmt.DateTime
+
+	/* Cursor.
+
+	   Cursor returned in the X-Next-Cursor header of a previous response. Results start after the last item of that page.
+	*/
+	Cursor *string
+
+	/* Enabled.
+
+	   Only return enabled (true) or disabled (false) pools.
+	*/
+	Enabled *bool
+
+	/* Label.
+
+	   Only return pools that have this label.
+	*/
+	Label *string
+
+	/* Limit.
+
+	   Maximum number of results to return. If not set, all results are returned.
+	*/
+	Limit *int64
+
+	/* ProviderName.
+
+	   Only return pools using this provider.
+	*/
+	ProviderName *string
+
+	/* UpdatedAfter.
+
+	   Only return results updated at or after this time (RFC3339).
+
+	   Format: date-time
+	*/
+	UpdatedAfter *strfmt.DateTime
+
+	/* UpdatedBefore.
+
+	   Only return results updated before this time (RFC3339).
+
+	   Format: date-time
+	*/
+	UpdatedBefore *strfmt.DateTime
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -113,6 +177,105 @@ func (o *ListPoolsParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithCreatedAfter adds the createdAfter to the list pools params
+func (o *ListPoolsParams) WithCreatedAfter(createdAfter *strfmt.DateTime) *ListPoolsParams {
+	o.SetCreatedAfter(createdAfter)
+	return o
+}
+
+// SetCreatedAfter adds the createdAfter to the list pools params
+func (o *ListPoolsParams) SetCreatedAfter(createdAfter *strfmt.DateTime) {
+	o.CreatedAfter = createdAfter
+}
+
+// WithCreatedBefore adds the createdBefore to the list pools params
+func (o *ListPoolsParams) WithCreatedBefore(createdBefore *strfmt.DateTime) *ListPoolsParams {
+	o.SetCreatedBefore(createdBefore)
+	return o
+}
+
+// SetCreatedBefore adds the createdBefore to the list pools params
+func (o *ListPoolsParams) SetCreatedBefore(createdBefore *strfmt.DateTime) {
+	o.CreatedBefore = createdBefore
+}
+
+// WithCursor adds the cursor to the list pools params
+func (o *ListPoolsParams) WithCursor(cursor *string) *ListPoolsParams {
+	o.SetCursor(cursor)
+	return o
+}
+
+// SetCursor adds the cursor to the list pools params
+func (o *ListPoolsParams) SetCursor(cursor *string) {
+	o.Cursor = cursor
+}
+
+// WithEnabled adds the enabled to the list pools params
+func (o *ListPoolsParams) WithEnabled(enabled *bool) *ListPoolsParams {
+	o.SetEnabled(enabled)
+	return o
+}
+
+// SetEnabled adds the enabled to the list pools params
+func (o *ListPoolsParams) SetEnabled(enabled *bool) {
+	o.Enabled = enabled
+}
+
+// WithLabel adds the label to the list pools params
+func (o *ListPoolsParams) WithLabel(label *string) *ListPoolsParams {
+	o.SetLabel(label)
+	return o
+}
+
+// SetLabel adds the label to the list pools params
+func (o *ListPoolsParams) SetLabel(label *string) {
+	o.Label = label
+}
+
+// WithLimit adds the limit to the list pools params
+func (o *ListPoolsParams) WithLimit(limit *int64) *ListPoolsParams {
+	o.SetLimit(limit)
+	return o
+}
+
+// SetLimit adds the limit to the list pools params
+func (o *ListPoolsParams) SetLimit(limit *int64) {
+	o.Limit = limit
+}
+
+// WithProviderName adds the providerName to the list pools params
+func (o *ListPoolsParams) WithProviderName(providerName *string) *ListPoolsParams {
+	o.SetProviderName(providerName)
+	return o
+}
+
+// SetProviderName adds the providerName to the list pools params
+func (o *ListPoolsParams) SetProviderName(providerName *string) {
+	o.ProviderName = providerName
+}
+
+// WithUpdatedAfter adds the updatedAfter to the list pools params
+func (o *ListPoolsParams) WithUpdatedAfter(updatedAfter *strfmt.DateTime) *ListPoolsParams {
+	o.SetUpdatedAfter(updatedAfter)
+	return o
+}
+
+// SetUpdatedAfter adds the updatedAfter to the list pools params
+func (o *ListPoolsParams) SetUpdatedAfter(updatedAfter *strfmt.DateTime) {
+	o.UpdatedAfter = updatedAfter
+}
+
+// WithUpdatedBefore adds the updatedBefore to the list pools params
+func (o *ListPoolsParams) WithUpdatedBefore(updatedBefore *strfmt.DateTime) *ListPoolsParams {
+	o.SetUpdatedBefore(updatedBefore)
+	return o
+}
+
+// SetUpdatedBefore adds the updatedBefore to the list pools params
+func (o *ListPoolsParams) SetUpdatedBefore(updatedBefore *strfmt.DateTime) {
+	o.UpdatedBefore = updatedBefore
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *ListPoolsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -121,6 +284,159 @@ func (o *ListPoolsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Reg
 	}
 	var res []error
 
+	if o.CreatedAfter != nil {
+
+		// query param createdAfter
+		var qrCreatedAfter strfmt.DateTime
+
+		if o.CreatedAfter != nil {
+			qrCreatedAfter = *o.CreatedAfter
+		}
+		qCreatedAfter := qrCreatedAfter.String()
+		if qCreatedAfter != "" {
+
+			if err := r.SetQueryParam("createdAfter", qCreatedAfter); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.CreatedBefore != nil {
+
+		// query param createdBefore
+		var qrCreatedBefore strfmt.DateTime
+
+		if o.CreatedBefore != nil {
+			qrCreatedBefore = *o.CreatedBefore
+		}
+		qCreatedBefore := qrCreatedBefore.String()
+		if qCreatedBefore != "" {
+
+			if err := r.SetQueryParam("createdBefore", qCreatedBefore); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Cursor != nil {
+
+		// query param cursor
+		var qrCursor string
+
+		if o.Cursor != nil {
+			qrCursor = *o.Cursor
+		}
+		qCursor := qrCursor
+		if qCursor != "" {
+
+			if err := r.SetQueryParam("cursor", qCursor); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Enabled != nil {
+
+		// query param enabled
+		var qrEnabled bool
+
+		if o.Enabled != nil {
+			qrEnabled = *o.Enabled
+		}
+		qEnabled := swag.FormatBool(qrEnabled)
+		if qEnabled != "" {
+
+			if err := r.SetQueryParam("enabled", qEnabled); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Label != nil {
+
+		// query param label
+		var qrLabel string
+
+		if o.Label != nil {
+			qrLabel = *o.Label
+		}
+		qLabel := qrLabel
+		if qLabel != "" {
+
+			if err := r.SetQueryParam("label", qLabel); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.Limit != nil {
+
+		// query param limit
+		var qrLimit int64
+
+		if o.Limit != nil {
+			qrLimit = *o.Limit
+		}
+		qLimit := swag.FormatInt64(qrLimit)
+		if qLimit != "" {
+
+			if err := r.SetQueryParam("limit", qLimit); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.ProviderName != nil {
+
+		// query param providerName
+		var qrProviderName string
+
+		if o.ProviderName != nil {
+			qrProviderName = *o.ProviderName
+		}
+		qProviderName := qrProviderName
+		if qProviderName != "" {
+
+			if err := r.SetQueryParam("providerName", qProviderName); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.UpdatedAfter != nil {
+
+		// query param updatedAfter
+		var qrUpdatedAfter strfmt.DateTime
+
+		if o.UpdatedAfter != nil {
+			qrUpdatedAfter = *o.UpdatedAfter
+		}
+		qUpdatedAfter := qrUpdatedAfter.String()
+		if qUpdatedAfter != "" {
+
+			if err := r.SetQueryParam("updatedAfter", qUpdatedAfter); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.UpdatedBefore != nil {
+
+		// query param updatedBefore
+		var qrUpdatedBefore strfmt.DateTime
+
+		if o.UpdatedBefore != nil {
+			qrUpdatedBefore = *o.UpdatedBefore
+		}
+		qUpdatedBefore := qrUpdatedBefore.String()
+		if qUpdatedBefore != "" {
+
+			if err := r.SetQueryParam("updatedBefore", qUpdatedBefore); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
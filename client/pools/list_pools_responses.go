@@ -51,9 +51,14 @@ func NewListPoolsOK() *ListPoolsOK {
 /*
 ListPoolsOK describes a response with status code 200, with default header values.
 
-Pools
+A page of pools.
 */
 type ListPoolsOK struct {
+
+	/* Cursor of the next page. Not set on the last page.
+	 */
+	XNextCursor string
+
 	Payload garm_params.Pools
 }
 
@@ -103,6 +108,13 @@ func (o *ListPoolsOK) GetPayload() garm_params.Pools {
 
 func (o *ListPoolsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// hydrates response header X-Next-Cursor
+	hdrXNextCursor := response.GetHeader("X-Next-Cursor")
+
+	if hdrXNextCursor != "" {
+		o.XNextCursor = hdrXNextCursor
+	}
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
@@ -60,6 +60,8 @@ type ClientService interface {
 
 	ListPools(params *ListPoolsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ListPoolsOK, error)
 
+	ReleasePoolQuarantine(params *ReleasePoolQuarantineParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ReleasePoolQuarantineOK, error)
+
 	UpdatePool(params *UpdatePoolParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*UpdatePoolOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
@@ -173,6 +175,44 @@ func (a *Client) ListPools(params *ListPoolsParams, authInfo runtime.ClientAuthI
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+ReleasePoolQuarantine releases a pool from quarantine and enable it again
+*/
+func (a *Client) ReleasePoolQuarantine(params *ReleasePoolQuarantineParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*ReleasePoolQuarantineOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewReleasePoolQuarantineParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ReleasePoolQuarantine",
+		Method:             "DELETE",
+		PathPattern:        "/pools/{poolID}/quarantine",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ReleasePoolQuarantineReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ReleasePoolQuarantineOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ReleasePoolQuarantineDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 UpdatePool updates pool by ID
 */
@@ -0,0 +1,151 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package pools
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewReleasePoolQuarantineParams creates a new ReleasePoolQuarantineParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewReleasePoolQuarantineParams() *ReleasePoolQuarantineParams {
+	return &ReleasePoolQuarantineParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewReleasePoolQuarantineParamsWithTimeout creates a new ReleasePoolQuarantineParams object
+// with the ability to set a timeout on a request.
+func NewReleasePoolQuarantineParamsWithTimeout(timeout time.Duration) *ReleasePoolQuarantineParams {
+	return &ReleasePoolQuarantineParams{
+		timeout: timeout,
+	}
+}
+
+// NewReleasePoolQuarantineParamsWithContext creates a new ReleasePoolQuarantineParams object
+// with the ability to set a context for a request.
+func NewReleasePoolQuarantineParamsWithContext(ctx context.Context) *ReleasePoolQuarantineParams {
+	return &ReleasePoolQuarantineParams{
+		Context: ctx,
+	}
+}
+
+// NewReleasePoolQuarantineParamsWithHTTPClient creates a new ReleasePoolQuarantineParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewReleasePoolQuarantineParamsWithHTTPClient(client *http.Client) *ReleasePoolQuarantineParams {
+	return &ReleasePoolQuarantineParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+ReleasePoolQuarantineParams contains all the parameters to send to the API endpoint
+
+	for the release pool quarantine operation.
+
+	Typically these are written to a http.Request.
+*/
+type ReleasePoolQuarantineParams struct {
+
+	/* PoolID.
+
+	   ID of the quarantined pool.
+	*/
+	PoolID string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the release pool quarantine params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ReleasePoolQuarantineParams) WithDefaults() *ReleasePoolQuarantineParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the release pool quarantine params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *ReleasePoolQuarantineParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the release pool quarantine params
+func (o *ReleasePoolQuarantineParams) WithTimeout(timeout time.Duration) *ReleasePoolQuarantineParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the release pool quarantine params
+func (o *ReleasePoolQuarantineParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the release pool quarantine params
+func (o *ReleasePoolQuarantineParams) WithContext(ctx context.Context) *ReleasePoolQuarantineParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the release pool quarantine params
+func (o *ReleasePoolQuarantineParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the release pool quarantine params
+func (o *ReleasePoolQuarantineParams) WithHTTPClient(client *http.Client) *ReleasePoolQuarantineParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the release pool quarantine params
+func (o *ReleasePoolQuarantineParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithPoolID adds the poolID to the release pool quarantine params
+func (o *ReleasePoolQuarantineParams) WithPoolID(poolID string) *ReleasePoolQuarantineParams {
+	o.SetPoolID(poolID)
+	return o
+}
+
+// SetPoolID adds the poolId to the release pool quarantine params
+func (o *ReleasePoolQuarantineParams) SetPoolID(poolID string) {
+	o.PoolID = poolID
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *ReleasePoolQuarantineParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+
+	// path param poolID
+	if err := r.SetPathParam("poolID", o.PoolID); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package pools
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// ReleasePoolQuarantineReader is a Reader for the ReleasePoolQuarantine structure.
+type ReleasePoolQuarantineReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ReleasePoolQuarantineReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewReleasePoolQuarantineOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	default:
+		result := NewReleasePoolQuarantineDefault(response.Code())
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		if response.Code()/100 == 2 {
+			return result, nil
+		}
+		return nil, result
+	}
+}
+
+// NewReleasePoolQuarantineOK creates a ReleasePoolQuarantineOK with default headers values
+func NewReleasePoolQuarantineOK() *ReleasePoolQuarantineOK {
+	return &ReleasePoolQuarantineOK{}
+}
+
+/*
+ReleasePoolQuarantineOK describes a response with status code 200, with default header values.
+
+Pool
+*/
+type ReleasePoolQuarantineOK struct {
+	Payload garm_params.Pool
+}
+
+// IsSuccess returns true when this release pool quarantine o k response has a 2xx status code
+func (o *ReleasePoolQuarantineOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this release pool quarantine o k response has a 3xx status code
+func (o *ReleasePoolQuarantineOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this release pool quarantine o k response has a 4xx status code
+func (o *ReleasePoolQuarantineOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this release pool quarantine o k response has a 5xx status code
+func (o *ReleasePoolQuarantineOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this release pool quarantine o k response a status code equal to that given
+func (o *ReleasePoolQuarantineOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the release pool quarantine o k response
+func (o *ReleasePoolQuarantineOK) Code() int {
+	return 200
+}
+
+func (o *ReleasePoolQuarantineOK) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[DELETE /pools/{poolID}/quarantine][%d] releasePoolQuarantineOK %s", 200, payload)
+}
+
+func (o *ReleasePoolQuarantineOK) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[DELETE /pools/{poolID}/quarantine][%d] releasePoolQuarantineOK %s", 200, payload)
+}
+
+func (o *ReleasePoolQuarantineOK) GetPayload() garm_params.Pool {
+	return o.Payload
+}
+
+func (o *ReleasePoolQuarantineOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewReleasePoolQuarantineDefault creates a ReleasePoolQuarantineDefault with default headers values
+func NewReleasePoolQuarantineDefault(code int) *ReleasePoolQuarantineDefault {
+	return &ReleasePoolQuarantineDefault{
+		_statusCode: code,
+	}
+}
+
+/*
+ReleasePoolQuarantineDefault describes a response with status code -1, with default header values.
+
+APIErrorResponse
+*/
+type ReleasePoolQuarantineDefault struct {
+	_statusCode int
+
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this release pool quarantine default response has a 2xx status code
+func (o *ReleasePoolQuarantineDefault) IsSuccess() bool {
+	return o._statusCode/100 == 2
+}
+
+// IsRedirect returns true when this release pool quarantine default response has a 3xx status code
+func (o *ReleasePoolQuarantineDefault) IsRedirect() bool {
+	return o._statusCode/100 == 3
+}
+
+// IsClientError returns true when this release pool quarantine default response has a 4xx status code
+func (o *ReleasePoolQuarantineDefault) IsClientError() bool {
+	return o._statusCode/100 == 4
+}
+
+// IsServerError returns true when this release pool quarantine default response has a 5xx status code
+func (o *ReleasePoolQuarantineDefault) IsServerError() bool {
+	return o._statusCode/100 == 5
+}
+
+// IsCode returns true when this release pool quarantine default response a status code equal to that given
+func (o *ReleasePoolQuarantineDefault) IsCode(code int) bool {
+	return o._statusCode == code
+}
+
+// Code gets the status code for the release pool quarantine default response
+func (o *ReleasePoolQuarantineDefault) Code() int {
+	return o._statusCode
+}
+
+func (o *ReleasePoolQuarantineDefault) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[DELETE /pools/{poolID}/quarantine][%d] ReleasePoolQuarantine default %s", o._statusCode, payload)
+}
+
+func (o *ReleasePoolQuarantineDefault) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[DELETE /pools/{poolID}/quarantine][%d] ReleasePoolQuarantine default %s", o._statusCode, payload)
+}
+
+func (o *ReleasePoolQuarantineDefault) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *ReleasePoolQuarantineDefault) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package users
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// NewCreateUserParams creates a new CreateUserParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewCreateUserParams() *CreateUserParams {
+	return &CreateUserParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewCreateUserParamsWithTimeout creates a new CreateUserParams object
+// with the ability to set a timeout on a request.
+func NewCreateUserParamsWithTimeout(timeout time.Duration) *CreateUserParams {
+	return &CreateUserParams{
+		timeout: timeout,
+	}
+}
+
+// NewCreateUserParamsWithContext creates a new CreateUserParams object
+// with the ability to set a context for a request.
+func NewCreateUserParamsWithContext(ctx context.Context) *CreateUserParams {
+	return &CreateUserParams{
+		Context: ctx,
+	}
+}
+
+// NewCreateUserParamsWithHTTPClient creates a new CreateUserParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewCreateUserParamsWithHTTPClient(client *http.Client) *CreateUserParams {
+	return &CreateUserParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+CreateUserParams contains all the parameters to send to the API endpoint
+
+	for the create user operation.
+
+	Typically these are written to a http.Request.
+*/
+type CreateUserParams struct {
+
+	/* Body.
+
+	   Parameters used when creating a user.
+	*/
+	Body garm_params.NewUserParams
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the create user params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *CreateUserParams) WithDefaults() *CreateUserParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the create user params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *CreateUserParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the create user params
+func (o *CreateUserParams) WithTimeout(timeout time.Duration) *CreateUserParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the create user params
+func (o *CreateUserParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the create user params
+func (o *CreateUserParams) WithContext(ctx context.Context) *CreateUserParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the create user params
+func (o *CreateUserParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the create user params
+func (o *CreateUserParams) WithHTTPClient(client *http.Client) *CreateUserParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the create user params
+func (o *CreateUserParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the create user params
+func (o *CreateUserParams) WithBody(body garm_params.NewUserParams) *CreateUserParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the create user params
+func (o *CreateUserParams) SetBody(body garm_params.NewUserParams) {
+	o.Body = body
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *CreateUserParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if err := r.SetBodyParam(o.Body); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package users
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// CreateUserReader is a Reader for the CreateUser structure.
+type CreateUserReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *CreateUserReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewCreateUserOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewCreateUserBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("[POST /users] CreateUser", response, response.Code())
+	}
+}
+
+// NewCreateUserOK creates a CreateUserOK with default headers values
+func NewCreateUserOK() *CreateUserOK {
+	return &CreateUserOK{}
+}
+
+/*
+CreateUserOK describes a response with status code 200, with default header values.
+
+User
+*/
+type CreateUserOK struct {
+	Payload garm_params.User
+}
+
+// IsSuccess returns true when this create user o k response has a 2xx status code
+func (o *CreateUserOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this create user o k response has a 3xx status code
+func (o *CreateUserOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this create user o k response has a 4xx status code
+func (o *CreateUserOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this create user o k response has a 5xx status code
+func (o *CreateUserOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this create user o k response a status code equal to that given
+func (o *CreateUserOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the create user o k response
+func (o *CreateUserOK) Code() int {
+	return 200
+}
+
+func (o *CreateUserOK) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[POST /users][%d] createUserOK %s", 200, payload)
+}
+
+func (o *CreateUserOK) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[POST /users][%d] createUserOK %s", 200, payload)
+}
+
+func (o *CreateUserOK) GetPayload() garm_params.User {
+	return o.Payload
+}
+
+func (o *CreateUserOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewCreateUserBadRequest creates a CreateUserBadRequest with default headers values
+func NewCreateUserBadRequest() *CreateUserBadRequest {
+	return &CreateUserBadRequest{}
+}
+
+/*
+CreateUserBadRequest describes a response with status code 400, with default header values.
+
+APIErrorResponse
+*/
+type CreateUserBadRequest struct {
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this create user bad request response has a 2xx status code
+func (o *CreateUserBadRequest) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this create user bad request response has a 3xx status code
+func (o *CreateUserBadRequest) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this create user bad request response has a 4xx status code
+func (o *CreateUserBadRequest) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this create user bad request response has a 5xx status code
+func (o *CreateUserBadRequest) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this create user bad request response a status code equal to that given
+func (o *CreateUserBadRequest) IsCode(code int) bool {
+	return code == 400
+}
+
+// Code gets the status code for the create user bad request response
+func (o *CreateUserBadRequest) Code() int {
+	return 400
+}
+
+func (o *CreateUserBadRequest) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[POST /users][%d] createUserBadRequest %s", 400, payload)
+}
+
+func (o *CreateUserBadRequest) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[POST /users][%d] createUserBadRequest %s", 400, payload)
+}
+
+func (o *CreateUserBadRequest) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *CreateUserBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
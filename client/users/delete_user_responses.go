@@ -0,0 +1,106 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package users
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+)
+
+// DeleteUserReader is a Reader for the DeleteUser structure.
+type DeleteUserReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *DeleteUserReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	result := NewDeleteUserDefault(response.Code())
+	if err := result.readResponse(response, consumer, o.formats); err != nil {
+		return nil, err
+	}
+	if response.Code()/100 == 2 {
+		return result, nil
+	}
+	return nil, result
+}
+
+// NewDeleteUserDefault creates a DeleteUserDefault with default headers values
+func NewDeleteUserDefault(code int) *DeleteUserDefault {
+	return &DeleteUserDefault{
+		_statusCode: code,
+	}
+}
+
+/*
+DeleteUserDefault describes a response with status code -1, with default header values.
+
+APIErrorResponse
+*/
+type DeleteUserDefault struct {
+	_statusCode int
+
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this delete user default response has a 2xx status code
+func (o *DeleteUserDefault) IsSuccess() bool {
+	return o._statusCode/100 == 2
+}
+
+// IsRedirect returns true when this delete user default response has a 3xx status code
+func (o *DeleteUserDefault) IsRedirect() bool {
+	return o._statusCode/100 == 3
+}
+
+// IsClientError returns true when this delete user default response has a 4xx status code
+func (o *DeleteUserDefault) IsClientError() bool {
+	return o._statusCode/100 == 4
+}
+
+// IsServerError returns true when this delete user default response has a 5xx status code
+func (o *DeleteUserDefault) IsServerError() bool {
+	return o._statusCode/100 == 5
+}
+
+// IsCode returns true when this delete user default response a status code equal to that given
+func (o *DeleteUserDefault) IsCode(code int) bool {
+	return o._statusCode == code
+}
+
+// Code gets the status code for the delete user default response
+func (o *DeleteUserDefault) Code() int {
+	return o._statusCode
+}
+
+func (o *DeleteUserDefault) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[DELETE /users/{username}][%d] DeleteUser default %s", o._statusCode, payload)
+}
+
+func (o *DeleteUserDefault) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[DELETE /users/{username}][%d] DeleteUser default %s", o._statusCode, payload)
+}
+
+func (o *DeleteUserDefault) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *DeleteUserDefault) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package users
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// GetUserReader is a Reader for the GetUser structure.
+type GetUserReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetUserReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetUserOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewGetUserBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("[GET /users/{username}] GetUser", response, response.Code())
+	}
+}
+
+// NewGetUserOK creates a GetUserOK with default headers values
+func NewGetUserOK() *GetUserOK {
+	return &GetUserOK{}
+}
+
+/*
+GetUserOK describes a response with status code 200, with default header values.
+
+User
+*/
+type GetUserOK struct {
+	Payload garm_params.User
+}
+
+// IsSuccess returns true when this get user o k response has a 2xx status code
+func (o *GetUserOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this get user o k response has a 3xx status code
+func (o *GetUserOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this get user o k response has a 4xx status code
+func (o *GetUserOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this get user o k response has a 5xx status code
+func (o *GetUserOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this get user o k response a status code equal to that given
+func (o *GetUserOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the get user o k response
+func (o *GetUserOK) Code() int {
+	return 200
+}
+
+func (o *GetUserOK) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /users/{username}][%d] getUserOK %s", 200, payload)
+}
+
+func (o *GetUserOK) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /users/{username}][%d] getUserOK %s", 200, payload)
+}
+
+func (o *GetUserOK) GetPayload() garm_params.User {
+	return o.Payload
+}
+
+func (o *GetUserOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetUserBadRequest creates a GetUserBadRequest with default headers values
+func NewGetUserBadRequest() *GetUserBadRequest {
+	return &GetUserBadRequest{}
+}
+
+/*
+GetUserBadRequest describes a response with status code 400, with default header values.
+
+APIErrorResponse
+*/
+type GetUserBadRequest struct {
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this get user bad request response has a 2xx status code
+func (o *GetUserBadRequest) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this get user bad request response has a 3xx status code
+func (o *GetUserBadRequest) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this get user bad request response has a 4xx status code
+func (o *GetUserBadRequest) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this get user bad request response has a 5xx status code
+func (o *GetUserBadRequest) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this get user bad request response a status code equal to that given
+func (o *GetUserBadRequest) IsCode(code int) bool {
+	return code == 400
+}
+
+// Code gets the status code for the get user bad request response
+func (o *GetUserBadRequest) Code() int {
+	return 400
+}
+
+func (o *GetUserBadRequest) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /users/{username}][%d] getUserBadRequest %s", 400, payload)
+}
+
+func (o *GetUserBadRequest) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /users/{username}][%d] getUserBadRequest %s", 400, payload)
+}
+
+func (o *GetUserBadRequest) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *GetUserBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
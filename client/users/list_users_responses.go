@@ -0,0 +1,179 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package users
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	apiserver_params "github.com/cloudbase/garm/apiserver/params"
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// ListUsersReader is a Reader for the ListUsers structure.
+type ListUsersReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *ListUsersReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewListUsersOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 400:
+		result := NewListUsersBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("[GET /users] ListUsers", response, response.Code())
+	}
+}
+
+// NewListUsersOK creates a ListUsersOK with default headers values
+func NewListUsersOK() *ListUsersOK {
+	return &ListUsersOK{}
+}
+
+/*
+ListUsersOK describes a response with status code 200, with default header values.
+
+Users
+*/
+type ListUsersOK struct {
+	Payload garm_params.Users
+}
+
+// IsSuccess returns true when this list users o k response has a 2xx status code
+func (o *ListUsersOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this list users o k response has a 3xx status code
+func (o *ListUsersOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this list users o k response has a 4xx status code
+func (o *ListUsersOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this list users o k response has a 5xx status code
+func (o *ListUsersOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this list users o k response a status code equal to that given
+func (o *ListUsersOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the list users o k response
+func (o *ListUsersOK) Code() int {
+	return 200
+}
+
+func (o *ListUsersOK) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /users][%d] listUsersOK %s", 200, payload)
+}
+
+func (o *ListUsersOK) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /users][%d] listUsersOK %s", 200, payload)
+}
+
+func (o *ListUsersOK) GetPayload() garm_params.Users {
+	return o.Payload
+}
+
+func (o *ListUsersOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewListUsersBadRequest creates a ListUsersBadRequest with default headers values
+func NewListUsersBadRequest() *ListUsersBadRequest {
+	return &ListUsersBadRequest{}
+}
+
+/*
+ListUsersBadRequest describes a response with status code 400, with default header values.
+
+APIErrorResponse
+*/
+type ListUsersBadRequest struct {
+	Payload apiserver_params.APIErrorResponse
+}
+
+// IsSuccess returns true when this list users bad request response has a 2xx status code
+func (o *ListUsersBadRequest) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this list users bad request response has a 3xx status code
+func (o *ListUsersBadRequest) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this list users bad request response has a 4xx status code
+func (o *ListUsersBadRequest) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this list users bad request response has a 5xx status code
+func (o *ListUsersBadRequest) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this list users bad request response a status code equal to that given
+func (o *ListUsersBadRequest) IsCode(code int) bool {
+	return code == 400
+}
+
+// Code gets the status code for the list users bad request response
+func (o *ListUsersBadRequest) Code() int {
+	return 400
+}
+
+func (o *ListUsersBadRequest) Error() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /users][%d] listUsersBadRequest %s", 400, payload)
+}
+
+func (o *ListUsersBadRequest) String() string {
+	payload, _ := json.Marshal(o.Payload)
+	return fmt.Sprintf("[GET /users][%d] listUsersBadRequest %s", 400, payload)
+}
+
+func (o *ListUsersBadRequest) GetPayload() apiserver_params.APIErrorResponse {
+	return o.Payload
+}
+
+func (o *ListUsersBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package users
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	garm_params "github.com/cloudbase/garm/params"
+)
+
+// NewUpdateUserParams creates a new UpdateUserParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewUpdateUserParams() *UpdateUserParams {
+	return &UpdateUserParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewUpdateUserParamsWithTimeout creates a new UpdateUserParams object
+// with the ability to set a timeout on a request.
+func NewUpdateUserParamsWithTimeout(timeout time.Duration) *UpdateUserParams {
+	return &UpdateUserParams{
+		timeout: timeout,
+	}
+}
+
+// NewUpdateUserParamsWithContext creates a new UpdateUserParams object
+// with the ability to set a context for a request.
+func NewUpdateUserParamsWithContext(ctx context.Context) *UpdateUserParams {
+	return &UpdateUserParams{
+		Context: ctx,
+	}
+}
+
+// NewUpdateUserParamsWithHTTPClient creates a new UpdateUserParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewUpdateUserParamsWithHTTPClient(client *http.Client) *UpdateUserParams {
+	return &UpdateUserParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+UpdateUserParams contains all the parameters to send to the API endpoint
+
+	for the update user operation.
+
+	Typically these are written to a http.Request.
+*/
+type UpdateUserParams struct {
+
+	/* Body.
+
+	   Parameters used when updating a user.
+	*/
+	Body garm_params.UpdateUserParams
+
+	/* Username.
+
+	   Username or email of the user.
+	*/
+	Username string
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the update user params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *UpdateUserParams) WithDefaults() *UpdateUserParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the update user params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *UpdateUserParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the update user params
+func (o *UpdateUserParams) WithTimeout(timeout time.Duration) *UpdateUserParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the update user params
+func (o *UpdateUserParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the update user params
+func (o *UpdateUserParams) WithContext(ctx context.Context) *UpdateUserParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the update user params
+func (o *UpdateUserParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the update user params
+func (o *UpdateUserParams) WithHTTPClient(client *http.Client) *UpdateUserParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the update user params
+func (o *UpdateUserParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithBody adds the body to the update user params
+func (o *UpdateUserParams) WithBody(body garm_params.UpdateUserParams) *UpdateUserParams {
+	o.SetBody(body)
+	return o
+}
+
+// SetBody adds the body to the update user params
+func (o *UpdateUserParams) SetBody(body garm_params.UpdateUserParams) {
+	o.Body = body
+}
+
+// WithUsername adds the username to the update user params
+func (o *UpdateUserParams) WithUsername(username string) *UpdateUserParams {
+	o.SetUsername(username)
+	return o
+}
+
+// SetUsername adds the username to the update user params
+func (o *UpdateUserParams) SetUsername(username string) {
+	o.Username = username
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *UpdateUserParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if err := r.SetBodyParam(o.Body); err != nil {
+		return err
+	}
+
+	// path param username
+	if err := r.SetPathParam("username", o.Username); err != nil {
+		return err
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	apiClientAudit "github.com/cloudbase/garm/client/audit"
+	"github.com/cloudbase/garm/params"
+)
+
+var auditCmd = &cobra.Command{
+	Use:          "audit",
+	SilenceUsage: true,
+	Short:        "Query the audit log",
+	Long: `Query the audit log.
+
+Every change made to GARM, either through the API or by GARM itself, is
+recorded in the audit log. Secrets are never stored in the audit log.`,
+	Run: nil,
+}
+
+var (
+	auditFilters    listFilters
+	auditUser       string
+	auditAction     string
+	auditEntityType string
+	auditEntityID   string
+)
+
+var auditListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List audit log entries",
+	Long: `List the entries recorded in the audit log, oldest first.
+
+Example:
+
+	List all pool deletions:
+	garm-cli audit list --action=delete --entity-type=pool
+
+	List everything a user changed since the start of the month:
+	garm-cli audit list --user=<USER_ID> --created-after=2024-06-01T00:00:00Z
+`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		createdAfter, err := parseTimeFlag("created-after", auditFilters.createdAfter)
+		if err != nil {
+			return err
+		}
+		createdBefore, err := parseTimeFlag("created-before", auditFilters.createdBefore)
+		if err != nil {
+			return err
+		}
+
+		listReq := apiClientAudit.NewListAuditEntriesParams()
+		listReq.CreatedAfter = createdAfter
+		listReq.CreatedBefore = createdBefore
+		listReq.Limit = auditFilters.limitParam()
+		listReq.Cursor = optionalString(auditFilters.cursor)
+		listReq.UserID = optionalString(auditUser)
+		listReq.Action = optionalString(auditAction)
+		listReq.EntityType = optionalString(auditEntityType)
+		listReq.EntityID = optionalString(auditEntityID)
+		response, err := apiCli.Audit.ListAuditEntries(listReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatAuditEntries(response.Payload)
+		printNextCursor(response.XNextCursor)
+		return nil
+	},
+}
+
+func formatAuditEntries(entries []params.AuditEntry) {
+	t := table.NewWriter()
+	header := table.Row{"ID", "Time", "User ID", "Actor", "Source IP", "Action", "Entity Type", "Entity ID", "Entity Name", "Changed Fields"}
+	t.AppendHeader(header)
+
+	for _, entry := range entries {
+		fields := make([]string, 0, len(entry.Changes))
+		for _, change := range entry.Changes {
+			fields = append(fields, change.Field)
+		}
+		t.AppendRow(table.Row{entry.ID, entry.CreatedAt.Format(time.RFC3339), entry.UserID, entry.Actor, entry.SourceIP, entry.Action, entry.EntityType, entry.EntityID, entry.EntityName, strings.Join(fields, ", ")})
+		t.AppendSeparator()
+	}
+	fmt.Println(t.Render())
+}
+
+func init() {
+	auditListCmd.Flags().Int64Var(&auditFilters.limit, "limit", 0, "Maximum number of results to return. If not set, all results are returned.")
+	auditListCmd.Flags().StringVar(&auditFilters.cursor, "cursor", "", "Fetch the page that follows this cursor. The cursor is printed when more results are available.")
+	auditListCmd.Flags().StringVar(&auditFilters.createdAfter, "created-after", "", "Only list entries recorded at or after this time (RFC3339).")
+	auditListCmd.Flags().StringVar(&auditFilters.createdBefore, "created-before", "", "Only list entries recorded before this time (RFC3339).")
+	auditListCmd.Flags().StringVar(&auditUser, "user", "", "Only list changes made by this user ID.")
+	auditListCmd.Flags().StringVar(&auditAction, "action", "", "Only list entries with this action (create, update, delete or quarantine).")
+	auditListCmd.Flags().StringVar(&auditEntityType, "entity-type", "", "Only list changes made to this type of entity (eg: pool, repository, github_credentials).")
+	auditListCmd.Flags().StringVar(&auditEntityID, "entity-id", "", "Only list changes made to the entity with this ID.")
+
+	auditCmd.AddCommand(
+		auditListCmd,
+	)
+
+	rootCmd.AddCommand(auditCmd)
+}
@@ -151,6 +151,10 @@ func renderControllerInfoTable(info params.ControllerInfo) string {
 	t.AppendRow(table.Row{"Callback URL", info.CallbackURL})
 	t.AppendRow(table.Row{"Webhook Base URL", info.WebhookURL})
 	t.AppendRow(table.Row{"Controller Webhook URL", info.ControllerWebhookURL})
+	if info.NodeID != "" {
+		t.AppendRow(table.Row{"Node ID", info.NodeID})
+		t.AppendRow(table.Row{"Leader Node ID", info.LeaderNodeID})
+	}
 	return t.Render()
 }
 
@@ -146,9 +146,9 @@ var enterpriseDeleteCmd = &cobra.Command{
 var enterpriseUpdateCmd = &cobra.Command{
 	Use:          "update",
 	Short:        "Update enterprise",
-	Long:         `Update enterprise credentials or webhook secret.`,
+	Long:         `Update enterprise credentials, webhook secret, fair share settings or runner limits.`,
 	SilenceUsage: true,
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if needsInit {
 			return errNeedsInitError
 		}
@@ -160,11 +160,30 @@ var enterpriseUpdateCmd = &cobra.Command{
 		if len(args) > 1 {
 			return fmt.Errorf("too many arguments")
 		}
+		var fairShareSettings *params.FairShareSettings
+		if fairShareFlagsChanged(cmd) {
+			// The fair share settings are replaced as a whole. Start from the current
+			// settings of the enterprise, so we only change the fields that were requested.
+			showEnterpriseReq := apiClientEnterprises.NewGetEnterpriseParams()
+			showEnterpriseReq.EnterpriseID = args[0]
+			current, err := apiCli.Enterprises.GetEnterprise(showEnterpriseReq, authToken)
+			if err != nil {
+				return err
+			}
+			fairShareSettings, err = fairShareFromFlags(cmd, current.Payload.FairShare)
+			if err != nil {
+				return err
+			}
+		}
+
 		updateEnterpriseReq := apiClientEnterprises.NewUpdateEnterpriseParams()
 		updateEnterpriseReq.Body = params.UpdateEntityParams{
-			WebhookSecret:    repoWebhookSecret,
-			CredentialsName:  repoCreds,
-			PoolBalancerType: params.PoolBalancerType(poolBalancerType),
+			WebhookSecret:        repoWebhookSecret,
+			CredentialsName:      repoCreds,
+			PoolBalancerType:     params.PoolBalancerType(poolBalancerType),
+			FairShare:            fairShareSettings,
+			MaxConcurrentRunners: uintFromFlag(cmd, "max-concurrent-runners", maxConcurrent),
+			MaxPendingRunners:    uintFromFlag(cmd, "max-pending-runners", maxPending),
 		}
 		updateEnterpriseReq.EnterpriseID = args[0]
 		response, err := apiCli.Enterprises.UpdateEnterprise(updateEnterpriseReq, authToken)
@@ -180,13 +199,15 @@ func init() {
 	enterpriseAddCmd.Flags().StringVar(&enterpriseName, "name", "", "The name of the enterprise")
 	enterpriseAddCmd.Flags().StringVar(&enterpriseWebhookSecret, "webhook-secret", "", "The webhook secret for this enterprise")
 	enterpriseAddCmd.Flags().StringVar(&enterpriseCreds, "credentials", "", "Credentials name. See credentials list.")
-	enterpriseAddCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", string(params.PoolBalancerTypeRoundRobin), "The balancing strategy to use when creating runners in pools matching requested labels.")
+	enterpriseAddCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", string(params.PoolBalancerTypeRoundRobin), "The balancing strategy to use when creating runners in pools matching requested labels (roundrobin, pack, weighted, capacity).")
 
 	enterpriseAddCmd.MarkFlagRequired("credentials") //nolint
 	enterpriseAddCmd.MarkFlagRequired("name")        //nolint
 	enterpriseUpdateCmd.Flags().StringVar(&enterpriseWebhookSecret, "webhook-secret", "", "The webhook secret for this enterprise")
 	enterpriseUpdateCmd.Flags().StringVar(&enterpriseCreds, "credentials", "", "Credentials name. See credentials list.")
-	enterpriseUpdateCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", "", "The balancing strategy to use when creating runners in pools matching requested labels.")
+	enterpriseUpdateCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", "", "The balancing strategy to use when creating runners in pools matching requested labels (roundrobin, pack, weighted, capacity).")
+	addRunnerLimitFlags(enterpriseUpdateCmd)
+	addFairShareFlags(enterpriseUpdateCmd)
 
 	enterpriseCmd.AddCommand(
 		enterpriseListCmd,
@@ -219,10 +240,16 @@ func formatOneEnterprise(enterprise params.Enterprise) {
 	t.AppendRow(table.Row{"Name", enterprise.Name})
 	t.AppendRow(table.Row{"Pool balancer type", enterprise.GetBalancerType()})
 	t.AppendRow(table.Row{"Credentials", enterprise.Credentials.Name})
+	t.AppendRow(table.Row{"Max concurrent runners", formatRunnerLimit(enterprise.MaxConcurrentRunners)})
+	t.AppendRow(table.Row{"Max pending runners", formatRunnerLimit(enterprise.MaxPendingRunners)})
+	formatFairShare(t, enterprise.FairShare)
 	t.AppendRow(table.Row{"Pool manager running", enterprise.PoolManagerStatus.IsRunning})
 	if !enterprise.PoolManagerStatus.IsRunning {
 		t.AppendRow(table.Row{"Failure reason", enterprise.PoolManagerStatus.FailureReason})
 	}
+	for _, pool := range enterprise.PoolManagerStatus.DegradedPools {
+		t.AppendRow(table.Row{"Degraded pools", formatDegradedPool(pool)}, rowConfigAutoMerge)
+	}
 
 	if len(enterprise.Pools) > 0 {
 		for _, pool := range enterprise.Pools {
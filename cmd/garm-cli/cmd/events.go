@@ -0,0 +1,79 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	eventEntityTypes []string
+	eventOperations  []string
+	eventEntityID    string
+)
+
+var eventsCmd = &cobra.Command{
+	Use:          "debug-events",
+	SilenceUsage: true,
+	Short:        "Stream database change events",
+	Long: `Stream the changes made to the GARM database as they happen.
+
+Each event is printed as a JSON object on its own line, holding the entity type,
+the operation and the changed entity. Secrets are never included.
+
+Example:
+
+	Follow all pool and runner deletions:
+	garm-cli debug-events --entity-type=pool,instance --operation=delete
+
+	Follow all changes of a repository:
+	garm-cli debug-events --entity-type=repository --entity-id=<REPO_ID>
+`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		query := url.Values{}
+		for _, entityType := range eventEntityTypes {
+			query.Add("entityType", entityType)
+		}
+		for _, operation := range eventOperations {
+			query.Add("operation", operation)
+		}
+		if eventEntityID != "" {
+			query.Set("entityID", eventEntityID)
+		}
+
+		c, err := dialWebsocket("/api/v1/ws/events", query)
+		if err != nil {
+			return fmt.Errorf("failed to stream events: %w", err)
+		}
+		return streamWebsocket(c, func(message []byte) {
+			fmt.Println(string(message))
+		})
+	},
+}
+
+func init() {
+	eventsCmd.Flags().StringSliceVar(&eventEntityTypes, "entity-type", nil, "Only stream events for these entity types (repository, organization, enterprise, pool, instance, job, controller, github_credentials, github_endpoint, user, notification_sink, notification_event).")
+	eventsCmd.Flags().StringSliceVar(&eventOperations, "operation", nil, "Only stream events for these operations (create, update or delete).")
+	eventsCmd.Flags().StringVar(&eventEntityID, "entity-id", "", "Only stream events for the entity with this ID. GitHub endpoints are identified by name.")
+
+	rootCmd.AddCommand(eventsCmd)
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/spf13/cobra"
+)
+
+// listFilters holds the pagination and filter flags shared by the
+// commands that list all runners, pools or jobs.
+type listFilters struct {
+	limit         int64
+	cursor        string
+	label         string
+	provider      string
+	createdAfter  string
+	createdBefore string
+	updatedAfter  string
+	updatedBefore string
+}
+
+var listFilterFlags = []string{
+	"limit", "cursor", "label", "provider",
+	"created-after", "created-before", "updated-after", "updated-before",
+}
+
+func (l *listFilters) addFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64Var(&l.limit, "limit", 0, "Maximum number of results to return. If not set, all results are returned.")
+	cmd.Flags().StringVar(&l.cursor, "cursor", "", "Fetch the page that follows this cursor. The cursor is printed when more results are available.")
+	cmd.Flags().StringVar(&l.label, "label", "", "Only list results that match this label.")
+	cmd.Flags().StringVar(&l.provider, "provider", "", "Only list results that belong to this provider.")
+	cmd.Flags().StringVar(&l.createdAfter, "created-after", "", "Only list results created at or after this time (RFC3339).")
+	cmd.Flags().StringVar(&l.createdBefore, "created-before", "", "Only list results created before this time (RFC3339).")
+	cmd.Flags().StringVar(&l.updatedAfter, "updated-after", "", "Only list results updated at or after this time (RFC3339).")
+	cmd.Flags().StringVar(&l.updatedBefore, "updated-before", "", "Only list results updated before this time (RFC3339).")
+}
+
+// anyChanged returns true if any of the given flags was set on the command line.
+func anyChanged(cmd *cobra.Command, flags ...string) bool {
+	for _, flag := range flags {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *listFilters) limitParam() *int64 {
+	if l.limit <= 0 {
+		return nil
+	}
+	return &l.limit
+}
+
+func (l *listFilters) timeParams() (createdAfter, createdBefore, updatedAfter, updatedBefore *strfmt.DateTime, err error) {
+	if createdAfter, err = parseTimeFlag("created-after", l.createdAfter); err != nil {
+		return
+	}
+	if createdBefore, err = parseTimeFlag("created-before", l.createdBefore); err != nil {
+		return
+	}
+	if updatedAfter, err = parseTimeFlag("updated-after", l.updatedAfter); err != nil {
+		return
+	}
+	updatedBefore, err = parseTimeFlag("updated-before", l.updatedBefore)
+	return
+}
+
+func parseTimeFlag(name, val string) (*strfmt.DateTime, error) {
+	if val == "" {
+		return nil, nil
+	}
+	ts, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s (expected RFC3339, eg: 2024-01-02T15:04:05Z): %w", name, err)
+	}
+	dt := strfmt.DateTime(ts)
+	return &dt, nil
+}
+
+func optionalString(val string) *string {
+	if val == "" {
+		return nil
+	}
+	return &val
+}
+
+func printNextCursor(next string) {
+	if next == "" {
+		return
+	}
+	fmt.Printf("More results are available. Use --cursor=%s to fetch the next page.\n", next)
+}
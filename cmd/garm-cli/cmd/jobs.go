@@ -17,6 +17,7 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -35,27 +36,109 @@ var jobsCmd = &cobra.Command{
 	Run:          nil,
 }
 
+var (
+	jobFilters          listFilters
+	jobStatusFilter     string
+	jobPoolFilter       string
+	jobConclusionFilter string
+	jobHistory          bool
+)
+
 var jobsListCmd = &cobra.Command{
-	Use:          "list",
-	Aliases:      []string{"ls"},
-	Short:        "List jobs",
-	Long:         `List all jobs currently recorded in the system.`,
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List jobs",
+	Long: `List all jobs currently recorded in the system.
+
+Completed jobs are removed from this list. Use --history to list the job
+history instead, which keeps completed jobs for the configured retention period.
+
+Example:
+
+	List queued jobs that requested a label:
+	garm-cli job list --status=queued --label=ubuntu
+
+	List failed jobs of the last day:
+	garm-cli job list --history --conclusion=failure --created-after=2024-06-01T00:00:00Z
+`,
 	SilenceUsage: true,
-	RunE: func(_ *cobra.Command, _ []string) error {
+	RunE: func(cmd *cobra.Command, _ []string) error {
 		if needsInit {
 			return errNeedsInitError
 		}
 
+		if jobHistory {
+			return listJobHistory()
+		}
+		if cmd.Flags().Changed("conclusion") {
+			return fmt.Errorf("--conclusion can only be used together with --history")
+		}
+
+		var err error
 		listJobsReq := apiClientJobs.NewListJobsParams()
+		listJobsReq.CreatedAfter, listJobsReq.CreatedBefore, listJobsReq.UpdatedAfter, listJobsReq.UpdatedBefore, err = jobFilters.timeParams()
+		if err != nil {
+			return err
+		}
+		listJobsReq.Limit = jobFilters.limitParam()
+		listJobsReq.Cursor = optionalString(jobFilters.cursor)
+		listJobsReq.Label = optionalString(jobFilters.label)
+		listJobsReq.ProviderName = optionalString(jobFilters.provider)
+		listJobsReq.Status = optionalString(jobStatusFilter)
+		listJobsReq.PoolID = optionalString(jobPoolFilter)
 		response, err := apiCli.Jobs.ListJobs(listJobsReq, authToken)
 		if err != nil {
 			return err
 		}
 		formatJobs(response.Payload)
+		printNextCursor(response.XNextCursor)
 		return nil
 	},
 }
 
+func listJobHistory() error {
+	var err error
+	listReq := apiClientJobs.NewListJobHistoryParams()
+	listReq.CreatedAfter, listReq.CreatedBefore, listReq.UpdatedAfter, listReq.UpdatedBefore, err = jobFilters.timeParams()
+	if err != nil {
+		return err
+	}
+	listReq.Limit = jobFilters.limitParam()
+	listReq.Cursor = optionalString(jobFilters.cursor)
+	listReq.Label = optionalString(jobFilters.label)
+	listReq.ProviderName = optionalString(jobFilters.provider)
+	listReq.Status = optionalString(jobStatusFilter)
+	listReq.Conclusion = optionalString(jobConclusionFilter)
+	listReq.PoolID = optionalString(jobPoolFilter)
+	response, err := apiCli.Jobs.ListJobHistory(listReq, authToken)
+	if err != nil {
+		return err
+	}
+	formatJobHistory(response.Payload)
+	printNextCursor(response.XNextCursor)
+	return nil
+}
+
+func formatJobHistory(history []params.JobHistory) {
+	t := table.NewWriter()
+	header := table.Row{"ID", "Name", "Status", "Conclusion", "Repository", "Runner Name", "Pool ID", "Provider", "Queued At", "Wait", "Duration"}
+	t.AppendHeader(header)
+
+	for _, job := range history {
+		repo := fmt.Sprintf("%s/%s", job.RepositoryOwner, job.RepositoryName)
+		var wait, duration string
+		if job.StartedAt != nil {
+			wait = job.WaitTime().Round(time.Second).String()
+		}
+		if job.CompletedAt != nil {
+			duration = job.RunTime().Round(time.Second).String()
+		}
+		t.AppendRow(table.Row{job.ID, job.Name, job.Status, job.Conclusion, repo, job.RunnerName, job.PoolID, job.ProviderName, job.QueuedAt.Format(time.RFC3339), wait, duration})
+		t.AppendSeparator()
+	}
+	fmt.Println(t.Render())
+}
+
 func formatJobs(jobs []params.Job) {
 	t := table.NewWriter()
 	header := table.Row{"ID", "Name", "Status", "Conclusion", "Runner Name", "Repository", "Requested Labels", "Locked by"}
@@ -74,6 +157,12 @@ func formatJobs(jobs []params.Job) {
 }
 
 func init() {
+	jobsListCmd.Flags().StringVar(&jobStatusFilter, "status", "", "Only list jobs with this status (queued, in_progress or completed).")
+	jobsListCmd.Flags().StringVar(&jobPoolFilter, "pool", "", "Only list jobs picked up by a runner of this pool ID.")
+	jobsListCmd.Flags().StringVar(&jobConclusionFilter, "conclusion", "", "Only list jobs with this conclusion (success, failure, cancelled, etc). Used with --history.")
+	jobsListCmd.Flags().BoolVar(&jobHistory, "history", false, "List the job history, including completed jobs.")
+	jobFilters.addFlags(jobsListCmd)
+
 	jobsCmd.AddCommand(
 		jobsListCmd,
 	)
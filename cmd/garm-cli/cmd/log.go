@@ -3,7 +3,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -24,82 +23,100 @@ var logCmd = &cobra.Command{
 	Short:        "Stream garm log",
 	Long:         `Stream all garm logging to the terminal.`,
 	RunE: func(_ *cobra.Command, _ []string) error {
-		interrupt := make(chan os.Signal, 1)
-		signal.Notify(interrupt, os.Interrupt)
-
-		parsedURL, err := url.Parse(mgr.BaseURL)
+		c, err := dialWebsocket("/api/v1/ws", nil)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to stream logs: %w", err)
 		}
+		return streamWebsocket(c, func(message []byte) {
+			fmt.Println(util.SanitizeLogEntry(string(message)))
+		})
+	},
+}
 
-		wsScheme := "ws"
-		if parsedURL.Scheme == "https" {
-			wsScheme = "wss"
-		}
-		u := url.URL{Scheme: wsScheme, Host: parsedURL.Host, Path: "/api/v1/ws"}
-		slog.Debug("connecting", "url", u.String())
+// dialWebsocket connects to a websocket endpoint of the GARM API, using the
+// credentials of the current profile.
+func dialWebsocket(path string, query url.Values) (*websocket.Conn, error) {
+	parsedURL, err := url.Parse(mgr.BaseURL)
+	if err != nil {
+		return nil, err
+	}
 
-		header := http.Header{}
-		header.Add("Authorization", fmt.Sprintf("Bearer %s", mgr.Token))
+	wsScheme := "ws"
+	if parsedURL.Scheme == "https" {
+		wsScheme = "wss"
+	}
+	u := url.URL{Scheme: wsScheme, Host: parsedURL.Host, Path: path, RawQuery: query.Encode()}
+	slog.Debug("connecting", "url", u.String())
 
-		c, response, err := websocket.DefaultDialer.Dial(u.String(), header)
-		if err != nil {
-			var resp apiParams.APIErrorResponse
-			var msg string
-			var status string
-			if response != nil {
-				if response.Body != nil {
-					if err := json.NewDecoder(response.Body).Decode(&resp); err == nil {
-						msg = resp.Details
-					}
+	header := http.Header{}
+	header.Add("Authorization", fmt.Sprintf("Bearer %s", mgr.Token))
+
+	c, response, err := websocket.DefaultDialer.Dial(u.String(), header)
+	if err != nil {
+		var resp apiParams.APIErrorResponse
+		var msg string
+		var status string
+		if response != nil {
+			if response.Body != nil {
+				if err := json.NewDecoder(response.Body).Decode(&resp); err == nil {
+					msg = resp.Details
 				}
-				status = response.Status
 			}
-			log.Fatalf("failed to stream logs: %q %s (%s)", err, msg, status)
+			status = response.Status
 		}
-		defer c.Close()
+		return nil, fmt.Errorf("%q %s (%s)", err, msg, status)
+	}
+	return c, nil
+}
 
-		done := make(chan struct{})
+// streamWebsocket calls handler for every message received on the connection,
+// until the connection is closed or the user interrupts the command.
+func streamWebsocket(c *websocket.Conn, handler func(message []byte)) error {
+	defer c.Close()
 
-		go func() {
-			defer close(done)
-			for {
-				_, message, err := c.ReadMessage()
-				if err != nil {
-					slog.With(slog.Any("error", err)).Error("reading log message")
-					return
-				}
-				fmt.Println(util.SanitizeLogEntry(string(message)))
-			}
-		}()
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
 
-		ticker := time.NewTicker(time.Second)
-		defer ticker.Stop()
+	done := make(chan struct{})
 
+	go func() {
+		defer close(done)
 		for {
+			_, message, err := c.ReadMessage()
+			if err != nil {
+				slog.With(slog.Any("error", err)).Error("reading message")
+				return
+			}
+			handler(message)
+		}
+	}()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return nil
+		case t := <-ticker.C:
+			err := c.WriteMessage(websocket.TextMessage, []byte(t.String()))
+			if err != nil {
+				return err
+			}
+		case <-interrupt:
+			// Cleanly close the connection by sending a close message and then
+			// waiting (with timeout) for the server to close the connection.
+			err := c.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			if err != nil {
+				return err
+			}
 			select {
 			case <-done:
-				return nil
-			case t := <-ticker.C:
-				err := c.WriteMessage(websocket.TextMessage, []byte(t.String()))
-				if err != nil {
-					return err
-				}
-			case <-interrupt:
-				// Cleanly close the connection by sending a close message and then
-				// waiting (with timeout) for the server to close the connection.
-				err := c.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-				if err != nil {
-					return err
-				}
-				select {
-				case <-done:
-				case <-time.After(time.Second):
-				}
-				return nil
+			case <-time.After(time.Second):
 			}
+			return nil
 		}
-	},
+	}
 }
 
 func init() {
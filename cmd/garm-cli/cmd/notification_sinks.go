@@ -0,0 +1,425 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	apiClientNotifications "github.com/cloudbase/garm/client/notifications"
+	"github.com/cloudbase/garm/params"
+)
+
+var (
+	sinkName        string
+	sinkDescription string
+	sinkURL         string
+	sinkFormat      string
+	sinkHeaders     []string
+	sinkRulesFile   string
+	sinkEventTypes  []string
+	sinkMinLevel    string
+	sinkEntityType  string
+	sinkEntityID    string
+	sinkEnabled     bool
+)
+
+var notificationSinkCmd = &cobra.Command{
+	Use:          "notification-sink",
+	Aliases:      []string{"notification-sinks", "sink"},
+	SilenceUsage: true,
+	Short:        "Manage notification sinks",
+	Long: `Manage notification sinks.
+
+Notification sinks are webhooks that receive notifications when pools or
+runners need attention. For example, when a pool stops creating runners
+because its provider keeps failing, or when a pool is quarantined.
+
+Sinks defined in the config file are listed, but can only be changed by
+editing the config file.`,
+	Run: nil,
+}
+
+var notificationSinkListCmd = &cobra.Command{
+	Use:          "list",
+	Aliases:      []string{"ls"},
+	Short:        "List notification sinks",
+	Long:         `List the notification sinks defined in the config file and through the API.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		listReq := apiClientNotifications.NewListNotificationSinksParams()
+		response, err := apiCli.Notifications.ListNotificationSinks(listReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatNotificationSinks(response.Payload)
+		return nil
+	},
+}
+
+var notificationSinkShowCmd = &cobra.Command{
+	Use:          "show",
+	Aliases:      []string{"get"},
+	Short:        "Show details of a notification sink",
+	Long:         `Show the details of a notification sink.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		sinkID, err := parseSinkID(args)
+		if err != nil {
+			return err
+		}
+
+		showReq := apiClientNotifications.NewGetNotificationSinkParams().WithSinkID(sinkID)
+		response, err := apiCli.Notifications.GetNotificationSink(showReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOneNotificationSink(response.Payload)
+		return nil
+	},
+}
+
+var notificationSinkAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a notification sink",
+	Long: `Add a notification sink.
+
+Without rules, all events are sent to the sink. A single rule can be set using
+the --event-type, --min-level, --entity-type and --entity-id flags. Multiple rules
+can be loaded from a JSON file, using --rules-file. An event is sent if it matches
+any of the rules.
+
+Example:
+
+	Send errors to a Slack channel:
+	garm-cli notification-sink add --name=ops --format=slack \
+		--url=https://hooks.slack.com/services/... --min-level=error
+
+	Send quarantine events to a generic webhook, with an API key:
+	garm-cli notification-sink add --name=pager --url=https://example.com/hook \
+		--header="X-Api-Key=secret" --event-type=pool_quarantined`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		if len(args) > 0 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		headers, err := parseSinkHeaders()
+		if err != nil {
+			return err
+		}
+		rules, err := parseSinkRules(cmd)
+		if err != nil {
+			return err
+		}
+
+		addReq := apiClientNotifications.NewCreateNotificationSinkParams()
+		addReq.Body = params.CreateNotificationSinkParams{
+			Name:        sinkName,
+			Description: sinkDescription,
+			URL:         sinkURL,
+			Format:      params.NotificationFormat(sinkFormat),
+			Headers:     headers,
+			Rules:       rules,
+			Enabled:     &sinkEnabled,
+		}
+		response, err := apiCli.Notifications.CreateNotificationSink(addReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOneNotificationSink(response.Payload)
+		return nil
+	},
+}
+
+var notificationSinkUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a notification sink",
+	Long: `Update a notification sink.
+
+Headers and rules replace all the headers and rules of the sink, if set.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		sinkID, err := parseSinkID(args)
+		if err != nil {
+			return err
+		}
+
+		var updateParams params.UpdateNotificationSinkParams
+		if cmd.Flags().Changed("name") {
+			updateParams.Name = &sinkName
+		}
+		if cmd.Flags().Changed("description") {
+			updateParams.Description = &sinkDescription
+		}
+		if cmd.Flags().Changed("url") {
+			updateParams.URL = &sinkURL
+		}
+		if cmd.Flags().Changed("format") {
+			format := params.NotificationFormat(sinkFormat)
+			updateParams.Format = &format
+		}
+		if cmd.Flags().Changed("enabled") {
+			updateParams.Enabled = &sinkEnabled
+		}
+		if cmd.Flags().Changed("header") {
+			updateParams.Headers, err = parseSinkHeaders()
+			if err != nil {
+				return err
+			}
+		}
+		updateParams.Rules, err = parseSinkRules(cmd)
+		if err != nil {
+			return err
+		}
+
+		updateReq := apiClientNotifications.NewUpdateNotificationSinkParams().WithSinkID(sinkID)
+		updateReq.Body = updateParams
+		response, err := apiCli.Notifications.UpdateNotificationSink(updateReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOneNotificationSink(response.Payload)
+		return nil
+	},
+}
+
+var notificationSinkDeleteCmd = &cobra.Command{
+	Use:          "delete",
+	Aliases:      []string{"remove", "rm"},
+	Short:        "Delete a notification sink",
+	Long:         `Delete a notification sink.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		sinkID, err := parseSinkID(args)
+		if err != nil {
+			return err
+		}
+
+		deleteReq := apiClientNotifications.NewDeleteNotificationSinkParams().WithSinkID(sinkID)
+		return apiCli.Notifications.DeleteNotificationSink(deleteReq, authToken)
+	},
+}
+
+var notificationSinkTestCmd = &cobra.Command{
+	Use:          "test",
+	Short:        "Send a test notification to a sink",
+	Long:         `Send a test notification to a sink. Delivery errors are reported back.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		sinkID, err := parseSinkID(args)
+		if err != nil {
+			return err
+		}
+
+		testReq := apiClientNotifications.NewTestNotificationSinkParams().WithSinkID(sinkID)
+		if err := apiCli.Notifications.TestNotificationSink(testReq, authToken); err != nil {
+			return err
+		}
+		fmt.Println("Test notification sent")
+		return nil
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{notificationSinkAddCmd, notificationSinkUpdateCmd} {
+		cmd.Flags().StringVar(&sinkName, "name", "", "Name of the notification sink.")
+		cmd.Flags().StringVar(&sinkDescription, "description", "", "Description of the notification sink.")
+		cmd.Flags().StringVar(&sinkURL, "url", "", "URL notifications are posted to.")
+		cmd.Flags().StringVar(&sinkFormat, "format", string(params.NotificationFormatJSON), "Format of the notifications (json, slack or teams).")
+		cmd.Flags().StringArrayVar(&sinkHeaders, "header", nil, "HTTP header sent with each notification, as name=value. Can be repeated.")
+		cmd.Flags().BoolVar(&sinkEnabled, "enabled", true, "Send notifications to this sink.")
+		cmd.Flags().StringVar(&sinkRulesFile, "rules-file", "", "Path to a JSON file with a list of rules.")
+		cmd.Flags().StringSliceVar(&sinkEventTypes, "event-type", nil, "Only send these event types.")
+		cmd.Flags().StringVar(&sinkMinLevel, "min-level", "", "Only send events of this level or higher (info, warning or error).")
+		cmd.Flags().StringVar(&sinkEntityType, "entity-type", "", "Only send events about this type of entity (repository, organization or enterprise).")
+		cmd.Flags().StringVar(&sinkEntityID, "entity-id", "", "Only send events about the repository, organization, enterprise or pool with this ID.")
+		for _, flag := range []string{"event-type", "min-level", "entity-type", "entity-id"} {
+			cmd.MarkFlagsMutuallyExclusive("rules-file", flag)
+		}
+	}
+	notificationSinkAddCmd.MarkFlagRequired("name")
+	notificationSinkAddCmd.MarkFlagRequired("url")
+
+	notificationSinkCmd.AddCommand(
+		notificationSinkListCmd,
+		notificationSinkShowCmd,
+		notificationSinkAddCmd,
+		notificationSinkUpdateCmd,
+		notificationSinkDeleteCmd,
+		notificationSinkTestCmd,
+	)
+	rootCmd.AddCommand(notificationSinkCmd)
+}
+
+func parseSinkID(args []string) (int64, error) {
+	if len(args) < 1 {
+		return 0, fmt.Errorf("missing required argument: sink ID")
+	}
+	if len(args) > 1 {
+		return 0, fmt.Errorf("too many arguments")
+	}
+	sinkID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sink ID: %s", args[0])
+	}
+	return sinkID, nil
+}
+
+func parseSinkHeaders() (map[string]string, error) {
+	headers := map[string]string{}
+	for _, header := range sinkHeaders {
+		name, value, ok := strings.Cut(header, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid header %q; expected name=value", header)
+		}
+		headers[name] = value
+	}
+	return headers, nil
+}
+
+// parseSinkRules returns the rules set on the command line, or nil if no rule
+// flags were set.
+func parseSinkRules(cmd *cobra.Command) ([]params.NotificationRule, error) {
+	if sinkRulesFile != "" {
+		data, err := os.ReadFile(sinkRulesFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading rules file: %w", err)
+		}
+		var rules []params.NotificationRule
+		if err := json.Unmarshal(data, &rules); err != nil {
+			return nil, fmt.Errorf("parsing rules file: %w", err)
+		}
+		return rules, nil
+	}
+
+	var changed bool
+	for _, flag := range []string{"event-type", "min-level", "entity-type", "entity-id"} {
+		changed = changed || cmd.Flags().Changed(flag)
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	rule := params.NotificationRule{
+		MinLevel:   params.EventLevel(sinkMinLevel),
+		EntityType: params.GithubEntityType(sinkEntityType),
+		EntityID:   sinkEntityID,
+	}
+	for _, eventType := range sinkEventTypes {
+		rule.EventTypes = append(rule.EventTypes, params.NotificationEventType(eventType))
+	}
+	return []params.NotificationRule{rule}, nil
+}
+
+func formatNotificationRule(rule params.NotificationRule) string {
+	var parts []string
+	if len(rule.EventTypes) > 0 {
+		types := make([]string, len(rule.EventTypes))
+		for idx, eventType := range rule.EventTypes {
+			types[idx] = string(eventType)
+		}
+		parts = append(parts, fmt.Sprintf("types=%s", strings.Join(types, ",")))
+	}
+	if rule.MinLevel != "" {
+		parts = append(parts, fmt.Sprintf("min_level=%s", rule.MinLevel))
+	}
+	if rule.EntityType != "" {
+		parts = append(parts, fmt.Sprintf("entity_type=%s", rule.EntityType))
+	}
+	if rule.EntityID != "" {
+		parts = append(parts, fmt.Sprintf("entity_id=%s", rule.EntityID))
+	}
+	if len(parts) == 0 {
+		return "all events"
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatNotificationSinks(sinks []params.NotificationSink) {
+	t := table.NewWriter()
+	header := table.Row{"ID", "Name", "Format", "URL", "Enabled", "Rules", "Source"}
+	t.AppendHeader(header)
+	for _, val := range sinks {
+		id := ""
+		if val.Source == params.NotificationSinkSourceAPI {
+			id = strconv.FormatUint(uint64(val.ID), 10)
+		}
+		t.AppendRow(table.Row{id, val.Name, val.Format, val.URLHost, val.Enabled, len(val.Rules), val.Source})
+		t.AppendSeparator()
+	}
+	fmt.Println(t.Render())
+}
+
+func formatOneNotificationSink(sink params.NotificationSink) {
+	t := table.NewWriter()
+	header := table.Row{"Field", "Value"}
+	t.AppendHeader(header)
+
+	t.AppendRow(table.Row{"ID", sink.ID})
+	t.AppendRow(table.Row{"Name", sink.Name})
+	t.AppendRow(table.Row{"Description", sink.Description})
+	t.AppendRow(table.Row{"Format", sink.Format})
+	t.AppendRow(table.Row{"URL", sink.URLHost})
+	t.AppendRow(table.Row{"Enabled", sink.Enabled})
+	t.AppendRow(table.Row{"Source", sink.Source})
+	t.AppendRow(table.Row{"Created At", sink.CreatedAt})
+	t.AppendRow(table.Row{"Updated At", sink.UpdatedAt})
+	for _, name := range sink.HeaderNames {
+		t.AppendRow(table.Row{"Headers", name})
+	}
+	if len(sink.Rules) == 0 {
+		t.AppendRow(table.Row{"Rules", "all events"})
+	}
+	for _, rule := range sink.Rules {
+		t.AppendRow(table.Row{"Rules", formatNotificationRule(rule)})
+	}
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, AutoMerge: true},
+		{Number: 2, AutoMerge: false, WidthMax: 100},
+	})
+	fmt.Println(t.Render())
+}
@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
@@ -198,9 +199,9 @@ var orgAddCmd = &cobra.Command{
 var orgUpdateCmd = &cobra.Command{
 	Use:          "update",
 	Short:        "Update organization",
-	Long:         `Update organization credentials or webhook secret.`,
+	Long:         `Update organization credentials, webhook secret, job backfill settings, fair share settings or runner limits.`,
 	SilenceUsage: true,
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if needsInit {
 			return errNeedsInitError
 		}
@@ -212,11 +213,33 @@ var orgUpdateCmd = &cobra.Command{
 		if len(args) > 1 {
 			return fmt.Errorf("too many arguments")
 		}
+		var jobBackfill *params.JobBackfillSettings
+		var fairShareSettings *params.FairShareSettings
+		if jobBackfillFromFlags(cmd, params.JobBackfillSettings{}) != nil || fairShareFlagsChanged(cmd) {
+			// The job backfill and fair share settings are replaced as a whole. Start from the
+			// current settings of the organization, so we only change the fields that were requested.
+			showOrgReq := apiClientOrgs.NewGetOrgParams()
+			showOrgReq.OrgID = args[0]
+			current, err := apiCli.Organizations.GetOrg(showOrgReq, authToken)
+			if err != nil {
+				return err
+			}
+			jobBackfill = jobBackfillFromFlags(cmd, current.Payload.JobBackfill)
+			fairShareSettings, err = fairShareFromFlags(cmd, current.Payload.FairShare)
+			if err != nil {
+				return err
+			}
+		}
+
 		updateOrgReq := apiClientOrgs.NewUpdateOrgParams()
 		updateOrgReq.Body = params.UpdateEntityParams{
-			WebhookSecret:    orgWebhookSecret,
-			CredentialsName:  orgCreds,
-			PoolBalancerType: params.PoolBalancerType(poolBalancerType),
+			WebhookSecret:        orgWebhookSecret,
+			CredentialsName:      orgCreds,
+			PoolBalancerType:     params.PoolBalancerType(poolBalancerType),
+			JobBackfill:          jobBackfill,
+			FairShare:            fairShareSettings,
+			MaxConcurrentRunners: uintFromFlag(cmd, "max-concurrent-runners", maxConcurrent),
+			MaxPendingRunners:    uintFromFlag(cmd, "max-pending-runners", maxPending),
 		}
 		updateOrgReq.OrgID = args[0]
 		response, err := apiCli.Organizations.UpdateOrg(updateOrgReq, authToken)
@@ -303,7 +326,7 @@ var orgDeleteCmd = &cobra.Command{
 
 func init() {
 	orgAddCmd.Flags().StringVar(&orgName, "name", "", "The name of the organization")
-	orgAddCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", string(params.PoolBalancerTypeRoundRobin), "The balancing strategy to use when creating runners in pools matching requested labels.")
+	orgAddCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", string(params.PoolBalancerTypeRoundRobin), "The balancing strategy to use when creating runners in pools matching requested labels (roundrobin, pack, weighted, capacity).")
 	orgAddCmd.Flags().StringVar(&orgWebhookSecret, "webhook-secret", "", "The webhook secret for this organization")
 	orgAddCmd.Flags().StringVar(&orgCreds, "credentials", "", "Credentials name. See credentials list.")
 	orgAddCmd.Flags().BoolVar(&orgRandomWebhookSecret, "random-webhook-secret", false, "Generate a random webhook secret for this organization.")
@@ -318,7 +341,12 @@ func init() {
 
 	orgUpdateCmd.Flags().StringVar(&orgWebhookSecret, "webhook-secret", "", "The webhook secret for this organization")
 	orgUpdateCmd.Flags().StringVar(&orgCreds, "credentials", "", "Credentials name. See credentials list.")
-	orgUpdateCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", "", "The balancing strategy to use when creating runners in pools matching requested labels.")
+	orgUpdateCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", "", "The balancing strategy to use when creating runners in pools matching requested labels (roundrobin, pack, weighted, capacity).")
+	addRunnerLimitFlags(orgUpdateCmd)
+	addFairShareFlags(orgUpdateCmd)
+	orgUpdateCmd.Flags().BoolVar(&backfillJobs, "backfill-queued-jobs", false, "Ask GitHub for queued jobs when the pool manager starts, to pick up jobs that were queued while GARM was down. Requires --backfill-repos or --backfill-max-repos.")
+	orgUpdateCmd.Flags().StringVar(&backfillRepos, "backfill-repos", "", "A comma separated list of repositories to scan for queued jobs. Set to an empty value to remove the list.")
+	orgUpdateCmd.Flags().UintVar(&backfillMaxRepos, "backfill-max-repos", 0, "The maximum number of repositories to scan for queued jobs, most recently pushed to first. Only used if --backfill-repos is not set.")
 
 	orgWebhookInstallCmd.Flags().BoolVar(&insecureOrgWebhook, "insecure", false, "Ignore self signed certificate errors.")
 	orgWebhookCmd.AddCommand(
@@ -359,10 +387,24 @@ func formatOneOrganization(org params.Organization) {
 	t.AppendRow(table.Row{"Name", org.Name})
 	t.AppendRow(table.Row{"Pool balancer type", org.GetBalancerType()})
 	t.AppendRow(table.Row{"Credentials", org.CredentialsName})
+	t.AppendRow(table.Row{"Backfill queued jobs", org.JobBackfill.Enabled})
+	if org.JobBackfill.Enabled {
+		if len(org.JobBackfill.Repositories) > 0 {
+			t.AppendRow(table.Row{"Backfill repositories", strings.Join(org.JobBackfill.Repositories, ", ")})
+		} else {
+			t.AppendRow(table.Row{"Backfill max repositories", org.JobBackfill.MaxRepositories})
+		}
+	}
+	t.AppendRow(table.Row{"Max concurrent runners", formatRunnerLimit(org.MaxConcurrentRunners)})
+	t.AppendRow(table.Row{"Max pending runners", formatRunnerLimit(org.MaxPendingRunners)})
+	formatFairShare(t, org.FairShare)
 	t.AppendRow(table.Row{"Pool manager running", org.PoolManagerStatus.IsRunning})
 	if !org.PoolManagerStatus.IsRunning {
 		t.AppendRow(table.Row{"Failure reason", org.PoolManagerStatus.FailureReason})
 	}
+	for _, pool := range org.PoolManagerStatus.DegradedPools {
+		t.AppendRow(table.Row{"Degraded pools", formatDegradedPool(pool)}, rowConfigAutoMerge)
+	}
 	if len(org.Pools) > 0 {
 		for _, pool := range org.Pools {
 			t.AppendRow(table.Row{"Pools", pool.ID}, rowConfigAutoMerge)
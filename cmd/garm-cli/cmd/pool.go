@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/pkg/errors"
@@ -49,11 +50,31 @@ var (
 	poolEnterprise             string
 	poolExtraSpecsFile         string
 	poolExtraSpecs             string
+	poolScalingSchedulesFile   string
+	poolScalingSchedules       string
+	poolScaleDownFactor        float64
+	poolScaleDownIdleGrace     uint
+	poolScaleDownMinRunnerAge  uint
+	poolScaleDownOrder         string
+	poolScaleDownMode          string
+	poolWeight                 uint
+	poolMaxIdleAge             uint
+	poolMaxLifetime            uint
+	poolMaxConcurrentCreates   uint
+	poolCreatesPerMinute       uint
+	poolQuarantineThreshold    uint
+	poolRequiredLabels         string
+	poolExcludedLabels         string
 	poolAll                    bool
 	poolGitHubRunnerGroup      string
 	priority                   uint
+
+	poolFilters       listFilters
+	poolEnabledFilter bool
 )
 
+var poolFilterFlags = append([]string{"enabled"}, listFilterFlags...)
+
 type poolsPayloadGetter interface {
 	GetPayload() params.Pools
 }
@@ -91,6 +112,9 @@ Example:
 	List all pools from all repos, orgs and enterprises:
 	garm-cli pool list --all
 
+	List disabled pools with a given label:
+	garm-cli pool list --all --enabled=false --label=ubuntu
+
 `,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -98,7 +122,12 @@ Example:
 			return errNeedsInitError
 		}
 
+		if anyChanged(cmd, poolFilterFlags...) && !cmd.Flags().Changed("all") {
+			return fmt.Errorf("filters can only be used together with --all")
+		}
+
 		var response poolsPayloadGetter
+		var next string
 		var err error
 
 		switch len(args) {
@@ -117,7 +146,22 @@ Example:
 				response, err = apiCli.Enterprises.ListEnterprisePools(listEnterprisePoolsReq, authToken)
 			} else if cmd.Flags().Changed("all") {
 				listPoolsReq := apiClientPools.NewListPoolsParams()
-				response, err = apiCli.Pools.ListPools(listPoolsReq, authToken)
+				listPoolsReq.CreatedAfter, listPoolsReq.CreatedBefore, listPoolsReq.UpdatedAfter, listPoolsReq.UpdatedBefore, err = poolFilters.timeParams()
+				if err != nil {
+					return err
+				}
+				listPoolsReq.Limit = poolFilters.limitParam()
+				listPoolsReq.Cursor = optionalString(poolFilters.cursor)
+				listPoolsReq.Label = optionalString(poolFilters.label)
+				listPoolsReq.ProviderName = optionalString(poolFilters.provider)
+				if cmd.Flags().Changed("enabled") {
+					listPoolsReq.Enabled = &poolEnabledFilter
+				}
+				var resp *apiClientPools.ListPoolsOK
+				resp, err = apiCli.Pools.ListPools(listPoolsReq, authToken)
+				if err == nil {
+					response, next = resp, resp.XNextCursor
+				}
 			} else {
 				cmd.Help() //nolint
 				os.Exit(0)
@@ -131,6 +175,7 @@ Example:
 			return err
 		}
 		formatPools(response.GetPayload())
+		printNextCursor(next)
 		return nil
 	},
 }
@@ -192,6 +237,35 @@ var poolDeleteCmd = &cobra.Command{
 	},
 }
 
+var poolReleaseQuarantineCmd = &cobra.Command{
+	Use:          "release-quarantine",
+	Short:        "Release a pool from quarantine",
+	Long:         `Enable a pool that was disabled after repeated bootstrap failures.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		if len(args) == 0 {
+			return fmt.Errorf("requires a pool ID")
+		}
+
+		if len(args) > 1 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		releaseReq := apiClientPools.NewReleasePoolQuarantineParams()
+		releaseReq.PoolID = args[0]
+		response, err := apiCli.Pools.ReleasePoolQuarantine(releaseReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOnePool(response.Payload)
+		return nil
+	},
+}
+
 type poolPayloadGetter interface {
 	GetPayload() params.Pool
 }
@@ -224,6 +298,12 @@ var poolAddCmd = &cobra.Command{
 			RunnerBootstrapTimeout: poolRunnerBootstrapTimeout,
 			GitHubRunnerGroup:      poolGitHubRunnerGroup,
 			Priority:               priority,
+			Weight:                 poolWeight,
+			MaxIdleAge:             poolMaxIdleAge,
+			MaxLifetime:            poolMaxLifetime,
+			MaxConcurrentCreates:   poolMaxConcurrentCreates,
+			CreatesPerMinute:       poolCreatesPerMinute,
+			QuarantineThreshold:    poolQuarantineThreshold,
 		}
 
 		if cmd.Flags().Changed("extra-specs") {
@@ -242,11 +322,18 @@ var poolAddCmd = &cobra.Command{
 			newPoolParams.ExtraSpecs = data
 		}
 
+		schedules, err := scalingSchedulesFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		newPoolParams.ScalingSchedules = schedules
+		newPoolParams.ScaleDownPolicy = scaleDownPolicyFromFlags(cmd, params.PoolScaleDownPolicy{})
+		newPoolParams.LabelRules = labelRulesFromFlags(cmd, params.PoolLabelRules{})
+
 		if err := newPoolParams.Validate(); err != nil {
 			return err
 		}
 
-		var err error
 		var response poolPayloadGetter
 		if cmd.Flags().Changed("repo") {
 			newRepoPoolReq := apiClientRepos.NewCreateRepoPoolParams()
@@ -329,6 +416,24 @@ explicitly remove them using the runner delete command.
 		if cmd.Flags().Changed("priority") {
 			poolUpdateParams.Priority = &priority
 		}
+		if cmd.Flags().Changed("weight") {
+			poolUpdateParams.Weight = &poolWeight
+		}
+		if cmd.Flags().Changed("max-idle-age") {
+			poolUpdateParams.MaxIdleAge = &poolMaxIdleAge
+		}
+		if cmd.Flags().Changed("max-lifetime") {
+			poolUpdateParams.MaxLifetime = &poolMaxLifetime
+		}
+		if cmd.Flags().Changed("max-concurrent-creates") {
+			poolUpdateParams.MaxConcurrentCreates = &poolMaxConcurrentCreates
+		}
+		if cmd.Flags().Changed("creates-per-minute") {
+			poolUpdateParams.CreatesPerMinute = &poolCreatesPerMinute
+		}
+		if cmd.Flags().Changed("quarantine-threshold") {
+			poolUpdateParams.QuarantineThreshold = &poolQuarantineThreshold
+		}
 
 		if cmd.Flags().Changed("min-idle-runners") {
 			poolUpdateParams.MinIdleRunners = &poolMinIdleRunners
@@ -368,6 +473,35 @@ explicitly remove them using the runner delete command.
 			poolUpdateParams.ExtraSpecs = data
 		}
 
+		schedules, err := scalingSchedulesFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		poolUpdateParams.ScalingSchedules = schedules
+
+		if scaleDownPolicyFlagsChanged(cmd) || labelRulesFlagsChanged(cmd) {
+			// The scale down policy and the label rules are replaced as a whole. Start from
+			// the current values of the pool, so we only change the fields that were requested.
+			getPoolReq := apiClientPools.NewGetPoolParams()
+			getPoolReq.PoolID = args[0]
+			current, err := apiCli.Pools.GetPool(getPoolReq, authToken)
+			if err != nil {
+				return err
+			}
+			if scaleDownPolicyFlagsChanged(cmd) {
+				policy := scaleDownPolicyFromFlags(cmd, current.Payload.ScaleDownPolicy)
+				poolUpdateParams.ScaleDownPolicy = &policy
+			}
+			if labelRulesFlagsChanged(cmd) {
+				rules := labelRulesFromFlags(cmd, current.Payload.LabelRules)
+				poolUpdateParams.LabelRules = &rules
+			}
+		}
+
+		if err := poolUpdateParams.Validate(); err != nil {
+			return err
+		}
+
 		updatePoolReq.PoolID = args[0]
 		updatePoolReq.Body = poolUpdateParams
 		response, err := apiCli.Pools.UpdatePool(updatePoolReq, authToken)
@@ -386,9 +520,14 @@ func init() {
 	poolListCmd.Flags().StringVarP(&poolEnterprise, "enterprise", "e", "", "List all pools within this enterprise.")
 	poolListCmd.Flags().BoolVarP(&poolAll, "all", "a", false, "List all pools, regardless of org or repo.")
 	poolListCmd.MarkFlagsMutuallyExclusive("repo", "org", "all", "enterprise")
+	poolListCmd.Flags().BoolVar(&poolEnabledFilter, "enabled", false, "Only list enabled (true) or disabled (false) pools. Used with --all.")
+	poolFilters.addFlags(poolListCmd)
 
 	poolUpdateCmd.Flags().StringVar(&poolImage, "image", "", "The provider-specific image name to use for runners in this pool.")
 	poolUpdateCmd.Flags().UintVar(&priority, "priority", 0, "When multiple pools match the same labels, priority dictates the order by which they are returned, in descending order.")
+	poolUpdateCmd.Flags().UintVar(&poolWeight, "weight", 0, "The relative share of runners created in this pool, when the entity uses the weighted pool balancer.")
+	poolUpdateCmd.Flags().StringVar(&poolRequiredLabels, "required-labels", "", "A comma separated list of labels a job must explicitly request for this pool to be eligible. An empty value removes them.")
+	poolUpdateCmd.Flags().StringVar(&poolExcludedLabels, "excluded-labels", "", "A comma separated list of labels that make this pool ineligible, if requested by a job. An empty value removes them.")
 	poolUpdateCmd.Flags().StringVar(&poolFlavor, "flavor", "", "The flavor to use for this runner.")
 	poolUpdateCmd.Flags().StringVar(&poolTags, "tags", "", "A comma separated list of tags to assign to this runner.")
 	poolUpdateCmd.Flags().StringVar(&poolOSType, "os-type", "linux", "Operating system type (windows, linux, etc).")
@@ -399,12 +538,28 @@ func init() {
 	poolUpdateCmd.Flags().StringVar(&poolGitHubRunnerGroup, "runner-group", "", "The GitHub runner group in which all runners of this pool will be added.")
 	poolUpdateCmd.Flags().BoolVar(&poolEnabled, "enabled", false, "Enable this pool.")
 	poolUpdateCmd.Flags().UintVar(&poolRunnerBootstrapTimeout, "runner-bootstrap-timeout", 20, "Duration in minutes after which a runner is considered failed if it does not join Github.")
+	poolUpdateCmd.Flags().UintVar(&poolMaxIdleAge, "max-idle-age", 0, "Duration in minutes after which an idle runner is replaced with a new one. Set to 0 to disable.")
+	poolUpdateCmd.Flags().UintVar(&poolMaxLifetime, "max-lifetime", 0, "Duration in minutes after which an idle runner is replaced with a new one, regardless of how long it was idle. Set to 0 to disable.")
+	poolUpdateCmd.Flags().UintVar(&poolMaxConcurrentCreates, "max-concurrent-creates", 0, "The maximum number of instances of this pool that may be created at the same time. Set to 0 to use the limit of the provider.")
+	poolUpdateCmd.Flags().UintVar(&poolCreatesPerMinute, "creates-per-minute", 0, "The maximum number of instance creations of this pool that may start in one minute. Set to 0 to use the limit of the provider.")
+	poolUpdateCmd.Flags().UintVar(&poolQuarantineThreshold, "quarantine-threshold", 0, "The number of consecutive bootstrap failures after which this pool is disabled. Set to 0 to disable.")
 	poolUpdateCmd.Flags().StringVar(&poolExtraSpecsFile, "extra-specs-file", "", "A file containing a valid json which will be passed to the IaaS provider managing the pool.")
 	poolUpdateCmd.Flags().StringVar(&poolExtraSpecs, "extra-specs", "", "A valid json which will be passed to the IaaS provider managing the pool.")
 	poolUpdateCmd.MarkFlagsMutuallyExclusive("extra-specs-file", "extra-specs")
+	poolUpdateCmd.Flags().StringVar(&poolScalingSchedulesFile, "scaling-schedules-file", "", "A file containing a json list of time of day scaling schedules for this pool. An empty list removes all schedules.")
+	poolUpdateCmd.Flags().StringVar(&poolScalingSchedules, "scaling-schedules", "", "A json list of time of day scaling schedules for this pool. An empty list removes all schedules.")
+	poolUpdateCmd.MarkFlagsMutuallyExclusive("scaling-schedules-file", "scaling-schedules")
+	poolUpdateCmd.Flags().Float64Var(&poolScaleDownFactor, "scale-down-factor", 0, "The fraction (0 < factor <= 1) of surplus idle runners removed in one scale down iteration. Set to 0 to use the default (0.5).")
+	poolUpdateCmd.Flags().UintVar(&poolScaleDownIdleGrace, "scale-down-idle-grace-period", 0, "Duration in minutes a runner needs to be idle before it is considered for scale down. Set to 0 to disable the grace period.")
+	poolUpdateCmd.Flags().UintVar(&poolScaleDownMinRunnerAge, "scale-down-min-runner-age", 0, "Minimum age in minutes a runner needs to have before it is considered for scale down.")
+	poolUpdateCmd.Flags().StringVar(&poolScaleDownOrder, "scale-down-order", "", "Which idle runners to remove first when scaling down (oldest, newest).")
+	poolUpdateCmd.Flags().StringVar(&poolScaleDownMode, "scale-down-mode", "", "Whether surplus idle runners are deleted or stopped in the provider when scaling down (delete, hibernate).")
 
 	poolAddCmd.Flags().StringVar(&poolProvider, "provider-name", "", "The name of the provider where runners will be created.")
 	poolAddCmd.Flags().UintVar(&priority, "priority", 0, "When multiple pools match the same labels, priority dictates the order by which they are returned, in descending order.")
+	poolAddCmd.Flags().UintVar(&poolWeight, "weight", 0, "The relative share of runners created in this pool, when the entity uses the weighted pool balancer. Defaults to 1.")
+	poolAddCmd.Flags().StringVar(&poolRequiredLabels, "required-labels", "", "A comma separated list of labels a job must explicitly request for this pool to be eligible.")
+	poolAddCmd.Flags().StringVar(&poolExcludedLabels, "excluded-labels", "", "A comma separated list of labels that make this pool ineligible, if requested by a job.")
 	poolAddCmd.Flags().StringVar(&poolImage, "image", "", "The provider-specific image name to use for runners in this pool.")
 	poolAddCmd.Flags().StringVar(&poolFlavor, "flavor", "", "The flavor to use for this runner.")
 	poolAddCmd.Flags().StringVar(&poolRunnerPrefix, "runner-prefix", "", "The name prefix to use for runners in this pool.")
@@ -416,6 +571,11 @@ func init() {
 	poolAddCmd.Flags().StringVar(&poolGitHubRunnerGroup, "runner-group", "", "The GitHub runner group in which all runners of this pool will be added.")
 	poolAddCmd.Flags().UintVar(&poolMaxRunners, "max-runners", 5, "The maximum number of runner this pool will create.")
 	poolAddCmd.Flags().UintVar(&poolRunnerBootstrapTimeout, "runner-bootstrap-timeout", 20, "Duration in minutes after which a runner is considered failed if it does not join Github.")
+	poolAddCmd.Flags().UintVar(&poolMaxIdleAge, "max-idle-age", 0, "Duration in minutes after which an idle runner is replaced with a new one. Set to 0 to disable.")
+	poolAddCmd.Flags().UintVar(&poolMaxLifetime, "max-lifetime", 0, "Duration in minutes after which an idle runner is replaced with a new one, regardless of how long it was idle. Set to 0 to disable.")
+	poolAddCmd.Flags().UintVar(&poolMaxConcurrentCreates, "max-concurrent-creates", 0, "The maximum number of instances of this pool that may be created at the same time. Set to 0 to use the limit of the provider.")
+	poolAddCmd.Flags().UintVar(&poolCreatesPerMinute, "creates-per-minute", 0, "The maximum number of instance creations of this pool that may start in one minute. Set to 0 to use the limit of the provider.")
+	poolAddCmd.Flags().UintVar(&poolQuarantineThreshold, "quarantine-threshold", 0, "The number of consecutive bootstrap failures after which this pool is disabled. Set to 0 to disable.")
 	poolAddCmd.Flags().UintVar(&poolMinIdleRunners, "min-idle-runners", 1, "Attempt to maintain a minimum of idle self-hosted runners of this type.")
 	poolAddCmd.Flags().BoolVar(&poolEnabled, "enabled", false, "Enable this pool.")
 	poolAddCmd.MarkFlagRequired("provider-name") //nolint
@@ -428,6 +588,14 @@ func init() {
 	poolAddCmd.Flags().StringVarP(&poolEnterprise, "enterprise", "e", "", "Add the new pool within this enterprise.")
 	poolAddCmd.MarkFlagsMutuallyExclusive("repo", "org", "enterprise")
 	poolAddCmd.MarkFlagsMutuallyExclusive("extra-specs-file", "extra-specs")
+	poolAddCmd.Flags().StringVar(&poolScalingSchedulesFile, "scaling-schedules-file", "", "A file containing a json list of time of day scaling schedules for this pool.")
+	poolAddCmd.Flags().StringVar(&poolScalingSchedules, "scaling-schedules", "", "A json list of time of day scaling schedules for this pool.")
+	poolAddCmd.MarkFlagsMutuallyExclusive("scaling-schedules-file", "scaling-schedules")
+	poolAddCmd.Flags().Float64Var(&poolScaleDownFactor, "scale-down-factor", 0, "The fraction (0 < factor <= 1) of surplus idle runners removed in one scale down iteration. Defaults to 0.5.")
+	poolAddCmd.Flags().UintVar(&poolScaleDownIdleGrace, "scale-down-idle-grace-period", 0, "Duration in minutes a runner needs to be idle before it is considered for scale down. Set to 0 to disable the grace period. Defaults to 2.")
+	poolAddCmd.Flags().UintVar(&poolScaleDownMinRunnerAge, "scale-down-min-runner-age", 0, "Minimum age in minutes a runner needs to have before it is considered for scale down.")
+	poolAddCmd.Flags().StringVar(&poolScaleDownOrder, "scale-down-order", "", "Which idle runners to remove first when scaling down (oldest, newest). Defaults to oldest.")
+	poolAddCmd.Flags().StringVar(&poolScaleDownMode, "scale-down-mode", "", "Whether surplus idle runners are deleted or stopped in the provider when scaling down (delete, hibernate). Defaults to delete.")
 
 	poolCmd.AddCommand(
 		poolListCmd,
@@ -435,6 +603,7 @@ func init() {
 		poolDeleteCmd,
 		poolUpdateCmd,
 		poolAddCmd,
+		poolReleaseQuarantineCmd,
 	)
 
 	rootCmd.AddCommand(poolCmd)
@@ -448,6 +617,87 @@ func extraSpecsFromFile(specsFile string) (json.RawMessage, error) {
 	return asRawMessage(data)
 }
 
+// scalingSchedulesFromFlags returns the scaling schedules passed in via the command
+// line. A nil value is returned if no schedules were specified.
+func scalingSchedulesFromFlags(cmd *cobra.Command) ([]params.PoolScalingSchedule, error) {
+	var data []byte
+	switch {
+	case cmd.Flags().Changed("scaling-schedules"):
+		data = []byte(poolScalingSchedules)
+	case poolScalingSchedulesFile != "":
+		var err error
+		data, err = os.ReadFile(poolScalingSchedulesFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "opening scaling schedules file")
+		}
+	default:
+		return nil, nil
+	}
+
+	schedules := []params.PoolScalingSchedule{}
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return nil, errors.Wrap(err, "decoding scaling schedules")
+	}
+	return schedules, nil
+}
+
+func scaleDownPolicyFlagsChanged(cmd *cobra.Command) bool {
+	for _, flag := range []string{"scale-down-factor", "scale-down-idle-grace-period", "scale-down-min-runner-age", "scale-down-order", "scale-down-mode"} {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// scaleDownPolicyFromFlags applies the scale down flags that were set on the command
+// line on top of the given policy.
+func scaleDownPolicyFromFlags(cmd *cobra.Command, policy params.PoolScaleDownPolicy) params.PoolScaleDownPolicy {
+	if cmd.Flags().Changed("scale-down-factor") {
+		policy.Factor = poolScaleDownFactor
+	}
+	if cmd.Flags().Changed("scale-down-idle-grace-period") {
+		idleGracePeriod := poolScaleDownIdleGrace
+		policy.IdleGracePeriod = &idleGracePeriod
+	}
+	if cmd.Flags().Changed("scale-down-min-runner-age") {
+		policy.MinRunnerAge = poolScaleDownMinRunnerAge
+	}
+	if cmd.Flags().Changed("scale-down-order") {
+		policy.Order = params.ScaleDownOrder(poolScaleDownOrder)
+	}
+	if cmd.Flags().Changed("scale-down-mode") {
+		policy.Mode = params.ScaleDownMode(poolScaleDownMode)
+	}
+	return policy
+}
+
+func labelRulesFlagsChanged(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("required-labels") || cmd.Flags().Changed("excluded-labels")
+}
+
+// labelRulesFromFlags applies the label rule flags that were set on the command
+// line on top of the given rules.
+func labelRulesFromFlags(cmd *cobra.Command, rules params.PoolLabelRules) params.PoolLabelRules {
+	if cmd.Flags().Changed("required-labels") {
+		rules.RequiredLabels = labelsFromFlag(poolRequiredLabels)
+	}
+	if cmd.Flags().Changed("excluded-labels") {
+		rules.ExcludedLabels = labelsFromFlag(poolExcludedLabels)
+	}
+	return rules
+}
+
+func labelsFromFlag(value string) []string {
+	labels := []string{}
+	for _, label := range strings.Split(value, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
 func asRawMessage(data []byte) (json.RawMessage, error) {
 	// unmarshaling and marshaling again will remove new lines and verify we
 	// have a valid json.
@@ -525,6 +775,7 @@ func formatOnePool(pool params.Pool) {
 	t.AppendRow(table.Row{"ID", pool.ID})
 	t.AppendRow(table.Row{"Provider Name", pool.ProviderName})
 	t.AppendRow(table.Row{"Priority", pool.Priority})
+	t.AppendRow(table.Row{"Weight", pool.GetWeight()})
 	t.AppendRow(table.Row{"Image", pool.Image})
 	t.AppendRow(table.Row{"Flavor", pool.Flavor})
 	t.AppendRow(table.Row{"OS Type", pool.OSType})
@@ -532,13 +783,33 @@ func formatOnePool(pool params.Pool) {
 	t.AppendRow(table.Row{"Max Runners", pool.MaxRunners})
 	t.AppendRow(table.Row{"Min Idle Runners", pool.MinIdleRunners})
 	t.AppendRow(table.Row{"Runner Bootstrap Timeout", pool.RunnerBootstrapTimeout})
+	t.AppendRow(table.Row{"Max Idle Age", formatRunnerExpiry(pool.GetMaxIdleAge())})
+	t.AppendRow(table.Row{"Max Lifetime", formatRunnerExpiry(pool.GetMaxLifetime())})
+	t.AppendRow(table.Row{"Max Concurrent Creates", formatCreationLimit(pool.MaxConcurrentCreates)})
+	t.AppendRow(table.Row{"Creates Per Minute", formatCreationLimit(pool.CreatesPerMinute)})
 	t.AppendRow(table.Row{"Tags", strings.Join(tags, ", ")})
+	t.AppendRow(table.Row{"Required Labels", strings.Join(pool.LabelRules.RequiredLabels, ", ")})
+	t.AppendRow(table.Row{"Excluded Labels", strings.Join(pool.LabelRules.ExcludedLabels, ", ")})
 	t.AppendRow(table.Row{"Belongs to", belongsTo})
 	t.AppendRow(table.Row{"Level", level})
 	t.AppendRow(table.Row{"Enabled", pool.Enabled})
+	t.AppendRow(table.Row{"Quarantine Threshold", formatQuarantineThreshold(pool.QuarantineThreshold)})
+	if pool.IsQuarantined() {
+		t.AppendRow(table.Row{"Quarantined At", pool.QuarantinedAt.Format("2006-01-02T15:04:05")})
+		t.AppendRow(table.Row{"Quarantine Reason", pool.QuarantineReason})
+	}
 	t.AppendRow(table.Row{"Runner Prefix", pool.GetRunnerPrefix()})
 	t.AppendRow(table.Row{"Extra specs", string(pool.ExtraSpecs)})
 	t.AppendRow(table.Row{"GitHub Runner Group", pool.GitHubRunnerGroup})
+	t.AppendRow(table.Row{"Scale Down Factor", pool.ScaleDownPolicy.GetFactor()})
+	t.AppendRow(table.Row{"Scale Down Idle Grace Period", pool.ScaleDownPolicy.GetIdleGracePeriod()})
+	t.AppendRow(table.Row{"Scale Down Min Runner Age", pool.ScaleDownPolicy.GetMinRunnerAge()})
+	t.AppendRow(table.Row{"Scale Down Order", pool.ScaleDownPolicy.GetOrder()})
+	t.AppendRow(table.Row{"Scale Down Mode", pool.ScaleDownPolicy.GetMode()})
+
+	for _, schedule := range pool.ScalingSchedules {
+		t.AppendRow(table.Row{"Scaling Schedules", schedule.String()}, rowConfigAutoMerge)
+	}
 
 	if len(pool.Instances) > 0 {
 		for _, instance := range pool.Instances {
@@ -552,3 +823,24 @@ func formatOnePool(pool params.Pool) {
 	})
 	fmt.Println(t.Render())
 }
+
+func formatRunnerExpiry(limit time.Duration) interface{} {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return limit
+}
+
+func formatQuarantineThreshold(threshold uint) interface{} {
+	if threshold == 0 {
+		return "disabled"
+	}
+	return threshold
+}
+
+func formatCreationLimit(limit uint) interface{} {
+	if limit == 0 {
+		return "provider default"
+	}
+	return limit
+}
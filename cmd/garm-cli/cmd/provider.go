@@ -65,10 +65,10 @@ func init() {
 
 func formatProviders(providers []params.Provider) {
 	t := table.NewWriter()
-	header := table.Row{"Name", "Description", "Type"}
+	header := table.Row{"Name", "Description", "Type", "Max concurrent creates", "Creates per minute"}
 	t.AppendHeader(header)
 	for _, val := range providers {
-		t.AppendRow(table.Row{val.Name, val.Description, val.ProviderType})
+		t.AppendRow(table.Row{val.Name, val.Description, val.ProviderType, formatRunnerLimit(val.MaxConcurrentCreates), formatRunnerLimit(val.CreatesPerMinute)})
 		t.AppendSeparator()
 	}
 	fmt.Println(t.Render())
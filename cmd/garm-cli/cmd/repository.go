@@ -221,9 +221,9 @@ var repoListCmd = &cobra.Command{
 var repoUpdateCmd = &cobra.Command{
 	Use:          "update",
 	Short:        "Update repository",
-	Long:         `Update repository credentials or webhook secret.`,
+	Long:         `Update repository credentials, webhook secret, job backfill settings, fair share settings or runner limits.`,
 	SilenceUsage: true,
-	RunE: func(_ *cobra.Command, args []string) error {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if needsInit {
 			return errNeedsInitError
 		}
@@ -235,11 +235,31 @@ var repoUpdateCmd = &cobra.Command{
 		if len(args) > 1 {
 			return fmt.Errorf("too many arguments")
 		}
+		var fairShareSettings *params.FairShareSettings
+		if fairShareFlagsChanged(cmd) {
+			// The fair share settings are replaced as a whole. Start from the current
+			// settings of the repository, so we only change the fields that were requested.
+			showRepoReq := apiClientRepos.NewGetRepoParams()
+			showRepoReq.RepoID = args[0]
+			current, err := apiCli.Repositories.GetRepo(showRepoReq, authToken)
+			if err != nil {
+				return err
+			}
+			fairShareSettings, err = fairShareFromFlags(cmd, current.Payload.FairShare)
+			if err != nil {
+				return err
+			}
+		}
+
 		updateReposReq := apiClientRepos.NewUpdateRepoParams()
 		updateReposReq.Body = params.UpdateEntityParams{
-			WebhookSecret:    repoWebhookSecret,
-			CredentialsName:  repoCreds,
-			PoolBalancerType: params.PoolBalancerType(poolBalancerType),
+			WebhookSecret:        repoWebhookSecret,
+			CredentialsName:      repoCreds,
+			PoolBalancerType:     params.PoolBalancerType(poolBalancerType),
+			JobBackfill:          jobBackfillFromFlags(cmd, params.JobBackfillSettings{}),
+			FairShare:            fairShareSettings,
+			MaxConcurrentRunners: uintFromFlag(cmd, "max-concurrent-runners", maxConcurrent),
+			MaxPendingRunners:    uintFromFlag(cmd, "max-pending-runners", maxPending),
 		}
 		updateReposReq.RepoID = args[0]
 
@@ -306,7 +326,7 @@ var repoDeleteCmd = &cobra.Command{
 
 func init() {
 	repoAddCmd.Flags().StringVar(&repoOwner, "owner", "", "The owner of this repository")
-	repoAddCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", string(params.PoolBalancerTypeRoundRobin), "The balancing strategy to use when creating runners in pools matching requested labels.")
+	repoAddCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", string(params.PoolBalancerTypeRoundRobin), "The balancing strategy to use when creating runners in pools matching requested labels (roundrobin, pack, weighted, capacity).")
 	repoAddCmd.Flags().StringVar(&repoName, "name", "", "The name of the repository")
 	repoAddCmd.Flags().StringVar(&repoWebhookSecret, "webhook-secret", "", "The webhook secret for this repository")
 	repoAddCmd.Flags().StringVar(&repoCreds, "credentials", "", "Credentials name. See credentials list.")
@@ -323,7 +343,10 @@ func init() {
 
 	repoUpdateCmd.Flags().StringVar(&repoWebhookSecret, "webhook-secret", "", "The webhook secret for this repository. If you update this secret, you will have to manually update the secret in GitHub as well.")
 	repoUpdateCmd.Flags().StringVar(&repoCreds, "credentials", "", "Credentials name. See credentials list.")
-	repoUpdateCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", "", "The balancing strategy to use when creating runners in pools matching requested labels.")
+	repoUpdateCmd.Flags().StringVar(&poolBalancerType, "pool-balancer-type", "", "The balancing strategy to use when creating runners in pools matching requested labels (roundrobin, pack, weighted, capacity).")
+	addRunnerLimitFlags(repoUpdateCmd)
+	addFairShareFlags(repoUpdateCmd)
+	repoUpdateCmd.Flags().BoolVar(&backfillJobs, "backfill-queued-jobs", false, "Ask GitHub for queued jobs when the pool manager starts, to pick up jobs that were queued while GARM was down.")
 
 	repoWebhookInstallCmd.Flags().BoolVar(&insecureRepoWebhook, "insecure", false, "Ignore self signed certificate errors.")
 
@@ -366,10 +389,17 @@ func formatOneRepository(repo params.Repository) {
 	t.AppendRow(table.Row{"Name", repo.Name})
 	t.AppendRow(table.Row{"Pool balancer type", repo.GetBalancerType()})
 	t.AppendRow(table.Row{"Credentials", repo.CredentialsName})
+	t.AppendRow(table.Row{"Backfill queued jobs", repo.JobBackfill.Enabled})
+	t.AppendRow(table.Row{"Max concurrent runners", formatRunnerLimit(repo.MaxConcurrentRunners)})
+	t.AppendRow(table.Row{"Max pending runners", formatRunnerLimit(repo.MaxPendingRunners)})
+	formatFairShare(t, repo.FairShare)
 	t.AppendRow(table.Row{"Pool manager running", repo.PoolManagerStatus.IsRunning})
 	if !repo.PoolManagerStatus.IsRunning {
 		t.AppendRow(table.Row{"Failure reason", repo.PoolManagerStatus.FailureReason})
 	}
+	for _, pool := range repo.PoolManagerStatus.DegradedPools {
+		t.AppendRow(table.Row{"Degraded pools", formatDegradedPool(pool)}, rowConfigAutoMerge)
+	}
 
 	if len(repo.Pools) > 0 {
 		for _, pool := range repo.Pools {
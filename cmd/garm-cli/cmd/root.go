@@ -15,9 +15,12 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/go-openapi/runtime"
 	openapiRuntimeClient "github.com/go-openapi/runtime/client"
@@ -39,6 +42,15 @@ var (
 	needsInit         bool
 	debug             bool
 	poolBalancerType  string
+	backfillJobs      bool
+	backfillRepos     string
+	backfillMaxRepos  uint
+	maxConcurrent     uint
+	maxPending        uint
+	fairShare         bool
+	fairShareGroupBy  string
+	fairShareMax      uint
+	fairShareGroups   string
 	errNeedsInitError = fmt.Errorf("please log into a garm installation first")
 )
 
@@ -115,3 +127,115 @@ func formatOneHookInfo(hook params.HookInfo) {
 	})
 	fmt.Println(t.Render())
 }
+
+// jobBackfillFromFlags applies the job backfill flags that were set on the command
+// line on top of the given settings. Returns nil if none of the flags were set.
+func jobBackfillFromFlags(cmd *cobra.Command, current params.JobBackfillSettings) *params.JobBackfillSettings {
+	changed := false
+	if cmd.Flags().Changed("backfill-queued-jobs") {
+		current.Enabled = backfillJobs
+		changed = true
+	}
+	if cmd.Flags().Changed("backfill-repos") {
+		current.Repositories = nil
+		if backfillRepos != "" {
+			current.Repositories = strings.Split(backfillRepos, ",")
+		}
+		changed = true
+	}
+	if cmd.Flags().Changed("backfill-max-repos") {
+		current.MaxRepositories = backfillMaxRepos
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return &current
+}
+
+// uintFromFlag returns a pointer to the value of a uint flag, or nil if the flag
+// was not set on the command line.
+func uintFromFlag(cmd *cobra.Command, name string, val uint) *uint {
+	if !cmd.Flags().Changed(name) {
+		return nil
+	}
+	return &val
+}
+
+// addRunnerLimitFlags adds the flags that set entity wide runner limits to an update command.
+func addRunnerLimitFlags(cmd *cobra.Command) {
+	cmd.Flags().UintVar(&maxConcurrent, "max-concurrent-runners", 0, "The maximum number of runners across all pools. Set to 0 to remove the limit.")
+	cmd.Flags().UintVar(&maxPending, "max-pending-runners", 0, "The maximum number of runners across all pools that may be pending at the same time. Set to 0 to remove the limit.")
+}
+
+// addFairShareFlags adds the flags that control fair share scheduling to an update command.
+func addFairShareFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&fairShare, "fair-share", false, "Interleave runner creation for queued jobs between groups of jobs, so a burst of jobs in one group does not starve the others.")
+	cmd.Flags().StringVar(&fairShareGroupBy, "fair-share-group-by", "", "The key by which queued jobs are grouped (repository, workflow). Defaults to repository.")
+	cmd.Flags().UintVar(&fairShareMax, "fair-share-max-runners-per-group", 0, "The maximum number of jobs of a single group that may be pending or running at the same time. Set to 0 to remove the limit.")
+	cmd.Flags().StringVar(&fairShareGroups, "fair-share-groups", "", `A JSON list of per group settings. Example: [{"name": "org/repo", "priority": 10, "max_runners": 5}]. Set to an empty value to remove them.`)
+}
+
+// fairShareFromFlags applies the fair share flags that were set on the command line on
+// top of the given settings. Returns nil if none of the flags were set.
+func fairShareFromFlags(cmd *cobra.Command, current params.FairShareSettings) (*params.FairShareSettings, error) {
+	changed := false
+	if cmd.Flags().Changed("fair-share") {
+		current.Enabled = fairShare
+		changed = true
+	}
+	if cmd.Flags().Changed("fair-share-group-by") {
+		current.GroupBy = params.FairShareGroupBy(fairShareGroupBy)
+		changed = true
+	}
+	if cmd.Flags().Changed("fair-share-max-runners-per-group") {
+		current.MaxRunnersPerGroup = fairShareMax
+		changed = true
+	}
+	if cmd.Flags().Changed("fair-share-groups") {
+		current.Groups = nil
+		if fairShareGroups != "" {
+			if err := json.Unmarshal([]byte(fairShareGroups), &current.Groups); err != nil {
+				return nil, fmt.Errorf("failed to parse fair share groups: %w", err)
+			}
+		}
+		changed = true
+	}
+	if !changed {
+		return nil, nil
+	}
+	return &current, nil
+}
+
+// fairShareFlagsChanged returns true if any of the fair share flags were set.
+func fairShareFlagsChanged(cmd *cobra.Command) bool {
+	for _, name := range []string{"fair-share", "fair-share-group-by", "fair-share-max-runners-per-group", "fair-share-groups"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatFairShare(t table.Writer, settings params.FairShareSettings) {
+	t.AppendRow(table.Row{"Fair share", settings.Enabled})
+	if !settings.Enabled {
+		return
+	}
+	t.AppendRow(table.Row{"Fair share group by", settings.GetGroupBy()})
+	t.AppendRow(table.Row{"Fair share max runners per group", formatRunnerLimit(settings.MaxRunnersPerGroup)})
+	for _, group := range settings.Groups {
+		t.AppendRow(table.Row{"Fair share groups", fmt.Sprintf("%s (priority %d, max runners %v)", group.Name, group.Priority, formatRunnerLimit(settings.GroupMaxRunners(group.Name)))}, table.RowConfig{AutoMerge: true})
+	}
+}
+
+func formatRunnerLimit(limit uint) interface{} {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return limit
+}
+
+func formatDegradedPool(pool params.DegradedPool) string {
+	return fmt.Sprintf("%s (provider %s, retry at %s): %s", pool.PoolID, pool.ProviderName, pool.RetryAt.Format(time.RFC3339), pool.Reason)
+}
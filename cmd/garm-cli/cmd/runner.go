@@ -36,8 +36,15 @@ var (
 	forceRemove          bool
 	bypassGHUnauthorized bool
 	long                 bool
+
+	runnerFilters            listFilters
+	runnerStatusFilter       string
+	runnerRunnerStatusFilter string
+	runnerPoolFilter         string
 )
 
+var runnerFilterFlags = append([]string{"status", "runner-status", "pool"}, listFilterFlags...)
+
 // runnerCmd represents the runner command
 var runnerCmd = &cobra.Command{
 	Use:          "runner",
@@ -79,6 +86,9 @@ Example:
 	List all runners from all pools belonging to all repos and orgs:
 	garm-cli runner list --all
 
+	List idle runners of one provider, 50 at a time:
+	garm-cli runner list --all --runner-status=idle --provider=lxd --limit=50
+
 `,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -86,7 +96,12 @@ Example:
 			return errNeedsInitError
 		}
 
+		if anyChanged(cmd, runnerFilterFlags...) && !cmd.Flags().Changed("all") {
+			return fmt.Errorf("filters can only be used together with --all")
+		}
+
 		var response instancesPayloadGetter
+		var next string
 		var err error
 
 		switch len(args) {
@@ -116,7 +131,22 @@ Example:
 				response, err = apiCli.Enterprises.ListEnterpriseInstances(listEnterpriseInstancesReq, authToken)
 			} else if cmd.Flags().Changed("all") {
 				listInstancesReq := apiClientInstances.NewListInstancesParams()
-				response, err = apiCli.Instances.ListInstances(listInstancesReq, authToken)
+				listInstancesReq.CreatedAfter, listInstancesReq.CreatedBefore, listInstancesReq.UpdatedAfter, listInstancesReq.UpdatedBefore, err = runnerFilters.timeParams()
+				if err != nil {
+					return err
+				}
+				listInstancesReq.Limit = runnerFilters.limitParam()
+				listInstancesReq.Cursor = optionalString(runnerFilters.cursor)
+				listInstancesReq.Label = optionalString(runnerFilters.label)
+				listInstancesReq.ProviderName = optionalString(runnerFilters.provider)
+				listInstancesReq.Status = optionalString(runnerStatusFilter)
+				listInstancesReq.RunnerStatus = optionalString(runnerRunnerStatusFilter)
+				listInstancesReq.PoolID = optionalString(runnerPoolFilter)
+				var resp *apiClientInstances.ListInstancesOK
+				resp, err = apiCli.Instances.ListInstances(listInstancesReq, authToken)
+				if err == nil {
+					response, next = resp, resp.XNextCursor
+				}
 			} else {
 				cmd.Help() //nolint
 				os.Exit(0)
@@ -132,6 +162,7 @@ Example:
 
 		instances := response.GetPayload()
 		formatInstances(instances, long)
+		printNextCursor(next)
 		return nil
 	},
 }
@@ -207,6 +238,10 @@ func init() {
 	runnerListCmd.Flags().BoolVarP(&runnerAll, "all", "a", false, "List all runners, regardless of org or repo.")
 	runnerListCmd.Flags().BoolVarP(&long, "long", "l", false, "Include information about tasks.")
 	runnerListCmd.MarkFlagsMutuallyExclusive("repo", "org", "enterprise", "all")
+	runnerListCmd.Flags().StringVar(&runnerStatusFilter, "status", "", "Only list runners with this instance status. Used with --all.")
+	runnerListCmd.Flags().StringVar(&runnerRunnerStatusFilter, "runner-status", "", "Only list runners with this runner status. Used with --all.")
+	runnerListCmd.Flags().StringVar(&runnerPoolFilter, "pool", "", "Only list runners of this pool ID. Used with --all.")
+	runnerFilters.addFlags(runnerListCmd)
 
 	runnerDeleteCmd.Flags().BoolVarP(&forceRemove, "force-remove-runner", "f", false, "Forcefully remove a runner. If set to true, GARM will ignore provider errors when removing the runner.")
 	runnerDeleteCmd.Flags().BoolVarP(&bypassGHUnauthorized, "bypass-github-unauthorized", "b", false, "Ignore Unauthorized errors from GitHub and proceed with removing runner from provider and DB. This is useful when credentials are no longer valid and you want to remove your runners. Warning, this has the potential to leave orphaned runners in GitHub. You will need to update your credentials to properly consolidate.")
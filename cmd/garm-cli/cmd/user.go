@@ -0,0 +1,285 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	apiClientUsers "github.com/cloudbase/garm/client/users"
+	"github.com/cloudbase/garm/cmd/garm-cli/common"
+	"github.com/cloudbase/garm/params"
+)
+
+var (
+	userName     string
+	userEmail    string
+	userFullName string
+	userPassword string
+	userRole     string
+	userEnabled  bool
+)
+
+var userCmd = &cobra.Command{
+	Use:          "user",
+	Aliases:      []string{"users"},
+	SilenceUsage: true,
+	Short:        "Manage users",
+	Long: `Manage the users that can access GARM.
+
+Each user has one of the following roles:
+
+  viewer    can view all resources, except for credentials, users,
+            notification sinks and the audit log.
+  operator  can do everything a viewer can, and can also scale pools
+            and delete runners.
+  admin     has full access, including to credentials, endpoints, users
+            and controller settings.
+
+Only admins can manage users.`,
+	Run: nil,
+}
+
+var userListCmd = &cobra.Command{
+	Use:          "list",
+	Aliases:      []string{"ls"},
+	Short:        "List users",
+	Long:         `List all users.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		listReq := apiClientUsers.NewListUsersParams()
+		response, err := apiCli.Users.ListUsers(listReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatUsers(response.Payload)
+		return nil
+	},
+}
+
+var userShowCmd = &cobra.Command{
+	Use:          "show",
+	Aliases:      []string{"get"},
+	Short:        "Show details of a user",
+	Long:         `Show the details of a user, by username or email.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		username, err := parseUsername(args)
+		if err != nil {
+			return err
+		}
+
+		showReq := apiClientUsers.NewGetUserParams().WithUsername(username)
+		response, err := apiCli.Users.GetUser(showReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOneUser(response.Payload)
+		return nil
+	},
+}
+
+var userAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a user",
+	Long: `Add a user. If the password is not set, you will be prompted for it.
+
+Example:
+
+	garm-cli user add --username=jdoe --email=jdoe@example.com --role=operator`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		if len(args) > 0 {
+			return fmt.Errorf("too many arguments")
+		}
+
+		if userPassword == "" {
+			passwd, err := common.PromptPassword("Password", "")
+			if err != nil {
+				return err
+			}
+			if _, err := common.PromptPassword("Confirm password", passwd); err != nil {
+				return err
+			}
+			userPassword = passwd
+		}
+
+		addReq := apiClientUsers.NewCreateUserParams()
+		addReq.Body = params.NewUserParams{
+			Username: userName,
+			Email:    userEmail,
+			FullName: userFullName,
+			Password: userPassword,
+			Role:     params.UserRole(userRole),
+		}
+		response, err := apiCli.Users.CreateUser(addReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOneUser(response.Payload)
+		return nil
+	},
+}
+
+var userUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a user",
+	Long: `Update the full name, password, role or status of a user.
+
+Example:
+
+	Make a user an admin:
+	garm-cli user update jdoe --role=admin
+
+	Disable a user:
+	garm-cli user update jdoe --enabled=false`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		username, err := parseUsername(args)
+		if err != nil {
+			return err
+		}
+
+		var updateParams params.UpdateUserParams
+		if cmd.Flags().Changed("full-name") {
+			updateParams.FullName = userFullName
+		}
+		if cmd.Flags().Changed("password") {
+			if userPassword == "" {
+				return fmt.Errorf("password can not be empty")
+			}
+			updateParams.Password = userPassword
+		}
+		if cmd.Flags().Changed("role") {
+			role := params.UserRole(userRole)
+			updateParams.Role = &role
+		}
+		if cmd.Flags().Changed("enabled") {
+			updateParams.Enabled = &userEnabled
+		}
+
+		updateReq := apiClientUsers.NewUpdateUserParams().WithUsername(username)
+		updateReq.Body = updateParams
+		response, err := apiCli.Users.UpdateUser(updateReq, authToken)
+		if err != nil {
+			return err
+		}
+		formatOneUser(response.Payload)
+		return nil
+	},
+}
+
+var userDeleteCmd = &cobra.Command{
+	Use:          "delete",
+	Aliases:      []string{"remove", "rm"},
+	Short:        "Delete a user",
+	Long:         `Delete a user. Users that own github credentials can not be deleted.`,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if needsInit {
+			return errNeedsInitError
+		}
+
+		username, err := parseUsername(args)
+		if err != nil {
+			return err
+		}
+
+		deleteReq := apiClientUsers.NewDeleteUserParams().WithUsername(username)
+		return apiCli.Users.DeleteUser(deleteReq, authToken)
+	},
+}
+
+func init() {
+	userAddCmd.Flags().StringVar(&userName, "username", "", "The username of the new user.")
+	userAddCmd.Flags().StringVar(&userEmail, "email", "", "The email address of the new user.")
+	userAddCmd.MarkFlagRequired("username")
+	userAddCmd.MarkFlagRequired("email")
+	for _, cmd := range []*cobra.Command{userAddCmd, userUpdateCmd} {
+		cmd.Flags().StringVar(&userFullName, "full-name", "", "The full name of the user.")
+		cmd.Flags().StringVar(&userPassword, "password", "", "The password of the user.")
+		cmd.Flags().StringVar(&userRole, "role", string(params.UserRoleViewer), "The role of the user (viewer, operator or admin).")
+	}
+	userUpdateCmd.Flags().BoolVar(&userEnabled, "enabled", true, "Allow the user to log in.")
+
+	userCmd.AddCommand(
+		userListCmd,
+		userShowCmd,
+		userAddCmd,
+		userUpdateCmd,
+		userDeleteCmd,
+	)
+	rootCmd.AddCommand(userCmd)
+}
+
+func parseUsername(args []string) (string, error) {
+	if len(args) < 1 {
+		return "", fmt.Errorf("missing required argument: username")
+	}
+	if len(args) > 1 {
+		return "", fmt.Errorf("too many arguments")
+	}
+	return args[0], nil
+}
+
+func formatUsers(users []params.User) {
+	t := table.NewWriter()
+	header := table.Row{"ID", "Username", "Email", "Full Name", "Role", "Enabled"}
+	t.AppendHeader(header)
+	for _, val := range users {
+		t.AppendRow(table.Row{val.ID, val.Username, val.Email, val.FullName, val.Role, val.Enabled})
+		t.AppendSeparator()
+	}
+	fmt.Println(t.Render())
+}
+
+func formatOneUser(user params.User) {
+	t := table.NewWriter()
+	header := table.Row{"Field", "Value"}
+	t.AppendHeader(header)
+
+	t.AppendRow(table.Row{"ID", user.ID})
+	t.AppendRow(table.Row{"Username", user.Username})
+	t.AppendRow(table.Row{"Email", user.Email})
+	t.AppendRow(table.Row{"Full Name", user.FullName})
+	t.AppendRow(table.Row{"Role", user.Role})
+	t.AppendRow(table.Row{"Enabled", user.Enabled})
+	t.AppendRow(table.Row{"Created At", user.CreatedAt})
+	t.AppendRow(table.Row{"Updated At", user.UpdatedAt})
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, AutoMerge: true},
+		{Number: 2, AutoMerge: false, WidthMax: 100},
+	})
+	fmt.Println(t.Render())
+}
@@ -144,7 +144,7 @@ func setupLogging(ctx context.Context, logCfg config.Logging, hub *websocket.Hub
 	slog.SetDefault(slog.New(wrapped))
 }
 
-func maybeUpdateURLsFromConfig(cfg config.Config, store common.Store) error {
+func maybeUpdateURLsFromConfig(ctx context.Context, cfg config.Config, store common.Store) error {
 	info, err := store.ControllerInfo()
 	if err != nil {
 		return errors.Wrap(err, "fetching controller info")
@@ -169,7 +169,7 @@ func maybeUpdateURLsFromConfig(cfg config.Config, store common.Store) error {
 		return nil
 	}
 
-	_, err = store.UpdateController(updateParams)
+	_, err = store.UpdateController(ctx, updateParams)
 	if err != nil {
 		return errors.Wrap(err, "updating controller info")
 	}
@@ -193,6 +193,37 @@ func main() {
 		log.Fatalf("Fetching config: %+v", err) //nolint:gocritic
 	}
 
+	// Migrate credentials to the new format. This field will be read
+	// by the DB migration logic, which also runs from the subcommands
+	// below when they open a database created by an older release.
+	cfg.Database.MigrateCredentials = cfg.Github
+
+	switch flag.Arg(0) {
+	case "export":
+		if err := runExport(ctx, cfg, flag.Args()[1:]); err != nil {
+			log.Fatalf("export failed: %+v", err)
+		}
+		return
+	case "import":
+		if err := runImport(ctx, cfg, flag.Args()[1:]); err != nil {
+			log.Fatalf("import failed: %+v", err)
+		}
+		return
+	case "migrate":
+		if err := runMigrate(ctx, cfg, flag.Args()[1:]); err != nil {
+			log.Fatalf("migration failed: %+v", err)
+		}
+		return
+	case "rotate-passphrase":
+		if err := runRotatePassphrase(ctx, cfg, flag.Args()[1:]); err != nil {
+			log.Fatalf("passphrase rotation failed: %+v", err)
+		}
+		return
+	case "":
+	default:
+		log.Fatalf("unknown command %q", flag.Arg(0))
+	}
+
 	logCfg := cfg.GetLoggingConfig()
 	var hub *websocket.Hub
 	if logCfg.EnableLogStreamer != nil && *logCfg.EnableLogStreamer {
@@ -204,9 +235,6 @@ func main() {
 	}
 	setupLogging(ctx, logCfg, hub)
 
-	// Migrate credentials to the new format. This field will be read
-	// by the DB migration logic.
-	cfg.Database.MigrateCredentials = cfg.Github
 	db, err := database.NewDatabase(ctx, cfg.Database)
 	if err != nil {
 		log.Fatal(err)
@@ -216,7 +244,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	if err := maybeUpdateURLsFromConfig(*cfg, db); err != nil {
+	if err := maybeUpdateURLsFromConfig(ctx, *cfg, db); err != nil {
 		log.Fatal(err)
 	}
 
@@ -0,0 +1,120 @@
+// Copyright 2022 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/database"
+	"github.com/cloudbase/garm/database/common"
+)
+
+const migrateUsage = "usage: garm migrate status|up|down [-to VERSION]"
+
+// runMigrate shows or changes the schema version of the database.
+func runMigrate(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(migrateUsage)
+	}
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ExitOnError)
+	target := flags.Uint("to", 0, "schema version to migrate to. Up defaults to the latest version, down to the previous one")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	migrator, err := database.NewSchemaMigrator(ctx, cfg.Database)
+	if err != nil {
+		return errors.Wrap(err, "opening database")
+	}
+
+	switch args[0] {
+	case "status":
+		return printMigrationStatus(ctx, migrator)
+	case "up":
+		if err := migrator.MigrateUp(ctx, *target); err != nil {
+			return errors.Wrap(err, "applying migrations")
+		}
+	case "down":
+		if !isFlagSet(flags, "to") {
+			current, err := currentSchemaVersion(ctx, migrator)
+			if err != nil {
+				return err
+			}
+			if current == 0 {
+				return fmt.Errorf("no migration was applied")
+			}
+			*target = current - 1
+		}
+		if err := migrator.MigrateDown(ctx, *target); err != nil {
+			return errors.Wrap(err, "reverting migrations")
+		}
+	default:
+		return fmt.Errorf(migrateUsage)
+	}
+	return printMigrationStatus(ctx, migrator)
+}
+
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	found := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			found = true
+		}
+	})
+	return found
+}
+
+func currentSchemaVersion(ctx context.Context, migrator common.SchemaMigrator) (uint, error) {
+	status, err := migrator.MigrationStatus(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "fetching migration status")
+	}
+	var current uint
+	for _, m := range status {
+		if m.AppliedAt != nil && m.Version > current {
+			current = m.Version
+		}
+	}
+	return current, nil
+}
+
+func printMigrationStatus(ctx context.Context, migrator common.SchemaMigrator) error {
+	status, err := migrator.MigrationStatus(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetching migration status")
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tDESCRIPTION\tAPPLIED AT")
+	for _, m := range status {
+		appliedAt := "pending"
+		if m.AppliedAt != nil {
+			appliedAt = m.AppliedAt.Format(time.RFC3339)
+		}
+		description := m.Description
+		if m.Unknown {
+			description += " (unknown to this version of GARM)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", m.Version, description, appliedAt)
+	}
+	return w.Flush()
+}
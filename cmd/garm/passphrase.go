@@ -0,0 +1,56 @@
+// Copyright 2022 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/database"
+)
+
+// runRotatePassphrase re-encrypts all secrets in the database with the passphrase
+// set in the config, after the passphrase was changed.
+func runRotatePassphrase(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("rotate-passphrase", flag.ExitOnError)
+	oldPassphraseFile := flags.String("old-passphrase-file", "", "file holding the passphrase the secrets are currently encrypted with")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *oldPassphraseFile == "" {
+		return fmt.Errorf("missing -old-passphrase-file")
+	}
+	oldPassphrase, err := readPassphraseFile(*oldPassphraseFile)
+	if err != nil {
+		return err
+	}
+	if len(oldPassphrase) != 32 {
+		return fmt.Errorf("old passphrase must be a string of 32 characters")
+	}
+
+	db, err := database.NewDatabase(ctx, cfg.Database)
+	if err != nil {
+		return errors.Wrap(err, "opening database")
+	}
+	if err := db.RotatePassphrase(ctx, oldPassphrase); err != nil {
+		return errors.Wrap(err, "rotating passphrase")
+	}
+	fmt.Println("All secrets were re-encrypted with the new passphrase")
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/database"
+	"github.com/cloudbase/garm/database/common"
+)
+
+func readPassphraseFile(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "reading passphrase file")
+	}
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase file %s is empty", path)
+	}
+	return passphrase, nil
+}
+
+// runExport writes the state of the controller configured in cfg to an archive.
+func runExport(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	output := flags.String("output", "", "file the archive is written to")
+	passphraseFile := flags.String("passphrase-file", "", "file holding the passphrase used to encrypt the archive")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *output == "" {
+		return fmt.Errorf("missing -output")
+	}
+	passphrase, err := readPassphraseFile(*passphraseFile)
+	if err != nil {
+		return err
+	}
+
+	db, err := database.NewDatabase(ctx, cfg.Database)
+	if err != nil {
+		return errors.Wrap(err, "opening database")
+	}
+	state, err := db.ExportState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "exporting state")
+	}
+	data, err := common.MarshalStateArchive(state, passphrase)
+	if err != nil {
+		return errors.Wrap(err, "creating archive")
+	}
+	if err := os.WriteFile(*output, data, 0o600); err != nil {
+		return errors.Wrap(err, "writing archive")
+	}
+	fmt.Printf("Exported %d repositories, %d organizations, %d enterprises and %d pools to %s\n",
+		len(state.Repositories), len(state.Organizations), len(state.Enterprises), len(state.Pools), *output)
+	return nil
+}
+
+// runImport loads an archive into the empty database configured in cfg.
+func runImport(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	input := flags.String("input", "", "archive to import")
+	passphraseFile := flags.String("passphrase-file", "", "file holding the passphrase used to encrypt the archive")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *input == "" {
+		return fmt.Errorf("missing -input")
+	}
+	passphrase, err := readPassphraseFile(*passphraseFile)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(*input)
+	if err != nil {
+		return errors.Wrap(err, "reading archive")
+	}
+	state, err := common.UnmarshalStateArchive(data, passphrase)
+	if err != nil {
+		return errors.Wrap(err, "reading archive")
+	}
+
+	db, err := database.NewDatabase(ctx, cfg.Database)
+	if err != nil {
+		return errors.Wrap(err, "opening database")
+	}
+	if err := db.ImportState(ctx, state); err != nil {
+		return errors.Wrap(err, "importing state")
+	}
+	fmt.Printf("Imported %d repositories, %d organizations, %d enterprises and %d pools from %s\n",
+		len(state.Repositories), len(state.Organizations), len(state.Enterprises), len(state.Pools), *input)
+	return nil
+}
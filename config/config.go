@@ -50,6 +50,8 @@ const (
 	MySQLBackend DBBackendType = "mysql"
 	// SQLiteBackend represents the SQLite3 DB backend
 	SQLiteBackend DBBackendType = "sqlite3"
+	// PostgresBackend represents the PostgreSQL DB backend
+	PostgresBackend DBBackendType = "postgres"
 	// EnvironmentVariablePrefix is the prefix for all environment variables
 	// that can not be used to get overwritten via the external provider
 	EnvironmentVariablePrefix = "GARM"
@@ -101,6 +103,12 @@ type Config struct {
 	Github    []Github   `toml:"github,omitempty"`
 	JWTAuth   JWTAuth    `toml:"jwt_auth" json:"jwt-auth"`
 	Logging   Logging    `toml:"logging" json:"logging"`
+	// HighAvailability holds the settings needed to run multiple GARM
+	// controllers against the same database.
+	HighAvailability HighAvailability `toml:"high_availability,omitempty" json:"high-availability,omitempty"`
+	// Notifications holds the settings for outbound notifications about
+	// pool and runner problems.
+	Notifications Notifications `toml:"notifications,omitempty" json:"notifications,omitempty"`
 }
 
 // Validate validates the config
@@ -130,6 +138,18 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("error validating logging config: %w", err)
 	}
 
+	if err := c.HighAvailability.Validate(); err != nil {
+		return fmt.Errorf("error validating high_availability config: %w", err)
+	}
+
+	if err := c.Notifications.Validate(); err != nil {
+		return fmt.Errorf("error validating notifications config: %w", err)
+	}
+
+	if c.HighAvailability.Enable && c.Database.DbBackend == SQLiteBackend {
+		return fmt.Errorf("high availability requires a database that can be shared between controllers; %s is not supported", SQLiteBackend)
+	}
+
 	providerNames := map[string]int{}
 
 	for _, provider := range c.Providers {
@@ -459,6 +479,13 @@ type Provider struct {
 	// JIT configuration.
 	DisableJITConfig bool     `toml:"disable_jit_config" json:"disable-jit-config"`
 	External         External `toml:"external" json:"external"`
+	// MaxConcurrentCreates is the maximum number of instances this provider may be creating
+	// at the same time, across all pools. Instances above this limit stay pending until
+	// another instance finishes being created. A value of 0 means no limit.
+	MaxConcurrentCreates uint `toml:"max_concurrent_creates" json:"max-concurrent-creates"`
+	// CreatesPerMinute is the maximum number of instance creations this provider may start
+	// in one minute, across all pools. A value of 0 means no limit.
+	CreatesPerMinute uint `toml:"creates_per_minute" json:"creates-per-minute"`
 }
 
 func (p *Provider) Validate() error {
@@ -483,6 +510,7 @@ type Database struct {
 	DbBackend DBBackendType `toml:"backend" json:"backend"`
 	MySQL     MySQL         `toml:"mysql" json:"mysql"`
 	SQLite    SQLite        `toml:"sqlite3" json:"sqlite3"`
+	Postgres  Postgres      `toml:"postgres" json:"postgres"`
 	// Passphrase is used to encrypt any sensitive info before
 	// inserting it into the database. This is just temporary until
 	// we move to something like vault or barbican for secrets storage.
@@ -490,6 +518,14 @@ type Database struct {
 	// in the DB. This field must be set and must be exactly 32 characters.
 	Passphrase string `toml:"passphrase"`
 
+	// JobHistoryRetention is the amount of time job history entries are kept
+	// after they were last updated. Defaults to 30 days.
+	JobHistoryRetention time.Duration `toml:"job_history_retention" json:"job-history-retention"`
+
+	// AuditLogRetention is the amount of time audit log entries are kept after
+	// they were recorded. Defaults to 90 days.
+	AuditLogRetention time.Duration `toml:"audit_log_retention" json:"audit-log-retention"`
+
 	// MigrateCredentials is a list of github credentials that need to be migrated
 	// from the config file to the database. This field will be removed once GARM
 	// reaches version 0.2.x. It's only meant to be used for the migration process.
@@ -513,12 +549,33 @@ func (d *Database) GormParams() (dbType DBBackendType, uri string, err error) {
 		if err != nil {
 			return "", "", errors.Wrap(err, "fetching sqlite3 connection string")
 		}
+	case PostgresBackend:
+		uri, err = d.Postgres.ConnectionString()
+		if err != nil {
+			return "", "", errors.Wrap(err, "fetching postgres connection string")
+		}
 	default:
 		return "", "", fmt.Errorf("invalid database backend: %s", dbType)
 	}
 	return
 }
 
+// GetJobHistoryRetention returns the configured job history retention or the default value.
+func (d *Database) GetJobHistoryRetention() time.Duration {
+	if d.JobHistoryRetention == 0 {
+		return appdefaults.DefaultJobHistoryRetention
+	}
+	return d.JobHistoryRetention
+}
+
+// GetAuditLogRetention returns the configured audit log retention or the default value.
+func (d *Database) GetAuditLogRetention() time.Duration {
+	if d.AuditLogRetention == 0 {
+		return appdefaults.DefaultAuditLogRetention
+	}
+	return d.AuditLogRetention
+}
+
 // Validate validates the database config entry
 func (d *Database) Validate() error {
 	if d.DbBackend == "" {
@@ -534,6 +591,14 @@ func (d *Database) Validate() error {
 		return fmt.Errorf("database passphrase is too weak")
 	}
 
+	if d.JobHistoryRetention < 0 {
+		return fmt.Errorf("job_history_retention must be positive")
+	}
+
+	if d.AuditLogRetention < 0 {
+		return fmt.Errorf("audit_log_retention must be positive")
+	}
+
 	switch d.DbBackend {
 	case MySQLBackend:
 		if err := d.MySQL.Validate(); err != nil {
@@ -543,6 +608,10 @@ func (d *Database) Validate() error {
 		if err := d.SQLite.Validate(); err != nil {
 			return fmt.Errorf("validating sqlite3 config: %w", err)
 		}
+	case PostgresBackend:
+		if err := d.Postgres.Validate(); err != nil {
+			return fmt.Errorf("validating postgres config: %w", err)
+		}
 	default:
 		return fmt.Errorf("invalid database backend: %s", d.DbBackend)
 	}
@@ -605,6 +674,51 @@ func (m *MySQL) ConnectionString() (string, error) {
 	return connString, nil
 }
 
+// Postgres is the config entry for the postgres section
+type Postgres struct {
+	Username string `toml:"username" json:"username"`
+	Password string `toml:"password" json:"password"`
+	// Hostname is the host name or IP address of the server, optionally followed
+	// by a port. The default port is 5432.
+	Hostname     string `toml:"hostname" json:"hostname"`
+	DatabaseName string `toml:"database" json:"database"`
+	// SSLMode is the libpq sslmode used to connect to the server (disable, require,
+	// verify-ca, verify-full, etc). Defaults to "require".
+	SSLMode string `toml:"sslmode" json:"sslmode"`
+}
+
+// Validate validates a Postgres config entry
+func (p *Postgres) Validate() error {
+	if p.Username == "" || p.Password == "" || p.Hostname == "" || p.DatabaseName == "" {
+		return fmt.Errorf(
+			"database, username, password, hostname are mandatory parameters for the database section")
+	}
+	return nil
+}
+
+// ConnectionString returns a gorm compatible connection string
+func (p *Postgres) ConnectionString() (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+
+	sslMode := p.SSLMode
+	if sslMode == "" {
+		sslMode = "require"
+	}
+	connURL := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(p.Username, p.Password),
+		Host:   p.Hostname,
+		Path:   p.DatabaseName,
+		RawQuery: url.Values{
+			"sslmode":         []string{sslMode},
+			"connect_timeout": []string{"5"},
+		}.Encode(),
+	}
+	return connURL.String(), nil
+}
+
 // TLSConfig is the API server TLS config
 type TLSConfig struct {
 	CRT string `toml:"certificate" json:"certificate"`
@@ -660,6 +774,163 @@ func (m *Metrics) Duration() time.Duration {
 	return duration
 }
 
+// HighAvailability holds settings for running multiple GARM controllers in an
+// active/passive setup. All controllers must use the same database. They elect a
+// leader using a lease that is stored in the database. Only the leader reconciles
+// pools, while all controllers serve the API and accept webhooks.
+type HighAvailability struct {
+	// Enable turns on leader election.
+	Enable bool `toml:"enable" json:"enable"`
+	// NodeID uniquely identifies this controller among the other controllers
+	// that use the same database. Defaults to the hostname.
+	NodeID string `toml:"node_id" json:"node-id"`
+	// LeaseDuration is the amount of time the leader holds the lease after its
+	// last heartbeat. If the leader fails to renew the lease within this interval,
+	// another controller takes over.
+	LeaseDuration time.Duration `toml:"lease_duration" json:"lease-duration"`
+	// HeartbeatInterval is the interval at which the leader renews the lease and
+	// at which the other controllers attempt to acquire it.
+	HeartbeatInterval time.Duration `toml:"heartbeat_interval" json:"heartbeat-interval"`
+}
+
+// GetNodeID returns the configured node ID or the hostname if none is set.
+func (h *HighAvailability) GetNodeID() (string, error) {
+	if h.NodeID != "" {
+		return h.NodeID, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("fetching hostname: %w", err)
+	}
+	return hostname, nil
+}
+
+// GetLeaseDuration returns the configured lease duration or the default value.
+func (h *HighAvailability) GetLeaseDuration() time.Duration {
+	if h.LeaseDuration == 0 {
+		return appdefaults.DefaultHALeaseDuration
+	}
+	return h.LeaseDuration
+}
+
+// GetHeartbeatInterval returns the configured heartbeat interval or the default value.
+func (h *HighAvailability) GetHeartbeatInterval() time.Duration {
+	if h.HeartbeatInterval == 0 {
+		return appdefaults.DefaultHAHeartbeatInterval
+	}
+	return h.HeartbeatInterval
+}
+
+// Validate validates the high availability config
+func (h *HighAvailability) Validate() error {
+	if !h.Enable {
+		return nil
+	}
+
+	if len(h.NodeID) > 64 {
+		return fmt.Errorf("node_id must be at most 64 characters long")
+	}
+
+	if h.LeaseDuration < 0 || h.HeartbeatInterval < 0 {
+		return fmt.Errorf("lease_duration and heartbeat_interval must be positive")
+	}
+
+	// The leader needs to be able to miss at least one heartbeat before losing the lease.
+	if h.GetHeartbeatInterval()*2 > h.GetLeaseDuration() {
+		return fmt.Errorf("lease_duration (%s) must be at least twice the heartbeat_interval (%s)", h.GetLeaseDuration(), h.GetHeartbeatInterval())
+	}
+	return nil
+}
+
+// Notifications holds settings for sending notifications to external systems
+// when pools or runners need attention. Sinks defined here are read only. More
+// sinks can be added through the API.
+type Notifications struct {
+	// DedupWindow is the amount of time during which a notification about the same
+	// problem, for the same pool or entity, is not sent again to a sink.
+	DedupWindow time.Duration `toml:"dedup_window" json:"dedup-window"`
+	// MaxAttempts is the number of times GARM attempts to deliver a notification
+	// before giving up.
+	MaxAttempts int `toml:"max_attempts" json:"max-attempts"`
+	// Sinks is a list of webhooks that receive notifications.
+	Sinks []NotificationSink `toml:"sink,omitempty" json:"sink,omitempty"`
+}
+
+// GetDedupWindow returns the configured dedup window or the default value.
+func (n *Notifications) GetDedupWindow() time.Duration {
+	if n.DedupWindow == 0 {
+		return appdefaults.DefaultNotificationDedupWindow
+	}
+	return n.DedupWindow
+}
+
+// GetMaxAttempts returns the configured number of attempts or the default value.
+func (n *Notifications) GetMaxAttempts() int {
+	if n.MaxAttempts == 0 {
+		return appdefaults.DefaultNotificationMaxAttempts
+	}
+	return n.MaxAttempts
+}
+
+// Validate validates the notifications config
+func (n *Notifications) Validate() error {
+	if n.DedupWindow < 0 {
+		return fmt.Errorf("dedup_window must be positive")
+	}
+	if n.MaxAttempts < 0 {
+		return fmt.Errorf("max_attempts must be positive")
+	}
+
+	names := map[string]bool{}
+	for _, sink := range n.Sinks {
+		if err := sink.Validate(); err != nil {
+			return fmt.Errorf("error validating sink %s: %w", sink.Name, err)
+		}
+		if names[sink.Name] {
+			return fmt.Errorf("duplicate sink name %s", sink.Name)
+		}
+		names[sink.Name] = true
+	}
+	return nil
+}
+
+// NotificationSink is a webhook that receives notifications.
+type NotificationSink struct {
+	// Name uniquely identifies the sink.
+	Name string `toml:"name" json:"name"`
+	// URL is the address notifications are posted to.
+	URL string `toml:"url" json:"url"`
+	// Format is the format of the notification body. One of json, slack
+	// or teams. Defaults to json.
+	Format params.NotificationFormat `toml:"format" json:"format"`
+	// Headers are additional HTTP headers sent with each notification.
+	Headers map[string]string `toml:"headers" json:"headers"`
+	// Disabled stops notifications from being sent to this sink.
+	Disabled bool `toml:"disabled" json:"disabled"`
+	// Rules select the events sent to this sink. If no rules are set,
+	// all events are sent.
+	Rules []params.NotificationRule `toml:"rule" json:"rule"`
+}
+
+// Validate validates the notification sink config
+func (n *NotificationSink) Validate() error {
+	return n.Params().Validate()
+}
+
+// Params returns the sink as creation parameters, which share validation with
+// sinks created through the API.
+func (n *NotificationSink) Params() params.CreateNotificationSinkParams {
+	enabled := !n.Disabled
+	return params.CreateNotificationSinkParams{
+		Name:    n.Name,
+		URL:     n.URL,
+		Format:  n.Format,
+		Headers: n.Headers,
+		Rules:   n.Rules,
+		Enabled: &enabled,
+	}
+}
+
 // APIServer holds configuration for the API server
 // worker
 type APIServer struct {
@@ -0,0 +1,127 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/params"
+)
+
+// Redacted replaces the value of secrets in audit entries.
+const Redacted = "<redacted>"
+
+// sensitiveKeys are matched against the lower case names of all fields,
+// including nested ones. Matching fields have their value redacted.
+var sensitiveKeys = []string{"secret", "password", "token", "private_key", "passphrase", "jit"}
+
+// ignoredFields change on every update and carry no information.
+var ignoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if isSensitive(key) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redact(val)
+		}
+		return v
+	case []interface{}:
+		for idx, val := range v {
+			v[idx] = redact(val)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func toFields(value interface{}) (map[string]interface{}, error) {
+	if value == nil {
+		return map[string]interface{}{}, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling value")
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling value")
+	}
+	return redact(fields).(map[string]interface{}), nil
+}
+
+// Diff returns the top level fields that differ between the JSON representations
+// of before and after, sorted by name. Either of them may be nil, in which case
+// all fields of the other one are returned. Secrets are redacted.
+func Diff(before, after interface{}) ([]params.AuditChange, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]bool{}
+	for key := range beforeFields {
+		keys[key] = true
+	}
+	for key := range afterFields {
+		keys[key] = true
+	}
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+		if !ignoredFields[key] {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []params.AuditChange
+	for _, name := range names {
+		oldVal, newVal := beforeFields[name], afterFields[name]
+		if reflect.DeepEqual(oldVal, newVal) {
+			continue
+		}
+		changes = append(changes, params.AuditChange{
+			Field:  name,
+			Before: oldVal,
+			After:  newVal,
+		})
+	}
+	return changes, nil
+}
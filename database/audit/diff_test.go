@@ -0,0 +1,85 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudbase/garm/params"
+)
+
+type diffTestEntity struct {
+	Name      string            `json:"name"`
+	Size      int               `json:"size"`
+	Token     string            `json:"token,omitempty"`
+	Nested    map[string]string `json:"nested,omitempty"`
+	UpdatedAt string            `json:"updated_at"`
+}
+
+func TestDiff(t *testing.T) {
+	before := diffTestEntity{Name: "test", Size: 1, UpdatedAt: "yesterday"}
+	after := diffTestEntity{Name: "test", Size: 2, UpdatedAt: "today"}
+
+	changes, err := Diff(before, after)
+
+	require.Nil(t, err)
+	require.Equal(t, []params.AuditChange{{Field: "size", Before: float64(1), After: float64(2)}}, changes)
+}
+
+func TestDiffCreateAndDelete(t *testing.T) {
+	entity := diffTestEntity{Name: "test", Size: 1}
+
+	created, err := Diff(nil, entity)
+	require.Nil(t, err)
+	require.Equal(t, []params.AuditChange{
+		{Field: "name", After: "test"},
+		{Field: "size", After: float64(1)},
+	}, created)
+
+	deleted, err := Diff(entity, nil)
+	require.Nil(t, err)
+	require.Equal(t, []params.AuditChange{
+		{Field: "name", Before: "test"},
+		{Field: "size", Before: float64(1)},
+	}, deleted)
+}
+
+func TestDiffRedactsSecrets(t *testing.T) {
+	before := diffTestEntity{Name: "test", Token: "old-token", Nested: map[string]string{"client_secret": "old", "region": "a"}}
+	after := diffTestEntity{Name: "test", Token: "new-token", Nested: map[string]string{"client_secret": "new", "region": "b"}}
+
+	changes, err := Diff(before, after)
+
+	require.Nil(t, err)
+	// Both tokens are redacted to the same value, so the change is not listed.
+	require.Equal(t, []params.AuditChange{
+		{
+			Field:  "nested",
+			Before: map[string]interface{}{"client_secret": Redacted, "region": "a"},
+			After:  map[string]interface{}{"client_secret": Redacted, "region": "b"},
+		},
+	}, changes)
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	entity := diffTestEntity{Name: "test", Size: 1}
+
+	changes, err := Diff(entity, entity)
+
+	require.Nil(t, err)
+	require.Empty(t, changes)
+}
@@ -0,0 +1,507 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+// Package audit records the changes made to GARM in the audit log.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+// NewStore returns a store that records an audit entry for every change made
+// through it. All other calls are passed through to the wrapped store.
+func NewStore(store common.Store) common.Store {
+	return &auditStore{
+		Store: store,
+	}
+}
+
+type auditStore struct {
+	common.Store
+}
+
+// auditRecord describes an audited change.
+type auditRecord struct {
+	action     params.AuditAction
+	entityType common.DatabaseEntityType
+	entityID   string
+	entityName string
+	before     interface{}
+	after      interface{}
+	// secrets are fields that are not part of the entity representation,
+	// but were set by the operation. They are recorded as redacted changes.
+	secrets []string
+}
+
+// actor returns who made a change that was not made by a user. Runners report their
+// status using instance contexts. All other changes are made by GARM itself.
+func actor(ctx context.Context) string {
+	if auth.UserID(ctx) != "" {
+		return ""
+	}
+	if name := auth.InstanceName(ctx); name != "" {
+		return params.AuditActorInstancePrefix + name
+	}
+	return params.AuditActorController
+}
+
+func (s *auditStore) record(ctx context.Context, rec auditRecord) {
+	changes, err := Diff(rec.before, rec.after)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to compute audit changes", "entity_type", rec.entityType, "entity_id", rec.entityID)
+	}
+	for _, secret := range rec.secrets {
+		changes = append(changes, params.AuditChange{
+			Field:  secret,
+			Before: Redacted,
+			After:  Redacted,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+
+	entry := params.AuditEntry{
+		UserID:     auth.UserID(ctx),
+		Actor:      actor(ctx),
+		Action:     rec.action,
+		EntityType: string(rec.entityType),
+		EntityID:   rec.entityID,
+		EntityName: rec.entityName,
+		Changes:    changes,
+		SourceIP:   auth.SourceIP(ctx),
+	}
+	if _, err := s.Store.RecordAuditEntry(ctx, entry); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to record audit entry", "entity_type", rec.entityType, "entity_id", rec.entityID)
+	}
+}
+
+// optional returns a pointer to value if err is nil. It is used for the state
+// of an entity before a change. If it can not be fetched, the change is
+// recorded without it, and the wrapped store returns the error.
+func optional[T any](value T, err error) *T {
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// afterValue returns the state after a change, for use in auditRecord. Some
+// update calls return the entity with more details loaded than the getter we
+// use for the state before the change. To avoid recording those differences,
+// the entity is fetched again with the same getter when possible.
+func afterValue[T any](fetched *T, returned T) interface{} {
+	if fetched == nil {
+		return returned
+	}
+	return *fetched
+}
+
+// beforeValue returns the state before a change, for use in auditRecord.
+func beforeValue[T any](value *T) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func secretIf(set bool, name string) []string {
+	if set {
+		return []string{name}
+	}
+	return nil
+}
+
+func (s *auditStore) CreateGithubEndpoint(ctx context.Context, param params.CreateGithubEndpointParams) (params.GithubEndpoint, error) {
+	endpoint, err := s.Store.CreateGithubEndpoint(ctx, param)
+	if err == nil {
+		s.record(ctx, auditRecord{action: params.AuditActionCreate, entityType: common.GithubEndpointEntityType, entityID: endpoint.Name, entityName: endpoint.Name, after: endpoint})
+	}
+	return endpoint, err
+}
+
+func (s *auditStore) UpdateGithubEndpoint(ctx context.Context, name string, param params.UpdateGithubEndpointParams) (params.GithubEndpoint, error) {
+	before := optional(s.Store.GetGithubEndpoint(ctx, name))
+	endpoint, err := s.Store.UpdateGithubEndpoint(ctx, name, param)
+	if err == nil {
+		s.record(ctx, auditRecord{action: params.AuditActionUpdate, entityType: common.GithubEndpointEntityType, entityID: name, entityName: name, before: beforeValue(before), after: endpoint})
+	}
+	return endpoint, err
+}
+
+func (s *auditStore) DeleteGithubEndpoint(ctx context.Context, name string) error {
+	before := optional(s.Store.GetGithubEndpoint(ctx, name))
+	err := s.Store.DeleteGithubEndpoint(ctx, name)
+	if err == nil {
+		s.record(ctx, auditRecord{action: params.AuditActionDelete, entityType: common.GithubEndpointEntityType, entityID: name, entityName: name, before: beforeValue(before)})
+	}
+	return err
+}
+
+func (s *auditStore) CreateGithubCredentials(ctx context.Context, param params.CreateGithubCredentialsParams) (params.GithubCredentials, error) {
+	creds, err := s.Store.CreateGithubCredentials(ctx, param)
+	if err == nil {
+		s.record(ctx, auditRecord{
+			action: params.AuditActionCreate, entityType: common.GithubCredentialsEntityType,
+			entityID: fmt.Sprintf("%d", creds.ID), entityName: creds.Name,
+			after: creds, secrets: []string{"credentials"},
+		})
+	}
+	return creds, err
+}
+
+func (s *auditStore) UpdateGithubCredentials(ctx context.Context, id uint, param params.UpdateGithubCredentialsParams) (params.GithubCredentials, error) {
+	before := optional(s.Store.GetGithubCredentials(ctx, id, false))
+	creds, err := s.Store.UpdateGithubCredentials(ctx, id, param)
+	if err == nil {
+		after := optional(s.Store.GetGithubCredentials(ctx, id, false))
+		s.record(ctx, auditRecord{
+			action: params.AuditActionUpdate, entityType: common.GithubCredentialsEntityType,
+			entityID: fmt.Sprintf("%d", id), entityName: creds.Name,
+			before: beforeValue(before), after: afterValue(after, creds),
+			secrets: secretIf(param.PAT != nil || param.App != nil, "credentials"),
+		})
+	}
+	return creds, err
+}
+
+func (s *auditStore) DeleteGithubCredentials(ctx context.Context, id uint) error {
+	before := optional(s.Store.GetGithubCredentials(ctx, id, false))
+	err := s.Store.DeleteGithubCredentials(ctx, id)
+	if err == nil {
+		var name string
+		if before != nil {
+			name = before.Name
+		}
+		s.record(ctx, auditRecord{
+			action: params.AuditActionDelete, entityType: common.GithubCredentialsEntityType,
+			entityID: fmt.Sprintf("%d", id), entityName: name, before: beforeValue(before),
+		})
+	}
+	return err
+}
+
+func (s *auditStore) CreateRepository(ctx context.Context, owner, name, credentialsName, webhookSecret string, poolBalancerType params.PoolBalancerType) (params.Repository, error) {
+	repo, err := s.Store.CreateRepository(ctx, owner, name, credentialsName, webhookSecret, poolBalancerType)
+	if err == nil {
+		s.record(ctx, auditRecord{
+			action: params.AuditActionCreate, entityType: common.RepositoryEntityType,
+			entityID: repo.ID, entityName: repo.String(), after: repo,
+			secrets: secretIf(webhookSecret != "", "webhook_secret"),
+		})
+	}
+	return repo, err
+}
+
+func (s *auditStore) UpdateRepository(ctx context.Context, repoID string, param params.UpdateEntityParams) (params.Repository, error) {
+	before := optional(s.Store.GetRepositoryByID(ctx, repoID))
+	repo, err := s.Store.UpdateRepository(ctx, repoID, param)
+	if err == nil {
+		after := optional(s.Store.GetRepositoryByID(ctx, repoID))
+		s.record(ctx, auditRecord{
+			action: params.AuditActionUpdate, entityType: common.RepositoryEntityType,
+			entityID: repoID, entityName: repo.String(), before: beforeValue(before), after: afterValue(after, repo),
+			secrets: secretIf(param.WebhookSecret != "", "webhook_secret"),
+		})
+	}
+	return repo, err
+}
+
+func (s *auditStore) DeleteRepository(ctx context.Context, repoID string) error {
+	before := optional(s.Store.GetRepositoryByID(ctx, repoID))
+	err := s.Store.DeleteRepository(ctx, repoID)
+	if err == nil {
+		var name string
+		if before != nil {
+			name = before.String()
+		}
+		s.record(ctx, auditRecord{
+			action: params.AuditActionDelete, entityType: common.RepositoryEntityType,
+			entityID: repoID, entityName: name, before: beforeValue(before),
+		})
+	}
+	return err
+}
+
+func (s *auditStore) CreateOrganization(ctx context.Context, name, credentialsName, webhookSecret string, poolBalancerType params.PoolBalancerType) (params.Organization, error) {
+	org, err := s.Store.CreateOrganization(ctx, name, credentialsName, webhookSecret, poolBalancerType)
+	if err == nil {
+		s.record(ctx, auditRecord{
+			action: params.AuditActionCreate, entityType: common.OrganizationEntityType,
+			entityID: org.ID, entityName: org.Name, after: org,
+			secrets: secretIf(webhookSecret != "", "webhook_secret"),
+		})
+	}
+	return org, err
+}
+
+func (s *auditStore) UpdateOrganization(ctx context.Context, orgID string, param params.UpdateEntityParams) (params.Organization, error) {
+	before := optional(s.Store.GetOrganizationByID(ctx, orgID))
+	org, err := s.Store.UpdateOrganization(ctx, orgID, param)
+	if err == nil {
+		after := optional(s.Store.GetOrganizationByID(ctx, orgID))
+		s.record(ctx, auditRecord{
+			action: params.AuditActionUpdate, entityType: common.OrganizationEntityType,
+			entityID: orgID, entityName: org.Name, before: beforeValue(before), after: afterValue(after, org),
+			secrets: secretIf(param.WebhookSecret != "", "webhook_secret"),
+		})
+	}
+	return org, err
+}
+
+func (s *auditStore) DeleteOrganization(ctx context.Context, orgID string) error {
+	before := optional(s.Store.GetOrganizationByID(ctx, orgID))
+	err := s.Store.DeleteOrganization(ctx, orgID)
+	if err == nil {
+		var name string
+		if before != nil {
+			name = before.Name
+		}
+		s.record(ctx, auditRecord{
+			action: params.AuditActionDelete, entityType: common.OrganizationEntityType,
+			entityID: orgID, entityName: name, before: beforeValue(before),
+		})
+	}
+	return err
+}
+
+func (s *auditStore) CreateEnterprise(ctx context.Context, name, credentialsName, webhookSecret string, poolBalancerType params.PoolBalancerType) (params.Enterprise, error) {
+	ent, err := s.Store.CreateEnterprise(ctx, name, credentialsName, webhookSecret, poolBalancerType)
+	if err == nil {
+		s.record(ctx, auditRecord{
+			action: params.AuditActionCreate, entityType: common.EnterpriseEntityType,
+			entityID: ent.ID, entityName: ent.Name, after: ent,
+			secrets: secretIf(webhookSecret != "", "webhook_secret"),
+		})
+	}
+	return ent, err
+}
+
+func (s *auditStore) UpdateEnterprise(ctx context.Context, enterpriseID string, param params.UpdateEntityParams) (params.Enterprise, error) {
+	before := optional(s.Store.GetEnterpriseByID(ctx, enterpriseID))
+	ent, err := s.Store.UpdateEnterprise(ctx, enterpriseID, param)
+	if err == nil {
+		after := optional(s.Store.GetEnterpriseByID(ctx, enterpriseID))
+		s.record(ctx, auditRecord{
+			action: params.AuditActionUpdate, entityType: common.EnterpriseEntityType,
+			entityID: enterpriseID, entityName: ent.Name, before: beforeValue(before), after: afterValue(after, ent),
+			secrets: secretIf(param.WebhookSecret != "", "webhook_secret"),
+		})
+	}
+	return ent, err
+}
+
+func (s *auditStore) DeleteEnterprise(ctx context.Context, enterpriseID string) error {
+	before := optional(s.Store.GetEnterpriseByID(ctx, enterpriseID))
+	err := s.Store.DeleteEnterprise(ctx, enterpriseID)
+	if err == nil {
+		var name string
+		if before != nil {
+			name = before.Name
+		}
+		s.record(ctx, auditRecord{
+			action: params.AuditActionDelete, entityType: common.EnterpriseEntityType,
+			entityID: enterpriseID, entityName: name, before: beforeValue(before),
+		})
+	}
+	return err
+}
+
+func (s *auditStore) DeletePoolByID(ctx context.Context, poolID string) error {
+	before := optional(s.Store.GetPoolByID(ctx, poolID))
+	err := s.Store.DeletePoolByID(ctx, poolID)
+	if err == nil {
+		s.record(ctx, auditRecord{action: params.AuditActionDelete, entityType: common.PoolEntityType, entityID: poolID, before: beforeValue(before)})
+	}
+	return err
+}
+
+func (s *auditStore) CreateEntityPool(ctx context.Context, entity params.GithubEntity, param params.CreatePoolParams) (params.Pool, error) {
+	pool, err := s.Store.CreateEntityPool(ctx, entity, param)
+	if err == nil {
+		s.record(ctx, auditRecord{action: params.AuditActionCreate, entityType: common.PoolEntityType, entityID: pool.ID, after: pool})
+	}
+	return pool, err
+}
+
+func (s *auditStore) UpdateEntityPool(ctx context.Context, entity params.GithubEntity, poolID string, param params.UpdatePoolParams) (params.Pool, error) {
+	before := optional(s.Store.GetEntityPool(ctx, entity, poolID))
+	pool, err := s.Store.UpdateEntityPool(ctx, entity, poolID, param)
+	if err == nil {
+		after := optional(s.Store.GetEntityPool(ctx, entity, poolID))
+		s.record(ctx, auditRecord{action: params.AuditActionUpdate, entityType: common.PoolEntityType, entityID: poolID, before: beforeValue(before), after: afterValue(after, pool)})
+	}
+	return pool, err
+}
+
+func (s *auditStore) DeleteEntityPool(ctx context.Context, entity params.GithubEntity, poolID string) error {
+	before := optional(s.Store.GetEntityPool(ctx, entity, poolID))
+	err := s.Store.DeleteEntityPool(ctx, entity, poolID)
+	if err == nil {
+		s.record(ctx, auditRecord{action: params.AuditActionDelete, entityType: common.PoolEntityType, entityID: poolID, before: beforeValue(before)})
+	}
+	return err
+}
+
+func (s *auditStore) QuarantineEntityPool(ctx context.Context, entity params.GithubEntity, poolID string, reason string) (params.Pool, error) {
+	before := optional(s.Store.GetEntityPool(ctx, entity, poolID))
+	pool, err := s.Store.QuarantineEntityPool(ctx, entity, poolID, reason)
+	if err == nil {
+		after := optional(s.Store.GetEntityPool(ctx, entity, poolID))
+		s.record(ctx, auditRecord{action: params.AuditActionQuarantine, entityType: common.PoolEntityType, entityID: poolID, before: beforeValue(before), after: afterValue(after, pool)})
+	}
+	return pool, err
+}
+
+func (s *auditStore) CreateUser(ctx context.Context, user params.NewUserParams) (params.User, error) {
+	newUser, err := s.Store.CreateUser(ctx, user)
+	if err == nil {
+		s.record(ctx, auditRecord{
+			action: params.AuditActionCreate, entityType: common.UserEntityType,
+			entityID: newUser.ID, entityName: newUser.Username, after: newUser,
+			secrets: secretIf(user.Password != "", "password"),
+		})
+	}
+	return newUser, err
+}
+
+func (s *auditStore) UpdateUser(ctx context.Context, user string, param params.UpdateUserParams) (params.User, error) {
+	before := optional(s.Store.GetUser(ctx, user))
+	updated, err := s.Store.UpdateUser(ctx, user, param)
+	if err == nil {
+		s.record(ctx, auditRecord{
+			action: params.AuditActionUpdate, entityType: common.UserEntityType,
+			entityID: updated.ID, entityName: updated.Username, before: beforeValue(before), after: updated,
+			secrets: secretIf(param.Password != "", "password"),
+		})
+	}
+	return updated, err
+}
+
+func (s *auditStore) DeleteUser(ctx context.Context, user string) error {
+	before := optional(s.Store.GetUser(ctx, user))
+	err := s.Store.DeleteUser(ctx, user)
+	if err == nil && before != nil {
+		s.record(ctx, auditRecord{
+			action: params.AuditActionDelete, entityType: common.UserEntityType,
+			entityID: before.ID, entityName: before.Username, before: beforeValue(before),
+		})
+	}
+	return err
+}
+
+func (s *auditStore) CreateInstance(ctx context.Context, poolID string, param params.CreateInstanceParams) (params.Instance, error) {
+	instance, err := s.Store.CreateInstance(ctx, poolID, param)
+	if err == nil {
+		s.record(ctx, auditRecord{action: params.AuditActionCreate, entityType: common.InstanceEntityType, entityID: instance.ID, entityName: instance.Name, after: instance})
+	}
+	return instance, err
+}
+
+func (s *auditStore) DeleteInstance(ctx context.Context, poolID string, instanceName string) error {
+	before := optional(s.Store.GetPoolInstanceByName(ctx, poolID, instanceName))
+	err := s.Store.DeleteInstance(ctx, poolID, instanceName)
+	if err == nil {
+		var id string
+		if before != nil {
+			id = before.ID
+		}
+		s.record(ctx, auditRecord{action: params.AuditActionDelete, entityType: common.InstanceEntityType, entityID: id, entityName: instanceName, before: beforeValue(before)})
+	}
+	return err
+}
+
+// instanceStatus holds the instance fields we audit on update. Instances are
+// updated very often, mostly to record status messages and provider details,
+// so only changes to their status are recorded.
+type instanceStatus struct {
+	Status       string `json:"status"`
+	RunnerStatus string `json:"runner_status"`
+}
+
+func (s *auditStore) UpdateInstance(ctx context.Context, instanceName string, param params.UpdateInstanceParams) (params.Instance, error) {
+	if param.Status == "" && param.RunnerStatus == "" {
+		return s.Store.UpdateInstance(ctx, instanceName, param)
+	}
+	before := optional(s.Store.GetInstanceByName(ctx, instanceName))
+	instance, err := s.Store.UpdateInstance(ctx, instanceName, param)
+	if err != nil || before == nil {
+		return instance, err
+	}
+	if before.Status == instance.Status && before.RunnerStatus == instance.RunnerStatus {
+		return instance, nil
+	}
+	s.record(ctx, auditRecord{
+		action: params.AuditActionUpdate, entityType: common.InstanceEntityType,
+		entityID: instance.ID, entityName: instance.Name,
+		before: instanceStatus{Status: string(before.Status), RunnerStatus: string(before.RunnerStatus)},
+		after:  instanceStatus{Status: string(instance.Status), RunnerStatus: string(instance.RunnerStatus)},
+	})
+	return instance, nil
+}
+
+func (s *auditStore) UpdateController(ctx context.Context, info params.UpdateControllerParams) (params.ControllerInfo, error) {
+	before := optional(s.Store.ControllerInfo())
+	updated, err := s.Store.UpdateController(ctx, info)
+	if err == nil {
+		s.record(ctx, auditRecord{
+			action: params.AuditActionUpdate, entityType: common.ControllerEntityType,
+			entityID: updated.ControllerID.String(), before: beforeValue(before), after: updated,
+		})
+	}
+	return updated, err
+}
+
+func (s *auditStore) CreateNotificationSink(ctx context.Context, param params.CreateNotificationSinkParams) (params.NotificationSink, error) {
+	sink, err := s.Store.CreateNotificationSink(ctx, param)
+	if err == nil {
+		s.record(ctx, auditRecord{
+			action: params.AuditActionCreate, entityType: common.NotificationSinkEntityType,
+			entityID: fmt.Sprintf("%d", sink.ID), entityName: sink.Name,
+			after: sink, secrets: append([]string{"url"}, secretIf(len(param.Headers) > 0, "headers")...),
+		})
+	}
+	return sink, err
+}
+
+func (s *auditStore) UpdateNotificationSink(ctx context.Context, id uint, param params.UpdateNotificationSinkParams) (params.NotificationSink, error) {
+	before := optional(s.Store.GetNotificationSink(ctx, id))
+	sink, err := s.Store.UpdateNotificationSink(ctx, id, param)
+	if err == nil {
+		s.record(ctx, auditRecord{
+			action: params.AuditActionUpdate, entityType: common.NotificationSinkEntityType,
+			entityID: fmt.Sprintf("%d", id), entityName: sink.Name,
+			before: beforeValue(before), after: sink,
+			secrets: append(secretIf(param.URL != nil, "url"), secretIf(param.Headers != nil, "headers")...),
+		})
+	}
+	return sink, err
+}
+
+func (s *auditStore) DeleteNotificationSink(ctx context.Context, id uint) error {
+	before := optional(s.Store.GetNotificationSink(ctx, id))
+	err := s.Store.DeleteNotificationSink(ctx, id)
+	if err == nil && before != nil {
+		s.record(ctx, auditRecord{
+			action: params.AuditActionDelete, entityType: common.NotificationSinkEntityType,
+			entityID: fmt.Sprintf("%d", id), entityName: before.Name, before: beforeValue(before),
+		})
+	}
+	return err
+}
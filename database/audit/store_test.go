@@ -0,0 +1,241 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+
+	"github.com/cloudbase/garm/auth"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/database/sql"
+	"github.com/cloudbase/garm/database/watcher"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+type StoreTestSuite struct {
+	suite.Suite
+	Store dbCommon.Store
+	db    dbCommon.Store
+	ctx   context.Context
+
+	creds params.GithubCredentials
+}
+
+func (s *StoreTestSuite) SetupTest() {
+	watcher.SetWatcher(&garmTesting.MockWatcher{})
+	db, err := sql.NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+
+	// Fixtures are created directly in the database, so they are not audited.
+	adminCtx := garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(adminCtx, db, s.T())
+	s.creds = garmTesting.CreateTestGithubCredentials(adminCtx, "test-creds", db, s.T(), endpoint)
+
+	s.ctx = auth.SetSourceIP(adminCtx, "192.168.1.10")
+	s.db = db
+	s.Store = NewStore(db)
+}
+
+// createPool creates a repository and a pool directly in the database.
+func (s *StoreTestSuite) createPool() params.Pool {
+	repo, err := s.db.CreateRepository(s.ctx, "test-owner", "test-repo", s.creds.Name, "super-secret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+	entity, err := repo.GetEntity()
+	s.Require().Nil(err)
+	pool, err := s.db.CreateEntityPool(s.ctx, entity, params.CreatePoolParams{
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "test-image",
+		Flavor:       "test-flavor",
+		OSType:       "linux",
+		Tags:         []string{"linux"},
+	})
+	s.Require().Nil(err)
+	return pool
+}
+
+func (s *StoreTestSuite) listEntries(filter params.AuditFilter) []params.AuditEntry {
+	entries, _, err := s.Store.ListAuditEntries(s.ctx, filter)
+	s.Require().Nil(err)
+	return entries
+}
+
+func (s *StoreTestSuite) TestCreateRecordsEntry() {
+	repo, err := s.Store.CreateRepository(s.ctx, "test-owner", "test-repo", s.creds.Name, "super-secret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+
+	entries := s.listEntries(params.AuditFilter{})
+
+	s.Require().Len(entries, 1)
+	entry := entries[0]
+	s.Require().Equal(params.AuditActionCreate, entry.Action)
+	s.Require().Equal(string(dbCommon.RepositoryEntityType), entry.EntityType)
+	s.Require().Equal(repo.ID, entry.EntityID)
+	s.Require().Equal("test-owner/test-repo", entry.EntityName)
+	s.Require().Equal(auth.UserID(s.ctx), entry.UserID)
+	s.Require().Empty(entry.Actor)
+	s.Require().Equal("192.168.1.10", entry.SourceIP)
+	s.Require().Contains(entry.Changes, params.AuditChange{Field: "webhook_secret", Before: Redacted, After: Redacted})
+	s.Require().Contains(entry.Changes, params.AuditChange{Field: "name", After: "test-repo"})
+}
+
+func (s *StoreTestSuite) TestUpdateRecordsOnlyChangedFields() {
+	repo, err := s.Store.CreateRepository(s.ctx, "test-owner", "test-repo", s.creds.Name, "super-secret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+
+	_, err = s.Store.UpdateRepository(s.ctx, repo.ID, params.UpdateEntityParams{PoolBalancerType: params.PoolBalancerTypePack})
+	s.Require().Nil(err)
+
+	entries := s.listEntries(params.AuditFilter{Action: params.AuditActionUpdate})
+
+	s.Require().Len(entries, 1)
+	s.Require().Equal([]params.AuditChange{
+		{Field: "pool_balancing_type", Before: string(params.PoolBalancerTypeRoundRobin), After: string(params.PoolBalancerTypePack)},
+	}, entries[0].Changes)
+}
+
+func (s *StoreTestSuite) TestDeleteRecordsEntry() {
+	repo, err := s.Store.CreateRepository(s.ctx, "test-owner", "test-repo", s.creds.Name, "super-secret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+
+	err = s.Store.DeleteRepository(s.ctx, repo.ID)
+	s.Require().Nil(err)
+
+	entries := s.listEntries(params.AuditFilter{Action: params.AuditActionDelete})
+
+	s.Require().Len(entries, 1)
+	s.Require().Equal(repo.ID, entries[0].EntityID)
+	s.Require().Equal("test-owner/test-repo", entries[0].EntityName)
+	s.Require().Contains(entries[0].Changes, params.AuditChange{Field: "name", Before: "test-repo"})
+}
+
+func (s *StoreTestSuite) TestCredentialsNeverRecorded() {
+	_, err := s.Store.UpdateGithubCredentials(s.ctx, s.creds.ID, params.UpdateGithubCredentialsParams{
+		PAT: &params.GithubPAT{OAuth2Token: "new-token"},
+	})
+	s.Require().Nil(err)
+
+	entries := s.listEntries(params.AuditFilter{EntityType: string(dbCommon.GithubCredentialsEntityType)})
+
+	s.Require().Len(entries, 1)
+	s.Require().Equal([]params.AuditChange{{Field: "credentials", Before: Redacted, After: Redacted}}, entries[0].Changes)
+}
+
+func (s *StoreTestSuite) TestFailedChangeNotRecorded() {
+	err := s.Store.DeleteRepository(s.ctx, "9a5ff8b1-0d0c-4e42-a4b1-1e0f1c9f1a2b")
+	s.Require().NotNil(err)
+
+	s.Require().Empty(s.listEntries(params.AuditFilter{}))
+}
+
+func (s *StoreTestSuite) TestChangesMadeByGARM() {
+	_, err := s.Store.InitController()
+	s.Require().Nil(err)
+	metadataURL := "https://garm.example.com/api/v1/metadata"
+
+	_, err = s.Store.UpdateController(context.Background(), params.UpdateControllerParams{MetadataURL: &metadataURL})
+	s.Require().Nil(err)
+
+	entries := s.listEntries(params.AuditFilter{EntityType: string(dbCommon.ControllerEntityType)})
+
+	s.Require().Len(entries, 1)
+	s.Require().Empty(entries[0].UserID)
+	s.Require().Equal(params.AuditActorController, entries[0].Actor)
+	s.Require().Empty(entries[0].SourceIP)
+	s.Require().Equal([]params.AuditChange{{Field: "metadata_url", Before: "", After: metadataURL}}, entries[0].Changes)
+}
+
+func (s *StoreTestSuite) TestUserControllerUpdateRecordsUser() {
+	_, err := s.Store.InitController()
+	s.Require().Nil(err)
+	callbackURL := "https://garm.example.com/api/v1/callbacks"
+
+	_, err = s.Store.UpdateController(s.ctx, params.UpdateControllerParams{CallbackURL: &callbackURL})
+	s.Require().Nil(err)
+
+	entries := s.listEntries(params.AuditFilter{EntityType: string(dbCommon.ControllerEntityType)})
+
+	s.Require().Len(entries, 1)
+	s.Require().Equal(auth.UserID(s.ctx), entries[0].UserID)
+	s.Require().Equal("192.168.1.10", entries[0].SourceIP)
+}
+
+func (s *StoreTestSuite) TestInstanceChangesByGARMRecorded() {
+	pool := s.createPool()
+	ctx := auth.GetAdminContext(context.Background())
+
+	instance, err := s.Store.CreateInstance(ctx, pool.ID, params.CreateInstanceParams{Name: "test-instance", Status: commonParams.InstancePendingCreate})
+	s.Require().Nil(err)
+	_, err = s.Store.UpdateInstance(ctx, instance.Name, params.UpdateInstanceParams{Status: commonParams.InstanceRunning})
+	s.Require().Nil(err)
+	// Only status changes are recorded.
+	_, err = s.Store.UpdateInstance(ctx, instance.Name, params.UpdateInstanceParams{ProviderID: "provider-id"})
+	s.Require().Nil(err)
+	// Runners report their status using instance contexts.
+	instanceCtx := auth.SetInstanceName(context.Background(), instance.Name)
+	_, err = s.Store.UpdateInstance(instanceCtx, instance.Name, params.UpdateInstanceParams{RunnerStatus: params.RunnerIdle})
+	s.Require().Nil(err)
+	err = s.Store.DeleteInstance(ctx, pool.ID, instance.Name)
+	s.Require().Nil(err)
+
+	entries := s.listEntries(params.AuditFilter{EntityType: string(dbCommon.InstanceEntityType)})
+
+	s.Require().Len(entries, 4)
+	for _, entry := range entries {
+		s.Require().Empty(entry.UserID)
+	}
+	s.Require().Equal(params.AuditActionCreate, entries[0].Action)
+	s.Require().Equal(params.AuditActorController, entries[0].Actor)
+	s.Require().Equal(params.AuditActionUpdate, entries[1].Action)
+	s.Require().Equal(params.AuditActorController, entries[1].Actor)
+	s.Require().Equal(params.AuditActionUpdate, entries[2].Action)
+	s.Require().Equal(params.AuditActorInstancePrefix+instance.Name, entries[2].Actor)
+	s.Require().Equal([]params.AuditChange{
+		{Field: "runner_status", Before: "", After: string(params.RunnerIdle)},
+	}, entries[2].Changes)
+	s.Require().Equal(params.AuditActionDelete, entries[3].Action)
+	s.Require().Equal(params.AuditActorController, entries[3].Actor)
+}
+
+func (s *StoreTestSuite) TestInstanceChangesByUserRecorded() {
+	pool := s.createPool()
+
+	instance, err := s.Store.CreateInstance(s.ctx, pool.ID, params.CreateInstanceParams{Name: "test-instance", Status: commonParams.InstancePendingCreate})
+	s.Require().Nil(err)
+	_, err = s.Store.UpdateInstance(s.ctx, instance.Name, params.UpdateInstanceParams{Status: commonParams.InstanceRunning})
+	s.Require().Nil(err)
+
+	entries := s.listEntries(params.AuditFilter{EntityType: string(dbCommon.InstanceEntityType)})
+
+	s.Require().Len(entries, 2)
+	s.Require().Equal(params.AuditActionCreate, entries[0].Action)
+	s.Require().Equal(params.AuditActionUpdate, entries[1].Action)
+	s.Require().Equal([]params.AuditChange{
+		{Field: "status", Before: string(commonParams.InstancePendingCreate), After: string(commonParams.InstanceRunning)},
+	}, entries[1].Changes)
+}
+
+func TestStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(StoreTestSuite))
+}
@@ -9,4 +9,5 @@ var (
 	ErrConsumerAlreadyRegistered = fmt.Errorf("consumer already registered")
 	ErrWatcherAlreadyStarted     = fmt.Errorf("watcher already started")
 	ErrWatcherNotInitialized     = fmt.Errorf("watcher not initialized")
+	ErrSchemaTooNew              = fmt.Errorf("database schema is newer than this version of GARM supports")
 )
@@ -0,0 +1,43 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package common
+
+import (
+	"context"
+	"time"
+)
+
+// MigrationStatus describes a schema migration and whether it was applied.
+type MigrationStatus struct {
+	Version     uint
+	Description string
+	// AppliedAt is nil if the migration was not applied yet.
+	AppliedAt *time.Time
+	// Unknown is set for migrations that were applied by a newer version
+	// of GARM.
+	Unknown bool
+}
+
+// SchemaMigrator manages the schema version of the database.
+type SchemaMigrator interface {
+	// MigrationStatus returns all known migrations, along with any migration
+	// applied by a newer version of GARM.
+	MigrationStatus(ctx context.Context) ([]MigrationStatus, error)
+	// MigrateUp applies migrations up to and including the target version.
+	// A target of 0 applies all migrations.
+	MigrateUp(ctx context.Context, target uint) error
+	// MigrateDown reverts migrations newer than the target version.
+	MigrateDown(ctx context.Context, target uint) error
+}
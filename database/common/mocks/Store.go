@@ -5,8 +5,13 @@ package mocks
 import (
 	context "context"
 
-	params "github.com/cloudbase/garm/params"
+	common "github.com/cloudbase/garm/database/common"
+
 	mock "github.com/stretchr/testify/mock"
+
+	params "github.com/cloudbase/garm/params"
+
+	time "time"
 )
 
 // Store is an autogenerated mock type for the Store type
@@ -14,6 +19,34 @@ type Store struct {
 	mock.Mock
 }
 
+// AcquireLease provides a mock function with given fields: ctx, name, holderID, duration
+func (_m *Store) AcquireLease(ctx context.Context, name string, holderID string, duration time.Duration) (params.ControllerLease, error) {
+	ret := _m.Called(ctx, name, holderID, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireLease")
+	}
+
+	var r0 params.ControllerLease
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (params.ControllerLease, error)); ok {
+		return rf(ctx, name, holderID, duration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) params.ControllerLease); ok {
+		r0 = rf(ctx, name, holderID, duration)
+	} else {
+		r0 = ret.Get(0).(params.ControllerLease)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, name, holderID, duration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddInstanceEvent provides a mock function with given fields: ctx, instanceName, event, eventLevel, eventMessage
 func (_m *Store) AddInstanceEvent(ctx context.Context, instanceName string, event params.EventType, eventLevel params.EventLevel, eventMessage string) error {
 	ret := _m.Called(ctx, instanceName, event, eventLevel, eventMessage)
@@ -218,6 +251,34 @@ func (_m *Store) CreateInstance(ctx context.Context, poolID string, param params
 	return r0, r1
 }
 
+// CreateNotificationSink provides a mock function with given fields: ctx, param
+func (_m *Store) CreateNotificationSink(ctx context.Context, param params.CreateNotificationSinkParams) (params.NotificationSink, error) {
+	ret := _m.Called(ctx, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotificationSink")
+	}
+
+	var r0 params.NotificationSink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.CreateNotificationSinkParams) (params.NotificationSink, error)); ok {
+		return rf(ctx, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.CreateNotificationSinkParams) params.NotificationSink); ok {
+		r0 = rf(ctx, param)
+	} else {
+		r0 = ret.Get(0).(params.NotificationSink)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.CreateNotificationSinkParams) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateOrUpdateJob provides a mock function with given fields: ctx, job
 func (_m *Store) CreateOrUpdateJob(ctx context.Context, job params.Job) (params.Job, error) {
 	ret := _m.Called(ctx, job)
@@ -456,6 +517,24 @@ func (_m *Store) DeleteJob(ctx context.Context, jobID int64) error {
 	return r0
 }
 
+// DeleteNotificationSink provides a mock function with given fields: ctx, id
+func (_m *Store) DeleteNotificationSink(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNotificationSink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteOrganization provides a mock function with given fields: ctx, orgID
 func (_m *Store) DeleteOrganization(ctx context.Context, orgID string) error {
 	ret := _m.Called(ctx, orgID)
@@ -510,6 +589,52 @@ func (_m *Store) DeleteRepository(ctx context.Context, repoID string) error {
 	return r0
 }
 
+// DeleteUser provides a mock function with given fields: ctx, user
+func (_m *Store) DeleteUser(ctx context.Context, user string) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportState provides a mock function with given fields: ctx
+func (_m *Store) ExportState(ctx context.Context) (common.ControllerState, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportState")
+	}
+
+	var r0 common.ControllerState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (common.ControllerState, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) common.ControllerState); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(common.ControllerState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindPoolsMatchingAllTags provides a mock function with given fields: ctx, entityType, entityID, tags
 func (_m *Store) FindPoolsMatchingAllTags(ctx context.Context, entityType params.GithubEntityType, entityID string, tags []string) ([]params.Pool, error) {
 	ret := _m.Called(ctx, entityType, entityID, tags)
@@ -792,6 +917,62 @@ func (_m *Store) GetJobByID(ctx context.Context, jobID int64) (params.Job, error
 	return r0, r1
 }
 
+// GetLease provides a mock function with given fields: ctx, name
+func (_m *Store) GetLease(ctx context.Context, name string) (params.ControllerLease, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLease")
+	}
+
+	var r0 params.ControllerLease
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (params.ControllerLease, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) params.ControllerLease); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(params.ControllerLease)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNotificationSink provides a mock function with given fields: ctx, id
+func (_m *Store) GetNotificationSink(ctx context.Context, id uint) (params.NotificationSink, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotificationSink")
+	}
+
+	var r0 params.NotificationSink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (params.NotificationSink, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) params.NotificationSink); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(params.NotificationSink)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOrganization provides a mock function with given fields: ctx, name
 func (_m *Store) GetOrganization(ctx context.Context, name string) (params.Organization, error) {
 	ret := _m.Called(ctx, name)
@@ -1034,6 +1215,24 @@ func (_m *Store) HasAdminUser(ctx context.Context) bool {
 	return r0
 }
 
+// ImportState provides a mock function with given fields: ctx, state
+func (_m *Store) ImportState(ctx context.Context, state common.ControllerState) error {
+	ret := _m.Called(ctx, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.ControllerState) error); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InitController provides a mock function with given fields:
 func (_m *Store) InitController() (params.ControllerInfo, error) {
 	ret := _m.Called()
@@ -1152,6 +1351,43 @@ func (_m *Store) ListAllPools(ctx context.Context) ([]params.Pool, error) {
 	return r0, r1
 }
 
+// ListAuditEntries provides a mock function with given fields: ctx, filter
+func (_m *Store) ListAuditEntries(ctx context.Context, filter params.AuditFilter) ([]params.AuditEntry, string, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuditEntries")
+	}
+
+	var r0 []params.AuditEntry
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.AuditFilter) ([]params.AuditEntry, string, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.AuditFilter) []params.AuditEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.AuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.AuditFilter) string); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, params.AuditFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListEnterprises provides a mock function with given fields: ctx
 func (_m *Store) ListEnterprises(ctx context.Context) ([]params.Enterprise, error) {
 	ret := _m.Called(ctx)
@@ -1332,6 +1568,117 @@ func (_m *Store) ListGithubEndpoints(ctx context.Context) ([]params.GithubEndpoi
 	return r0, r1
 }
 
+// ListInstances provides a mock function with given fields: ctx, filter
+func (_m *Store) ListInstances(ctx context.Context, filter params.InstanceFilter) ([]params.Instance, string, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInstances")
+	}
+
+	var r0 []params.Instance
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.InstanceFilter) ([]params.Instance, string, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.InstanceFilter) []params.Instance); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.Instance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.InstanceFilter) string); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, params.InstanceFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListJobHistory provides a mock function with given fields: ctx, filter
+func (_m *Store) ListJobHistory(ctx context.Context, filter params.JobHistoryFilter) ([]params.JobHistory, string, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobHistory")
+	}
+
+	var r0 []params.JobHistory
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.JobHistoryFilter) ([]params.JobHistory, string, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.JobHistoryFilter) []params.JobHistory); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.JobHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.JobHistoryFilter) string); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, params.JobHistoryFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListJobs provides a mock function with given fields: ctx, filter
+func (_m *Store) ListJobs(ctx context.Context, filter params.JobFilter) ([]params.Job, string, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobs")
+	}
+
+	var r0 []params.Job
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.JobFilter) ([]params.Job, string, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.JobFilter) []params.Job); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.JobFilter) string); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, params.JobFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListJobsByStatus provides a mock function with given fields: ctx, status
 func (_m *Store) ListJobsByStatus(ctx context.Context, status params.JobStatus) ([]params.Job, error) {
 	ret := _m.Called(ctx, status)
@@ -1362,6 +1709,36 @@ func (_m *Store) ListJobsByStatus(ctx context.Context, status params.JobStatus)
 	return r0, r1
 }
 
+// ListNotificationSinks provides a mock function with given fields: ctx
+func (_m *Store) ListNotificationSinks(ctx context.Context) ([]params.NotificationSink, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNotificationSinks")
+	}
+
+	var r0 []params.NotificationSink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]params.NotificationSink, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []params.NotificationSink); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.NotificationSink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListOrganizations provides a mock function with given fields: ctx
 func (_m *Store) ListOrganizations(ctx context.Context) ([]params.Organization, error) {
 	ret := _m.Called(ctx)
@@ -1422,6 +1799,43 @@ func (_m *Store) ListPoolInstances(ctx context.Context, poolID string) ([]params
 	return r0, r1
 }
 
+// ListPools provides a mock function with given fields: ctx, filter
+func (_m *Store) ListPools(ctx context.Context, filter params.PoolFilter) ([]params.Pool, string, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPools")
+	}
+
+	var r0 []params.Pool
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.PoolFilter) ([]params.Pool, string, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.PoolFilter) []params.Pool); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.Pool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.PoolFilter) string); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, params.PoolFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListRepositories provides a mock function with given fields: ctx
 func (_m *Store) ListRepositories(ctx context.Context) ([]params.Repository, error) {
 	ret := _m.Called(ctx)
@@ -1452,6 +1866,36 @@ func (_m *Store) ListRepositories(ctx context.Context) ([]params.Repository, err
 	return r0, r1
 }
 
+// ListUsers provides a mock function with given fields: ctx
+func (_m *Store) ListUsers(ctx context.Context) ([]params.User, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []params.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]params.User, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []params.User); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]params.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LockJob provides a mock function with given fields: ctx, jobID, entityID
 func (_m *Store) LockJob(ctx context.Context, jobID int64, entityID string) error {
 	ret := _m.Called(ctx, jobID, entityID)
@@ -1498,6 +1942,154 @@ func (_m *Store) PoolInstanceCount(ctx context.Context, poolID string) (int64, e
 	return r0, r1
 }
 
+// PurgeAuditEntries provides a mock function with given fields: ctx, olderThan
+func (_m *Store) PurgeAuditEntries(ctx context.Context, olderThan time.Time) (int64, error) {
+	ret := _m.Called(ctx, olderThan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeAuditEntries")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, olderThan)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, olderThan)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, olderThan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeJobHistory provides a mock function with given fields: ctx, olderThan
+func (_m *Store) PurgeJobHistory(ctx context.Context, olderThan time.Time) (int64, error) {
+	ret := _m.Called(ctx, olderThan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeJobHistory")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, olderThan)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, olderThan)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, olderThan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuarantineEntityPool provides a mock function with given fields: ctx, entity, poolID, reason
+func (_m *Store) QuarantineEntityPool(ctx context.Context, entity params.GithubEntity, poolID string, reason string) (params.Pool, error) {
+	ret := _m.Called(ctx, entity, poolID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuarantineEntityPool")
+	}
+
+	var r0 params.Pool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.GithubEntity, string, string) (params.Pool, error)); ok {
+		return rf(ctx, entity, poolID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.GithubEntity, string, string) params.Pool); ok {
+		r0 = rf(ctx, entity, poolID, reason)
+	} else {
+		r0 = ret.Get(0).(params.Pool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.GithubEntity, string, string) error); ok {
+		r1 = rf(ctx, entity, poolID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordAuditEntry provides a mock function with given fields: ctx, entry
+func (_m *Store) RecordAuditEntry(ctx context.Context, entry params.AuditEntry) (params.AuditEntry, error) {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordAuditEntry")
+	}
+
+	var r0 params.AuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, params.AuditEntry) (params.AuditEntry, error)); ok {
+		return rf(ctx, entry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, params.AuditEntry) params.AuditEntry); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Get(0).(params.AuditEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, params.AuditEntry) error); ok {
+		r1 = rf(ctx, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseLease provides a mock function with given fields: ctx, name, holderID
+func (_m *Store) ReleaseLease(ctx context.Context, name string, holderID string) error {
+	ret := _m.Called(ctx, name, holderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, holderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotatePassphrase provides a mock function with given fields: ctx, oldPassphrase
+func (_m *Store) RotatePassphrase(ctx context.Context, oldPassphrase string) error {
+	ret := _m.Called(ctx, oldPassphrase)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotatePassphrase")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, oldPassphrase)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UnlockJob provides a mock function with given fields: ctx, jobID, entityID
 func (_m *Store) UnlockJob(ctx context.Context, jobID int64, entityID string) error {
 	ret := _m.Called(ctx, jobID, entityID)
@@ -1516,9 +2108,9 @@ func (_m *Store) UnlockJob(ctx context.Context, jobID int64, entityID string) er
 	return r0
 }
 
-// UpdateController provides a mock function with given fields: info
-func (_m *Store) UpdateController(info params.UpdateControllerParams) (params.ControllerInfo, error) {
-	ret := _m.Called(info)
+// UpdateController provides a mock function with given fields: ctx, info
+func (_m *Store) UpdateController(ctx context.Context, info params.UpdateControllerParams) (params.ControllerInfo, error) {
+	ret := _m.Called(ctx, info)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateController")
@@ -1526,17 +2118,17 @@ func (_m *Store) UpdateController(info params.UpdateControllerParams) (params.Co
 
 	var r0 params.ControllerInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(params.UpdateControllerParams) (params.ControllerInfo, error)); ok {
-		return rf(info)
+	if rf, ok := ret.Get(0).(func(context.Context, params.UpdateControllerParams) (params.ControllerInfo, error)); ok {
+		return rf(ctx, info)
 	}
-	if rf, ok := ret.Get(0).(func(params.UpdateControllerParams) params.ControllerInfo); ok {
-		r0 = rf(info)
+	if rf, ok := ret.Get(0).(func(context.Context, params.UpdateControllerParams) params.ControllerInfo); ok {
+		r0 = rf(ctx, info)
 	} else {
 		r0 = ret.Get(0).(params.ControllerInfo)
 	}
 
-	if rf, ok := ret.Get(1).(func(params.UpdateControllerParams) error); ok {
-		r1 = rf(info)
+	if rf, ok := ret.Get(1).(func(context.Context, params.UpdateControllerParams) error); ok {
+		r1 = rf(ctx, info)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1684,6 +2276,34 @@ func (_m *Store) UpdateInstance(ctx context.Context, instanceName string, param
 	return r0, r1
 }
 
+// UpdateNotificationSink provides a mock function with given fields: ctx, id, param
+func (_m *Store) UpdateNotificationSink(ctx context.Context, id uint, param params.UpdateNotificationSinkParams) (params.NotificationSink, error) {
+	ret := _m.Called(ctx, id, param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationSink")
+	}
+
+	var r0 params.NotificationSink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, params.UpdateNotificationSinkParams) (params.NotificationSink, error)); ok {
+		return rf(ctx, id, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, params.UpdateNotificationSinkParams) params.NotificationSink); ok {
+		r0 = rf(ctx, id, param)
+	} else {
+		r0 = ret.Get(0).(params.NotificationSink)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, params.UpdateNotificationSinkParams) error); ok {
+		r1 = rf(ctx, id, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateOrganization provides a mock function with given fields: ctx, orgID, param
 func (_m *Store) UpdateOrganization(ctx context.Context, orgID string, param params.UpdateEntityParams) (params.Organization, error) {
 	ret := _m.Called(ctx, orgID, param)
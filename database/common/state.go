@@ -0,0 +1,249 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package common
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm-provider-common/util"
+	"github.com/cloudbase/garm/params"
+)
+
+// StateVersion is the version of the ControllerState format. It must be
+// incremented whenever a change is made that older versions of GARM can
+// not import.
+const StateVersion = 1
+
+const (
+	archiveSaltLength = 16
+	archiveKeyRounds  = 600000
+)
+
+// ControllerState holds the configuration of a GARM controller. It is used to
+// move a controller to a different host or database backend. Secrets are
+// stored in plain text, so that they can be sealed with the passphrase of the
+// destination database on import. Runners and jobs are not part of the state.
+type ControllerState struct {
+	Version       int                     `json:"version"`
+	ExportedAt    time.Time               `json:"exported_at"`
+	Controller    StateController         `json:"controller"`
+	Users         []StateUser             `json:"users,omitempty"`
+	Endpoints     []StateGithubEndpoint   `json:"endpoints,omitempty"`
+	Credentials   []StateGithubCredential `json:"credentials,omitempty"`
+	Repositories  []StateRepository       `json:"repositories,omitempty"`
+	Organizations []StateOrganization     `json:"organizations,omitempty"`
+	Enterprises   []StateEnterprise       `json:"enterprises,omitempty"`
+	Pools         []StatePool             `json:"pools,omitempty"`
+
+	NotificationSinks []StateNotificationSink `json:"notification_sinks,omitempty"`
+}
+
+type StateController struct {
+	ControllerID   uuid.UUID `json:"controller_id"`
+	CallbackURL    string    `json:"callback_url,omitempty"`
+	MetadataURL    string    `json:"metadata_url,omitempty"`
+	WebhookBaseURL string    `json:"webhook_base_url,omitempty"`
+}
+
+type StateUser struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	FullName string    `json:"full_name,omitempty"`
+	Email    string    `json:"email"`
+	// Password is the bcrypt hash of the password of the user.
+	Password string `json:"password"`
+	IsAdmin  bool   `json:"is_admin"`
+	// Role is the role of the user. States exported before roles were added
+	// do not have it, and the role is derived from IsAdmin.
+	Role    string `json:"role,omitempty"`
+	Enabled bool   `json:"enabled"`
+}
+
+type StateGithubEndpoint struct {
+	Name          string `json:"name"`
+	Description   string `json:"description,omitempty"`
+	APIBaseURL    string `json:"api_base_url"`
+	UploadBaseURL string `json:"upload_base_url"`
+	BaseURL       string `json:"base_url"`
+	CACertBundle  []byte `json:"ca_cert_bundle,omitempty"`
+}
+
+type StateGithubCredential struct {
+	Name         string                `json:"name"`
+	Description  string                `json:"description,omitempty"`
+	UserID       *uuid.UUID            `json:"user_id,omitempty"`
+	EndpointName string                `json:"endpoint_name"`
+	AuthType     params.GithubAuthType `json:"auth_type"`
+	// Secret holds the PAT or the GitHub App credentials.
+	Secret json.RawMessage `json:"secret"`
+}
+
+// StateEntity holds the settings shared by repositories, organizations
+// and enterprises.
+type StateEntity struct {
+	ID               uuid.UUID               `json:"id"`
+	CredentialsName  string                  `json:"credentials_name"`
+	EndpointName     string                  `json:"endpoint_name"`
+	WebhookSecret    string                  `json:"webhook_secret,omitempty"`
+	PoolBalancerType params.PoolBalancerType `json:"pool_balancer_type,omitempty"`
+	FairShare        json.RawMessage         `json:"fair_share,omitempty"`
+
+	MaxConcurrentRunners uint `json:"max_concurrent_runners,omitempty"`
+	MaxPendingRunners    uint `json:"max_pending_runners,omitempty"`
+}
+
+type StateRepository struct {
+	StateEntity
+	Owner       string          `json:"owner"`
+	Name        string          `json:"name"`
+	JobBackfill json.RawMessage `json:"job_backfill,omitempty"`
+}
+
+type StateOrganization struct {
+	StateEntity
+	Name        string          `json:"name"`
+	JobBackfill json.RawMessage `json:"job_backfill,omitempty"`
+}
+
+type StateEnterprise struct {
+	StateEntity
+	Name string `json:"name"`
+}
+
+type StatePool struct {
+	ID           uuid.UUID  `json:"id"`
+	RepoID       *uuid.UUID `json:"repo_id,omitempty"`
+	OrgID        *uuid.UUID `json:"org_id,omitempty"`
+	EnterpriseID *uuid.UUID `json:"enterprise_id,omitempty"`
+
+	ProviderName           string              `json:"provider_name"`
+	RunnerPrefix           string              `json:"runner_prefix"`
+	MaxRunners             uint                `json:"max_runners"`
+	MinIdleRunners         uint                `json:"min_idle_runners"`
+	RunnerBootstrapTimeout uint                `json:"runner_bootstrap_timeout"`
+	Image                  string              `json:"image"`
+	Flavor                 string              `json:"flavor"`
+	OSType                 commonParams.OSType `json:"os_type"`
+	OSArch                 commonParams.OSArch `json:"os_arch"`
+	Tags                   []string            `json:"tags"`
+	Enabled                bool                `json:"enabled"`
+	ExtraSpecs             json.RawMessage     `json:"extra_specs,omitempty"`
+	GitHubRunnerGroup      string              `json:"github-runner-group,omitempty"`
+	Priority               uint                `json:"priority"`
+	Weight                 uint                `json:"weight,omitempty"`
+	MaxIdleAge             uint                `json:"max_idle_age,omitempty"`
+	MaxLifetime            uint                `json:"max_lifetime,omitempty"`
+	MaxConcurrentCreates   uint                `json:"max_concurrent_creates,omitempty"`
+	CreatesPerMinute       uint                `json:"creates_per_minute,omitempty"`
+	QuarantineThreshold    uint                `json:"quarantine_threshold,omitempty"`
+	QuarantineReason       string              `json:"quarantine_reason,omitempty"`
+	QuarantinedAt          *time.Time          `json:"quarantined_at,omitempty"`
+	ScalingSchedules       json.RawMessage     `json:"scaling_schedules,omitempty"`
+	ScaleDownPolicy        json.RawMessage     `json:"scale_down_policy,omitempty"`
+	LabelRules             json.RawMessage     `json:"label_rules,omitempty"`
+}
+
+// stateArchive is the on-disk envelope of an exported ControllerState. When a
+// passphrase is used, the state is sealed with a key derived from it.
+type stateArchive struct {
+	Version   int             `json:"version"`
+	Encrypted bool            `json:"encrypted"`
+	Salt      []byte          `json:"salt,omitempty"`
+	Sealed    []byte          `json:"sealed,omitempty"`
+	State     json.RawMessage `json:"state,omitempty"`
+}
+
+func archiveKey(passphrase string, salt []byte) []byte {
+	return pbkdf2.Key([]byte(passphrase), salt, archiveKeyRounds, 32, sha256.New)
+}
+
+// MarshalStateArchive serializes the state into an archive. If passphrase is not
+// empty, the archive is encrypted.
+func MarshalStateArchive(state ControllerState, passphrase string) ([]byte, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling state")
+	}
+	archive := stateArchive{
+		Version: state.Version,
+	}
+	if passphrase == "" {
+		archive.State = data
+	} else {
+		salt := make([]byte, archiveSaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, errors.Wrap(err, "generating salt")
+		}
+		sealed, err := util.Seal(data, archiveKey(passphrase, salt))
+		if err != nil {
+			return nil, errors.Wrap(err, "encrypting state")
+		}
+		archive.Encrypted = true
+		archive.Salt = salt
+		archive.Sealed = sealed
+	}
+	return json.MarshalIndent(archive, "", "  ")
+}
+
+// UnmarshalStateArchive reads an archive produced by MarshalStateArchive.
+func UnmarshalStateArchive(data []byte, passphrase string) (ControllerState, error) {
+	var archive stateArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return ControllerState{}, errors.Wrap(err, "decoding archive")
+	}
+	if archive.Version < 1 || archive.Version > StateVersion {
+		return ControllerState{}, fmt.Errorf("unsupported archive version %d", archive.Version)
+	}
+
+	stateData := []byte(archive.State)
+	if archive.Encrypted {
+		if passphrase == "" {
+			return ControllerState{}, fmt.Errorf("archive is encrypted and no passphrase was given")
+		}
+		decrypted, err := util.Unseal(archive.Sealed, archiveKey(passphrase, archive.Salt))
+		if err != nil {
+			return ControllerState{}, errors.Wrap(err, "decrypting archive")
+		}
+		stateData = decrypted
+	}
+
+	var state ControllerState
+	if err := json.Unmarshal(stateData, &state); err != nil {
+		return ControllerState{}, errors.Wrap(err, "decoding state")
+	}
+	if state.Version != archive.Version {
+		return ControllerState{}, fmt.Errorf("archive version %d does not match state version %d", archive.Version, state.Version)
+	}
+	return state, nil
+}
+
+type StateNotificationSink struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description,omitempty"`
+	Format      params.NotificationFormat `json:"format"`
+	URL         string                    `json:"url"`
+	Headers     map[string]string         `json:"headers,omitempty"`
+	Rules       json.RawMessage           `json:"rules,omitempty"`
+	Enabled     bool                      `json:"enabled"`
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func testState() ControllerState {
+	return ControllerState{
+		Version: StateVersion,
+		Controller: StateController{
+			ControllerID: uuid.New(),
+		},
+		Credentials: []StateGithubCredential{
+			{
+				Name:   "test-creds",
+				Secret: json.RawMessage(`{"oauth2_token":"test-token"}`),
+			},
+		},
+	}
+}
+
+func TestStateArchivePlain(t *testing.T) {
+	state := testState()
+
+	data, err := MarshalStateArchive(state, "")
+	require.NoError(t, err)
+	require.Contains(t, string(data), "test-token")
+
+	restored, err := UnmarshalStateArchive(data, "")
+	require.NoError(t, err)
+	require.Equal(t, state.Controller.ControllerID, restored.Controller.ControllerID)
+	require.JSONEq(t, string(state.Credentials[0].Secret), string(restored.Credentials[0].Secret))
+}
+
+func TestStateArchiveEncrypted(t *testing.T) {
+	state := testState()
+
+	data, err := MarshalStateArchive(state, "archive-passphrase")
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "test-token")
+
+	restored, err := UnmarshalStateArchive(data, "archive-passphrase")
+	require.NoError(t, err)
+	require.Equal(t, state.Controller.ControllerID, restored.Controller.ControllerID)
+
+	_, err = UnmarshalStateArchive(data, "")
+	require.EqualError(t, err, "archive is encrypted and no passphrase was given")
+
+	_, err = UnmarshalStateArchive(data, "wrong-passphrase")
+	require.ErrorContains(t, err, "decrypting archive")
+}
+
+func TestStateArchiveUnsupportedVersion(t *testing.T) {
+	state := testState()
+	state.Version = StateVersion + 1
+
+	data, err := MarshalStateArchive(state, "")
+	require.NoError(t, err)
+
+	_, err = UnmarshalStateArchive(data, "")
+	require.EqualError(t, err, "unsupported archive version 2")
+}
@@ -16,6 +16,7 @@ package common
 
 import (
 	"context"
+	"time"
 
 	"github.com/cloudbase/garm/params"
 )
@@ -65,10 +66,10 @@ type EnterpriseStore interface {
 }
 
 type PoolStore interface {
-	// Probably a bad idea without some king of filter or at least pagination
-	// nolint:golangci-lint,godox
-	// TODO: add filter/pagination
 	ListAllPools(ctx context.Context) ([]params.Pool, error)
+	// ListPools returns a page of pools matching the filter, along with
+	// the cursor of the next page. The cursor is empty on the last page.
+	ListPools(ctx context.Context, filter params.PoolFilter) ([]params.Pool, string, error)
 	GetPoolByID(ctx context.Context, poolID string) (params.Pool, error)
 	DeletePoolByID(ctx context.Context, poolID string) error
 
@@ -84,8 +85,11 @@ type UserStore interface {
 	GetUserByID(ctx context.Context, userID string) (params.User, error)
 	GetAdminUser(ctx context.Context) (params.User, error)
 
+	ListUsers(ctx context.Context) ([]params.User, error)
+
 	CreateUser(ctx context.Context, user params.NewUserParams) (params.User, error)
 	UpdateUser(ctx context.Context, user string, param params.UpdateUserParams) (params.User, error)
+	DeleteUser(ctx context.Context, user string) error
 	HasAdminUser(ctx context.Context) bool
 }
 
@@ -94,11 +98,10 @@ type InstanceStore interface {
 	DeleteInstance(ctx context.Context, poolID string, instanceName string) error
 	UpdateInstance(ctx context.Context, instanceName string, param params.UpdateInstanceParams) (params.Instance, error)
 
-	// Probably a bad idea without some king of filter or at least pagination
-	//
-	// nolint:golangci-lint,godox
-	// TODO: add filter/pagination
 	ListAllInstances(ctx context.Context) ([]params.Instance, error)
+	// ListInstances returns a page of instances matching the filter, along with
+	// the cursor of the next page. The cursor is empty on the last page.
+	ListInstances(ctx context.Context, filter params.InstanceFilter) ([]params.Instance, string, error)
 
 	GetInstanceByName(ctx context.Context, instanceName string) (params.Instance, error)
 	AddInstanceEvent(ctx context.Context, instanceName string, event params.EventType, eventLevel params.EventLevel, eventMessage string) error
@@ -109,6 +112,9 @@ type JobsStore interface {
 	ListEntityJobsByStatus(ctx context.Context, entityType params.GithubEntityType, entityID string, status params.JobStatus) ([]params.Job, error)
 	ListJobsByStatus(ctx context.Context, status params.JobStatus) ([]params.Job, error)
 	ListAllJobs(ctx context.Context) ([]params.Job, error)
+	// ListJobs returns a page of jobs matching the filter, along with
+	// the cursor of the next page. The cursor is empty on the last page.
+	ListJobs(ctx context.Context, filter params.JobFilter) ([]params.Job, string, error)
 
 	GetJobByID(ctx context.Context, jobID int64) (params.Job, error)
 	DeleteJob(ctx context.Context, jobID int64) error
@@ -117,6 +123,13 @@ type JobsStore interface {
 	BreakLockJobIsQueued(ctx context.Context, jobID int64) error
 
 	DeleteCompletedJobs(ctx context.Context) error
+
+	// ListJobHistory returns a page of job history entries matching the filter,
+	// along with the cursor of the next page.
+	ListJobHistory(ctx context.Context, filter params.JobHistoryFilter) ([]params.JobHistory, string, error)
+	// PurgeJobHistory removes history entries last updated before olderThan and
+	// returns the number of removed entries.
+	PurgeJobHistory(ctx context.Context, olderThan time.Time) (int64, error)
 }
 
 type EntityPoolStore interface {
@@ -124,6 +137,9 @@ type EntityPoolStore interface {
 	GetEntityPool(ctx context.Context, entity params.GithubEntity, poolID string) (params.Pool, error)
 	DeleteEntityPool(ctx context.Context, entity params.GithubEntity, poolID string) error
 	UpdateEntityPool(ctx context.Context, entity params.GithubEntity, poolID string, param params.UpdatePoolParams) (params.Pool, error)
+	// QuarantineEntityPool disables the pool and records the reason. Enabling the pool
+	// through UpdateEntityPool releases it from quarantine.
+	QuarantineEntityPool(ctx context.Context, entity params.GithubEntity, poolID string, reason string) (params.Pool, error)
 
 	ListEntityPools(ctx context.Context, entity params.GithubEntity) ([]params.Pool, error)
 	ListEntityInstances(ctx context.Context, entity params.GithubEntity) ([]params.Instance, error)
@@ -132,7 +148,43 @@ type EntityPoolStore interface {
 type ControllerStore interface {
 	ControllerInfo() (params.ControllerInfo, error)
 	InitController() (params.ControllerInfo, error)
-	UpdateController(info params.UpdateControllerParams) (params.ControllerInfo, error)
+	UpdateController(ctx context.Context, info params.UpdateControllerParams) (params.ControllerInfo, error)
+}
+
+type LeaseStore interface {
+	AcquireLease(ctx context.Context, name, holderID string, duration time.Duration) (params.ControllerLease, error)
+	ReleaseLease(ctx context.Context, name, holderID string) error
+	GetLease(ctx context.Context, name string) (params.ControllerLease, error)
+}
+
+type AuditStore interface {
+	RecordAuditEntry(ctx context.Context, entry params.AuditEntry) (params.AuditEntry, error)
+	// ListAuditEntries returns a page of audit entries matching the filter, oldest
+	// first, along with the cursor of the next page.
+	ListAuditEntries(ctx context.Context, filter params.AuditFilter) ([]params.AuditEntry, string, error)
+	// PurgeAuditEntries removes entries recorded before olderThan and returns the
+	// number of removed entries.
+	PurgeAuditEntries(ctx context.Context, olderThan time.Time) (int64, error)
+}
+
+type NotificationSinkStore interface {
+	CreateNotificationSink(ctx context.Context, param params.CreateNotificationSinkParams) (params.NotificationSink, error)
+	GetNotificationSink(ctx context.Context, id uint) (params.NotificationSink, error)
+	ListNotificationSinks(ctx context.Context) ([]params.NotificationSink, error)
+	UpdateNotificationSink(ctx context.Context, id uint, param params.UpdateNotificationSinkParams) (params.NotificationSink, error)
+	DeleteNotificationSink(ctx context.Context, id uint) error
+}
+
+type StateStore interface {
+	// ExportState returns the configuration of the controller, with all secrets
+	// in plain text.
+	ExportState(ctx context.Context) (ControllerState, error)
+	// ImportState loads a previously exported configuration into an empty
+	// database, sealing secrets with the passphrase of this database.
+	ImportState(ctx context.Context, state ControllerState) error
+	// RotatePassphrase re-encrypts all secrets, which are currently sealed with
+	// oldPassphrase, using the passphrase the database was opened with.
+	RotatePassphrase(ctx context.Context, oldPassphrase string) error
 }
 
 //go:generate mockery --name=Store
@@ -148,6 +200,10 @@ type Store interface {
 	GithubCredentialsStore
 	ControllerStore
 	EntityPoolStore
+	LeaseStore
+	StateStore
+	AuditStore
+	NotificationSinkStore
 
 	ControllerInfo() (params.ControllerInfo, error)
 	InitController() (params.ControllerInfo, error)
@@ -19,18 +19,39 @@ const (
 	ControllerEntityType        DatabaseEntityType = "controller"
 	GithubCredentialsEntityType DatabaseEntityType = "github_credentials" // #nosec G101
 	GithubEndpointEntityType    DatabaseEntityType = "github_endpoint"
+	NotificationSinkEntityType  DatabaseEntityType = "notification_sink"
+	// NotificationEventEntityType is not a database entity. Notification events
+	// are sent through the watcher by the pool managers.
+	NotificationEventEntityType DatabaseEntityType = "notification_event"
 )
 
 const (
 	CreateOperation OperationType = "create"
 	UpdateOperation OperationType = "update"
 	DeleteOperation OperationType = "delete"
+	// ResyncOperation is sent by the watcher to a consumer that fell behind and
+	// had events dropped from its buffer. A consumer that receives this event
+	// should reload any state it derives from the database. The payload is a
+	// ResyncPayload.
+	ResyncOperation OperationType = "resync"
 )
 
 type ChangePayload struct {
-	EntityType DatabaseEntityType
-	Operation  OperationType
-	Payload    interface{}
+	// Seq is a monotonically increasing sequence number assigned by the watcher
+	// to each event, in the order in which events are received from producers.
+	Seq        uint64             `json:"seq"`
+	EntityType DatabaseEntityType `json:"entity_type"`
+	Operation  OperationType      `json:"operation"`
+	Payload    interface{}        `json:"payload"`
+}
+
+// ResyncPayload is the payload of a resync event.
+type ResyncPayload struct {
+	// Missed is the number of events that were dropped for this consumer.
+	Missed uint64 `json:"missed"`
+	// LastMissedSeq is the sequence number of the newest dropped event. Events
+	// received after the resync event all have a greater sequence number.
+	LastMissedSeq uint64 `json:"last_missed_seq"`
 }
 
 type Consumer interface {
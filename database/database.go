@@ -19,15 +19,34 @@ import (
 	"fmt"
 
 	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/database/audit"
 	"github.com/cloudbase/garm/database/common"
 	"github.com/cloudbase/garm/database/sql"
 )
 
+// NewDatabase returns the store for the configured database. Changes made through
+// the store are recorded in the audit log.
 func NewDatabase(ctx context.Context, cfg config.Database) (common.Store, error) {
 	dbBackend := cfg.DbBackend
 	switch dbBackend {
-	case config.MySQLBackend, config.SQLiteBackend:
-		return sql.NewSQLDatabase(ctx, cfg)
+	case config.MySQLBackend, config.SQLiteBackend, config.PostgresBackend:
+		store, err := sql.NewSQLDatabase(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return audit.NewStore(store), nil
+	default:
+		return nil, fmt.Errorf("db backend not available: %s", dbBackend)
+	}
+}
+
+// NewSchemaMigrator returns a SchemaMigrator for the configured database. Unlike
+// NewDatabase, it does not apply any pending migrations.
+func NewSchemaMigrator(ctx context.Context, cfg config.Database) (common.SchemaMigrator, error) {
+	dbBackend := cfg.DbBackend
+	switch dbBackend {
+	case config.MySQLBackend, config.SQLiteBackend, config.PostgresBackend:
+		return sql.NewSchemaMigrator(ctx, cfg)
 	default:
 		return nil, fmt.Errorf("db backend not available: %s", dbBackend)
 	}
@@ -0,0 +1,54 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/database/watcher"
+	garmTesting "github.com/cloudbase/garm/internal/testing"
+)
+
+func init() {
+	watcher.SetWatcher(&garmTesting.MockWatcher{})
+}
+
+// TestNewDatabase opens the store through NewDatabase, using the backend given by
+// GARM_TEST_DB_BACKEND, and stores GitHub credentials, which hold a binary payload.
+func TestNewDatabase(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewDatabase(ctx, garmTesting.GetTestDBConfig(t))
+	require.Nil(t, err)
+
+	adminCtx := garmTesting.ImpersonateAdminContext(ctx, store, t)
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(adminCtx, store, t)
+	creds := garmTesting.CreateTestGithubCredentials(adminCtx, "test-creds", store, t, endpoint)
+
+	fetched, err := store.GetGithubCredentialsByName(adminCtx, creds.Name, true)
+	require.Nil(t, err)
+	require.Equal(t, creds.ID, fetched.ID)
+	require.NotEmpty(t, fetched.CredentialsPayload)
+}
+
+func TestNewDatabaseUnknownBackend(t *testing.T) {
+	_, err := NewDatabase(context.Background(), config.Database{DbBackend: "unknown"})
+
+	require.NotNil(t, err)
+	require.Equal(t, "db backend not available: unknown", err.Error())
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/params"
+)
+
+func sqlToParamsAuditEntry(entry AuditEntry) (params.AuditEntry, error) {
+	ret := params.AuditEntry{
+		ID:         entry.ID,
+		UserID:     entry.UserID,
+		Actor:      entry.Actor,
+		Action:     params.AuditAction(entry.Action),
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		EntityName: entry.EntityName,
+		SourceIP:   entry.SourceIP,
+		CreatedAt:  entry.CreatedAt,
+	}
+	if len(entry.Changes) > 0 {
+		if err := json.Unmarshal(entry.Changes, &ret.Changes); err != nil {
+			return params.AuditEntry{}, errors.Wrap(err, "unmarshaling changes")
+		}
+	}
+	return ret, nil
+}
+
+func (s *sqlDatabase) RecordAuditEntry(_ context.Context, entry params.AuditEntry) (params.AuditEntry, error) {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return params.AuditEntry{}, errors.Wrap(err, "marshaling changes")
+	}
+	newEntry := AuditEntry{
+		UserID:     entry.UserID,
+		Actor:      entry.Actor,
+		Action:     string(entry.Action),
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		EntityName: entry.EntityName,
+		Changes:    changes,
+		SourceIP:   entry.SourceIP,
+	}
+	if err := s.conn.Create(&newEntry).Error; err != nil {
+		return params.AuditEntry{}, errors.Wrap(err, "creating audit entry")
+	}
+	return sqlToParamsAuditEntry(newEntry)
+}
+
+func (s *sqlDatabase) ListAuditEntries(_ context.Context, filter params.AuditFilter) ([]params.AuditEntry, string, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, "", errors.Wrap(err, "validating filter")
+	}
+
+	query := s.conn.Model(&AuditEntry{})
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	query = applyTimeRange(query, filter.TimeRangeFilter)
+
+	query, err := paginate(query, filter.ListParams, parseAuditCursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fetching audit entries")
+	}
+
+	var entries []AuditEntry
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, "", errors.Wrap(err, "fetching audit entries")
+	}
+	entries, next := nextPage(entries, filter.ListParams, func(e AuditEntry) string { return strconv.FormatUint(uint64(e.ID), 10) })
+
+	ret := make([]params.AuditEntry, len(entries))
+	for idx, entry := range entries {
+		ret[idx], err = sqlToParamsAuditEntry(entry)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "converting audit entry")
+		}
+	}
+	return ret, next, nil
+}
+
+// PurgeAuditEntries removes audit entries that were recorded before olderThan.
+func (s *sqlDatabase) PurgeAuditEntries(_ context.Context, olderThan time.Time) (int64, error) {
+	q := s.conn.Where("created_at < ?", olderThan).Delete(&AuditEntry{})
+	if q.Error != nil {
+		return 0, errors.Wrap(q.Error, "purging audit entries")
+	}
+	return q.RowsAffected, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+type AuditTestSuite struct {
+	suite.Suite
+	Store   dbCommon.Store
+	entries []params.AuditEntry
+}
+
+func auditEntryIDs(entries []params.AuditEntry) []uint {
+	ids := make([]uint, len(entries))
+	for idx, entry := range entries {
+		ids[idx] = entry.ID
+	}
+	return ids
+}
+
+func (s *AuditTestSuite) SetupTest() {
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+
+	s.entries = nil
+	for _, entry := range []params.AuditEntry{
+		{UserID: "user-1", Action: params.AuditActionCreate, EntityType: "pool", EntityID: "pool-1", SourceIP: "10.0.0.1"},
+		{UserID: "user-1", Action: params.AuditActionUpdate, EntityType: "pool", EntityID: "pool-1", SourceIP: "10.0.0.1", Changes: []params.AuditChange{{Field: "max_runners", Before: float64(1), After: float64(5)}}},
+		{UserID: "user-2", Action: params.AuditActionDelete, EntityType: "repository", EntityID: "repo-1", EntityName: "owner/repo"},
+		{Action: params.AuditActionUpdate, EntityType: "instance", EntityID: "instance-1", Changes: []params.AuditChange{{Field: "status", Before: "creating", After: "running"}}},
+	} {
+		recorded, err := s.Store.RecordAuditEntry(context.Background(), entry)
+		if err != nil {
+			s.FailNow(fmt.Sprintf("failed to record audit entry: %s", err))
+		}
+		s.entries = append(s.entries, recorded)
+	}
+}
+
+func (s *AuditTestSuite) TestRecordAuditEntry() {
+	entry := s.entries[1]
+
+	s.Require().NotZero(entry.ID)
+	s.Require().False(entry.CreatedAt.IsZero())
+	s.Require().Equal("user-1", entry.UserID)
+	s.Require().Equal("10.0.0.1", entry.SourceIP)
+	s.Require().Equal([]params.AuditChange{{Field: "max_runners", Before: float64(1), After: float64(5)}}, entry.Changes)
+}
+
+func (s *AuditTestSuite) TestListAuditEntries() {
+	entries, next, err := s.Store.ListAuditEntries(context.Background(), params.AuditFilter{})
+
+	s.Require().Nil(err)
+	s.Require().Empty(next)
+	s.Require().Equal(auditEntryIDs(s.entries), auditEntryIDs(entries))
+	s.Require().Equal(s.entries[1].Changes, entries[1].Changes)
+}
+
+func (s *AuditTestSuite) TestListAuditEntriesFilters() {
+	tests := []struct {
+		name     string
+		filter   params.AuditFilter
+		expected []params.AuditEntry
+	}{
+		{"user", params.AuditFilter{UserID: "user-1"}, s.entries[:2]},
+		{"action", params.AuditFilter{Action: params.AuditActionUpdate}, []params.AuditEntry{s.entries[1], s.entries[3]}},
+		{"entity type", params.AuditFilter{EntityType: "repository"}, s.entries[2:3]},
+		{"entity", params.AuditFilter{EntityType: "pool", EntityID: "pool-1", Action: params.AuditActionCreate}, s.entries[:1]},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			entries, _, err := s.Store.ListAuditEntries(context.Background(), tc.filter)
+			s.Require().Nil(err)
+			s.Require().Equal(auditEntryIDs(tc.expected), auditEntryIDs(entries))
+		})
+	}
+}
+
+func (s *AuditTestSuite) TestListAuditEntriesCreatedRange() {
+	future := time.Now().Add(time.Hour)
+
+	entries, _, err := s.Store.ListAuditEntries(context.Background(), params.AuditFilter{
+		TimeRangeFilter: params.TimeRangeFilter{CreatedAfter: &future},
+	})
+
+	s.Require().Nil(err)
+	s.Require().Empty(entries)
+}
+
+func (s *AuditTestSuite) TestListAuditEntriesUpdatedRangeFails() {
+	now := time.Now()
+
+	_, _, err := s.Store.ListAuditEntries(context.Background(), params.AuditFilter{
+		TimeRangeFilter: params.TimeRangeFilter{UpdatedAfter: &now},
+	})
+
+	s.Require().NotNil(err)
+	s.Require().Equal("validating filter: audit entries can only be filtered by creation time", err.Error())
+}
+
+func (s *AuditTestSuite) TestListAuditEntriesPagination() {
+	filter := params.AuditFilter{ListParams: params.ListParams{Limit: 3}}
+
+	first, next, err := s.Store.ListAuditEntries(context.Background(), filter)
+	s.Require().Nil(err)
+	s.Require().Equal(auditEntryIDs(s.entries[:3]), auditEntryIDs(first))
+	s.Require().NotEmpty(next)
+
+	filter.Cursor = next
+	second, next, err := s.Store.ListAuditEntries(context.Background(), filter)
+	s.Require().Nil(err)
+	s.Require().Equal(auditEntryIDs(s.entries[3:]), auditEntryIDs(second))
+	s.Require().Empty(next)
+}
+
+func (s *AuditTestSuite) TestListAuditEntriesInvalidCursor() {
+	_, _, err := s.Store.ListAuditEntries(context.Background(), params.AuditFilter{ListParams: params.ListParams{Cursor: "not-a-number"}})
+
+	s.Require().NotNil(err)
+}
+
+func (s *AuditTestSuite) TestPurgeAuditEntries() {
+	removed, err := s.Store.PurgeAuditEntries(context.Background(), time.Now().Add(-time.Hour))
+	s.Require().Nil(err)
+	s.Require().Equal(int64(0), removed)
+
+	removed, err = s.Store.PurgeAuditEntries(context.Background(), time.Now().Add(time.Hour))
+	s.Require().Nil(err)
+	s.Require().Equal(int64(len(s.entries)), removed)
+
+	entries, _, err := s.Store.ListAuditEntries(context.Background(), params.AuditFilter{})
+	s.Require().Nil(err)
+	s.Require().Empty(entries)
+}
+
+func TestAuditTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditTestSuite))
+}
@@ -15,6 +15,7 @@
 package sql
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/google/uuid"
@@ -83,7 +84,7 @@ func (s *sqlDatabase) InitController() (params.ControllerInfo, error) {
 	}, nil
 }
 
-func (s *sqlDatabase) UpdateController(info params.UpdateControllerParams) (paramInfo params.ControllerInfo, err error) {
+func (s *sqlDatabase) UpdateController(_ context.Context, info params.UpdateControllerParams) (paramInfo params.ControllerInfo, err error) {
 	defer func() {
 		if err == nil {
 			s.sendNotify(common.ControllerEntityType, common.UpdateOperation, paramInfo)
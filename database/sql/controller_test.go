@@ -32,7 +32,7 @@ type CtrlTestSuite struct {
 }
 
 func (s *CtrlTestSuite) SetupTest() {
-	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
 	if err != nil {
 		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
 	}
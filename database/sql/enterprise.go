@@ -16,6 +16,7 @@ package sql
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
 
 	"github.com/google/uuid"
@@ -201,6 +202,22 @@ func (s *sqlDatabase) UpdateEnterprise(ctx context.Context, enterpriseID string,
 			enterprise.PoolBalancerType = param.PoolBalancerType
 		}
 
+		if param.MaxConcurrentRunners != nil {
+			enterprise.MaxConcurrentRunners = *param.MaxConcurrentRunners
+		}
+
+		if param.MaxPendingRunners != nil {
+			enterprise.MaxPendingRunners = *param.MaxPendingRunners
+		}
+
+		if param.FairShare != nil {
+			fairShare, err := json.Marshal(param.FairShare)
+			if err != nil {
+				return errors.Wrap(err, "marshaling fair share settings")
+			}
+			enterprise.FairShare = fairShare
+		}
+
 		q := tx.Save(&enterprise)
 		if q.Error != nil {
 			return errors.Wrap(q.Error, "saving enterprise")
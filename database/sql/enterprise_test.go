@@ -77,8 +77,8 @@ func (s *EnterpriseTestSuite) assertSQLMockExpectations() {
 }
 
 func (s *EnterpriseTestSuite) SetupTest() {
-	// create testing sqlite database
-	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	// create testing database
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
 	if err != nil {
 		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
 	}
@@ -194,7 +194,7 @@ func (s *EnterpriseTestSuite) TestCreateEnterprise() {
 }
 
 func (s *EnterpriseTestSuite) TestCreateEnterpriseInvalidDBPassphrase() {
-	cfg := garmTesting.GetTestSqliteDBConfig(s.T())
+	cfg := garmTesting.GetTestDBConfig(s.T())
 	conn, err := newDBConn(cfg)
 	if err != nil {
 		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
@@ -341,6 +341,19 @@ func (s *EnterpriseTestSuite) TestUpdateEnterprise() {
 	s.Require().Equal(s.Fixtures.UpdateRepoParams.WebhookSecret, enterprise.WebhookSecret)
 }
 
+func (s *EnterpriseTestSuite) TestUpdateEnterpriseRunnerLimits() {
+	maxConcurrent := uint(20)
+	param := s.Fixtures.UpdateRepoParams
+	param.MaxConcurrentRunners = &maxConcurrent
+	_, err := s.Store.UpdateEnterprise(s.adminCtx, s.Fixtures.Enterprises[0].ID, param)
+	s.Require().Nil(err)
+
+	enterprise, err := s.Store.GetEnterpriseByID(s.adminCtx, s.Fixtures.Enterprises[0].ID)
+	s.Require().Nil(err)
+	s.Require().Equal(maxConcurrent, enterprise.MaxConcurrentRunners)
+	s.Require().Equal(uint(0), enterprise.MaxPendingRunners)
+}
+
 func (s *EnterpriseTestSuite) TestUpdateEnterpriseInvalidEnterpriseID() {
 	_, err := s.Store.UpdateEnterprise(s.adminCtx, "dummy-enterprise-id", s.Fixtures.UpdateRepoParams)
 
@@ -0,0 +1,139 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/params"
+)
+
+// Pagination is keyset based. Results are ordered by primary key and the
+// cursor holds the primary key of the last item of a page. This keeps pages
+// stable while rows are being added or removed, and avoids the cost of large
+// offsets. The order is not meaningful for UUID keys, but it is consistent.
+
+func encodeCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	id, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(id) == 0 {
+		return "", runnerErrors.NewBadRequestError("invalid cursor")
+	}
+	return string(id), nil
+}
+
+// paginate orders the query by primary key and applies the cursor and limit.
+// One extra row is fetched so we know if another page follows.
+func paginate(q *gorm.DB, opts params.ListParams, parseID func(string) (interface{}, error)) (*gorm.DB, error) {
+	q = q.Order("id asc")
+	if opts.Cursor != "" {
+		raw, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		id, err := parseID(raw)
+		if err != nil {
+			return nil, runnerErrors.NewBadRequestError("invalid cursor")
+		}
+		q = q.Where("id > ?", id)
+	}
+	if opts.Limit > 0 {
+		q = q.Limit(int(opts.Limit) + 1)
+	}
+	return q, nil
+}
+
+// nextPage trims the extra row fetched by paginate and returns the cursor
+// of the next page, if any.
+func nextPage[T any](rows []T, opts params.ListParams, idOf func(T) string) ([]T, string) {
+	if opts.Limit == 0 || uint(len(rows)) <= opts.Limit {
+		return rows, ""
+	}
+	rows = rows[:opts.Limit]
+	return rows, encodeCursor(idOf(rows[len(rows)-1]))
+}
+
+func parseUUIDCursor(raw string) (interface{}, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing id")
+	}
+	return id, nil
+}
+
+func parseJobCursor(raw string) (interface{}, error) {
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing job ID")
+	}
+	return id, nil
+}
+
+func parseAuditCursor(raw string) (interface{}, error) {
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing audit entry ID")
+	}
+	return id, nil
+}
+
+func applyTimeRange(q *gorm.DB, f params.TimeRangeFilter) *gorm.DB {
+	if f.CreatedAfter != nil {
+		q = q.Where("created_at >= ?", *f.CreatedAfter)
+	}
+	if f.CreatedBefore != nil {
+		q = q.Where("created_at < ?", *f.CreatedBefore)
+	}
+	if f.UpdatedAfter != nil {
+		q = q.Where("updated_at >= ?", *f.UpdatedAfter)
+	}
+	if f.UpdatedBefore != nil {
+		q = q.Where("updated_at < ?", *f.UpdatedBefore)
+	}
+	return q
+}
+
+// poolsWithTag is a subquery returning the IDs of pools that have the given tag.
+func (s *sqlDatabase) poolsWithTag(tag string) *gorm.DB {
+	return s.conn.Table("pool_tags").
+		Select("pool_tags.pool_id").
+		Joins("join tags on tags.id = pool_tags.tag_id").
+		Where("tags.name = ?", tag)
+}
+
+// jsonArrayContains returns a condition matching rows where the JSON array
+// stored in column contains the given string. JSON functions differ between
+// backends, so we need a variant for each of them.
+func (s *sqlDatabase) jsonArrayContains(column string) string {
+	switch s.cfg.DbBackend {
+	case config.MySQLBackend:
+		return fmt.Sprintf("JSON_CONTAINS(%s, JSON_QUOTE(?))", column)
+	case config.PostgresBackend:
+		return fmt.Sprintf("%s @> jsonb_build_array(CAST(? AS text))", column)
+	default:
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s) WHERE json_each.value = ?)", column)
+	}
+}
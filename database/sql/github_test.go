@@ -49,7 +49,7 @@ type GithubTestSuite struct {
 }
 
 func (s *GithubTestSuite) SetupTest() {
-	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
 	if err != nil {
 		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
 	}
@@ -288,22 +288,57 @@ func (s *sqlDatabase) ListPoolInstances(_ context.Context, poolID string) ([]par
 	return ret, nil
 }
 
-func (s *sqlDatabase) ListAllInstances(_ context.Context) ([]params.Instance, error) {
-	var instances []Instance
+func (s *sqlDatabase) ListAllInstances(ctx context.Context) ([]params.Instance, error) {
+	instances, _, err := s.ListInstances(ctx, params.InstanceFilter{})
+	return instances, err
+}
 
-	q := s.conn.Model(&Instance{}).Preload("Job").Find(&instances)
-	if q.Error != nil {
-		return nil, errors.Wrap(q.Error, "fetching instances")
+func (s *sqlDatabase) ListInstances(_ context.Context, filter params.InstanceFilter) ([]params.Instance, string, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, "", errors.Wrap(err, "validating filter")
+	}
+
+	q := s.conn.Model(&Instance{}).Preload("Job")
+	if filter.Status != "" {
+		q = q.Where("status = ?", filter.Status)
+	}
+	if filter.RunnerStatus != "" {
+		q = q.Where("runner_status = ?", filter.RunnerStatus)
+	}
+	if filter.PoolID != "" {
+		poolID, err := uuid.Parse(filter.PoolID)
+		if err != nil {
+			return nil, "", errors.Wrap(runnerErrors.ErrBadRequest, "parsing pool id")
+		}
+		q = q.Where("pool_id = ?", poolID)
+	}
+	if filter.ProviderName != "" {
+		q = q.Where("pool_id in (?)", s.conn.Model(&Pool{}).Select("id").Where("provider_name = ?", filter.ProviderName))
 	}
+	if filter.Label != "" {
+		q = q.Where("pool_id in (?)", s.poolsWithTag(filter.Label))
+	}
+	q = applyTimeRange(q, filter.TimeRangeFilter)
+
+	q, err := paginate(q, filter.ListParams, parseUUIDCursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fetching instances")
+	}
+
+	var instances []Instance
+	if err := q.Find(&instances).Error; err != nil {
+		return nil, "", errors.Wrap(err, "fetching instances")
+	}
+	instances, next := nextPage(instances, filter.ListParams, func(i Instance) string { return i.ID.String() })
+
 	ret := make([]params.Instance, len(instances))
-	var err error
 	for idx, instance := range instances {
 		ret[idx], err = s.sqlToParamsInstance(instance)
 		if err != nil {
-			return nil, errors.Wrap(err, "converting instance")
+			return nil, "", errors.Wrap(err, "converting instance")
 		}
 	}
-	return ret, nil
+	return ret, next, nil
 }
 
 func (s *sqlDatabase) PoolInstanceCount(_ context.Context, poolID string) (int64, error) {
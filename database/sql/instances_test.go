@@ -21,6 +21,7 @@ import (
 	"regexp"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
@@ -70,8 +71,8 @@ func (s *InstancesTestSuite) assertSQLMockExpectations() {
 }
 
 func (s *InstancesTestSuite) SetupTest() {
-	// create testing sqlite database
-	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	// create testing database
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
 	if err != nil {
 		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
 	}
@@ -535,6 +536,91 @@ func (s *InstancesTestSuite) TestListAllInstancesDBFetchErr() {
 	s.Require().Equal("fetching instances: fetch instances mock error", err.Error())
 }
 
+func (s *InstancesTestSuite) TestListInstancesPagination() {
+	filter := params.InstanceFilter{ListParams: params.ListParams{Limit: 2}}
+
+	page1, next, err := s.Store.ListInstances(s.adminCtx, filter)
+	s.Require().Nil(err)
+	s.Require().Len(page1, 2)
+	s.Require().NotEmpty(next)
+
+	filter.Cursor = next
+	page2, next, err := s.Store.ListInstances(s.adminCtx, filter)
+	s.Require().Nil(err)
+	s.Require().Len(page2, 1)
+	s.Require().Empty(next)
+
+	s.equalInstancesByName(s.Fixtures.Instances, append(page1, page2...))
+}
+
+func (s *InstancesTestSuite) TestListInstancesLimitMatchesTotal() {
+	instances, next, err := s.Store.ListInstances(s.adminCtx, params.InstanceFilter{ListParams: params.ListParams{Limit: 3}})
+
+	s.Require().Nil(err)
+	s.Require().Len(instances, 3)
+	s.Require().Empty(next)
+}
+
+func (s *InstancesTestSuite) TestListInstancesInvalidCursor() {
+	_, _, err := s.Store.ListInstances(s.adminCtx, params.InstanceFilter{ListParams: params.ListParams{Cursor: "not-a-cursor!"}})
+
+	s.Require().Equal("fetching instances: invalid cursor", err.Error())
+}
+
+func (s *InstancesTestSuite) TestListInstancesFilters() {
+	_, err := s.Store.UpdateInstance(s.adminCtx, s.Fixtures.Instances[0].Name, params.UpdateInstanceParams{
+		Status:       commonParams.InstanceStopped,
+		RunnerStatus: params.RunnerActive,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name     string
+		filter   params.InstanceFilter
+		expected []params.Instance
+	}{
+		{"status", params.InstanceFilter{Status: commonParams.InstanceStopped}, s.Fixtures.Instances[:1]},
+		{"runner status", params.InstanceFilter{RunnerStatus: params.RunnerIdle}, s.Fixtures.Instances[1:]},
+		{"pool", params.InstanceFilter{PoolID: s.Fixtures.Pool.ID}, s.Fixtures.Instances},
+		{"provider", params.InstanceFilter{ProviderName: "test-provider"}, s.Fixtures.Instances},
+		{"unknown provider", params.InstanceFilter{ProviderName: "dummy-provider"}, nil},
+		{"label", params.InstanceFilter{Label: "amd64"}, s.Fixtures.Instances},
+		{"unknown label", params.InstanceFilter{Label: "arm64"}, nil},
+	}
+	for _, tc := range tests {
+		instances, _, err := s.Store.ListInstances(s.adminCtx, tc.filter)
+		s.Require().Nil(err, tc.name)
+		s.equalInstancesByName(append([]params.Instance{}, tc.expected...), instances)
+	}
+}
+
+func (s *InstancesTestSuite) TestListInstancesTimeRange() {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	instances, _, err := s.Store.ListInstances(s.adminCtx, params.InstanceFilter{
+		TimeRangeFilter: params.TimeRangeFilter{CreatedAfter: &past, CreatedBefore: &future},
+	})
+	s.Require().Nil(err)
+	s.equalInstancesByName(s.Fixtures.Instances, instances)
+
+	instances, _, err = s.Store.ListInstances(s.adminCtx, params.InstanceFilter{
+		TimeRangeFilter: params.TimeRangeFilter{UpdatedAfter: &future},
+	})
+	s.Require().Nil(err)
+	s.Require().Len(instances, 0)
+}
+
+func (s *InstancesTestSuite) TestListInstancesInvalidTimeRange() {
+	now := time.Now()
+
+	_, _, err := s.Store.ListInstances(s.adminCtx, params.InstanceFilter{
+		TimeRangeFilter: params.TimeRangeFilter{CreatedAfter: &now, CreatedBefore: &now},
+	})
+
+	s.Require().Equal("validating filter: created_after must be before created_before", err.Error())
+}
+
 func (s *InstancesTestSuite) TestPoolInstanceCount() {
 	instancesCount, err := s.Store.PoolInstanceCount(s.adminCtx, s.Fixtures.Pool.ID)
 
@@ -0,0 +1,190 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/params"
+)
+
+func sqlToParamsJobHistory(entry JobHistory) (params.JobHistory, error) {
+	labels := []string{}
+	if entry.Labels != nil {
+		if err := json.Unmarshal(entry.Labels, &labels); err != nil {
+			return params.JobHistory{}, errors.Wrap(err, "unmarshaling labels")
+		}
+	}
+
+	return params.JobHistory{
+		ID:              entry.ID,
+		RunID:           entry.RunID,
+		Name:            entry.Name,
+		WorkflowName:    entry.WorkflowName,
+		Status:          entry.Status,
+		Conclusion:      entry.Conclusion,
+		RepositoryName:  entry.RepositoryName,
+		RepositoryOwner: entry.RepositoryOwner,
+		Labels:          labels,
+		PoolID:          entry.PoolID,
+		ProviderName:    entry.ProviderName,
+		RunnerName:      entry.RunnerName,
+		QueuedAt:        entry.QueuedAt,
+		StartedAt:       entry.StartedAt,
+		CompletedAt:     entry.CompletedAt,
+		CreatedAt:       entry.CreatedAt,
+		UpdatedAt:       entry.UpdatedAt,
+	}, nil
+}
+
+// recordJobHistory creates or updates the history entry of a job. It is called
+// every time a job is saved, so the history follows the job through its lifecycle
+// and survives the removal of the job once it completes.
+func (s *sqlDatabase) recordJobHistory(job WorkflowJob, runnerName string) error {
+	var entry JobHistory
+	q := s.conn.Where("id = ?", job.ID).First(&entry)
+	if q.Error != nil {
+		if !errors.Is(q.Error, gorm.ErrRecordNotFound) {
+			return errors.Wrap(q.Error, "fetching job history")
+		}
+		entry = JobHistory{
+			ID:       job.ID,
+			QueuedAt: job.CreatedAt,
+		}
+	}
+
+	entry.RunID = job.RunID
+	entry.Name = job.Name
+	entry.Status = job.Status
+	entry.Conclusion = job.Conclusion
+	entry.RepositoryName = job.RepositoryName
+	entry.RepositoryOwner = job.RepositoryOwner
+	entry.Labels = job.Labels
+	if job.WorkflowName != "" {
+		entry.WorkflowName = job.WorkflowName
+	}
+	if runnerName != "" {
+		entry.RunnerName = runnerName
+	}
+
+	switch params.JobStatus(job.Status) {
+	case params.JobStatusInProgress, params.JobStatusCompleted:
+		if !job.StartedAt.IsZero() {
+			startedAt := job.StartedAt
+			entry.StartedAt = &startedAt
+		}
+		if params.JobStatus(job.Status) == params.JobStatusCompleted && !job.CompletedAt.IsZero() {
+			completedAt := job.CompletedAt
+			entry.CompletedAt = &completedAt
+		}
+	}
+
+	if job.InstanceID != nil {
+		// The instance may be removed while the job is still running, so we
+		// also look at soft deleted instances and pools.
+		var instance Instance
+		err := s.conn.Unscoped().
+			Preload("Pool", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Where("id = ?", *job.InstanceID).
+			First(&instance).Error
+		switch {
+		case err == nil:
+			entry.RunnerName = instance.Name
+			entry.PoolID = instance.PoolID.String()
+			entry.ProviderName = instance.Pool.ProviderName
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return errors.Wrap(err, "fetching instance")
+		}
+	}
+
+	if err := s.conn.Save(&entry).Error; err != nil {
+		return errors.Wrap(err, "saving job history")
+	}
+	return nil
+}
+
+// updateJobHistory records the job in the history. Failing to do so is logged,
+// but does not prevent the job from being processed.
+func (s *sqlDatabase) updateJobHistory(ctx context.Context, job WorkflowJob, runnerName string) {
+	if err := s.recordJobHistory(job, runnerName); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			ctx, "failed to record job history", "job_id", job.ID)
+	}
+}
+
+func (s *sqlDatabase) ListJobHistory(_ context.Context, filter params.JobHistoryFilter) ([]params.JobHistory, string, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, "", errors.Wrap(err, "validating filter")
+	}
+
+	query := s.conn.Model(&JobHistory{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Conclusion != "" {
+		query = query.Where("conclusion = ?", filter.Conclusion)
+	}
+	if filter.PoolID != "" {
+		if _, err := uuid.Parse(filter.PoolID); err != nil {
+			return nil, "", errors.Wrap(runnerErrors.ErrBadRequest, "parsing pool id")
+		}
+		query = query.Where("pool_id = ?", filter.PoolID)
+	}
+	if filter.ProviderName != "" {
+		query = query.Where("provider_name = ?", filter.ProviderName)
+	}
+	if filter.Label != "" {
+		query = query.Where(s.jsonArrayContains("labels"), filter.Label)
+	}
+	query = applyTimeRange(query, filter.TimeRangeFilter)
+
+	query, err := paginate(query, filter.ListParams, parseJobCursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fetching job history")
+	}
+
+	var entries []JobHistory
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, "", errors.Wrap(err, "fetching job history")
+	}
+	entries, next := nextPage(entries, filter.ListParams, func(j JobHistory) string { return strconv.FormatInt(j.ID, 10) })
+
+	ret := make([]params.JobHistory, len(entries))
+	for idx, entry := range entries {
+		ret[idx], err = sqlToParamsJobHistory(entry)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "converting job history")
+		}
+	}
+	return ret, next, nil
+}
+
+// PurgeJobHistory removes history entries that were last updated before olderThan.
+func (s *sqlDatabase) PurgeJobHistory(_ context.Context, olderThan time.Time) (int64, error) {
+	q := s.conn.Where("updated_at < ?", olderThan).Delete(&JobHistory{})
+	if q.Error != nil {
+		return 0, errors.Wrap(q.Error, "purging job history")
+	}
+	return q.RowsAffected, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -31,6 +32,7 @@ func sqlWorkflowJobToParamsJob(job WorkflowJob) (params.Job, error) {
 		Action:          job.Action,
 		Status:          job.Status,
 		Name:            job.Name,
+		WorkflowName:    job.WorkflowName,
 		Conclusion:      job.Conclusion,
 		StartedAt:       job.StartedAt,
 		CompletedAt:     job.CompletedAt,
@@ -66,6 +68,7 @@ func (s *sqlDatabase) paramsJobToWorkflowJob(ctx context.Context, job params.Job
 		Action:          job.Action,
 		Status:          job.Status,
 		Name:            job.Name,
+		WorkflowName:    job.WorkflowName,
 		Conclusion:      job.Conclusion,
 		StartedAt:       job.StartedAt,
 		CompletedAt:     job.CompletedAt,
@@ -237,6 +240,10 @@ func (s *sqlDatabase) CreateOrUpdateJob(ctx context.Context, job params.Job) (pa
 		workflowJob.RunnerGroupID = job.RunnerGroupID
 		workflowJob.RunnerGroupName = job.RunnerGroupName
 
+		if job.WorkflowName != "" {
+			workflowJob.WorkflowName = job.WorkflowName
+		}
+
 		if job.LockedBy != uuid.Nil {
 			workflowJob.LockedBy = job.LockedBy
 		}
@@ -264,6 +271,7 @@ func (s *sqlDatabase) CreateOrUpdateJob(ctx context.Context, job params.Job) (pa
 		if err := s.conn.Save(&workflowJob).Error; err != nil {
 			return params.Job{}, errors.Wrap(err, "saving job")
 		}
+		s.updateJobHistory(ctx, workflowJob, job.RunnerName)
 	} else {
 		operation = common.CreateOperation
 
@@ -274,6 +282,7 @@ func (s *sqlDatabase) CreateOrUpdateJob(ctx context.Context, job params.Job) (pa
 		if err := s.conn.Create(&workflowJob).Error; err != nil {
 			return params.Job{}, errors.Wrap(err, "creating job")
 		}
+		s.updateJobHistory(ctx, workflowJob, job.RunnerName)
 	}
 
 	asParams, err := sqlWorkflowJobToParamsJob(workflowJob)
@@ -342,26 +351,59 @@ func (s *sqlDatabase) ListEntityJobsByStatus(_ context.Context, entityType param
 	return ret, nil
 }
 
-func (s *sqlDatabase) ListAllJobs(_ context.Context) ([]params.Job, error) {
-	var jobs []WorkflowJob
+func (s *sqlDatabase) ListAllJobs(ctx context.Context) ([]params.Job, error) {
+	jobs, _, err := s.ListJobs(ctx, params.JobFilter{})
+	return jobs, err
+}
+
+func (s *sqlDatabase) ListJobs(_ context.Context, filter params.JobFilter) ([]params.Job, string, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, "", errors.Wrap(err, "validating filter")
+	}
+
 	query := s.conn.Model(&WorkflowJob{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.PoolID != "" {
+		poolID, err := uuid.Parse(filter.PoolID)
+		if err != nil {
+			return nil, "", errors.Wrap(runnerErrors.ErrBadRequest, "parsing pool id")
+		}
+		query = query.Where("instance_id in (?)", s.conn.Model(&Instance{}).Select("id").Where("pool_id = ?", poolID))
+	}
+	if filter.ProviderName != "" {
+		pools := s.conn.Model(&Pool{}).Select("id").Where("provider_name = ?", filter.ProviderName)
+		query = query.Where("instance_id in (?)", s.conn.Model(&Instance{}).Select("id").Where("pool_id in (?)", pools))
+	}
+	if filter.Label != "" {
+		query = query.Where(s.jsonArrayContains("labels"), filter.Label)
+	}
+	query = applyTimeRange(query, filter.TimeRangeFilter)
+
+	query, err := paginate(query, filter.ListParams, parseJobCursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fetching jobs")
+	}
 
+	var jobs []WorkflowJob
 	if err := query.Preload("Instance").Find(&jobs); err.Error != nil {
 		if errors.Is(err.Error, gorm.ErrRecordNotFound) {
-			return []params.Job{}, nil
+			return []params.Job{}, "", nil
 		}
-		return nil, err.Error
+		return nil, "", err.Error
 	}
+	jobs, next := nextPage(jobs, filter.ListParams, func(j WorkflowJob) string { return strconv.FormatInt(j.ID, 10) })
 
 	ret := make([]params.Job, len(jobs))
 	for idx, job := range jobs {
 		jobParam, err := sqlWorkflowJobToParamsJob(job)
 		if err != nil {
-			return nil, errors.Wrap(err, "converting job")
+			return nil, "", errors.Wrap(err, "converting job")
 		}
 		ret[idx] = jobParam
 	}
-	return ret, nil
+	return ret, next, nil
 }
 
 // GetJobByID gets a job by id.
@@ -0,0 +1,211 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing"
+	"github.com/cloudbase/garm/params"
+)
+
+type JobsTestSuite struct {
+	suite.Suite
+	Store    dbCommon.Store
+	adminCtx context.Context
+	pool     params.Pool
+}
+
+func (s *JobsTestSuite) SetupTest() {
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+
+	adminCtx := garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+	s.adminCtx = adminCtx
+
+	githubEndpoint := garmTesting.CreateDefaultGithubEndpoint(adminCtx, db, s.T())
+	creds := garmTesting.CreateTestGithubCredentials(adminCtx, "new-creds", db, s.T(), githubEndpoint)
+
+	org, err := s.Store.CreateOrganization(s.adminCtx, "test-org", creds.Name, "test-webhookSecret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+	entity, err := org.GetEntity()
+	s.Require().Nil(err)
+
+	s.pool, err = s.Store.CreateEntityPool(s.adminCtx, entity, params.CreatePoolParams{
+		ProviderName:   "test-provider",
+		MaxRunners:     4,
+		MinIdleRunners: 2,
+		Image:          "test-image",
+		Flavor:         "test-flavor",
+		OSType:         "linux",
+		Tags:           []string{"linux"},
+	})
+	s.Require().Nil(err)
+
+	_, err = s.Store.CreateInstance(s.adminCtx, s.pool.ID, params.CreateInstanceParams{
+		Name:         "test-instance",
+		OSType:       "linux",
+		OSArch:       "amd64",
+		Status:       commonParams.InstanceRunning,
+		RunnerStatus: params.RunnerActive,
+	})
+	s.Require().Nil(err)
+
+	jobs := []params.Job{
+		{ID: 1, Status: string(params.JobStatusQueued), Labels: []string{"self-hosted", "linux"}},
+		{ID: 2, Status: string(params.JobStatusInProgress), Labels: []string{"self-hosted", "arm64"}, RunnerName: "test-instance"},
+		{ID: 3, Status: string(params.JobStatusCompleted), Labels: []string{"linux"}},
+	}
+	for _, job := range jobs {
+		_, err := s.Store.CreateOrUpdateJob(s.adminCtx, job)
+		s.Require().Nil(err)
+	}
+}
+
+func jobIDs(jobs []params.Job) []int64 {
+	ret := make([]int64, len(jobs))
+	for idx, job := range jobs {
+		ret[idx] = job.ID
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+func (s *JobsTestSuite) TestListJobsPagination() {
+	filter := params.JobFilter{ListParams: params.ListParams{Limit: 2}}
+
+	page1, next, err := s.Store.ListJobs(s.adminCtx, filter)
+	s.Require().Nil(err)
+	s.Require().Equal([]int64{1, 2}, jobIDs(page1))
+	s.Require().NotEmpty(next)
+
+	filter.Cursor = next
+	page2, next, err := s.Store.ListJobs(s.adminCtx, filter)
+	s.Require().Nil(err)
+	s.Require().Equal([]int64{3}, jobIDs(page2))
+	s.Require().Empty(next)
+}
+
+func (s *JobsTestSuite) TestListJobsFilters() {
+	tests := []struct {
+		name     string
+		filter   params.JobFilter
+		expected []int64
+	}{
+		{"no filter", params.JobFilter{}, []int64{1, 2, 3}},
+		{"status", params.JobFilter{Status: params.JobStatusQueued}, []int64{1}},
+		{"label", params.JobFilter{Label: "linux"}, []int64{1, 3}},
+		{"label substring", params.JobFilter{Label: "linu"}, []int64{}},
+		{"label and status", params.JobFilter{Label: "self-hosted", Status: params.JobStatusInProgress}, []int64{2}},
+		{"pool", params.JobFilter{PoolID: s.pool.ID}, []int64{2}},
+		{"provider", params.JobFilter{ProviderName: "test-provider"}, []int64{2}},
+		{"unknown provider", params.JobFilter{ProviderName: "dummy-provider"}, []int64{}},
+	}
+	for _, tc := range tests {
+		jobs, _, err := s.Store.ListJobs(s.adminCtx, tc.filter)
+		s.Require().Nil(err, tc.name)
+		s.Require().Equal(tc.expected, jobIDs(jobs), tc.name)
+	}
+}
+
+func (s *JobsTestSuite) TestListJobsInvalidCursor() {
+	_, _, err := s.Store.ListJobs(s.adminCtx, params.JobFilter{ListParams: params.ListParams{Cursor: encodeCursor("not-a-number")}})
+
+	s.Require().Equal("fetching jobs: invalid cursor", err.Error())
+}
+
+func (s *JobsTestSuite) TestJobHistoryFollowsJob() {
+	startedAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	completedAt := time.Now().UTC().Truncate(time.Second)
+	_, err := s.Store.CreateOrUpdateJob(s.adminCtx, params.Job{
+		ID:          2,
+		Status:      string(params.JobStatusCompleted),
+		Conclusion:  "success",
+		StartedAt:   startedAt,
+		CompletedAt: completedAt,
+	})
+	s.Require().Nil(err)
+	s.Require().Nil(s.Store.DeleteCompletedJobs(s.adminCtx))
+
+	history, _, err := s.Store.ListJobHistory(s.adminCtx, params.JobHistoryFilter{Status: params.JobStatusCompleted})
+	s.Require().Nil(err)
+	s.Require().Len(history, 2)
+
+	entry := history[0]
+	s.Require().Equal(int64(2), entry.ID)
+	s.Require().Equal("success", entry.Conclusion)
+	s.Require().Equal(s.pool.ID, entry.PoolID)
+	s.Require().Equal("test-provider", entry.ProviderName)
+	s.Require().Equal("test-instance", entry.RunnerName)
+	s.Require().Equal([]string{"self-hosted", "arm64"}, entry.Labels)
+	s.Require().NotNil(entry.StartedAt)
+	s.Require().True(startedAt.Equal(*entry.StartedAt))
+	s.Require().NotNil(entry.CompletedAt)
+	s.Require().True(completedAt.Equal(*entry.CompletedAt))
+	s.Require().False(entry.QueuedAt.IsZero())
+}
+
+func (s *JobsTestSuite) TestListJobHistoryFilters() {
+	tests := []struct {
+		name     string
+		filter   params.JobHistoryFilter
+		expected []int64
+	}{
+		{"no filter", params.JobHistoryFilter{}, []int64{1, 2, 3}},
+		{"status", params.JobHistoryFilter{Status: params.JobStatusQueued}, []int64{1}},
+		{"label", params.JobHistoryFilter{Label: "linux"}, []int64{1, 3}},
+		{"pool", params.JobHistoryFilter{PoolID: s.pool.ID}, []int64{2}},
+		{"provider", params.JobHistoryFilter{ProviderName: "test-provider"}, []int64{2}},
+		{"limit", params.JobHistoryFilter{ListParams: params.ListParams{Limit: 1}}, []int64{1}},
+	}
+	for _, tc := range tests {
+		history, _, err := s.Store.ListJobHistory(s.adminCtx, tc.filter)
+		s.Require().Nil(err, tc.name)
+		ids := make([]int64, len(history))
+		for idx, entry := range history {
+			ids[idx] = entry.ID
+		}
+		s.Require().Equal(tc.expected, ids, tc.name)
+	}
+}
+
+func (s *JobsTestSuite) TestPurgeJobHistory() {
+	removed, err := s.Store.PurgeJobHistory(s.adminCtx, time.Now().Add(-time.Hour))
+	s.Require().Nil(err)
+	s.Require().Equal(int64(0), removed)
+
+	removed, err = s.Store.PurgeJobHistory(s.adminCtx, time.Now().Add(time.Hour))
+	s.Require().Nil(err)
+	s.Require().Equal(int64(3), removed)
+
+	history, _, err := s.Store.ListJobHistory(s.adminCtx, params.JobHistoryFilter{})
+	s.Require().Nil(err)
+	s.Require().Len(history, 0)
+}
+
+func TestJobsTestSuite(t *testing.T) {
+	suite.Run(t, new(JobsTestSuite))
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/params"
+)
+
+func sqlLeaseToParamsLease(lease ControllerLease) params.ControllerLease {
+	return params.ControllerLease{
+		Name:       lease.Name,
+		HolderID:   lease.HolderID,
+		AcquiredAt: lease.AcquiredAt,
+		RenewedAt:  lease.RenewedAt,
+		ExpiresAt:  lease.ExpiresAt,
+	}
+}
+
+// AcquireLease attempts to acquire or renew the lease with the given name on behalf of
+// holderID. The lease is granted if it does not exist, if it is already held by holderID
+// or if it expired. The current state of the lease is returned regardless of the outcome,
+// so callers need to check the holder of the returned lease.
+//
+// Each step is a single conditional statement, so concurrent callers can never both
+// be granted the same lease.
+func (s *sqlDatabase) AcquireLease(ctx context.Context, name, holderID string, duration time.Duration) (params.ControllerLease, error) {
+	if name == "" || holderID == "" {
+		return params.ControllerLease{}, runnerErrors.NewBadRequestError("missing lease name or holder ID")
+	}
+	now := time.Now().UTC()
+	expires := now.Add(duration)
+
+	// Renew the lease if we already hold it.
+	q := s.conn.WithContext(ctx).Model(&ControllerLease{}).
+		Where("name = ? and holder_id = ?", name, holderID).
+		Updates(map[string]interface{}{
+			"renewed_at": now,
+			"expires_at": expires,
+		})
+	if q.Error != nil {
+		return params.ControllerLease{}, errors.Wrap(q.Error, "renewing lease")
+	}
+
+	if q.RowsAffected == 0 {
+		// Take over the lease if it expired.
+		q = s.conn.WithContext(ctx).Model(&ControllerLease{}).
+			Where("name = ? and expires_at < ?", name, now).
+			Updates(map[string]interface{}{
+				"holder_id":   holderID,
+				"acquired_at": now,
+				"renewed_at":  now,
+				"expires_at":  expires,
+			})
+		if q.Error != nil {
+			return params.ControllerLease{}, errors.Wrap(q.Error, "taking over lease")
+		}
+	}
+
+	if q.RowsAffected == 0 {
+		// The lease may not exist yet. If another controller creates it at the same
+		// time, only one of the inserts will succeed.
+		newLease := ControllerLease{
+			Name:       name,
+			HolderID:   holderID,
+			AcquiredAt: now,
+			RenewedAt:  now,
+			ExpiresAt:  expires,
+		}
+		q = s.conn.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&newLease)
+		if q.Error != nil {
+			return params.ControllerLease{}, errors.Wrap(q.Error, "creating lease")
+		}
+	}
+
+	lease, err := s.getLease(ctx, name)
+	if err != nil {
+		return params.ControllerLease{}, errors.Wrap(err, "fetching lease")
+	}
+	return sqlLeaseToParamsLease(lease), nil
+}
+
+// ReleaseLease expires the lease with the given name, if it is held by holderID. This
+// allows other controllers to take over without waiting for the lease to expire.
+func (s *sqlDatabase) ReleaseLease(ctx context.Context, name, holderID string) error {
+	q := s.conn.WithContext(ctx).Model(&ControllerLease{}).
+		Where("name = ? and holder_id = ?", name, holderID).
+		Update("expires_at", time.Now().UTC())
+	if q.Error != nil {
+		return errors.Wrap(q.Error, "releasing lease")
+	}
+	return nil
+}
+
+func (s *sqlDatabase) GetLease(ctx context.Context, name string) (params.ControllerLease, error) {
+	lease, err := s.getLease(ctx, name)
+	if err != nil {
+		return params.ControllerLease{}, errors.Wrap(err, "fetching lease")
+	}
+	return sqlLeaseToParamsLease(lease), nil
+}
+
+func (s *sqlDatabase) getLease(ctx context.Context, name string) (ControllerLease, error) {
+	var lease ControllerLease
+	q := s.conn.WithContext(ctx).Where("name = ?", name).First(&lease)
+	if q.Error != nil {
+		if errors.Is(q.Error, gorm.ErrRecordNotFound) {
+			return ControllerLease{}, runnerErrors.ErrNotFound
+		}
+		return ControllerLease{}, errors.Wrap(q.Error, "fetching lease")
+	}
+	return lease, nil
+}
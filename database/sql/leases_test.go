@@ -0,0 +1,127 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+)
+
+const testLeaseName = "test-lease"
+
+type LeaseTestSuite struct {
+	suite.Suite
+	Store dbCommon.Store
+}
+
+func (s *LeaseTestSuite) SetupTest() {
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+}
+
+func (s *LeaseTestSuite) TestAcquireLease() {
+	lease, err := s.Store.AcquireLease(context.Background(), testLeaseName, "node-1", time.Minute)
+
+	s.Require().Nil(err)
+	s.Require().Equal(testLeaseName, lease.Name)
+	s.Require().Equal("node-1", lease.HolderID)
+	s.Require().True(lease.IsHeldBy("node-1", time.Now()))
+}
+
+func (s *LeaseTestSuite) TestAcquireLeaseRenew() {
+	first, err := s.Store.AcquireLease(context.Background(), testLeaseName, "node-1", time.Minute)
+	s.Require().Nil(err)
+
+	second, err := s.Store.AcquireLease(context.Background(), testLeaseName, "node-1", 2*time.Minute)
+
+	s.Require().Nil(err)
+	s.Require().Equal("node-1", second.HolderID)
+	s.Require().True(first.AcquiredAt.Equal(second.AcquiredAt))
+	s.Require().True(second.ExpiresAt.After(first.ExpiresAt))
+}
+
+func (s *LeaseTestSuite) TestAcquireLeaseHeldByOtherHolder() {
+	_, err := s.Store.AcquireLease(context.Background(), testLeaseName, "node-1", time.Minute)
+	s.Require().Nil(err)
+
+	lease, err := s.Store.AcquireLease(context.Background(), testLeaseName, "node-2", time.Minute)
+
+	s.Require().Nil(err)
+	s.Require().Equal("node-1", lease.HolderID)
+	s.Require().False(lease.IsHeldBy("node-2", time.Now()))
+}
+
+func (s *LeaseTestSuite) TestAcquireLeaseTakeOverExpired() {
+	_, err := s.Store.AcquireLease(context.Background(), testLeaseName, "node-1", -time.Second)
+	s.Require().Nil(err)
+
+	lease, err := s.Store.AcquireLease(context.Background(), testLeaseName, "node-2", time.Minute)
+
+	s.Require().Nil(err)
+	s.Require().Equal("node-2", lease.HolderID)
+	s.Require().True(lease.IsHeldBy("node-2", time.Now()))
+}
+
+func (s *LeaseTestSuite) TestAcquireLeaseMissingHolder() {
+	_, err := s.Store.AcquireLease(context.Background(), testLeaseName, "", time.Minute)
+
+	s.Require().Equal(runnerErrors.NewBadRequestError("missing lease name or holder ID"), err)
+}
+
+func (s *LeaseTestSuite) TestReleaseLease() {
+	_, err := s.Store.AcquireLease(context.Background(), testLeaseName, "node-1", time.Minute)
+	s.Require().Nil(err)
+
+	err = s.Store.ReleaseLease(context.Background(), testLeaseName, "node-1")
+	s.Require().Nil(err)
+
+	lease, err := s.Store.AcquireLease(context.Background(), testLeaseName, "node-2", time.Minute)
+	s.Require().Nil(err)
+	s.Require().Equal("node-2", lease.HolderID)
+}
+
+func (s *LeaseTestSuite) TestReleaseLeaseHeldByOtherHolder() {
+	_, err := s.Store.AcquireLease(context.Background(), testLeaseName, "node-1", time.Minute)
+	s.Require().Nil(err)
+
+	err = s.Store.ReleaseLease(context.Background(), testLeaseName, "node-2")
+	s.Require().Nil(err)
+
+	lease, err := s.Store.GetLease(context.Background(), testLeaseName)
+	s.Require().Nil(err)
+	s.Require().True(lease.IsHeldBy("node-1", time.Now()))
+}
+
+func (s *LeaseTestSuite) TestGetLeaseNotFound() {
+	_, err := s.Store.GetLease(context.Background(), testLeaseName)
+
+	s.Require().Equal("fetching lease: not found", err.Error())
+}
+
+func TestLeaseTestSuite(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(LeaseTestSuite))
+}
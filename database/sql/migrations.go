@@ -0,0 +1,289 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+// migration is a numbered schema change. Migrations must be idempotent, as the
+// baseline schema is created from the current models.
+type migration struct {
+	version     uint
+	description string
+	up          func(s *sqlDatabase) error
+	// down reverts the migration. Migrations without a down step can not be
+	// reverted.
+	down func(s *sqlDatabase) error
+}
+
+// migrations holds all schema migrations, ordered by version. New migrations
+// must be appended to the end of the list, with the next version number.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "baseline schema",
+		up:          (*sqlDatabase).migrateBaselineSchema,
+	},
+	{
+		version:     2,
+		description: "add controller leases table",
+		up:          createTable(&ControllerLease{}),
+		down:        dropTable(&ControllerLease{}),
+	},
+	{
+		version:     3,
+		description: "add job history table",
+		up:          createTable(&JobHistory{}),
+		down:        dropTable(&JobHistory{}),
+	},
+	{
+		version:     4,
+		description: "add audit log table",
+		up:          createTable(&AuditEntry{}),
+		down:        dropTable(&AuditEntry{}),
+	},
+	{
+		version:     5,
+		description: "add notification sinks table",
+		up:          createTable(&NotificationSink{}),
+		down:        dropTable(&NotificationSink{}),
+	},
+	{
+		version:     6,
+		description: "add user roles",
+		up:          (*sqlDatabase).migrateUserRoles,
+		down:        dropColumn(&User{}, "role"),
+	},
+	{
+		version:     7,
+		description: "add actor to audit log",
+		up:          addColumn(&AuditEntry{}, "Actor"),
+		down:        dropColumn(&AuditEntry{}, "actor"),
+	},
+}
+
+func latestSchemaVersion() uint {
+	return migrations[len(migrations)-1].version
+}
+
+func createTable(model interface{}) func(s *sqlDatabase) error {
+	return func(s *sqlDatabase) error {
+		if s.conn.Migrator().HasTable(model) {
+			return nil
+		}
+		return s.conn.Migrator().CreateTable(model)
+	}
+}
+
+func dropTable(model interface{}) func(s *sqlDatabase) error {
+	return func(s *sqlDatabase) error {
+		return s.conn.Migrator().DropTable(model)
+	}
+}
+
+func addColumn(model interface{}, field string) func(s *sqlDatabase) error {
+	return func(s *sqlDatabase) error {
+		if s.conn.Migrator().HasColumn(model, field) {
+			return nil
+		}
+		return s.conn.Migrator().AddColumn(model, field)
+	}
+}
+
+func dropColumn(model interface{}, column string) func(s *sqlDatabase) error {
+	return func(s *sqlDatabase) error {
+		if !s.conn.Migrator().HasColumn(model, column) {
+			return nil
+		}
+		return s.conn.Migrator().DropColumn(model, column)
+	}
+}
+
+// migrateUserRoles adds the role column to the users table. Existing admin users
+// get the admin role and everyone else gets the viewer role.
+func (s *sqlDatabase) migrateUserRoles() error {
+	if !s.conn.Migrator().HasColumn(&User{}, "role") {
+		if err := s.conn.Migrator().AddColumn(&User{}, "Role"); err != nil {
+			return errors.Wrap(err, "adding role column")
+		}
+	}
+	if err := s.conn.Model(&User{}).
+		Where("is_admin = ? and (role = ? or role is null)", true, "").
+		Update("role", string(params.UserRoleAdmin)).Error; err != nil {
+		return errors.Wrap(err, "setting admin role")
+	}
+	if err := s.conn.Model(&User{}).
+		Where("role = ? or role is null", "").
+		Update("role", string(params.UserRoleViewer)).Error; err != nil {
+		return errors.Wrap(err, "setting viewer role")
+	}
+	return nil
+}
+
+func (s *sqlDatabase) ensureSchemaMigrationsTable() error {
+	if s.conn.Migrator().HasTable(&SchemaMigration{}) {
+		return nil
+	}
+	if err := s.conn.Migrator().CreateTable(&SchemaMigration{}); err != nil {
+		return errors.Wrap(err, "creating schema migrations table")
+	}
+	return nil
+}
+
+// schemaVersion returns the version of the last applied migration, or 0 if no
+// migration was applied yet.
+func (s *sqlDatabase) schemaVersion() (uint, error) {
+	if !s.conn.Migrator().HasTable(&SchemaMigration{}) {
+		return 0, nil
+	}
+	var last SchemaMigration
+	if err := s.conn.Order("version desc").First(&last).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "fetching schema version")
+	}
+	return last.Version, nil
+}
+
+// checkSchemaVersion returns the current schema version, or an error if the
+// schema is newer than the migrations known to this binary.
+func (s *sqlDatabase) checkSchemaVersion() (uint, error) {
+	current, err := s.schemaVersion()
+	if err != nil {
+		return 0, err
+	}
+	if current > latestSchemaVersion() {
+		return 0, errors.Wrapf(common.ErrSchemaTooNew, "schema version is %d, latest known version is %d", current, latestSchemaVersion())
+	}
+	return current, nil
+}
+
+func (s *sqlDatabase) MigrationStatus(_ context.Context) ([]common.MigrationStatus, error) {
+	var applied []SchemaMigration
+	if s.conn.Migrator().HasTable(&SchemaMigration{}) {
+		if err := s.conn.Order("version asc").Find(&applied).Error; err != nil {
+			return nil, errors.Wrap(err, "fetching applied migrations")
+		}
+	}
+	appliedByVersion := map[uint]SchemaMigration{}
+	for _, m := range applied {
+		appliedByVersion[m.Version] = m
+	}
+
+	ret := make([]common.MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		status := common.MigrationStatus{
+			Version:     m.version,
+			Description: m.description,
+		}
+		if record, ok := appliedByVersion[m.version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		ret = append(ret, status)
+	}
+	for _, m := range applied {
+		if m.Version <= latestSchemaVersion() {
+			continue
+		}
+		appliedAt := m.AppliedAt
+		ret = append(ret, common.MigrationStatus{
+			Version:     m.Version,
+			Description: m.Description,
+			AppliedAt:   &appliedAt,
+			Unknown:     true,
+		})
+	}
+	return ret, nil
+}
+
+func (s *sqlDatabase) MigrateUp(_ context.Context, target uint) error {
+	if target == 0 {
+		target = latestSchemaVersion()
+	}
+	if target > latestSchemaVersion() {
+		return runnerErrors.NewBadRequestError("unknown schema version %d", target)
+	}
+	current, err := s.checkSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if err := s.ensureSchemaMigrationsTable(); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= current || m.version > target {
+			continue
+		}
+		slog.Info("applying schema migration", "version", m.version, "description", m.description)
+		if err := m.up(s); err != nil {
+			return errors.Wrapf(err, "applying migration %d (%s)", m.version, m.description)
+		}
+		record := SchemaMigration{
+			Version:     m.version,
+			Description: m.description,
+			AppliedAt:   time.Now().UTC(),
+		}
+		if err := s.conn.Create(&record).Error; err != nil {
+			return errors.Wrapf(err, "recording migration %d", m.version)
+		}
+	}
+	return nil
+}
+
+func (s *sqlDatabase) MigrateDown(_ context.Context, target uint) error {
+	current, err := s.checkSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if target >= current {
+		return runnerErrors.NewBadRequestError("target version %d is not lower than the current version %d", target, current)
+	}
+
+	var pending []migration
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.version <= target || m.version > current {
+			continue
+		}
+		if m.down == nil {
+			return runnerErrors.NewBadRequestError("migration %d (%s) can not be reverted", m.version, m.description)
+		}
+		pending = append(pending, m)
+	}
+
+	for _, m := range pending {
+		slog.Info("reverting schema migration", "version", m.version, "description", m.description)
+		if err := m.down(s); err != nil {
+			return errors.Wrapf(err, "reverting migration %d (%s)", m.version, m.description)
+		}
+		if err := s.conn.Where("version = ?", m.version).Delete(&SchemaMigration{}).Error; err != nil {
+			return errors.Wrapf(err, "removing migration %d record", m.version)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cloudbase/garm/config"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+type MigrationsTestSuite struct {
+	suite.Suite
+
+	cfg config.Database
+	db  *sqlDatabase
+}
+
+func (s *MigrationsTestSuite) SetupTest() {
+	s.cfg = garmTesting.GetTestDBConfig(s.T())
+	store, err := NewSQLDatabase(context.Background(), s.cfg)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.db = store.(*sqlDatabase)
+}
+
+func (s *MigrationsTestSuite) TestNewDatabaseAppliesAllMigrations() {
+	status, err := s.db.MigrationStatus(context.Background())
+
+	s.Require().Nil(err)
+	s.Require().Len(status, len(migrations))
+	for _, m := range status {
+		s.Require().NotNil(m.AppliedAt, "migration %d was not applied", m.Version)
+		s.Require().False(m.Unknown)
+	}
+	version, err := s.db.schemaVersion()
+	s.Require().Nil(err)
+	s.Require().Equal(latestSchemaVersion(), version)
+}
+
+func (s *MigrationsTestSuite) TestMigrateDownAndUp() {
+	err := s.db.MigrateDown(context.Background(), 1)
+
+	s.Require().Nil(err)
+	s.Require().False(s.db.conn.Migrator().HasTable(&JobHistory{}))
+	s.Require().False(s.db.conn.Migrator().HasTable(&ControllerLease{}))
+	status, err := s.db.MigrationStatus(context.Background())
+	s.Require().Nil(err)
+	s.Require().NotNil(status[0].AppliedAt)
+	s.Require().Nil(status[1].AppliedAt)
+	s.Require().Nil(status[2].AppliedAt)
+
+	err = s.db.MigrateUp(context.Background(), 2)
+	s.Require().Nil(err)
+	s.Require().True(s.db.conn.Migrator().HasTable(&ControllerLease{}))
+	s.Require().False(s.db.conn.Migrator().HasTable(&JobHistory{}))
+
+	err = s.db.MigrateUp(context.Background(), 0)
+	s.Require().Nil(err)
+	s.Require().True(s.db.conn.Migrator().HasTable(&JobHistory{}))
+	version, err := s.db.schemaVersion()
+	s.Require().Nil(err)
+	s.Require().Equal(latestSchemaVersion(), version)
+}
+
+func (s *MigrationsTestSuite) TestMigrateDownBaseline() {
+	err := s.db.MigrateDown(context.Background(), 0)
+
+	s.Require().NotNil(err)
+	s.Require().Equal("migration 1 (baseline schema) can not be reverted", err.Error())
+	version, err := s.db.schemaVersion()
+	s.Require().Nil(err)
+	s.Require().Equal(latestSchemaVersion(), version)
+}
+
+func (s *MigrationsTestSuite) TestMigrateDownInvalidTarget() {
+	err := s.db.MigrateDown(context.Background(), latestSchemaVersion())
+
+	s.Require().NotNil(err)
+	s.Require().Equal(fmt.Sprintf("target version %d is not lower than the current version %d", latestSchemaVersion(), latestSchemaVersion()), err.Error())
+}
+
+func (s *MigrationsTestSuite) TestMigrateUpUnknownVersion() {
+	err := s.db.MigrateUp(context.Background(), latestSchemaVersion()+1)
+
+	s.Require().NotNil(err)
+	s.Require().Equal(fmt.Sprintf("unknown schema version %d", latestSchemaVersion()+1), err.Error())
+}
+
+func (s *MigrationsTestSuite) TestNewDatabaseSchemaTooNew() {
+	newer := SchemaMigration{
+		Version:     latestSchemaVersion() + 1,
+		Description: "from the future",
+		AppliedAt:   time.Now().UTC(),
+	}
+	s.Require().Nil(s.db.conn.Create(&newer).Error)
+
+	_, err := NewSQLDatabase(context.Background(), s.cfg)
+
+	s.Require().NotNil(err)
+	s.Require().ErrorIs(err, dbCommon.ErrSchemaTooNew)
+
+	migrator, err := NewSchemaMigrator(context.Background(), s.cfg)
+	s.Require().Nil(err)
+	status, err := migrator.MigrationStatus(context.Background())
+	s.Require().Nil(err)
+	s.Require().Len(status, len(migrations)+1)
+	s.Require().True(status[len(status)-1].Unknown)
+	s.Require().Equal("from the future", status[len(status)-1].Description)
+}
+
+func (s *MigrationsTestSuite) TestNewDatabaseWithoutSchemaVersion() {
+	// Databases created before versioned migrations have no schema migrations table.
+	s.Require().Nil(s.db.conn.Migrator().DropTable(&SchemaMigration{}))
+
+	store, err := NewSQLDatabase(context.Background(), s.cfg)
+
+	s.Require().Nil(err)
+	version, err := store.(*sqlDatabase).schemaVersion()
+	s.Require().Nil(err)
+	s.Require().Equal(latestSchemaVersion(), version)
+}
+
+func (s *MigrationsTestSuite) TestMigrateUpImportsCredentials() {
+	// Databases created before credentials were stored in the database have no
+	// credentials, endpoints or schema migrations tables, but do have an admin user.
+	adminCtx := garmTesting.ImpersonateAdminContext(context.Background(), s.db, s.T())
+	s.Require().Nil(s.db.conn.Migrator().DropTable(&GithubCredentials{}, &GithubEndpoint{}, &SchemaMigration{}))
+	cfg := s.cfg
+	cfg.MigrateCredentials = []config.Github{
+		{
+			Name:        "legacy-creds",
+			Description: "credentials from the config file",
+			OAuth2Token: "legacy-token",
+		},
+	}
+
+	migrator, err := NewSchemaMigrator(context.Background(), cfg)
+	s.Require().Nil(err)
+	err = migrator.MigrateUp(context.Background(), 0)
+
+	s.Require().Nil(err)
+	creds, err := migrator.(*sqlDatabase).ListGithubCredentials(adminCtx)
+	s.Require().Nil(err)
+	s.Require().Len(creds, 1)
+	s.Require().Equal("legacy-creds", creds[0].Name)
+	s.Require().Equal("github.com", creds[0].Endpoint.Name)
+}
+
+func (s *MigrationsTestSuite) TestMigrateUserRoles() {
+	admin, err := s.db.CreateUser(context.Background(), params.NewUserParams{
+		Email:    "admin@example.com",
+		Username: "admin",
+		Password: "test-password",
+		IsAdmin:  true,
+	})
+	s.Require().Nil(err)
+	user, err := s.db.CreateUser(context.Background(), params.NewUserParams{
+		Email:    "user@example.com",
+		Username: "user",
+		Password: "test-password",
+		Role:     params.UserRoleOperator,
+	})
+	s.Require().Nil(err)
+
+	s.Require().Nil(s.db.MigrateDown(context.Background(), 5))
+	s.Require().False(s.db.conn.Migrator().HasColumn(&User{}, "role"))
+	s.Require().Nil(s.db.MigrateUp(context.Background(), 0))
+
+	admin, err = s.db.GetUserByID(context.Background(), admin.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(params.UserRoleAdmin, admin.Role)
+	user, err = s.db.GetUserByID(context.Background(), user.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(params.UserRoleViewer, user.Role)
+}
+
+func TestMigrationsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsTestSuite))
+}
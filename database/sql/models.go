@@ -15,17 +15,57 @@
 package sql
 
 import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 
 	commonParams "github.com/cloudbase/garm-provider-common/params"
 	"github.com/cloudbase/garm/params"
 )
 
+// blob holds binary data. It is stored as longblob in MySQL and SQLite, and as
+// bytea in PostgreSQL, which has no longblob type.
+type blob []byte
+
+// GormDBDataType returns the column type of the blob for the database in use.
+func (blob) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "bytea"
+	}
+	return "longblob"
+}
+
+// Scan implements the sql.Scanner interface. The scanned bytes are copied, as
+// drivers may reuse their buffers.
+func (b *blob) Scan(value interface{}) error {
+	switch val := value.(type) {
+	case nil:
+		*b = nil
+	case []byte:
+		*b = bytes.Clone(val)
+	case string:
+		*b = blob(val)
+	default:
+		return fmt.Errorf("failed to scan blob value: %v", value)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (b blob) Value() (driver.Value, error) {
+	if b == nil {
+		return nil, nil
+	}
+	return []byte(b), nil
+}
+
 type Base struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
 	CreatedAt time.Time
@@ -84,6 +124,34 @@ type Pool struct {
 
 	Instances []Instance `gorm:"foreignKey:PoolID"`
 	Priority  uint       `gorm:"index:idx_pool_priority"`
+	// Weight is the relative share of runners created in this pool by the
+	// weighted pool balancer.
+	Weight uint
+	// MaxIdleAge is the number of minutes a runner may stay idle before it
+	// is recycled.
+	MaxIdleAge uint
+	// MaxLifetime is the maximum age of a runner in minutes, counted from
+	// its creation, whether it was idle or not. Runners past it are recycled
+	// once they are idle.
+	MaxLifetime uint
+	// MaxConcurrentCreates and CreatesPerMinute override the instance
+	// creation limits of the provider.
+	MaxConcurrentCreates uint
+	CreatesPerMinute     uint
+	// QuarantineThreshold is the number of consecutive bootstrap failures
+	// after which the pool is disabled. QuarantineReason and QuarantinedAt
+	// record why and when that happened.
+	QuarantineThreshold uint
+	QuarantineReason    string
+	QuarantinedAt       *time.Time
+	// ScalingSchedules holds the time of day windows during which the pool
+	// uses different min idle and max runner values.
+	ScalingSchedules datatypes.JSON
+	// ScaleDownPolicy controls how idle runners are removed from the pool.
+	ScaleDownPolicy datatypes.JSON
+	// LabelRules holds the labels that must be requested by a job for the pool
+	// to be eligible, and the labels that exclude the pool.
+	LabelRules datatypes.JSON
 }
 
 type Repository struct {
@@ -100,6 +168,11 @@ type Repository struct {
 	Pools            []Pool                  `gorm:"foreignKey:RepoID"`
 	Jobs             []WorkflowJob           `gorm:"foreignKey:RepoID;constraint:OnDelete:SET NULL"`
 	PoolBalancerType params.PoolBalancerType `gorm:"type:varchar(64)"`
+	JobBackfill      datatypes.JSON
+	FairShare        datatypes.JSON
+
+	MaxConcurrentRunners uint
+	MaxPendingRunners    uint
 
 	EndpointName *string        `gorm:"index:idx_owner_nocase,unique,collate:nocase"`
 	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName;constraint:OnDelete:SET NULL"`
@@ -118,6 +191,11 @@ type Organization struct {
 	Pools            []Pool                  `gorm:"foreignKey:OrgID"`
 	Jobs             []WorkflowJob           `gorm:"foreignKey:OrgID;constraint:OnDelete:SET NULL"`
 	PoolBalancerType params.PoolBalancerType `gorm:"type:varchar(64)"`
+	JobBackfill      datatypes.JSON
+	FairShare        datatypes.JSON
+
+	MaxConcurrentRunners uint
+	MaxPendingRunners    uint
 
 	EndpointName *string        `gorm:"index"`
 	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName;constraint:OnDelete:SET NULL"`
@@ -136,6 +214,10 @@ type Enterprise struct {
 	Pools            []Pool                  `gorm:"foreignKey:EnterpriseID"`
 	Jobs             []WorkflowJob           `gorm:"foreignKey:EnterpriseID;constraint:OnDelete:SET NULL"`
 	PoolBalancerType params.PoolBalancerType `gorm:"type:varchar(64)"`
+	FairShare        datatypes.JSON
+
+	MaxConcurrentRunners uint
+	MaxPendingRunners    uint
 
 	EndpointName *string        `gorm:"index"`
 	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName;constraint:OnDelete:SET NULL"`
@@ -177,10 +259,10 @@ type Instance struct {
 	RunnerStatus      params.RunnerStatus
 	CallbackURL       string
 	MetadataURL       string
-	ProviderFault     []byte `gorm:"type:longblob"`
+	ProviderFault     blob
 	CreateAttempt     int
 	TokenFetched      bool
-	JitConfiguration  []byte `gorm:"type:longblob"`
+	JitConfiguration  blob
 	GitHubRunnerGroup string
 	AditionalLabels   datatypes.JSON
 
@@ -199,8 +281,10 @@ type User struct {
 	FullName string `gorm:"type:varchar(254)"`
 	Email    string `gorm:"type:varchar(254);unique;index:idx_email"`
 	Password string `gorm:"type:varchar(60)"`
-	IsAdmin  bool
-	Enabled  bool
+	// IsAdmin is kept in sync with the role, and is set for users with the admin role.
+	IsAdmin bool
+	Role    string `gorm:"type:varchar(32)"`
+	Enabled bool
 }
 
 type ControllerInfo struct {
@@ -229,6 +313,8 @@ type WorkflowJob struct {
 	Status string
 	// Name is the name if the job that was triggered.
 	Name string
+	// WorkflowName is the name of the workflow the job is part of.
+	WorkflowName string
 
 	StartedAt   time.Time
 	CompletedAt time.Time
@@ -272,6 +358,80 @@ type WorkflowJob struct {
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
+// JobHistory records the lifecycle of a workflow job. Entries are kept after
+// the job itself is removed, until the retention period expires.
+type JobHistory struct {
+	ID           int64 `gorm:"primaryKey;autoIncrement:false"`
+	RunID        int64
+	Name         string
+	WorkflowName string
+	Status       string `gorm:"index"`
+	Conclusion   string
+
+	RepositoryName  string
+	RepositoryOwner string
+	Labels          datatypes.JSON
+
+	// PoolID is not a foreign key. History outlives the pools it references.
+	PoolID       string `gorm:"type:varchar(64);index"`
+	ProviderName string `gorm:"index"`
+	RunnerName   string
+
+	QueuedAt    time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time `gorm:"index"`
+}
+
+// AuditEntry records a change made to GARM.
+type AuditEntry struct {
+	ID         uint   `gorm:"primaryKey"`
+	UserID     string `gorm:"type:varchar(64);index"`
+	Actor      string `gorm:"type:varchar(255)"`
+	Action     string `gorm:"type:varchar(64);index"`
+	EntityType string `gorm:"type:varchar(64);index:idx_audit_entity"`
+	EntityID   string `gorm:"type:varchar(255);index:idx_audit_entity"`
+	EntityName string
+	Changes    datatypes.JSON
+	SourceIP   string    `gorm:"type:varchar(64)"`
+	CreatedAt  time.Time `gorm:"index"`
+}
+
+// NotificationSink is an HTTP endpoint that receives notification events.
+type NotificationSink struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Name        string `gorm:"type:varchar(64);uniqueIndex"`
+	Description string `gorm:"type:text"`
+	Format      string `gorm:"type:varchar(64)"`
+	// Payload holds the sealed URL and headers of the sink.
+	Payload blob
+	URLHost string
+	Rules   datatypes.JSON
+	Enabled bool
+}
+
+// SchemaMigration records a schema migration that was applied to the database.
+type SchemaMigration struct {
+	Version     uint `gorm:"primaryKey;autoIncrement:false"`
+	Description string
+	AppliedAt   time.Time
+}
+
+// ControllerLease is used to elect a leader among the GARM controllers that
+// share the same database.
+type ControllerLease struct {
+	Name       string `gorm:"type:varchar(64);primary_key;"`
+	HolderID   string `gorm:"type:varchar(64)"`
+	AcquiredAt time.Time
+	RenewedAt  time.Time
+	ExpiresAt  time.Time
+}
+
 type GithubEndpoint struct {
 	Name      string `gorm:"type:varchar(64) collate nocase;primary_key;"`
 	CreatedAt time.Time
@@ -282,7 +442,7 @@ type GithubEndpoint struct {
 	APIBaseURL    string `gorm:"type:text collate nocase"`
 	UploadBaseURL string `gorm:"type:text collate nocase"`
 	BaseURL       string `gorm:"type:text collate nocase"`
-	CACertBundle  []byte `gorm:"type:longblob"`
+	CACertBundle  blob
 }
 
 type GithubCredentials struct {
@@ -294,7 +454,7 @@ type GithubCredentials struct {
 
 	Description string                `gorm:"type:text"`
 	AuthType    params.GithubAuthType `gorm:"index"`
-	Payload     []byte                `gorm:"type:longblob"`
+	Payload     blob
 
 	Endpoint     GithubEndpoint `gorm:"foreignKey:EndpointName"`
 	EndpointName *string        `gorm:"index"`
@@ -0,0 +1,58 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestBlobDataType(t *testing.T) {
+	tests := []struct {
+		dialector gorm.Dialector
+		expected  string
+	}{
+		{dialector: sqlite.Open(""), expected: "longblob"},
+		{dialector: mysql.New(mysql.Config{}), expected: "longblob"},
+		{dialector: postgres.New(postgres.Config{}), expected: "bytea"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.dialector.Name(), func(t *testing.T) {
+			db := &gorm.DB{Config: &gorm.Config{Dialector: tc.dialector}}
+			require.Equal(t, tc.expected, blob{}.GormDBDataType(db, nil))
+		})
+	}
+}
+
+func TestBlobScanCopiesData(t *testing.T) {
+	data := []byte("provider fault")
+	var b blob
+	require.Nil(t, b.Scan(data))
+	data[0] = 'P'
+	require.Equal(t, blob("provider fault"), b)
+
+	require.Nil(t, b.Scan(nil))
+	require.Nil(t, b)
+
+	value, err := b.Value()
+	require.Nil(t, err)
+	require.Nil(t, value)
+}
@@ -0,0 +1,247 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+// notificationSinkPayload holds the fields of a notification sink that may
+// contain secrets. It is stored sealed.
+type notificationSinkPayload struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+func (s *sqlDatabase) sqlToParamsNotificationSink(sink NotificationSink) (params.NotificationSink, error) {
+	ret := params.NotificationSink{
+		ID:          sink.ID,
+		Name:        sink.Name,
+		Description: sink.Description,
+		Format:      params.NotificationFormat(sink.Format),
+		URLHost:     sink.URLHost,
+		Enabled:     sink.Enabled,
+		Source:      params.NotificationSinkSourceAPI,
+		CreatedAt:   sink.CreatedAt,
+		UpdatedAt:   sink.UpdatedAt,
+	}
+	if len(sink.Rules) > 0 {
+		if err := json.Unmarshal(sink.Rules, &ret.Rules); err != nil {
+			return params.NotificationSink{}, errors.Wrap(err, "unmarshaling rules")
+		}
+	}
+
+	var payload notificationSinkPayload
+	if err := s.unsealAndUnmarshal(sink.Payload, &payload); err != nil {
+		return params.NotificationSink{}, errors.Wrap(err, "unsealing payload")
+	}
+	ret.URL = payload.URL
+	ret.Headers = payload.Headers
+	for name := range payload.Headers {
+		ret.HeaderNames = append(ret.HeaderNames, name)
+	}
+	sort.Strings(ret.HeaderNames)
+	return ret, nil
+}
+
+func (s *sqlDatabase) CreateNotificationSink(_ context.Context, param params.CreateNotificationSinkParams) (sink params.NotificationSink, err error) {
+	if err := param.Validate(); err != nil {
+		return params.NotificationSink{}, errors.Wrap(err, "validating params")
+	}
+	defer func() {
+		if err == nil {
+			s.sendNotify(common.NotificationSinkEntityType, common.CreateOperation, sink)
+		}
+	}()
+
+	payload, err := s.marshalAndSeal(notificationSinkPayload{URL: param.URL, Headers: param.Headers})
+	if err != nil {
+		return params.NotificationSink{}, errors.Wrap(err, "sealing payload")
+	}
+	rules, err := json.Marshal(param.Rules)
+	if err != nil {
+		return params.NotificationSink{}, errors.Wrap(err, "marshaling rules")
+	}
+	format := param.Format
+	if format == "" {
+		format = params.NotificationFormatJSON
+	}
+	enabled := true
+	if param.Enabled != nil {
+		enabled = *param.Enabled
+	}
+
+	newSink := NotificationSink{
+		Name:        param.Name,
+		Description: param.Description,
+		Format:      string(format),
+		Payload:     payload,
+		URLHost:     params.NotificationURLHost(param.URL),
+		Rules:       rules,
+		Enabled:     enabled,
+	}
+	err = s.conn.Transaction(func(tx *gorm.DB) error {
+		var existing NotificationSink
+		if err := tx.Where("name = ?", param.Name).First(&existing).Error; err == nil {
+			return runnerErrors.NewConflictError("notification sink %s already exists", param.Name)
+		}
+		if err := tx.Create(&newSink).Error; err != nil {
+			return errors.Wrap(err, "creating notification sink")
+		}
+		return nil
+	})
+	if err != nil {
+		return params.NotificationSink{}, err
+	}
+	return s.sqlToParamsNotificationSink(newSink)
+}
+
+func (s *sqlDatabase) getNotificationSink(tx *gorm.DB, id uint) (NotificationSink, error) {
+	var sink NotificationSink
+	if err := tx.Where("id = ?", id).First(&sink).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return NotificationSink{}, errors.Wrap(runnerErrors.ErrNotFound, "notification sink not found")
+		}
+		return NotificationSink{}, errors.Wrap(err, "fetching notification sink")
+	}
+	return sink, nil
+}
+
+func (s *sqlDatabase) GetNotificationSink(_ context.Context, id uint) (params.NotificationSink, error) {
+	sink, err := s.getNotificationSink(s.conn, id)
+	if err != nil {
+		return params.NotificationSink{}, err
+	}
+	return s.sqlToParamsNotificationSink(sink)
+}
+
+func (s *sqlDatabase) ListNotificationSinks(_ context.Context) ([]params.NotificationSink, error) {
+	var sinks []NotificationSink
+	if err := s.conn.Order("id asc").Find(&sinks).Error; err != nil {
+		return nil, errors.Wrap(err, "fetching notification sinks")
+	}
+
+	ret := make([]params.NotificationSink, len(sinks))
+	for idx, sink := range sinks {
+		var err error
+		ret[idx], err = s.sqlToParamsNotificationSink(sink)
+		if err != nil {
+			return nil, errors.Wrap(err, "converting notification sink")
+		}
+	}
+	return ret, nil
+}
+
+func (s *sqlDatabase) UpdateNotificationSink(_ context.Context, id uint, param params.UpdateNotificationSinkParams) (sink params.NotificationSink, err error) {
+	if err := param.Validate(); err != nil {
+		return params.NotificationSink{}, errors.Wrap(err, "validating params")
+	}
+	defer func() {
+		if err == nil {
+			s.sendNotify(common.NotificationSinkEntityType, common.UpdateOperation, sink)
+		}
+	}()
+
+	var dbSink NotificationSink
+	err = s.conn.Transaction(func(tx *gorm.DB) error {
+		var err error
+		dbSink, err = s.getNotificationSink(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if param.Name != nil && *param.Name != dbSink.Name {
+			var existing NotificationSink
+			if err := tx.Where("name = ?", *param.Name).First(&existing).Error; err == nil {
+				return runnerErrors.NewConflictError("notification sink %s already exists", *param.Name)
+			}
+			dbSink.Name = *param.Name
+		}
+		if param.Description != nil {
+			dbSink.Description = *param.Description
+		}
+		if param.Format != nil && *param.Format != "" {
+			dbSink.Format = string(*param.Format)
+		}
+		if param.Enabled != nil {
+			dbSink.Enabled = *param.Enabled
+		}
+		if param.Rules != nil {
+			rules, err := json.Marshal(param.Rules)
+			if err != nil {
+				return errors.Wrap(err, "marshaling rules")
+			}
+			dbSink.Rules = rules
+		}
+
+		if param.URL != nil || param.Headers != nil {
+			var payload notificationSinkPayload
+			if err := s.unsealAndUnmarshal(dbSink.Payload, &payload); err != nil {
+				return errors.Wrap(err, "unsealing payload")
+			}
+			if param.URL != nil {
+				payload.URL = *param.URL
+				dbSink.URLHost = params.NotificationURLHost(*param.URL)
+			}
+			if param.Headers != nil {
+				payload.Headers = param.Headers
+			}
+			dbSink.Payload, err = s.marshalAndSeal(payload)
+			if err != nil {
+				return errors.Wrap(err, "sealing payload")
+			}
+		}
+
+		if err := tx.Save(&dbSink).Error; err != nil {
+			return errors.Wrap(err, "saving notification sink")
+		}
+		return nil
+	})
+	if err != nil {
+		return params.NotificationSink{}, errors.Wrap(err, "updating notification sink")
+	}
+	return s.sqlToParamsNotificationSink(dbSink)
+}
+
+func (s *sqlDatabase) DeleteNotificationSink(_ context.Context, id uint) (err error) {
+	var name string
+	defer func() {
+		if err == nil && name != "" {
+			s.sendNotify(common.NotificationSinkEntityType, common.DeleteOperation, params.NotificationSink{ID: id, Name: name})
+		}
+	}()
+
+	sink, err := s.getNotificationSink(s.conn, id)
+	if err != nil {
+		if errors.Is(err, runnerErrors.ErrNotFound) {
+			return nil
+		}
+		return errors.Wrap(err, "deleting notification sink")
+	}
+	if err := s.conn.Delete(&sink).Error; err != nil {
+		return errors.Wrap(err, "deleting notification sink")
+	}
+	name = sink.Name
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+type NotificationSinksTestSuite struct {
+	suite.Suite
+	Store dbCommon.Store
+	sink  params.NotificationSink
+}
+
+func (s *NotificationSinksTestSuite) SetupTest() {
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+
+	s.sink, err = db.CreateNotificationSink(context.Background(), params.CreateNotificationSinkParams{
+		Name:    "ops",
+		URL:     "https://hooks.example.com/services/secret-token",
+		Headers: map[string]string{"X-Api-Key": "super-secret"},
+		Rules: []params.NotificationRule{
+			{EventTypes: []params.NotificationEventType{params.PoolDegradedEvent}},
+		},
+	})
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create notification sink: %s", err))
+	}
+}
+
+func (s *NotificationSinksTestSuite) TestCreateNotificationSinkDefaults() {
+	s.Require().NotZero(s.sink.ID)
+	s.Require().Equal(params.NotificationFormatJSON, s.sink.Format)
+	s.Require().True(s.sink.Enabled)
+	s.Require().Equal("https://hooks.example.com", s.sink.URLHost)
+	s.Require().Equal([]string{"X-Api-Key"}, s.sink.HeaderNames)
+	s.Require().Equal("api", s.sink.Source)
+}
+
+func (s *NotificationSinksTestSuite) TestCreateNotificationSinkDuplicateName() {
+	_, err := s.Store.CreateNotificationSink(context.Background(), params.CreateNotificationSinkParams{
+		Name: "ops",
+		URL:  "https://other.example.com",
+	})
+
+	s.Require().Equal(runnerErrors.NewConflictError("notification sink ops already exists"), err)
+}
+
+func (s *NotificationSinksTestSuite) TestCreateNotificationSinkInvalidURL() {
+	_, err := s.Store.CreateNotificationSink(context.Background(), params.CreateNotificationSinkParams{
+		Name: "bad",
+		URL:  "ftp://example.com",
+	})
+
+	s.Require().NotNil(err)
+}
+
+func (s *NotificationSinksTestSuite) TestGetNotificationSinkUnsealsSecrets() {
+	sink, err := s.Store.GetNotificationSink(context.Background(), s.sink.ID)
+
+	s.Require().Nil(err)
+	s.Require().Equal("https://hooks.example.com/services/secret-token", sink.URL)
+	s.Require().Equal("super-secret", sink.Headers["X-Api-Key"])
+	s.Require().Len(sink.Rules, 1)
+}
+
+func (s *NotificationSinksTestSuite) TestGetNotificationSinkNotFound() {
+	_, err := s.Store.GetNotificationSink(context.Background(), 9999)
+
+	s.Require().ErrorIs(err, runnerErrors.ErrNotFound)
+}
+
+func (s *NotificationSinksTestSuite) TestUpdateNotificationSink() {
+	name := "platform"
+	enabled := false
+	url := "https://chat.example.com/webhook"
+
+	sink, err := s.Store.UpdateNotificationSink(context.Background(), s.sink.ID, params.UpdateNotificationSinkParams{
+		Name:    &name,
+		URL:     &url,
+		Enabled: &enabled,
+	})
+
+	s.Require().Nil(err)
+	s.Require().Equal(name, sink.Name)
+	s.Require().False(sink.Enabled)
+	s.Require().Equal("https://chat.example.com", sink.URLHost)
+	s.Require().Equal(url, sink.URL)
+	// Headers and rules were not part of the update and must be kept.
+	s.Require().Equal("super-secret", sink.Headers["X-Api-Key"])
+	s.Require().Len(sink.Rules, 1)
+}
+
+func (s *NotificationSinksTestSuite) TestUpdateNotificationSinkDuplicateName() {
+	_, err := s.Store.CreateNotificationSink(context.Background(), params.CreateNotificationSinkParams{
+		Name: "other",
+		URL:  "https://other.example.com",
+	})
+	s.Require().Nil(err)
+	name := "other"
+
+	_, err = s.Store.UpdateNotificationSink(context.Background(), s.sink.ID, params.UpdateNotificationSinkParams{Name: &name})
+
+	var conflictErr *runnerErrors.ConflictError
+	s.Require().ErrorAs(err, &conflictErr)
+}
+
+func (s *NotificationSinksTestSuite) TestListNotificationSinks() {
+	_, err := s.Store.CreateNotificationSink(context.Background(), params.CreateNotificationSinkParams{
+		Name: "other",
+		URL:  "https://other.example.com",
+	})
+	s.Require().Nil(err)
+
+	sinks, err := s.Store.ListNotificationSinks(context.Background())
+
+	s.Require().Nil(err)
+	s.Require().Len(sinks, 2)
+	s.Require().Equal("ops", sinks[0].Name)
+	s.Require().Equal("other", sinks[1].Name)
+}
+
+func (s *NotificationSinksTestSuite) TestDeleteNotificationSink() {
+	err := s.Store.DeleteNotificationSink(context.Background(), s.sink.ID)
+	s.Require().Nil(err)
+
+	_, err = s.Store.GetNotificationSink(context.Background(), s.sink.ID)
+	s.Require().ErrorIs(err, runnerErrors.ErrNotFound)
+
+	// Deleting a missing sink is not an error.
+	err = s.Store.DeleteNotificationSink(context.Background(), s.sink.ID)
+	s.Require().Nil(err)
+}
+
+func TestNotificationSinksTestSuite(t *testing.T) {
+	suite.Run(t, new(NotificationSinksTestSuite))
+}
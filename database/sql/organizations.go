@@ -16,6 +16,7 @@ package sql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 
@@ -193,6 +194,30 @@ func (s *sqlDatabase) UpdateOrganization(ctx context.Context, orgID string, para
 			org.PoolBalancerType = param.PoolBalancerType
 		}
 
+		if param.MaxConcurrentRunners != nil {
+			org.MaxConcurrentRunners = *param.MaxConcurrentRunners
+		}
+
+		if param.MaxPendingRunners != nil {
+			org.MaxPendingRunners = *param.MaxPendingRunners
+		}
+
+		if param.JobBackfill != nil {
+			backfill, err := json.Marshal(param.JobBackfill)
+			if err != nil {
+				return errors.Wrap(err, "marshaling job backfill settings")
+			}
+			org.JobBackfill = backfill
+		}
+
+		if param.FairShare != nil {
+			fairShare, err := json.Marshal(param.FairShare)
+			if err != nil {
+				return errors.Wrap(err, "marshaling fair share settings")
+			}
+			org.FairShare = fairShare
+		}
+
 		q := tx.Save(&org)
 		if q.Error != nil {
 			return errors.Wrap(q.Error, "saving org")
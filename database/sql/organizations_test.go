@@ -77,8 +77,8 @@ func (s *OrgTestSuite) assertSQLMockExpectations() {
 }
 
 func (s *OrgTestSuite) SetupTest() {
-	// create testing sqlite database
-	dbConfig := garmTesting.GetTestSqliteDBConfig(s.T())
+	// create testing database
+	dbConfig := garmTesting.GetTestDBConfig(s.T())
 	db, err := NewSQLDatabase(context.Background(), dbConfig)
 	if err != nil {
 		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
@@ -195,7 +195,7 @@ func (s *OrgTestSuite) TestCreateOrganization() {
 }
 
 func (s *OrgTestSuite) TestCreateOrganizationInvalidDBPassphrase() {
-	cfg := garmTesting.GetTestSqliteDBConfig(s.T())
+	cfg := garmTesting.GetTestDBConfig(s.T())
 	conn, err := newDBConn(cfg)
 	if err != nil {
 		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
@@ -343,6 +343,41 @@ func (s *OrgTestSuite) TestUpdateOrganization() {
 	s.Require().Equal(s.Fixtures.UpdateRepoParams.WebhookSecret, org.WebhookSecret)
 }
 
+func (s *OrgTestSuite) TestUpdateOrganizationJobBackfill() {
+	param := s.Fixtures.UpdateRepoParams
+	param.JobBackfill = &params.JobBackfillSettings{
+		Enabled:         true,
+		Repositories:    []string{"test-repo"},
+		MaxRepositories: 10,
+	}
+	_, err := s.Store.UpdateOrganization(s.adminCtx, s.Fixtures.Orgs[0].ID, param)
+	s.Require().Nil(err)
+
+	org, err := s.Store.GetOrganizationByID(s.adminCtx, s.Fixtures.Orgs[0].ID)
+	s.Require().Nil(err)
+	s.Require().Equal(*param.JobBackfill, org.JobBackfill)
+}
+
+func (s *OrgTestSuite) TestUpdateOrganizationFairShare() {
+	param := s.Fixtures.UpdateRepoParams
+	param.FairShare = &params.FairShareSettings{
+		Enabled:            true,
+		GroupBy:            params.FairShareGroupByWorkflow,
+		MaxRunnersPerGroup: 5,
+		Groups:             []params.FairShareGroup{{Name: "release", Priority: 10, MaxRunners: 20}},
+	}
+	_, err := s.Store.UpdateOrganization(s.adminCtx, s.Fixtures.Orgs[0].ID, param)
+	s.Require().Nil(err)
+
+	org, err := s.Store.GetOrganizationByID(s.adminCtx, s.Fixtures.Orgs[0].ID)
+	s.Require().Nil(err)
+	s.Require().Equal(*param.FairShare, org.FairShare)
+
+	entity, err := org.GetEntity()
+	s.Require().Nil(err)
+	s.Require().Equal(*param.FairShare, entity.FairShare)
+}
+
 func (s *OrgTestSuite) TestUpdateOrganizationInvalidOrgID() {
 	_, err := s.Store.UpdateOrganization(s.adminCtx, "dummy-org-id", s.Fixtures.UpdateRepoParams)
 
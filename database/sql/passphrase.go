@@ -0,0 +1,96 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm-provider-common/util"
+)
+
+// sealedColumn is a column holding data sealed with the database passphrase.
+type sealedColumn struct {
+	name   string
+	model  interface{}
+	column string
+}
+
+var sealedColumns = []sealedColumn{
+	{"github credentials", &GithubCredentials{}, "payload"},
+	{"repositories", &Repository{}, "webhook_secret"},
+	{"organizations", &Organization{}, "webhook_secret"},
+	{"enterprises", &Enterprise{}, "webhook_secret"},
+	{"instances", &Instance{}, "jit_configuration"},
+	{"notification sinks", &NotificationSink{}, "payload"},
+}
+
+type sealedRow struct {
+	ID   string
+	Data []byte
+}
+
+func reseal(data []byte, oldPassphrase, newPassphrase string) ([]byte, error) {
+	decrypted, err := util.Unseal(data, []byte(oldPassphrase))
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting data")
+	}
+	return util.Seal(decrypted, []byte(newPassphrase))
+}
+
+// RotatePassphrase re-encrypts every sealed column of the database. Values are
+// unsealed with oldPassphrase and sealed with the passphrase this database was
+// opened with. All values are updated in a single transaction, so a failure
+// leaves the database untouched.
+func (s *sqlDatabase) RotatePassphrase(_ context.Context, oldPassphrase string) error {
+	if oldPassphrase == "" {
+		return runnerErrors.NewBadRequestError("missing old passphrase")
+	}
+	if oldPassphrase == s.cfg.Passphrase {
+		return runnerErrors.NewBadRequestError("old and new passphrase are identical")
+	}
+
+	return s.conn.Transaction(func(tx *gorm.DB) error {
+		for _, col := range sealedColumns {
+			var rows []sealedRow
+			q := tx.Unscoped().Model(col.model).
+				Select("id, " + col.column + " as data").
+				Where(col.column + " IS NOT NULL").
+				Scan(&rows)
+			if q.Error != nil {
+				return errors.Wrapf(q.Error, "fetching %s", col.name)
+			}
+			for _, row := range rows {
+				if len(row.Data) == 0 {
+					continue
+				}
+				sealed, err := reseal(row.Data, oldPassphrase, s.cfg.Passphrase)
+				if err != nil {
+					return errors.Wrapf(err, "re-encrypting %s %s", col.name, row.ID)
+				}
+				q := tx.Unscoped().Model(col.model).
+					Where("id = ?", row.ID).
+					UpdateColumn(col.column, sealed)
+				if q.Error != nil {
+					return errors.Wrapf(q.Error, "updating %s %s", col.name, row.ID)
+				}
+			}
+		}
+		return nil
+	})
+}
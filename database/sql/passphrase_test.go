@@ -0,0 +1,208 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm-provider-common/util"
+	"github.com/cloudbase/garm/config"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+const rotatedPassphrase = "VicWyftAfViUjDidsIkOnbyaheHatOb2"
+
+type PassphraseTestSuite struct {
+	suite.Suite
+	Store dbCommon.Store
+
+	dbConfig func(t *testing.T) config.Database
+	cfg      config.Database
+	adminCtx context.Context
+
+	creds      params.GithubCredentials
+	repo       params.Repository
+	org        params.Organization
+	enterprise params.Enterprise
+	instance   params.Instance
+}
+
+func (s *PassphraseTestSuite) SetupTest() {
+	s.cfg = s.dbConfig(s.T())
+	db, err := NewSQLDatabase(context.Background(), s.cfg)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+
+	s.adminCtx = garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(s.adminCtx, db, s.T())
+	s.creds = garmTesting.CreateTestGithubCredentials(s.adminCtx, "test-creds", db, s.T(), endpoint)
+
+	s.repo, err = db.CreateRepository(s.adminCtx, "test-owner", "test-repo", s.creds.Name, "test-repo-secret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+	s.org, err = db.CreateOrganization(s.adminCtx, "test-org", s.creds.Name, "test-org-secret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+	s.enterprise, err = db.CreateEnterprise(s.adminCtx, "test-enterprise", s.creds.Name, "test-enterprise-secret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+
+	entity, err := s.repo.GetEntity()
+	s.Require().Nil(err)
+	pool, err := db.CreateEntityPool(s.adminCtx, entity, params.CreatePoolParams{
+		ProviderName: "test-provider",
+		MaxRunners:   4,
+		Image:        "test-image",
+		Flavor:       "test-flavor",
+		OSType:       commonParams.Linux,
+		OSArch:       commonParams.Amd64,
+		Tags:         []string{"linux"},
+	})
+	s.Require().Nil(err)
+	s.instance, err = db.CreateInstance(s.adminCtx, pool.ID, params.CreateInstanceParams{
+		Name:             "test-instance",
+		OSType:           commonParams.Linux,
+		OSArch:           commonParams.Amd64,
+		JitConfiguration: map[string]string{".runner": "test-jit-config"},
+	})
+	s.Require().Nil(err)
+}
+
+// openWithPassphrase opens the test database using the given passphrase.
+func (s *PassphraseTestSuite) openWithPassphrase(passphrase string) dbCommon.Store {
+	cfg := s.cfg
+	cfg.Passphrase = passphrase
+	db, err := NewSQLDatabase(context.Background(), cfg)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	return db
+}
+
+func (s *PassphraseTestSuite) assertReadable(db dbCommon.Store) {
+	creds, err := db.GetGithubCredentialsByName(s.adminCtx, s.creds.Name, true)
+	s.Require().Nil(err)
+	s.Require().JSONEq(`{"oauth2_token":"test-token"}`, string(creds.CredentialsPayload))
+
+	repo, err := db.GetRepositoryByID(s.adminCtx, s.repo.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("test-repo-secret", repo.WebhookSecret)
+
+	org, err := db.GetOrganizationByID(s.adminCtx, s.org.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("test-org-secret", org.WebhookSecret)
+
+	enterprise, err := db.GetEnterpriseByID(s.adminCtx, s.enterprise.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("test-enterprise-secret", enterprise.WebhookSecret)
+
+	instance, err := db.GetInstanceByName(s.adminCtx, s.instance.Name)
+	s.Require().Nil(err)
+	s.Require().Equal(map[string]string{".runner": "test-jit-config"}, instance.JitConfiguration)
+}
+
+func (s *PassphraseTestSuite) TestRotatePassphrase() {
+	rotated := s.openWithPassphrase(rotatedPassphrase)
+
+	err := rotated.RotatePassphrase(s.adminCtx, s.cfg.Passphrase)
+
+	s.Require().Nil(err)
+	s.assertReadable(rotated)
+	_, err = s.Store.GetRepositoryByID(s.adminCtx, s.repo.ID)
+	s.Require().NotNil(err)
+}
+
+func (s *PassphraseTestSuite) TestRotatePassphraseWrongOldPassphrase() {
+	rotated := s.openWithPassphrase(rotatedPassphrase)
+
+	err := rotated.RotatePassphrase(s.adminCtx, "wrong-passphrase-wrong-passphras")
+
+	s.Require().NotNil(err)
+	s.Require().Regexp("^re-encrypting github credentials [0-9]+: decrypting data", err.Error())
+	s.assertReadable(s.Store)
+}
+
+func (s *PassphraseTestSuite) TestRotatePassphraseSamePassphrase() {
+	err := s.Store.RotatePassphrase(s.adminCtx, s.cfg.Passphrase)
+
+	s.Require().NotNil(err)
+	s.Require().Equal("old and new passphrase are identical", err.Error())
+}
+
+func TestPassphraseTestSuiteSQLite(t *testing.T) {
+	suite.Run(t, &PassphraseTestSuite{dbConfig: garmTesting.GetTestDBConfig})
+}
+
+func TestPassphraseTestSuiteMySQL(t *testing.T) {
+	suite.Run(t, &PassphraseTestSuite{dbConfig: garmTesting.GetTestMySQLDBConfig})
+}
+
+// TestRotatePassphraseMySQLRollback checks that the rotation is rolled back on MySQL,
+// if updating a sealed column fails.
+func TestRotatePassphraseMySQLRollback(t *testing.T) {
+	sqlDB, sqlMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to run 'sqlmock.New()', got error: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	gormConn, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("fail to open gorm connection: %v", err)
+	}
+	cfg := garmTesting.GetTestSqliteDBConfig(t)
+	store := &sqlDatabase{
+		conn: gormConn,
+		cfg:  cfg,
+	}
+	sealed, err := util.Seal([]byte(`"test-payload"`), []byte(rotatedPassphrase))
+	if err != nil {
+		t.Fatalf("failed to seal test data: %v", err)
+	}
+
+	sqlMock.ExpectBegin()
+	sqlMock.
+		ExpectQuery(regexp.QuoteMeta("SELECT id, payload as data FROM `github_credentials` WHERE payload IS NOT NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "data"}).AddRow("1", sealed))
+	sqlMock.
+		ExpectExec(regexp.QuoteMeta("UPDATE `github_credentials` SET `payload`=? WHERE id = ?")).
+		WillReturnError(fmt.Errorf("mocked update error"))
+	sqlMock.ExpectRollback()
+
+	err = store.RotatePassphrase(context.Background(), rotatedPassphrase)
+
+	if err == nil || err.Error() != "updating github credentials 1: mocked update error" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sqlMock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("failed to meet sqlmock expectations, got error: %v", err)
+	}
+}
@@ -16,7 +16,9 @@ package sql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -34,29 +36,52 @@ const (
 	entityTypeRepoName       = "repo_id"
 )
 
-func (s *sqlDatabase) ListAllPools(_ context.Context) ([]params.Pool, error) {
-	var pools []Pool
+func (s *sqlDatabase) ListAllPools(ctx context.Context) ([]params.Pool, error) {
+	pools, _, err := s.ListPools(ctx, params.PoolFilter{})
+	return pools, err
+}
+
+func (s *sqlDatabase) ListPools(_ context.Context, filter params.PoolFilter) ([]params.Pool, string, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, "", errors.Wrap(err, "validating filter")
+	}
 
 	q := s.conn.Model(&Pool{}).
 		Preload("Tags").
 		Preload("Organization").
 		Preload("Repository").
 		Preload("Enterprise").
-		Omit("extra_specs").
-		Find(&pools)
-	if q.Error != nil {
-		return nil, errors.Wrap(q.Error, "fetching all pools")
+		Omit("extra_specs")
+	if filter.ProviderName != "" {
+		q = q.Where("provider_name = ?", filter.ProviderName)
+	}
+	if filter.Label != "" {
+		q = q.Where("id in (?)", s.poolsWithTag(filter.Label))
+	}
+	if filter.Enabled != nil {
+		q = q.Where("enabled = ?", *filter.Enabled)
+	}
+	q = applyTimeRange(q, filter.TimeRangeFilter)
+
+	q, err := paginate(q, filter.ListParams, parseUUIDCursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fetching all pools")
+	}
+
+	var pools []Pool
+	if err := q.Find(&pools).Error; err != nil {
+		return nil, "", errors.Wrap(err, "fetching all pools")
 	}
+	pools, next := nextPage(pools, filter.ListParams, func(p Pool) string { return p.ID.String() })
 
 	ret := make([]params.Pool, len(pools))
-	var err error
 	for idx, val := range pools {
 		ret[idx], err = s.sqlToCommonPool(val)
 		if err != nil {
-			return nil, errors.Wrap(err, "converting pool")
+			return nil, "", errors.Wrap(err, "converting pool")
 		}
 	}
-	return ret, nil
+	return ret, next, nil
 }
 
 func (s *sqlDatabase) GetPoolByID(_ context.Context, poolID string) (params.Pool, error) {
@@ -227,12 +252,22 @@ func (s *sqlDatabase) findPoolByTags(id string, poolType params.GithubEntityType
 		return nil, runnerErrors.ErrNotFound
 	}
 
-	ret := make([]params.Pool, len(pools))
-	for idx, val := range pools {
-		ret[idx], err = s.sqlToCommonPool(val)
+	ret := make([]params.Pool, 0, len(pools))
+	for _, val := range pools {
+		pool, err := s.sqlToCommonPool(val)
 		if err != nil {
 			return nil, errors.Wrap(err, "converting pool")
 		}
+		// Label rules are stored as json, and are evaluated here, rather than in
+		// the query.
+		if !pool.LabelRules.Allows(tags) {
+			continue
+		}
+		ret = append(ret, pool)
+	}
+
+	if len(ret) == 0 {
+		return nil, runnerErrors.ErrNotFound
 	}
 
 	return ret, nil
@@ -278,10 +313,33 @@ func (s *sqlDatabase) CreateEntityPool(_ context.Context, entity params.GithubEn
 		RunnerBootstrapTimeout: param.RunnerBootstrapTimeout,
 		GitHubRunnerGroup:      param.GitHubRunnerGroup,
 		Priority:               param.Priority,
+		Weight:                 param.Weight,
+		MaxIdleAge:             param.MaxIdleAge,
+		MaxLifetime:            param.MaxLifetime,
+		MaxConcurrentCreates:   param.MaxConcurrentCreates,
+		CreatesPerMinute:       param.CreatesPerMinute,
+		QuarantineThreshold:    param.QuarantineThreshold,
 	}
 	if len(param.ExtraSpecs) > 0 {
 		newPool.ExtraSpecs = datatypes.JSON(param.ExtraSpecs)
 	}
+	if len(param.ScalingSchedules) > 0 {
+		schedules, err := json.Marshal(param.ScalingSchedules)
+		if err != nil {
+			return params.Pool{}, errors.Wrap(err, "marshaling scaling schedules")
+		}
+		newPool.ScalingSchedules = schedules
+	}
+	policy, err := json.Marshal(param.ScaleDownPolicy)
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "marshaling scale down policy")
+	}
+	newPool.ScaleDownPolicy = policy
+	labelRules, err := json.Marshal(param.LabelRules)
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "marshaling label rules")
+	}
+	newPool.LabelRules = labelRules
 
 	entityID, err := uuid.Parse(entity.ID)
 	if err != nil {
@@ -403,6 +461,38 @@ func (s *sqlDatabase) UpdateEntityPool(_ context.Context, entity params.GithubEn
 	return updatedPool, nil
 }
 
+func (s *sqlDatabase) QuarantineEntityPool(_ context.Context, entity params.GithubEntity, poolID string, reason string) (quarantinedPool params.Pool, err error) {
+	defer func() {
+		if err == nil {
+			s.sendNotify(common.PoolEntityType, common.UpdateOperation, quarantinedPool)
+		}
+	}()
+	err = s.conn.Transaction(func(tx *gorm.DB) error {
+		pool, err := s.getEntityPool(tx, entity.EntityType, entity.ID, poolID, "Tags", "Instances")
+		if err != nil {
+			return errors.Wrap(err, "fetching pool")
+		}
+
+		now := time.Now().UTC()
+		pool.Enabled = false
+		pool.QuarantineReason = reason
+		pool.QuarantinedAt = &now
+		if q := tx.Save(&pool); q.Error != nil {
+			return errors.Wrap(q.Error, "saving database entry")
+		}
+
+		quarantinedPool, err = s.sqlToCommonPool(pool)
+		if err != nil {
+			return errors.Wrap(err, "converting pool")
+		}
+		return nil
+	})
+	if err != nil {
+		return params.Pool{}, err
+	}
+	return quarantinedPool, nil
+}
+
 func (s *sqlDatabase) ListEntityPools(_ context.Context, entity params.GithubEntity) ([]params.Pool, error) {
 	pools, err := s.listEntityPools(s.conn, entity.EntityType, entity.ID, "Tags")
 	if err != nil {
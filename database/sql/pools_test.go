@@ -54,8 +54,8 @@ func (s *PoolsTestSuite) assertSQLMockExpectations() {
 }
 
 func (s *PoolsTestSuite) SetupTest() {
-	// create testing sqlite database
-	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	// create testing database
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
 	if err != nil {
 		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
 	}
@@ -137,7 +137,7 @@ func (s *PoolsTestSuite) TestListAllPools() {
 
 func (s *PoolsTestSuite) TestListAllPoolsDBFetchErr() {
 	s.Fixtures.SQLMock.
-		ExpectQuery(regexp.QuoteMeta("SELECT `pools`.`id`,`pools`.`created_at`,`pools`.`updated_at`,`pools`.`deleted_at`,`pools`.`provider_name`,`pools`.`runner_prefix`,`pools`.`max_runners`,`pools`.`min_idle_runners`,`pools`.`runner_bootstrap_timeout`,`pools`.`image`,`pools`.`flavor`,`pools`.`os_type`,`pools`.`os_arch`,`pools`.`enabled`,`pools`.`git_hub_runner_group`,`pools`.`repo_id`,`pools`.`org_id`,`pools`.`enterprise_id`,`pools`.`priority` FROM `pools` WHERE `pools`.`deleted_at` IS NULL")).
+		ExpectQuery(regexp.QuoteMeta("SELECT `pools`.`id`,`pools`.`created_at`,`pools`.`updated_at`,`pools`.`deleted_at`,`pools`.`provider_name`,`pools`.`runner_prefix`,`pools`.`max_runners`,`pools`.`min_idle_runners`,`pools`.`runner_bootstrap_timeout`,`pools`.`image`,`pools`.`flavor`,`pools`.`os_type`,`pools`.`os_arch`,`pools`.`enabled`,`pools`.`git_hub_runner_group`,`pools`.`repo_id`,`pools`.`org_id`,`pools`.`enterprise_id`,`pools`.`priority`,`pools`.`weight`,`pools`.`max_idle_age`,`pools`.`max_lifetime`,`pools`.`max_concurrent_creates`,`pools`.`creates_per_minute`,`pools`.`quarantine_threshold`,`pools`.`quarantine_reason`,`pools`.`quarantined_at`,`pools`.`scaling_schedules`,`pools`.`scale_down_policy`,`pools`.`label_rules` FROM `pools` WHERE `pools`.`deleted_at` IS NULL")).
 		WillReturnError(fmt.Errorf("mocked fetching all pools error"))
 
 	_, err := s.StoreSQLMocked.ListAllPools(s.adminCtx)
@@ -147,6 +147,54 @@ func (s *PoolsTestSuite) TestListAllPoolsDBFetchErr() {
 	s.Require().Equal("fetching all pools: mocked fetching all pools error", err.Error())
 }
 
+func (s *PoolsTestSuite) TestListPoolsPagination() {
+	filter := params.PoolFilter{ListParams: params.ListParams{Limit: 2}}
+
+	page1, next, err := s.Store.ListPools(s.adminCtx, filter)
+	s.Require().Nil(err)
+	s.Require().Len(page1, 2)
+	s.Require().NotEmpty(next)
+
+	filter.Cursor = next
+	page2, next, err := s.Store.ListPools(s.adminCtx, filter)
+	s.Require().Nil(err)
+	s.Require().Len(page2, 1)
+	s.Require().Empty(next)
+
+	garmTesting.EqualDBEntityID(s.T(), s.Fixtures.Pools, append(page1, page2...))
+}
+
+func (s *PoolsTestSuite) TestListPoolsFilters() {
+	entity, err := s.Fixtures.Org.GetEntity()
+	s.Require().Nil(err)
+	enabled := true
+	enabledPool, err := s.Store.UpdateEntityPool(s.adminCtx, entity, s.Fixtures.Pools[0].ID, params.UpdatePoolParams{Enabled: &enabled})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name     string
+		filter   params.PoolFilter
+		expected []params.Pool
+	}{
+		{"provider", params.PoolFilter{ProviderName: "test-provider"}, s.Fixtures.Pools},
+		{"unknown provider", params.PoolFilter{ProviderName: "dummy-provider"}, []params.Pool{}},
+		{"label", params.PoolFilter{Label: "amd64-linux-runner"}, s.Fixtures.Pools},
+		{"unknown label", params.PoolFilter{Label: "arm64"}, []params.Pool{}},
+		{"enabled", params.PoolFilter{Enabled: &enabled}, []params.Pool{enabledPool}},
+	}
+	for _, tc := range tests {
+		pools, _, err := s.Store.ListPools(s.adminCtx, tc.filter)
+		s.Require().Nil(err, tc.name)
+		garmTesting.EqualDBEntityID(s.T(), tc.expected, pools)
+	}
+}
+
+func (s *PoolsTestSuite) TestListPoolsInvalidCursor() {
+	_, _, err := s.Store.ListPools(s.adminCtx, params.PoolFilter{ListParams: params.ListParams{Cursor: encodeCursor("not-a-uuid")}})
+
+	s.Require().Equal("fetching all pools: invalid cursor", err.Error())
+}
+
 func (s *PoolsTestSuite) TestGetPoolByID() {
 	pool, err := s.Store.GetPoolByID(s.adminCtx, s.Fixtures.Pools[0].ID)
 
@@ -194,6 +242,169 @@ func (s *PoolsTestSuite) TestDeletePoolByIDDBRemoveErr() {
 	s.Require().Equal("removing pool: mocked removing pool error", err.Error())
 }
 
+func (s *PoolsTestSuite) TestCreatePoolWithScalingSchedules() {
+	entity, err := s.Fixtures.Org.GetEntity()
+	s.Require().Nil(err)
+	var maxRunners uint = 10
+	schedules := []params.PoolScalingSchedule{
+		{
+			Name:           "business-hours",
+			Days:           "mon-fri",
+			Start:          "08:00",
+			End:            "18:00",
+			Timezone:       "Europe/Bucharest",
+			MinIdleRunners: 5,
+			MaxRunners:     &maxRunners,
+		},
+	}
+
+	pool, err := s.Store.CreateEntityPool(
+		s.adminCtx,
+		entity,
+		params.CreatePoolParams{
+			ProviderName:     "test-provider",
+			MaxRunners:       4,
+			MinIdleRunners:   2,
+			Image:            "test-image-schedules",
+			Flavor:           "test-flavor",
+			OSType:           "linux",
+			Tags:             []string{"amd64-linux-runner"},
+			ScalingSchedules: schedules,
+		},
+	)
+	s.Require().Nil(err)
+	s.Require().Equal(schedules, pool.ScalingSchedules)
+
+	pool, err = s.Store.GetPoolByID(s.adminCtx, pool.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(schedules, pool.ScalingSchedules)
+}
+
+func (s *PoolsTestSuite) TestUpdatePoolScalingSchedules() {
+	entity, err := s.Fixtures.Org.GetEntity()
+	s.Require().Nil(err)
+	schedules := []params.PoolScalingSchedule{
+		{
+			Days:           "sat,sun",
+			MinIdleRunners: 0,
+		},
+	}
+
+	pool, err := s.Store.UpdateEntityPool(s.adminCtx, entity, s.Fixtures.Pools[0].ID, params.UpdatePoolParams{ScalingSchedules: schedules})
+	s.Require().Nil(err)
+	s.Require().Equal(schedules, pool.ScalingSchedules)
+
+	// A nil value leaves the schedules untouched.
+	var maxRunners uint = 8
+	pool, err = s.Store.UpdateEntityPool(s.adminCtx, entity, s.Fixtures.Pools[0].ID, params.UpdatePoolParams{MaxRunners: &maxRunners})
+	s.Require().Nil(err)
+	s.Require().Equal(schedules, pool.ScalingSchedules)
+
+	// An empty list removes them.
+	pool, err = s.Store.UpdateEntityPool(s.adminCtx, entity, s.Fixtures.Pools[0].ID, params.UpdatePoolParams{ScalingSchedules: []params.PoolScalingSchedule{}})
+	s.Require().Nil(err)
+	s.Require().Len(pool.ScalingSchedules, 0)
+}
+
+func (s *PoolsTestSuite) TestUpdatePoolScaleDownPolicy() {
+	entity, err := s.Fixtures.Org.GetEntity()
+	s.Require().Nil(err)
+	s.Require().Equal(params.PoolScaleDownPolicy{}, s.Fixtures.Pools[0].ScaleDownPolicy)
+
+	idleGracePeriod := uint(0)
+	policy := params.PoolScaleDownPolicy{
+		Factor:          1,
+		IdleGracePeriod: &idleGracePeriod,
+		MinRunnerAge:    30,
+		Order:           params.ScaleDownOrderNewestFirst,
+	}
+	pool, err := s.Store.UpdateEntityPool(s.adminCtx, entity, s.Fixtures.Pools[0].ID, params.UpdatePoolParams{ScaleDownPolicy: &policy})
+	s.Require().Nil(err)
+	s.Require().Equal(policy, pool.ScaleDownPolicy)
+
+	pool, err = s.Store.GetPoolByID(s.adminCtx, pool.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(policy, pool.ScaleDownPolicy)
+}
+
+func (s *PoolsTestSuite) TestUpdatePoolWeight() {
+	entity, err := s.Fixtures.Org.GetEntity()
+	s.Require().Nil(err)
+	s.Require().Equal(uint(1), s.Fixtures.Pools[0].GetWeight())
+
+	weight := uint(70)
+	pool, err := s.Store.UpdateEntityPool(s.adminCtx, entity, s.Fixtures.Pools[0].ID, params.UpdatePoolParams{Weight: &weight})
+	s.Require().Nil(err)
+	s.Require().Equal(weight, pool.Weight)
+
+	pool, err = s.Store.GetPoolByID(s.adminCtx, pool.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(weight, pool.Weight)
+}
+
+func (s *PoolsTestSuite) TestQuarantinePool() {
+	entity, err := s.Fixtures.Org.GetEntity()
+	s.Require().Nil(err)
+
+	pool, err := s.Store.QuarantineEntityPool(s.adminCtx, entity, s.Fixtures.Pools[0].ID, "3 consecutive bootstrap failures")
+	s.Require().Nil(err)
+	s.Require().False(pool.Enabled)
+	s.Require().True(pool.IsQuarantined())
+	s.Require().Equal("3 consecutive bootstrap failures", pool.QuarantineReason)
+
+	pool, err = s.Store.GetPoolByID(s.adminCtx, pool.ID)
+	s.Require().Nil(err)
+	s.Require().True(pool.IsQuarantined())
+
+	enabled := true
+	pool, err = s.Store.UpdateEntityPool(s.adminCtx, entity, pool.ID, params.UpdatePoolParams{Enabled: &enabled})
+	s.Require().Nil(err)
+	s.Require().True(pool.Enabled)
+	s.Require().False(pool.IsQuarantined())
+	s.Require().Empty(pool.QuarantineReason)
+}
+
+func (s *PoolsTestSuite) TestQuarantinePoolNotFound() {
+	entity, err := s.Fixtures.Org.GetEntity()
+	s.Require().Nil(err)
+
+	_, err = s.Store.QuarantineEntityPool(s.adminCtx, entity, "dummy-pool-id", "reason")
+	s.Require().NotNil(err)
+}
+
+func (s *PoolsTestSuite) TestFindPoolsMatchingAllTagsLabelRules() {
+	entity, err := s.Fixtures.Org.GetEntity()
+	s.Require().Nil(err)
+
+	enabled := true
+	_, err = s.Store.UpdateEntityPool(s.adminCtx, entity, s.Fixtures.Pools[0].ID, params.UpdatePoolParams{
+		Enabled: &enabled,
+		Tags:    []string{"linux", "gpu"},
+		LabelRules: &params.PoolLabelRules{
+			RequiredLabels: []string{"gpu"},
+		},
+	})
+	s.Require().Nil(err)
+	_, err = s.Store.UpdateEntityPool(s.adminCtx, entity, s.Fixtures.Pools[1].ID, params.UpdatePoolParams{
+		Enabled: &enabled,
+		Tags:    []string{"linux", "gpu"},
+		LabelRules: &params.PoolLabelRules{
+			ExcludedLabels: []string{"gpu"},
+		},
+	})
+	s.Require().Nil(err)
+
+	pools, err := s.Store.FindPoolsMatchingAllTags(s.adminCtx, entity.EntityType, entity.ID, []string{"linux"})
+	s.Require().Nil(err)
+	s.Require().Len(pools, 1)
+	s.Require().Equal(s.Fixtures.Pools[1].ID, pools[0].ID)
+
+	pools, err = s.Store.FindPoolsMatchingAllTags(s.adminCtx, entity.EntityType, entity.ID, []string{"linux", "gpu"})
+	s.Require().Nil(err)
+	s.Require().Len(pools, 1)
+	s.Require().Equal(s.Fixtures.Pools[0].ID, pools[0].ID)
+}
+
 func TestPoolsTestSuite(t *testing.T) {
 	t.Parallel()
 	suite.Run(t, new(PoolsTestSuite))
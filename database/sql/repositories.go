@@ -16,6 +16,7 @@ package sql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 
@@ -192,6 +193,30 @@ func (s *sqlDatabase) UpdateRepository(ctx context.Context, repoID string, param
 			repo.PoolBalancerType = param.PoolBalancerType
 		}
 
+		if param.MaxConcurrentRunners != nil {
+			repo.MaxConcurrentRunners = *param.MaxConcurrentRunners
+		}
+
+		if param.MaxPendingRunners != nil {
+			repo.MaxPendingRunners = *param.MaxPendingRunners
+		}
+
+		if param.JobBackfill != nil {
+			backfill, err := json.Marshal(param.JobBackfill)
+			if err != nil {
+				return errors.Wrap(err, "marshaling job backfill settings")
+			}
+			repo.JobBackfill = backfill
+		}
+
+		if param.FairShare != nil {
+			fairShare, err := json.Marshal(param.FairShare)
+			if err != nil {
+				return errors.Wrap(err, "marshaling fair share settings")
+			}
+			repo.FairShare = fairShare
+		}
+
 		q := tx.Save(&repo)
 		if q.Error != nil {
 			return errors.Wrap(q.Error, "saving repo")
@@ -93,8 +93,8 @@ func (s *RepoTestSuite) assertSQLMockExpectations() {
 }
 
 func (s *RepoTestSuite) SetupTest() {
-	// create testing sqlite database
-	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	// create testing database
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
 	if err != nil {
 		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
 	}
@@ -215,7 +215,7 @@ func (s *RepoTestSuite) TestCreateRepository() {
 }
 
 func (s *RepoTestSuite) TestCreateRepositoryInvalidDBPassphrase() {
-	cfg := garmTesting.GetTestSqliteDBConfig(s.T())
+	cfg := garmTesting.GetTestDBConfig(s.T())
 	conn, err := newDBConn(cfg)
 	if err != nil {
 		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
@@ -387,6 +387,48 @@ func (s *RepoTestSuite) TestUpdateRepository() {
 	s.Require().Equal(s.Fixtures.UpdateRepoParams.WebhookSecret, repo.WebhookSecret)
 }
 
+func (s *RepoTestSuite) TestUpdateRepositoryJobBackfill() {
+	param := s.Fixtures.UpdateRepoParams
+	param.JobBackfill = &params.JobBackfillSettings{
+		Enabled: true,
+	}
+	repo, err := s.Store.UpdateRepository(s.adminCtx, s.Fixtures.Repos[0].ID, param)
+	s.Require().Nil(err)
+	s.Require().True(repo.JobBackfill.Enabled)
+
+	repo, err = s.Store.GetRepositoryByID(s.adminCtx, s.Fixtures.Repos[0].ID)
+	s.Require().Nil(err)
+	s.Require().True(repo.JobBackfill.Enabled)
+}
+
+func (s *RepoTestSuite) TestUpdateRepositoryRunnerLimits() {
+	maxConcurrent := uint(10)
+	maxPending := uint(2)
+	param := s.Fixtures.UpdateRepoParams
+	param.MaxConcurrentRunners = &maxConcurrent
+	param.MaxPendingRunners = &maxPending
+	repo, err := s.Store.UpdateRepository(s.adminCtx, s.Fixtures.Repos[0].ID, param)
+	s.Require().Nil(err)
+	s.Require().Equal(maxConcurrent, repo.MaxConcurrentRunners)
+	s.Require().Equal(maxPending, repo.MaxPendingRunners)
+
+	// Limits that are not set in the update are left untouched.
+	repo, err = s.Store.UpdateRepository(s.adminCtx, s.Fixtures.Repos[0].ID, params.UpdateEntityParams{})
+	s.Require().Nil(err)
+	s.Require().Equal(maxConcurrent, repo.MaxConcurrentRunners)
+
+	unlimited := uint(0)
+	param.MaxConcurrentRunners = &unlimited
+	param.MaxPendingRunners = nil
+	_, err = s.Store.UpdateRepository(s.adminCtx, s.Fixtures.Repos[0].ID, param)
+	s.Require().Nil(err)
+
+	repo, err = s.Store.GetRepositoryByID(s.adminCtx, s.Fixtures.Repos[0].ID)
+	s.Require().Nil(err)
+	s.Require().Equal(uint(0), repo.MaxConcurrentRunners)
+	s.Require().Equal(maxPending, repo.MaxPendingRunners)
+}
+
 func (s *RepoTestSuite) TestUpdateRepositoryInvalidRepoID() {
 	_, err := s.Store.UpdateRepository(s.adminCtx, "dummy-repo-id", s.Fixtures.UpdateRepoParams)
 
@@ -23,6 +23,7 @@ import (
 
 	"github.com/pkg/errors"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -53,6 +54,8 @@ func newDBConn(dbCfg config.Database) (conn *gorm.DB, err error) {
 		conn, err = gorm.Open(mysql.Open(connURI), gormConfig)
 	case config.SQLiteBackend:
 		conn, err = gorm.Open(sqlite.Open(connURI), gormConfig)
+	case config.PostgresBackend:
+		conn, err = gorm.Open(postgres.Open(connURI), gormConfig)
 	}
 	if err != nil {
 		return nil, errors.Wrap(err, "connecting to database")
@@ -86,6 +89,20 @@ func NewSQLDatabase(ctx context.Context, cfg config.Database) (common.Store, err
 	return db, nil
 }
 
+// NewSchemaMigrator returns a SchemaMigrator for the database, without applying any
+// migration.
+func NewSchemaMigrator(ctx context.Context, cfg config.Database) (common.SchemaMigrator, error) {
+	conn, err := newDBConn(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating DB connection")
+	}
+	return &sqlDatabase{
+		conn: conn,
+		ctx:  ctx,
+		cfg:  cfg,
+	}, nil
+}
+
 type sqlDatabase struct {
 	conn     *gorm.DB
 	ctx      context.Context
@@ -118,6 +135,39 @@ DROP TABLE %s_old;
 COMMIT;
 `
 
+// setForeignKeys turns foreign key enforcement on or off. This is only needed for
+// SQLite, where tables are recreated during migrations.
+func (s *sqlDatabase) setForeignKeys(enabled bool) {
+	if s.cfg.DbBackend != config.SQLiteBackend {
+		return
+	}
+	if enabled {
+		s.conn.Exec("PRAGMA foreign_keys = ON")
+	} else {
+		s.conn.Exec("PRAGMA foreign_keys = OFF")
+	}
+}
+
+// ensureNocaseCollation creates the case insensitive "nocase" collation used by the
+// models and queries. SQLite has it built in. In PostgreSQL it needs to be created
+// as a nondeterministic ICU collation.
+func (s *sqlDatabase) ensureNocaseCollation() error {
+	if s.cfg.DbBackend != config.PostgresBackend {
+		return nil
+	}
+	var count int64
+	if err := s.conn.Raw("select count(*) from pg_collation where collname = 'nocase'").Scan(&count).Error; err != nil {
+		return errors.Wrap(err, "fetching collations")
+	}
+	if count > 0 {
+		return nil
+	}
+	if err := s.conn.Exec("create collation nocase (provider = icu, locale = 'und-u-ks-level2', deterministic = false)").Error; err != nil {
+		return errors.Wrap(err, "creating nocase collation")
+	}
+	return nil
+}
+
 func (s *sqlDatabase) cascadeMigrationSQLite(model interface{}, name string, justDrop bool) error {
 	if !s.conn.Migrator().HasTable(name) {
 		return nil
@@ -194,7 +244,7 @@ func (s *sqlDatabase) cascadeMigration() error {
 		if err := s.cascadeMigrationSQLite(&WorkflowJob{}, "workflow_jobs", false); err != nil {
 			return fmt.Errorf("failed to migrate addresses: %w", err)
 		}
-	case config.MySQLBackend:
+	case config.MySQLBackend, config.PostgresBackend:
 		return nil
 	default:
 		return fmt.Errorf("invalid db backend: %s", s.cfg.DbBackend)
@@ -222,8 +272,8 @@ func (s *sqlDatabase) ensureGithubEndpoint() error {
 }
 
 func (s *sqlDatabase) migrateCredentialsToDB() (err error) {
-	s.conn.Exec("PRAGMA foreign_keys = OFF")
-	defer s.conn.Exec("PRAGMA foreign_keys = ON")
+	s.setForeignKeys(false)
+	defer s.setForeignKeys(true)
 
 	adminUser, err := s.GetAdminUser(s.ctx)
 	if err != nil {
@@ -359,7 +409,11 @@ func (s *sqlDatabase) migrateCredentialsToDB() (err error) {
 	return nil
 }
 
-func (s *sqlDatabase) migrateDB() error {
+// migrateBaselineSchema brings the database to the schema GARM had before versioned
+// migrations were introduced. It runs the legacy fixups and creates the tables,
+// so it works on empty databases as well as on databases created by older
+// releases.
+func (s *sqlDatabase) migrateBaselineSchema() error {
 	if s.conn.Migrator().HasIndex(&Organization{}, "idx_organizations_name") {
 		if err := s.conn.Migrator().DropIndex(&Organization{}, "idx_organizations_name"); err != nil {
 			slog.With(slog.Any("error", err)).Error("failed to drop index idx_organizations_name")
@@ -394,7 +448,7 @@ func (s *sqlDatabase) migrateDB() error {
 		if s.conn.Migrator().HasColumn(&WorkflowJob{}, "runner_name") {
 			// Remove jobs that are not in "queued" status. We really only care about queued jobs. Once they transition
 			// to something else, we don't really consume them anyway.
-			if err := s.conn.Exec("delete from workflow_jobs where status is not 'queued'").Error; err != nil {
+			if err := s.conn.Exec("delete from workflow_jobs where status is null or status <> 'queued'").Error; err != nil {
 				return errors.Wrap(err, "updating workflow_jobs")
 			}
 			if err := s.conn.Migrator().DropColumn(&WorkflowJob{}, "runner_name"); err != nil {
@@ -407,7 +461,11 @@ func (s *sqlDatabase) migrateDB() error {
 	if !s.conn.Migrator().HasTable(&GithubCredentials{}) || !s.conn.Migrator().HasTable(&GithubEndpoint{}) {
 		needsCredentialMigration = true
 	}
-	s.conn.Exec("PRAGMA foreign_keys = OFF")
+	if err := s.ensureNocaseCollation(); err != nil {
+		return errors.Wrap(err, "ensuring collation")
+	}
+
+	s.setForeignKeys(false)
 	if err := s.conn.AutoMigrate(
 		&User{},
 		&GithubEndpoint{},
@@ -425,7 +483,7 @@ func (s *sqlDatabase) migrateDB() error {
 	); err != nil {
 		return errors.Wrap(err, "running auto migrate")
 	}
-	s.conn.Exec("PRAGMA foreign_keys = ON")
+	s.setForeignKeys(true)
 
 	if err := s.ensureGithubEndpoint(); err != nil {
 		return errors.Wrap(err, "ensuring github endpoint")
@@ -438,3 +496,16 @@ func (s *sqlDatabase) migrateDB() error {
 	}
 	return nil
 }
+
+// migrateDB applies all pending schema migrations. It refuses to run against a
+// database with a schema newer than the migrations known to this binary.
+func (s *sqlDatabase) migrateDB() error {
+	if err := s.MigrateUp(s.ctx, 0); err != nil {
+		return errors.Wrap(err, "applying schema migrations")
+	}
+
+	if err := s.ensureGithubEndpoint(); err != nil {
+		return errors.Wrap(err, "ensuring github endpoint")
+	}
+	return nil
+}
@@ -0,0 +1,586 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm-provider-common/util"
+	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+func rawJSON(data datatypes.JSON) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.RawMessage(data)
+}
+
+func entityCredentialsName(creds GithubCredentials, legacyName string) string {
+	if creds.Name != "" {
+		return creds.Name
+	}
+	return legacyName
+}
+
+func (s *sqlDatabase) unsealWebhookSecret(secret []byte) (string, error) {
+	if len(secret) == 0 {
+		return "", nil
+	}
+	data, err := util.Unseal(secret, []byte(s.cfg.Passphrase))
+	if err != nil {
+		return "", errors.Wrap(err, "decrypting secret")
+	}
+	return string(data), nil
+}
+
+func (s *sqlDatabase) sealWebhookSecret(secret string) ([]byte, error) {
+	if secret == "" {
+		return nil, nil
+	}
+	data, err := util.Seal([]byte(secret), []byte(s.cfg.Passphrase))
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting secret")
+	}
+	return data, nil
+}
+
+// ExportState returns the configuration of this controller. Secrets are unsealed
+// using the passphrase of this database.
+func (s *sqlDatabase) ExportState(_ context.Context) (common.ControllerState, error) {
+	state := common.ControllerState{
+		Version:    common.StateVersion,
+		ExportedAt: time.Now().UTC(),
+	}
+
+	var info ControllerInfo
+	if err := s.conn.Model(&ControllerInfo{}).First(&info).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.ControllerState{}, errors.Wrap(runnerErrors.ErrNotFound, "fetching controller info")
+		}
+		return common.ControllerState{}, errors.Wrap(err, "fetching controller info")
+	}
+	state.Controller = common.StateController{
+		ControllerID:   info.ControllerID,
+		CallbackURL:    info.CallbackURL,
+		MetadataURL:    info.MetadataURL,
+		WebhookBaseURL: info.WebhookBaseURL,
+	}
+
+	var users []User
+	if err := s.conn.Order("created_at asc").Find(&users).Error; err != nil {
+		return common.ControllerState{}, errors.Wrap(err, "fetching users")
+	}
+	for _, user := range users {
+		state.Users = append(state.Users, common.StateUser{
+			ID:       user.ID,
+			Username: user.Username,
+			FullName: user.FullName,
+			Email:    user.Email,
+			Password: user.Password,
+			IsAdmin:  user.IsAdmin,
+			Role:     user.Role,
+			Enabled:  user.Enabled,
+		})
+	}
+
+	var endpoints []GithubEndpoint
+	if err := s.conn.Order("name asc").Find(&endpoints).Error; err != nil {
+		return common.ControllerState{}, errors.Wrap(err, "fetching github endpoints")
+	}
+	for _, ep := range endpoints {
+		state.Endpoints = append(state.Endpoints, common.StateGithubEndpoint{
+			Name:          ep.Name,
+			Description:   ep.Description,
+			APIBaseURL:    ep.APIBaseURL,
+			UploadBaseURL: ep.UploadBaseURL,
+			BaseURL:       ep.BaseURL,
+			CACertBundle:  ep.CACertBundle,
+		})
+	}
+
+	var creds []GithubCredentials
+	if err := s.conn.Order("id asc").Find(&creds).Error; err != nil {
+		return common.ControllerState{}, errors.Wrap(err, "fetching github credentials")
+	}
+	for _, cred := range creds {
+		var secret json.RawMessage
+		if err := s.unsealAndUnmarshal(cred.Payload, &secret); err != nil {
+			return common.ControllerState{}, errors.Wrapf(err, "unsealing credentials %s", cred.Name)
+		}
+		stateCreds := common.StateGithubCredential{
+			Name:        cred.Name,
+			Description: cred.Description,
+			UserID:      cred.UserID,
+			AuthType:    cred.AuthType,
+			Secret:      secret,
+		}
+		if cred.EndpointName != nil {
+			stateCreds.EndpointName = *cred.EndpointName
+		}
+		state.Credentials = append(state.Credentials, stateCreds)
+	}
+
+	var repos []Repository
+	if err := s.conn.Preload("Credentials").Order("created_at asc").Find(&repos).Error; err != nil {
+		return common.ControllerState{}, errors.Wrap(err, "fetching repositories")
+	}
+	for _, repo := range repos {
+		secret, err := s.unsealWebhookSecret(repo.WebhookSecret)
+		if err != nil {
+			return common.ControllerState{}, errors.Wrapf(err, "unsealing webhook secret of %s/%s", repo.Owner, repo.Name)
+		}
+		stateRepo := common.StateRepository{
+			StateEntity: common.StateEntity{
+				ID:                   repo.ID,
+				CredentialsName:      entityCredentialsName(repo.Credentials, repo.CredentialsName),
+				WebhookSecret:        secret,
+				PoolBalancerType:     repo.PoolBalancerType,
+				FairShare:            rawJSON(repo.FairShare),
+				MaxConcurrentRunners: repo.MaxConcurrentRunners,
+				MaxPendingRunners:    repo.MaxPendingRunners,
+			},
+			Owner:       repo.Owner,
+			Name:        repo.Name,
+			JobBackfill: rawJSON(repo.JobBackfill),
+		}
+		if repo.EndpointName != nil {
+			stateRepo.EndpointName = *repo.EndpointName
+		}
+		state.Repositories = append(state.Repositories, stateRepo)
+	}
+
+	var orgs []Organization
+	if err := s.conn.Preload("Credentials").Order("created_at asc").Find(&orgs).Error; err != nil {
+		return common.ControllerState{}, errors.Wrap(err, "fetching organizations")
+	}
+	for _, org := range orgs {
+		secret, err := s.unsealWebhookSecret(org.WebhookSecret)
+		if err != nil {
+			return common.ControllerState{}, errors.Wrapf(err, "unsealing webhook secret of %s", org.Name)
+		}
+		stateOrg := common.StateOrganization{
+			StateEntity: common.StateEntity{
+				ID:                   org.ID,
+				CredentialsName:      entityCredentialsName(org.Credentials, org.CredentialsName),
+				WebhookSecret:        secret,
+				PoolBalancerType:     org.PoolBalancerType,
+				FairShare:            rawJSON(org.FairShare),
+				MaxConcurrentRunners: org.MaxConcurrentRunners,
+				MaxPendingRunners:    org.MaxPendingRunners,
+			},
+			Name:        org.Name,
+			JobBackfill: rawJSON(org.JobBackfill),
+		}
+		if org.EndpointName != nil {
+			stateOrg.EndpointName = *org.EndpointName
+		}
+		state.Organizations = append(state.Organizations, stateOrg)
+	}
+
+	var enterprises []Enterprise
+	if err := s.conn.Preload("Credentials").Order("created_at asc").Find(&enterprises).Error; err != nil {
+		return common.ControllerState{}, errors.Wrap(err, "fetching enterprises")
+	}
+	for _, ent := range enterprises {
+		secret, err := s.unsealWebhookSecret(ent.WebhookSecret)
+		if err != nil {
+			return common.ControllerState{}, errors.Wrapf(err, "unsealing webhook secret of %s", ent.Name)
+		}
+		stateEnt := common.StateEnterprise{
+			StateEntity: common.StateEntity{
+				ID:                   ent.ID,
+				CredentialsName:      entityCredentialsName(ent.Credentials, ent.CredentialsName),
+				WebhookSecret:        secret,
+				PoolBalancerType:     ent.PoolBalancerType,
+				FairShare:            rawJSON(ent.FairShare),
+				MaxConcurrentRunners: ent.MaxConcurrentRunners,
+				MaxPendingRunners:    ent.MaxPendingRunners,
+			},
+			Name: ent.Name,
+		}
+		if ent.EndpointName != nil {
+			stateEnt.EndpointName = *ent.EndpointName
+		}
+		state.Enterprises = append(state.Enterprises, stateEnt)
+	}
+
+	var pools []Pool
+	if err := s.conn.Preload("Tags").Order("created_at asc").Find(&pools).Error; err != nil {
+		return common.ControllerState{}, errors.Wrap(err, "fetching pools")
+	}
+	for _, pool := range pools {
+		tags := make([]string, 0, len(pool.Tags))
+		for _, tag := range pool.Tags {
+			tags = append(tags, tag.Name)
+		}
+		state.Pools = append(state.Pools, common.StatePool{
+			ID:                     pool.ID,
+			RepoID:                 pool.RepoID,
+			OrgID:                  pool.OrgID,
+			EnterpriseID:           pool.EnterpriseID,
+			ProviderName:           pool.ProviderName,
+			RunnerPrefix:           pool.RunnerPrefix,
+			MaxRunners:             pool.MaxRunners,
+			MinIdleRunners:         pool.MinIdleRunners,
+			RunnerBootstrapTimeout: pool.RunnerBootstrapTimeout,
+			Image:                  pool.Image,
+			Flavor:                 pool.Flavor,
+			OSType:                 pool.OSType,
+			OSArch:                 pool.OSArch,
+			Tags:                   tags,
+			Enabled:                pool.Enabled,
+			ExtraSpecs:             rawJSON(pool.ExtraSpecs),
+			GitHubRunnerGroup:      pool.GitHubRunnerGroup,
+			Priority:               pool.Priority,
+			Weight:                 pool.Weight,
+			MaxIdleAge:             pool.MaxIdleAge,
+			MaxLifetime:            pool.MaxLifetime,
+			MaxConcurrentCreates:   pool.MaxConcurrentCreates,
+			CreatesPerMinute:       pool.CreatesPerMinute,
+			QuarantineThreshold:    pool.QuarantineThreshold,
+			QuarantineReason:       pool.QuarantineReason,
+			QuarantinedAt:          pool.QuarantinedAt,
+			ScalingSchedules:       rawJSON(pool.ScalingSchedules),
+			ScaleDownPolicy:        rawJSON(pool.ScaleDownPolicy),
+			LabelRules:             rawJSON(pool.LabelRules),
+		})
+	}
+
+	var sinks []NotificationSink
+	if err := s.conn.Order("id asc").Find(&sinks).Error; err != nil {
+		return common.ControllerState{}, errors.Wrap(err, "fetching notification sinks")
+	}
+	for _, sink := range sinks {
+		var payload notificationSinkPayload
+		if err := s.unsealAndUnmarshal(sink.Payload, &payload); err != nil {
+			return common.ControllerState{}, errors.Wrapf(err, "unsealing notification sink %s", sink.Name)
+		}
+		state.NotificationSinks = append(state.NotificationSinks, common.StateNotificationSink{
+			Name:        sink.Name,
+			Description: sink.Description,
+			Format:      params.NotificationFormat(sink.Format),
+			URL:         payload.URL,
+			Headers:     payload.Headers,
+			Rules:       rawJSON(sink.Rules),
+			Enabled:     sink.Enabled,
+		})
+	}
+
+	return state, nil
+}
+
+// ensureEmptyForImport returns an error if the database already holds a
+// controller configuration.
+func (s *sqlDatabase) ensureEmptyForImport(tx *gorm.DB) error {
+	models := []struct {
+		name  string
+		model interface{}
+	}{
+		{"controller info", &ControllerInfo{}},
+		{"users", &User{}},
+		{"github credentials", &GithubCredentials{}},
+		{"repositories", &Repository{}},
+		{"organizations", &Organization{}},
+		{"enterprises", &Enterprise{}},
+		{"pools", &Pool{}},
+		{"notification sinks", &NotificationSink{}},
+	}
+	for _, m := range models {
+		var count int64
+		if err := tx.Model(m.model).Count(&count).Error; err != nil {
+			return errors.Wrapf(err, "counting %s", m.name)
+		}
+		if count > 0 {
+			return runnerErrors.NewConflictError("destination database is not empty (found %s)", m.name)
+		}
+	}
+	return nil
+}
+
+func (s *sqlDatabase) importEndpoint(tx *gorm.DB, param common.StateGithubEndpoint) error {
+	var endpoint GithubEndpoint
+	err := tx.Where("name = ?", param.Name).First(&endpoint).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.Wrap(err, "fetching github endpoint")
+	}
+	endpoint.Name = param.Name
+	endpoint.Description = param.Description
+	endpoint.APIBaseURL = param.APIBaseURL
+	endpoint.UploadBaseURL = param.UploadBaseURL
+	endpoint.BaseURL = param.BaseURL
+	endpoint.CACertBundle = param.CACertBundle
+	if err != nil {
+		return tx.Create(&endpoint).Error
+	}
+	return tx.Save(&endpoint).Error
+}
+
+func optionalName(name string) *string {
+	if name == "" {
+		return nil
+	}
+	return &name
+}
+
+// ImportState loads a controller configuration produced by ExportState into this
+// database. Secrets are sealed using the passphrase of this database. The
+// import is done in a single transaction and is refused if the database already
+// holds a controller configuration.
+func (s *sqlDatabase) ImportState(_ context.Context, state common.ControllerState) error {
+	if state.Version < 1 || state.Version > common.StateVersion {
+		return runnerErrors.NewBadRequestError("unsupported state version %d", state.Version)
+	}
+	if state.Controller.ControllerID == uuid.Nil {
+		return runnerErrors.NewBadRequestError("missing controller ID")
+	}
+
+	return s.conn.Transaction(func(tx *gorm.DB) error {
+		if err := s.ensureEmptyForImport(tx); err != nil {
+			return err
+		}
+
+		info := ControllerInfo{
+			ControllerID:   state.Controller.ControllerID,
+			CallbackURL:    state.Controller.CallbackURL,
+			MetadataURL:    state.Controller.MetadataURL,
+			WebhookBaseURL: state.Controller.WebhookBaseURL,
+		}
+		if err := tx.Create(&info).Error; err != nil {
+			return errors.Wrap(err, "saving controller info")
+		}
+
+		for _, user := range state.Users {
+			role := params.UserRole(user.Role)
+			if role == "" {
+				role = params.UserRoleViewer
+				if user.IsAdmin {
+					role = params.UserRoleAdmin
+				}
+			}
+			newUser := User{
+				Base:     Base{ID: user.ID},
+				Username: user.Username,
+				FullName: user.FullName,
+				Email:    user.Email,
+				Password: user.Password,
+				IsAdmin:  role == params.UserRoleAdmin,
+				Role:     string(role),
+				Enabled:  user.Enabled,
+			}
+			if err := tx.Create(&newUser).Error; err != nil {
+				return errors.Wrapf(err, "creating user %s", user.Username)
+			}
+		}
+
+		for _, ep := range state.Endpoints {
+			if err := s.importEndpoint(tx, ep); err != nil {
+				return errors.Wrapf(err, "importing github endpoint %s", ep.Name)
+			}
+		}
+
+		credentialIDs := map[string]uint{}
+		for _, cred := range state.Credentials {
+			payload, err := s.marshalAndSeal(cred.Secret)
+			if err != nil {
+				return errors.Wrapf(err, "sealing credentials %s", cred.Name)
+			}
+			newCreds := GithubCredentials{
+				Name:         cred.Name,
+				Description:  cred.Description,
+				UserID:       cred.UserID,
+				AuthType:     cred.AuthType,
+				EndpointName: optionalName(cred.EndpointName),
+				Payload:      payload,
+			}
+			if err := tx.Create(&newCreds).Error; err != nil {
+				return errors.Wrapf(err, "creating credentials %s", cred.Name)
+			}
+			credentialIDs[cred.Name] = newCreds.ID
+		}
+
+		credentialsID := func(name string) (*uint, error) {
+			if name == "" {
+				return nil, nil
+			}
+			id, ok := credentialIDs[name]
+			if !ok {
+				return nil, runnerErrors.NewBadRequestError("unknown credentials %s", name)
+			}
+			return &id, nil
+		}
+
+		for _, repo := range state.Repositories {
+			credsID, err := credentialsID(repo.CredentialsName)
+			if err != nil {
+				return errors.Wrapf(err, "importing repository %s/%s", repo.Owner, repo.Name)
+			}
+			secret, err := s.sealWebhookSecret(repo.WebhookSecret)
+			if err != nil {
+				return errors.Wrapf(err, "importing repository %s/%s", repo.Owner, repo.Name)
+			}
+			newRepo := Repository{
+				Base:                 Base{ID: repo.ID},
+				CredentialsName:      repo.CredentialsName,
+				CredentialsID:        credsID,
+				Owner:                repo.Owner,
+				Name:                 repo.Name,
+				WebhookSecret:        secret,
+				PoolBalancerType:     repo.PoolBalancerType,
+				JobBackfill:          datatypes.JSON(repo.JobBackfill),
+				FairShare:            datatypes.JSON(repo.FairShare),
+				MaxConcurrentRunners: repo.MaxConcurrentRunners,
+				MaxPendingRunners:    repo.MaxPendingRunners,
+				EndpointName:         optionalName(repo.EndpointName),
+			}
+			if err := tx.Omit("Credentials", "Endpoint").Create(&newRepo).Error; err != nil {
+				return errors.Wrapf(err, "creating repository %s/%s", repo.Owner, repo.Name)
+			}
+		}
+
+		for _, org := range state.Organizations {
+			credsID, err := credentialsID(org.CredentialsName)
+			if err != nil {
+				return errors.Wrapf(err, "importing organization %s", org.Name)
+			}
+			secret, err := s.sealWebhookSecret(org.WebhookSecret)
+			if err != nil {
+				return errors.Wrapf(err, "importing organization %s", org.Name)
+			}
+			newOrg := Organization{
+				Base:                 Base{ID: org.ID},
+				CredentialsName:      org.CredentialsName,
+				CredentialsID:        credsID,
+				Name:                 org.Name,
+				WebhookSecret:        secret,
+				PoolBalancerType:     org.PoolBalancerType,
+				JobBackfill:          datatypes.JSON(org.JobBackfill),
+				FairShare:            datatypes.JSON(org.FairShare),
+				MaxConcurrentRunners: org.MaxConcurrentRunners,
+				MaxPendingRunners:    org.MaxPendingRunners,
+				EndpointName:         optionalName(org.EndpointName),
+			}
+			if err := tx.Omit("Credentials", "Endpoint").Create(&newOrg).Error; err != nil {
+				return errors.Wrapf(err, "creating organization %s", org.Name)
+			}
+		}
+
+		for _, ent := range state.Enterprises {
+			credsID, err := credentialsID(ent.CredentialsName)
+			if err != nil {
+				return errors.Wrapf(err, "importing enterprise %s", ent.Name)
+			}
+			secret, err := s.sealWebhookSecret(ent.WebhookSecret)
+			if err != nil {
+				return errors.Wrapf(err, "importing enterprise %s", ent.Name)
+			}
+			newEnt := Enterprise{
+				Base:                 Base{ID: ent.ID},
+				CredentialsName:      ent.CredentialsName,
+				CredentialsID:        credsID,
+				Name:                 ent.Name,
+				WebhookSecret:        secret,
+				PoolBalancerType:     ent.PoolBalancerType,
+				FairShare:            datatypes.JSON(ent.FairShare),
+				MaxConcurrentRunners: ent.MaxConcurrentRunners,
+				MaxPendingRunners:    ent.MaxPendingRunners,
+				EndpointName:         optionalName(ent.EndpointName),
+			}
+			if err := tx.Omit("Credentials", "Endpoint").Create(&newEnt).Error; err != nil {
+				return errors.Wrapf(err, "creating enterprise %s", ent.Name)
+			}
+		}
+
+		for _, pool := range state.Pools {
+			newPool := Pool{
+				Base:                   Base{ID: pool.ID},
+				RepoID:                 pool.RepoID,
+				OrgID:                  pool.OrgID,
+				EnterpriseID:           pool.EnterpriseID,
+				ProviderName:           pool.ProviderName,
+				RunnerPrefix:           pool.RunnerPrefix,
+				MaxRunners:             pool.MaxRunners,
+				MinIdleRunners:         pool.MinIdleRunners,
+				RunnerBootstrapTimeout: pool.RunnerBootstrapTimeout,
+				Image:                  pool.Image,
+				Flavor:                 pool.Flavor,
+				OSType:                 pool.OSType,
+				OSArch:                 pool.OSArch,
+				Enabled:                pool.Enabled,
+				ExtraSpecs:             datatypes.JSON(pool.ExtraSpecs),
+				GitHubRunnerGroup:      pool.GitHubRunnerGroup,
+				Priority:               pool.Priority,
+				Weight:                 pool.Weight,
+				MaxIdleAge:             pool.MaxIdleAge,
+				MaxLifetime:            pool.MaxLifetime,
+				MaxConcurrentCreates:   pool.MaxConcurrentCreates,
+				CreatesPerMinute:       pool.CreatesPerMinute,
+				QuarantineThreshold:    pool.QuarantineThreshold,
+				QuarantineReason:       pool.QuarantineReason,
+				QuarantinedAt:          pool.QuarantinedAt,
+				ScalingSchedules:       datatypes.JSON(pool.ScalingSchedules),
+				ScaleDownPolicy:        datatypes.JSON(pool.ScaleDownPolicy),
+				LabelRules:             datatypes.JSON(pool.LabelRules),
+			}
+			if err := tx.Omit("Repository", "Organization", "Enterprise", "Tags").Create(&newPool).Error; err != nil {
+				return errors.Wrapf(err, "creating pool %s", pool.ID)
+			}
+
+			tags := make([]Tag, 0, len(pool.Tags))
+			for _, name := range pool.Tags {
+				tag, err := s.getOrCreateTag(tx, name)
+				if err != nil {
+					return errors.Wrapf(err, "creating tag %s", name)
+				}
+				tags = append(tags, tag)
+			}
+			if len(tags) > 0 {
+				if err := tx.Model(&newPool).Association("Tags").Append(&tags); err != nil {
+					return errors.Wrapf(err, "associating tags with pool %s", pool.ID)
+				}
+			}
+		}
+
+		for _, sink := range state.NotificationSinks {
+			payload, err := s.marshalAndSeal(notificationSinkPayload{URL: sink.URL, Headers: sink.Headers})
+			if err != nil {
+				return errors.Wrapf(err, "sealing notification sink %s", sink.Name)
+			}
+			newSink := NotificationSink{
+				Name:        sink.Name,
+				Description: sink.Description,
+				Format:      string(sink.Format),
+				Payload:     payload,
+				URLHost:     params.NotificationURLHost(sink.URL),
+				Rules:       datatypes.JSON(sink.Rules),
+				Enabled:     sink.Enabled,
+			}
+			if err := tx.Create(&newSink).Error; err != nil {
+				return errors.Wrapf(err, "creating notification sink %s", sink.Name)
+			}
+		}
+
+		return nil
+	})
+}
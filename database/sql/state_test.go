@@ -0,0 +1,203 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing" //nolint:typecheck
+	"github.com/cloudbase/garm/params"
+)
+
+const destinationPassphrase = "OkDebJiwOpUvcattUbVagDexyakOkCod"
+
+type StateTestSuite struct {
+	suite.Suite
+	Store dbCommon.Store
+
+	adminCtx   context.Context
+	controller params.ControllerInfo
+	creds      params.GithubCredentials
+	repo       params.Repository
+	org        params.Organization
+	pool       params.Pool
+}
+
+func (s *StateTestSuite) newDestination() dbCommon.Store {
+	cfg := garmTesting.GetTestDBConfig(s.T())
+	cfg.Passphrase = destinationPassphrase
+	db, err := NewSQLDatabase(context.Background(), cfg)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	return db
+}
+
+func (s *StateTestSuite) SetupTest() {
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+
+	s.controller, err = db.InitController()
+	s.Require().Nil(err)
+
+	s.adminCtx = garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(s.adminCtx, db, s.T())
+	s.creds = garmTesting.CreateTestGithubCredentials(s.adminCtx, "test-creds", db, s.T(), endpoint)
+
+	s.repo, err = db.CreateRepository(s.adminCtx, "test-owner", "test-repo", s.creds.Name, "test-webhook-secret", params.PoolBalancerTypeRoundRobin)
+	s.Require().Nil(err)
+	s.org, err = db.CreateOrganization(s.adminCtx, "test-org", s.creds.Name, "test-org-secret", params.PoolBalancerTypePack)
+	s.Require().Nil(err)
+
+	entity, err := s.repo.GetEntity()
+	s.Require().Nil(err)
+	s.pool, err = db.CreateEntityPool(s.adminCtx, entity, params.CreatePoolParams{
+		ProviderName:   "test-provider",
+		MaxRunners:     4,
+		MinIdleRunners: 2,
+		Image:          "test-image",
+		Flavor:         "test-flavor",
+		OSType:         commonParams.Linux,
+		OSArch:         commonParams.Amd64,
+		Tags:           []string{"linux", "gpu"},
+	})
+	s.Require().Nil(err)
+}
+
+func (s *StateTestSuite) TestExportState() {
+	state, err := s.Store.ExportState(s.adminCtx)
+
+	s.Require().Nil(err)
+	s.Require().Equal(dbCommon.StateVersion, state.Version)
+	s.Require().Equal(s.controller.ControllerID, state.Controller.ControllerID)
+	s.Require().Len(state.Users, 1)
+	s.Require().Len(state.Credentials, 1)
+	s.Require().JSONEq(`{"oauth2_token":"test-token"}`, string(state.Credentials[0].Secret))
+	s.Require().Len(state.Repositories, 1)
+	s.Require().Equal("test-webhook-secret", state.Repositories[0].WebhookSecret)
+	s.Require().Equal(s.creds.Name, state.Repositories[0].CredentialsName)
+	s.Require().Len(state.Organizations, 1)
+	s.Require().Len(state.Pools, 1)
+	s.Require().ElementsMatch([]string{"linux", "gpu"}, state.Pools[0].Tags)
+}
+
+func (s *StateTestSuite) TestImportStateReSealsSecrets() {
+	state, err := s.Store.ExportState(s.adminCtx)
+	s.Require().Nil(err)
+	dest := s.newDestination()
+
+	err = dest.ImportState(context.Background(), state)
+
+	s.Require().Nil(err)
+	info, err := dest.ControllerInfo()
+	s.Require().Nil(err)
+	s.Require().Equal(s.controller.ControllerID, info.ControllerID)
+
+	adminCtx := garmTesting.ImpersonateAdminContext(context.Background(), dest, s.T())
+	creds, err := dest.GetGithubCredentialsByName(adminCtx, s.creds.Name, true)
+	s.Require().Nil(err)
+	s.Require().JSONEq(`{"oauth2_token":"test-token"}`, string(creds.CredentialsPayload))
+
+	repo, err := dest.GetRepositoryByID(adminCtx, s.repo.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("test-webhook-secret", repo.WebhookSecret)
+	s.Require().Equal(s.creds.Name, repo.Credentials.Name)
+
+	org, err := dest.GetOrganizationByID(adminCtx, s.org.ID)
+	s.Require().Nil(err)
+	s.Require().Equal("test-org-secret", org.WebhookSecret)
+	s.Require().Equal(params.PoolBalancerTypePack, org.PoolBalancerType)
+
+	pool, err := dest.GetPoolByID(adminCtx, s.pool.ID)
+	s.Require().Nil(err)
+	s.Require().Equal(s.repo.ID, pool.RepoID)
+	s.Require().Equal(uint(4), pool.MaxRunners)
+	s.Require().Len(pool.Tags, 2)
+}
+
+func (s *StateTestSuite) TestImportStateNotificationSinks() {
+	_, err := s.Store.CreateNotificationSink(s.adminCtx, params.CreateNotificationSinkParams{
+		Name:    "ops",
+		URL:     "https://hooks.example.com/secret-token",
+		Format:  params.NotificationFormatSlack,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+		Rules:   []params.NotificationRule{{MinLevel: params.EventError}},
+	})
+	s.Require().Nil(err)
+	state, err := s.Store.ExportState(s.adminCtx)
+	s.Require().Nil(err)
+	s.Require().Len(state.NotificationSinks, 1)
+	s.Require().Equal("https://hooks.example.com/secret-token", state.NotificationSinks[0].URL)
+	dest := s.newDestination()
+
+	err = dest.ImportState(context.Background(), state)
+
+	s.Require().Nil(err)
+	sinks, err := dest.ListNotificationSinks(context.Background())
+	s.Require().Nil(err)
+	s.Require().Len(sinks, 1)
+	s.Require().Equal("https://hooks.example.com/secret-token", sinks[0].URL)
+	s.Require().Equal("Bearer token", sinks[0].Headers["Authorization"])
+	s.Require().Equal(params.NotificationFormatSlack, sinks[0].Format)
+	s.Require().Len(sinks[0].Rules, 1)
+}
+
+func (s *StateTestSuite) TestImportStateNonEmptyDatabase() {
+	state, err := s.Store.ExportState(s.adminCtx)
+	s.Require().Nil(err)
+
+	err = s.Store.ImportState(context.Background(), state)
+
+	s.Require().NotNil(err)
+	s.Require().Equal("destination database is not empty (found controller info)", err.Error())
+}
+
+func (s *StateTestSuite) TestImportStateUnsupportedVersion() {
+	state, err := s.Store.ExportState(s.adminCtx)
+	s.Require().Nil(err)
+	state.Version = dbCommon.StateVersion + 1
+
+	err = s.newDestination().ImportState(context.Background(), state)
+
+	s.Require().NotNil(err)
+	s.Require().Equal(fmt.Sprintf("unsupported state version %d", state.Version), err.Error())
+}
+
+func (s *StateTestSuite) TestImportStateUnknownCredentials() {
+	state, err := s.Store.ExportState(s.adminCtx)
+	s.Require().Nil(err)
+	state.Repositories[0].CredentialsName = "missing-creds"
+	dest := s.newDestination()
+
+	err = dest.ImportState(context.Background(), state)
+
+	s.Require().NotNil(err)
+	s.Require().Equal("importing repository test-owner/test-repo: unknown credentials missing-creds", err.Error())
+	_, err = dest.ControllerInfo()
+	s.Require().NotNil(err)
+}
+
+func TestStateTestSuite(t *testing.T) {
+	suite.Run(t, new(StateTestSuite))
+}
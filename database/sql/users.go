@@ -71,13 +71,25 @@ func (s *sqlDatabase) CreateUser(_ context.Context, user params.NewUserParams) (
 		return params.User{}, runnerErrors.NewBadRequestError("admin user already exists")
 	}
 
+	role := user.Role
+	if user.IsAdmin {
+		role = params.UserRoleAdmin
+	}
+	if role == "" {
+		role = params.UserRoleViewer
+	}
+	if !role.IsValid() {
+		return params.User{}, runnerErrors.NewBadRequestError("invalid role: %q", role)
+	}
+
 	newUser := User{
 		Username: user.Username,
 		Password: user.Password,
 		FullName: user.FullName,
 		Enabled:  user.Enabled,
 		Email:    user.Email,
-		IsAdmin:  user.IsAdmin,
+		IsAdmin:  role == params.UserRoleAdmin,
+		Role:     string(role),
 	}
 
 	q := s.conn.Save(&newUser)
@@ -127,6 +139,14 @@ func (s *sqlDatabase) UpdateUser(_ context.Context, user string, param params.Up
 		dbUser.Password = param.Password
 	}
 
+	if param.Role != nil {
+		if !param.Role.IsValid() {
+			return params.User{}, runnerErrors.NewBadRequestError("invalid role: %q", *param.Role)
+		}
+		dbUser.Role = string(*param.Role)
+		dbUser.IsAdmin = *param.Role == params.UserRoleAdmin
+	}
+
 	if q := s.conn.Save(&dbUser); q.Error != nil {
 		return params.User{}, errors.Wrap(q.Error, "saving user")
 	}
@@ -134,6 +154,42 @@ func (s *sqlDatabase) UpdateUser(_ context.Context, user string, param params.Up
 	return s.sqlToParamsUser(dbUser), nil
 }
 
+func (s *sqlDatabase) ListUsers(_ context.Context) ([]params.User, error) {
+	var users []User
+	if err := s.conn.Model(&User{}).Order("created_at asc").Find(&users).Error; err != nil {
+		return nil, errors.Wrap(err, "fetching users")
+	}
+
+	ret := make([]params.User, len(users))
+	for idx, user := range users {
+		ret[idx] = s.sqlToParamsUser(user)
+	}
+	return ret, nil
+}
+
+func (s *sqlDatabase) DeleteUser(_ context.Context, user string) error {
+	dbUser, err := s.getUserByUsernameOrEmail(user)
+	if err != nil {
+		if errors.Is(err, runnerErrors.ErrNotFound) {
+			return nil
+		}
+		return errors.Wrap(err, "fetching user")
+	}
+
+	var credentialsCount int64
+	if err := s.conn.Model(&GithubCredentials{}).Where("user_id = ?", dbUser.ID).Count(&credentialsCount).Error; err != nil {
+		return errors.Wrap(err, "fetching github credentials")
+	}
+	if credentialsCount > 0 {
+		return runnerErrors.NewBadRequestError("user owns %d github credentials", credentialsCount)
+	}
+
+	if q := s.conn.Unscoped().Delete(&dbUser); q.Error != nil {
+		return errors.Wrap(q.Error, "deleting user")
+	}
+	return nil
+}
+
 // GetAdminUser returns the system admin user. This is only for internal use.
 func (s *sqlDatabase) GetAdminUser(_ context.Context) (params.User, error) {
 	var user User
@@ -27,6 +27,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/cloudbase/garm/auth"
 	dbCommon "github.com/cloudbase/garm/database/common"
 	garmTesting "github.com/cloudbase/garm/internal/testing"
 	"github.com/cloudbase/garm/params"
@@ -54,8 +55,8 @@ func (s *UserTestSuite) assertSQLMockExpectations() {
 }
 
 func (s *UserTestSuite) SetupTest() {
-	// create testing sqlite database
-	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	// create testing database
+	db, err := NewSQLDatabase(context.Background(), garmTesting.GetTestDBConfig(s.T()))
 	if err != nil {
 		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
 	}
@@ -274,6 +275,97 @@ func (s *UserTestSuite) TestUpdateUserDBSaveErr() {
 	s.Require().Equal("saving user: saving user mock error", err.Error())
 }
 
+func (s *UserTestSuite) TestCreateUserDefaultsToViewer() {
+	user, err := s.Store.CreateUser(context.Background(), s.Fixtures.NewUserParams)
+
+	s.Require().Nil(err)
+	s.Require().Equal(params.UserRoleViewer, user.Role)
+	s.Require().False(user.IsAdmin)
+}
+
+func (s *UserTestSuite) TestCreateUserWithRole() {
+	s.Fixtures.NewUserParams.Role = params.UserRoleAdmin
+
+	user, err := s.Store.CreateUser(context.Background(), s.Fixtures.NewUserParams)
+
+	s.Require().Nil(err)
+	s.Require().Equal(params.UserRoleAdmin, user.Role)
+	s.Require().True(user.IsAdmin)
+}
+
+func (s *UserTestSuite) TestCreateUserInvalidRole() {
+	s.Fixtures.NewUserParams.Role = params.UserRole("superuser")
+
+	_, err := s.Store.CreateUser(context.Background(), s.Fixtures.NewUserParams)
+
+	s.Require().NotNil(err)
+	s.Require().Equal("invalid role: \"superuser\"", err.Error())
+}
+
+func (s *UserTestSuite) TestUpdateUserRole() {
+	role := params.UserRoleOperator
+	s.Fixtures.UpdateUserParams.Role = &role
+
+	user, err := s.Store.UpdateUser(context.Background(), s.Fixtures.Users[0].Username, s.Fixtures.UpdateUserParams)
+
+	s.Require().Nil(err)
+	s.Require().Equal(params.UserRoleOperator, user.Role)
+	s.Require().False(user.IsAdmin)
+
+	role = params.UserRoleAdmin
+	user, err = s.Store.UpdateUser(context.Background(), s.Fixtures.Users[0].Username, s.Fixtures.UpdateUserParams)
+
+	s.Require().Nil(err)
+	s.Require().Equal(params.UserRoleAdmin, user.Role)
+	s.Require().True(user.IsAdmin)
+}
+
+func (s *UserTestSuite) TestUpdateUserInvalidRole() {
+	role := params.UserRole("superuser")
+	s.Fixtures.UpdateUserParams.Role = &role
+
+	_, err := s.Store.UpdateUser(context.Background(), s.Fixtures.Users[0].Username, s.Fixtures.UpdateUserParams)
+
+	s.Require().NotNil(err)
+	s.Require().Equal("invalid role: \"superuser\"", err.Error())
+}
+
+func (s *UserTestSuite) TestListUsers() {
+	users, err := s.Store.ListUsers(context.Background())
+
+	s.Require().Nil(err)
+	s.Require().Len(users, len(s.Fixtures.Users))
+	for i, user := range users {
+		s.Require().Equal(s.Fixtures.Users[i].ID, user.ID)
+	}
+}
+
+func (s *UserTestSuite) TestDeleteUser() {
+	err := s.Store.DeleteUser(context.Background(), s.Fixtures.Users[0].Username)
+
+	s.Require().Nil(err)
+	_, err = s.Store.GetUserByID(context.Background(), s.Fixtures.Users[0].ID)
+	s.Require().NotNil(err)
+	s.Require().Equal("fetching user: not found", err.Error())
+}
+
+func (s *UserTestSuite) TestDeleteUserNotFound() {
+	err := s.Store.DeleteUser(context.Background(), "dummy-user")
+
+	s.Require().Nil(err)
+}
+
+func (s *UserTestSuite) TestDeleteUserOwnsCredentials() {
+	userCtx := auth.PopulateContext(context.Background(), s.Fixtures.Users[0])
+	endpoint := garmTesting.CreateDefaultGithubEndpoint(userCtx, s.Store, s.T())
+	garmTesting.CreateTestGithubCredentials(userCtx, "test-creds", s.Store, s.T(), endpoint)
+
+	err := s.Store.DeleteUser(context.Background(), s.Fixtures.Users[0].Username)
+
+	s.Require().NotNil(err)
+	s.Require().Equal("user owns 1 github credentials", err.Error())
+}
+
 func TestUserTestSuite(t *testing.T) {
 	suite.Run(t, new(UserTestSuite))
 }
@@ -65,6 +65,7 @@ func (s *sqlDatabase) sqlToParamsInstance(instance Instance) (params.Instance, e
 		MetadataURL:       instance.MetadataURL,
 		StatusMessages:    []params.StatusMessage{},
 		CreateAttempt:     instance.CreateAttempt,
+		CreatedAt:         instance.CreatedAt,
 		UpdatedAt:         instance.UpdatedAt,
 		TokenFetched:      instance.TokenFetched,
 		JitConfiguration:  jitConfig,
@@ -127,6 +128,9 @@ func (s *sqlDatabase) sqlToCommonOrganization(org Organization, detailed bool) (
 		WebhookSecret:    string(secret),
 		PoolBalancerType: org.PoolBalancerType,
 		Endpoint:         endpoint,
+
+		MaxConcurrentRunners: org.MaxConcurrentRunners,
+		MaxPendingRunners:    org.MaxPendingRunners,
 	}
 
 	if org.CredentialsID != nil {
@@ -145,6 +149,18 @@ func (s *sqlDatabase) sqlToCommonOrganization(org Organization, detailed bool) (
 		ret.PoolBalancerType = params.PoolBalancerTypeRoundRobin
 	}
 
+	if len(org.JobBackfill) > 0 {
+		if err := json.Unmarshal(org.JobBackfill, &ret.JobBackfill); err != nil {
+			return params.Organization{}, errors.Wrap(err, "unmarshaling job backfill settings")
+		}
+	}
+
+	if len(org.FairShare) > 0 {
+		if err := json.Unmarshal(org.FairShare, &ret.FairShare); err != nil {
+			return params.Organization{}, errors.Wrap(err, "unmarshaling fair share settings")
+		}
+	}
+
 	for idx, pool := range org.Pools {
 		ret.Pools[idx], err = s.sqlToCommonPool(pool)
 		if err != nil {
@@ -176,6 +192,9 @@ func (s *sqlDatabase) sqlToCommonEnterprise(enterprise Enterprise, detailed bool
 		WebhookSecret:    string(secret),
 		PoolBalancerType: enterprise.PoolBalancerType,
 		Endpoint:         endpoint,
+
+		MaxConcurrentRunners: enterprise.MaxConcurrentRunners,
+		MaxPendingRunners:    enterprise.MaxPendingRunners,
 	}
 
 	if enterprise.CredentialsID != nil {
@@ -194,6 +213,12 @@ func (s *sqlDatabase) sqlToCommonEnterprise(enterprise Enterprise, detailed bool
 		ret.PoolBalancerType = params.PoolBalancerTypeRoundRobin
 	}
 
+	if len(enterprise.FairShare) > 0 {
+		if err := json.Unmarshal(enterprise.FairShare, &ret.FairShare); err != nil {
+			return params.Enterprise{}, errors.Wrap(err, "unmarshaling fair share settings")
+		}
+	}
+
 	for idx, pool := range enterprise.Pools {
 		ret.Pools[idx], err = s.sqlToCommonPool(pool)
 		if err != nil {
@@ -224,6 +249,32 @@ func (s *sqlDatabase) sqlToCommonPool(pool Pool) (params.Pool, error) {
 		ExtraSpecs:             json.RawMessage(pool.ExtraSpecs),
 		GitHubRunnerGroup:      pool.GitHubRunnerGroup,
 		Priority:               pool.Priority,
+		Weight:                 pool.Weight,
+		MaxIdleAge:             pool.MaxIdleAge,
+		MaxLifetime:            pool.MaxLifetime,
+		MaxConcurrentCreates:   pool.MaxConcurrentCreates,
+		CreatesPerMinute:       pool.CreatesPerMinute,
+		QuarantineThreshold:    pool.QuarantineThreshold,
+		QuarantineReason:       pool.QuarantineReason,
+		QuarantinedAt:          pool.QuarantinedAt,
+	}
+
+	if len(pool.ScalingSchedules) > 0 {
+		if err := json.Unmarshal(pool.ScalingSchedules, &ret.ScalingSchedules); err != nil {
+			return params.Pool{}, errors.Wrap(err, "unmarshaling scaling schedules")
+		}
+	}
+
+	if len(pool.ScaleDownPolicy) > 0 {
+		if err := json.Unmarshal(pool.ScaleDownPolicy, &ret.ScaleDownPolicy); err != nil {
+			return params.Pool{}, errors.Wrap(err, "unmarshaling scale down policy")
+		}
+	}
+
+	if len(pool.LabelRules) > 0 {
+		if err := json.Unmarshal(pool.LabelRules, &ret.LabelRules); err != nil {
+			return params.Pool{}, errors.Wrap(err, "unmarshaling label rules")
+		}
 	}
 
 	if pool.RepoID != nil {
@@ -286,6 +337,9 @@ func (s *sqlDatabase) sqlToCommonRepository(repo Repository, detailed bool) (par
 		WebhookSecret:    string(secret),
 		PoolBalancerType: repo.PoolBalancerType,
 		Endpoint:         endpoint,
+
+		MaxConcurrentRunners: repo.MaxConcurrentRunners,
+		MaxPendingRunners:    repo.MaxPendingRunners,
 	}
 
 	if repo.CredentialsID != nil {
@@ -304,6 +358,18 @@ func (s *sqlDatabase) sqlToCommonRepository(repo Repository, detailed bool) (par
 		ret.PoolBalancerType = params.PoolBalancerTypeRoundRobin
 	}
 
+	if len(repo.JobBackfill) > 0 {
+		if err := json.Unmarshal(repo.JobBackfill, &ret.JobBackfill); err != nil {
+			return params.Repository{}, errors.Wrap(err, "unmarshaling job backfill settings")
+		}
+	}
+
+	if len(repo.FairShare) > 0 {
+		if err := json.Unmarshal(repo.FairShare, &ret.FairShare); err != nil {
+			return params.Repository{}, errors.Wrap(err, "unmarshaling fair share settings")
+		}
+	}
+
 	for idx, pool := range repo.Pools {
 		ret.Pools[idx], err = s.sqlToCommonPool(pool)
 		if err != nil {
@@ -325,6 +391,7 @@ func (s *sqlDatabase) sqlToParamsUser(user User) params.User {
 		Password:  user.Password,
 		Enabled:   user.Enabled,
 		IsAdmin:   user.IsAdmin,
+		Role:      params.UserRole(user.Role),
 	}
 }
 
@@ -352,6 +419,12 @@ func (s *sqlDatabase) updatePool(tx *gorm.DB, pool Pool, param params.UpdatePool
 		pool.Enabled = *param.Enabled
 	}
 
+	if pool.Enabled {
+		// An enabled pool is no longer quarantined.
+		pool.QuarantineReason = ""
+		pool.QuarantinedAt = nil
+	}
+
 	if param.Flavor != "" {
 		pool.Flavor = param.Flavor
 	}
@@ -396,6 +469,54 @@ func (s *sqlDatabase) updatePool(tx *gorm.DB, pool Pool, param params.UpdatePool
 		pool.Priority = *param.Priority
 	}
 
+	if param.Weight != nil {
+		pool.Weight = *param.Weight
+	}
+
+	if param.MaxIdleAge != nil {
+		pool.MaxIdleAge = *param.MaxIdleAge
+	}
+
+	if param.MaxLifetime != nil {
+		pool.MaxLifetime = *param.MaxLifetime
+	}
+
+	if param.MaxConcurrentCreates != nil {
+		pool.MaxConcurrentCreates = *param.MaxConcurrentCreates
+	}
+
+	if param.CreatesPerMinute != nil {
+		pool.CreatesPerMinute = *param.CreatesPerMinute
+	}
+
+	if param.QuarantineThreshold != nil {
+		pool.QuarantineThreshold = *param.QuarantineThreshold
+	}
+
+	if param.ScalingSchedules != nil {
+		schedules, err := json.Marshal(param.ScalingSchedules)
+		if err != nil {
+			return params.Pool{}, errors.Wrap(err, "marshaling scaling schedules")
+		}
+		pool.ScalingSchedules = schedules
+	}
+
+	if param.ScaleDownPolicy != nil {
+		policy, err := json.Marshal(param.ScaleDownPolicy)
+		if err != nil {
+			return params.Pool{}, errors.Wrap(err, "marshaling scale down policy")
+		}
+		pool.ScaleDownPolicy = policy
+	}
+
+	if param.LabelRules != nil {
+		labelRules, err := json.Marshal(param.LabelRules)
+		if err != nil {
+			return params.Pool{}, errors.Wrap(err, "marshaling label rules")
+		}
+		pool.LabelRules = labelRules
+	}
+
 	if q := tx.Save(&pool); q.Error != nil {
 		return params.Pool{}, errors.Wrap(q.Error, "saving database entry")
 	}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"log/slog"
 	"sync"
-	"time"
 
 	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/metrics"
 )
 
+// consumerBufferSize is the number of events that can be buffered for a consumer
+// that is slow to read them. When the buffer fills up, the oldest events are
+// dropped and the consumer receives a resync event.
+var consumerBufferSize = 1024
+
 type consumer struct {
 	messages chan common.ChangePayload
 	filters  []common.PayloadFilterFunc
@@ -18,6 +23,27 @@ type consumer struct {
 	closed bool
 	quit   chan struct{}
 	ctx    context.Context
+
+	// buffer holds the events that were not yet read by the consumer.
+	buffer *ringBuffer
+	// pending is signaled whenever an event is added to the buffer.
+	pending chan struct{}
+	// missed is the number of events dropped since the last resync event.
+	missed uint64
+	// lastMissedSeq is the sequence number of the newest dropped event.
+	lastMissedSeq uint64
+}
+
+func newConsumer(ctx context.Context, id string, filters ...common.PayloadFilterFunc) *consumer {
+	return &consumer{
+		messages: make(chan common.ChangePayload),
+		filters:  filters,
+		quit:     make(chan struct{}),
+		id:       id,
+		ctx:      ctx,
+		buffer:   newRingBuffer(consumerBufferSize),
+		pending:  make(chan struct{}, 1),
+	}
 }
 
 func (w *consumer) SetFilters(filters ...common.PayloadFilterFunc) {
@@ -30,13 +56,14 @@ func (w *consumer) Watch() <-chan common.ChangePayload {
 	return w.messages
 }
 
+// Close stops the consumer. The messages channel is closed by the delivery loop,
+// which is the only writer on that channel.
 func (w *consumer) Close() {
 	w.mux.Lock()
 	defer w.mux.Unlock()
 	if w.closed {
 		return
 	}
-	close(w.messages)
 	close(w.quit)
 	w.closed = true
 }
@@ -47,6 +74,18 @@ func (w *consumer) IsClosed() bool {
 	return w.closed
 }
 
+func (w *consumer) shouldSend(payload common.ChangePayload) bool {
+	for _, filter := range w.filters {
+		if !filter(payload) {
+			return false
+		}
+	}
+	return true
+}
+
+// Send adds the payload to the consumer buffer. This function never blocks. If the
+// buffer is full, the oldest event is dropped and a resync event is queued for the
+// consumer. See next().
 func (w *consumer) Send(payload common.ChangePayload) {
 	w.mux.Lock()
 	defer w.mux.Unlock()
@@ -55,28 +94,88 @@ func (w *consumer) Send(payload common.ChangePayload) {
 		return
 	}
 
-	if len(w.filters) > 0 {
-		shouldSend := true
-		for _, filter := range w.filters {
-			if !filter(payload) {
-				shouldSend = false
+	if !w.shouldSend(payload) {
+		return
+	}
+
+	if evicted, ok := w.buffer.push(payload); ok {
+		if w.missed == 0 {
+			slog.WarnContext(w.ctx, "consumer is falling behind; dropping events", "buffer_size", len(w.buffer.items))
+		}
+		w.missed++
+		w.lastMissedSeq = evicted.Seq
+		metrics.WatcherDroppedEvents.WithLabelValues(w.id).Inc()
+	}
+	metrics.WatcherConsumerLag.WithLabelValues(w.id).Set(float64(w.buffer.len()))
+
+	select {
+	case w.pending <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the next event that needs to be delivered to the consumer. If events
+// were dropped since the last call, the events that are still buffered are dropped as
+// well, and only a resync event is returned. The consumer reloads its state when it
+// receives the resync event, so the buffered events are already reflected in it.
+func (w *consumer) next() (common.ChangePayload, bool) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if w.missed > 0 {
+		for {
+			dropped, ok := w.buffer.pop()
+			if !ok {
 				break
 			}
+			w.missed++
+			w.lastMissedSeq = dropped.Seq
+			metrics.WatcherDroppedEvents.WithLabelValues(w.id).Inc()
 		}
-
-		if !shouldSend {
-			return
+		metrics.WatcherConsumerLag.WithLabelValues(w.id).Set(0)
+		payload := common.ChangePayload{
+			Seq:       w.lastMissedSeq,
+			Operation: common.ResyncOperation,
+			Payload: common.ResyncPayload{
+				Missed:        w.missed,
+				LastMissedSeq: w.lastMissedSeq,
+			},
 		}
+		w.missed = 0
+		metrics.WatcherResyncs.WithLabelValues(w.id).Inc()
+		return payload, true
 	}
 
-	slog.DebugContext(w.ctx, "sending payload")
-	select {
-	case <-w.quit:
-		slog.DebugContext(w.ctx, "consumer is closed")
-	case <-w.ctx.Done():
-		slog.DebugContext(w.ctx, "consumer is closed")
-	case <-time.After(1 * time.Second):
-		slog.DebugContext(w.ctx, "timeout trying to send payload", "payload", payload)
-	case w.messages <- payload:
+	payload, ok := w.buffer.pop()
+	if ok {
+		metrics.WatcherConsumerLag.WithLabelValues(w.id).Set(float64(w.buffer.len()))
+	}
+	return payload, ok
+}
+
+// deliver moves events from the buffer to the messages channel, in order. It runs
+// until the consumer is closed.
+func (w *consumer) deliver() {
+	defer close(w.messages)
+	for {
+		payload, ok := w.next()
+		if !ok {
+			select {
+			case <-w.pending:
+				continue
+			case <-w.quit:
+				return
+			case <-w.ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case w.messages <- payload:
+		case <-w.quit:
+			return
+		case <-w.ctx.Done():
+			return
+		}
 	}
 }
@@ -0,0 +1,37 @@
+//go:build testing
+
+package watcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudbase/garm/database/common"
+)
+
+func TestConsumerResyncDropsBufferedEvents(t *testing.T) {
+	oldSize := SetConsumerBufferSize(3)
+	defer SetConsumerBufferSize(oldSize)
+
+	c := newConsumer(context.Background(), "test-consumer")
+	for i := uint64(1); i <= 5; i++ {
+		c.Send(common.ChangePayload{Seq: i, Operation: common.UpdateOperation})
+	}
+
+	resync, ok := c.next()
+	require.True(t, ok)
+	require.Equal(t, common.ResyncOperation, resync.Operation)
+	require.Equal(t, uint64(5), resync.Seq)
+	require.Equal(t, common.ResyncPayload{Missed: 5, LastMissedSeq: 5}, resync.Payload)
+
+	// Events that were buffered when the resync was sent are not delivered.
+	_, ok = c.next()
+	require.False(t, ok)
+
+	c.Send(common.ChangePayload{Seq: 6, Operation: common.UpdateOperation})
+	payload, ok := c.next()
+	require.True(t, ok)
+	require.Equal(t, uint64(6), payload.Seq)
+}
@@ -1,6 +1,8 @@
 package watcher
 
 import (
+	"strconv"
+
 	dbCommon "github.com/cloudbase/garm/database/common"
 	"github.com/cloudbase/garm/params"
 )
@@ -165,3 +167,36 @@ func WithGithubCredentialsFilter(creds params.GithubCredentials) dbCommon.Payloa
 		return credsPayload.ID == creds.ID
 	}
 }
+
+// WithEntityIDFilter returns a filter function that filters payloads by the ID of the
+// changed entity, regardless of its type. GitHub endpoints are matched by name.
+func WithEntityIDFilter(id string) dbCommon.PayloadFilterFunc {
+	return func(payload dbCommon.ChangePayload) bool {
+		entityID, ok := payloadEntityID(payload)
+		if !ok {
+			return false
+		}
+		return entityID == id
+	}
+}
+
+func payloadEntityID(payload dbCommon.ChangePayload) (string, bool) {
+	switch p := payload.Payload.(type) {
+	case idGetter:
+		return p.GetID(), true
+	case params.Job:
+		return strconv.FormatInt(p.ID, 10), true
+	case params.GithubCredentials:
+		return strconv.FormatUint(uint64(p.ID), 10), true
+	case params.NotificationSink:
+		return strconv.FormatUint(uint64(p.ID), 10), true
+	case params.GithubEndpoint:
+		return p.Name, true
+	case params.User:
+		return p.ID, true
+	case params.ControllerInfo:
+		return p.ControllerID.String(), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,89 @@
+//go:build testing
+
+package watcher_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/database/watcher"
+	"github.com/cloudbase/garm/params"
+)
+
+func TestWithEntityIDFilter(t *testing.T) {
+	controllerID := uuid.New()
+	tests := []struct {
+		name     string
+		payload  interface{}
+		id       string
+		expected bool
+	}{
+		{"repository", params.Repository{ID: "repo-id"}, "repo-id", true},
+		{"pool", params.Pool{ID: "pool-id"}, "pool-id", true},
+		{"instance", params.Instance{ID: "instance-id"}, "instance-id", true},
+		{"job", params.Job{ID: 1234}, "1234", true},
+		{"credentials", params.GithubCredentials{ID: 12}, "12", true},
+		{"endpoint", params.GithubEndpoint{Name: "github.com"}, "github.com", true},
+		{"controller", params.ControllerInfo{ControllerID: controllerID}, controllerID.String(), true},
+		{"other id", params.Pool{ID: "pool-id"}, "other-id", false},
+		{"unknown payload", "pool-id", "pool-id", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := watcher.WithEntityIDFilter(tc.id)
+			require.Equal(t, tc.expected, filter(common.ChangePayload{Payload: tc.payload}))
+		})
+	}
+}
+
+func TestStripSecrets(t *testing.T) {
+	creds := params.GithubCredentials{
+		ID:                 1,
+		Name:               "test-creds",
+		CredentialsPayload: []byte(`{"oauth2_token": "super-secret"}`),
+		Repositories:       []params.Repository{{ID: "repo-id", Name: "repo", WebhookSecret: "repo-secret"}},
+	}
+	repo := params.Repository{
+		ID:            "repo-id",
+		Name:          "repo",
+		WebhookSecret: "repo-secret",
+		Credentials:   creds,
+		Pools: []params.Pool{
+			{ID: "pool-id", Instances: []params.Instance{{Name: "runner", JitConfiguration: map[string]string{".runner": "jit"}}}},
+		},
+	}
+	payload := common.ChangePayload{
+		EntityType: common.RepositoryEntityType,
+		Operation:  common.UpdateOperation,
+		Payload:    repo,
+	}
+
+	stripped := watcher.StripSecrets(payload)
+
+	strippedRepo, ok := stripped.Payload.(params.Repository)
+	require.True(t, ok)
+	require.Equal(t, "repo-id", strippedRepo.ID)
+	require.Empty(t, strippedRepo.WebhookSecret)
+	require.Nil(t, strippedRepo.Credentials.CredentialsPayload)
+	require.Equal(t, []params.Repository{{ID: "repo-id", Name: "repo"}}, strippedRepo.Credentials.Repositories)
+	require.Nil(t, strippedRepo.Pools[0].Instances[0].JitConfiguration)
+	require.Equal(t, "runner", strippedRepo.Pools[0].Instances[0].Name)
+
+	// The original payload is shared with other consumers and must not change.
+	require.Equal(t, "repo-secret", repo.WebhookSecret)
+	require.NotNil(t, repo.Credentials.CredentialsPayload)
+	require.Equal(t, "repo-secret", repo.Credentials.Repositories[0].WebhookSecret)
+	require.NotNil(t, repo.Pools[0].Instances[0].JitConfiguration)
+}
+
+func TestStripSecretsUser(t *testing.T) {
+	stripped := watcher.StripSecrets(common.ChangePayload{
+		EntityType: common.UserEntityType,
+		Payload:    params.User{ID: "user-id", Password: "hashed-password"},
+	})
+
+	require.Equal(t, params.User{ID: "user-id"}, stripped.Payload)
+}
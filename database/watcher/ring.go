@@ -0,0 +1,53 @@
+package watcher
+
+import "github.com/cloudbase/garm/database/common"
+
+// ringBuffer is a fixed size FIFO queue of change payloads. When the buffer is
+// full, pushing a new payload overwrites the oldest one.
+type ringBuffer struct {
+	items []common.ChangePayload
+	// head is the index of the oldest payload in the buffer.
+	head int
+	size int
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &ringBuffer{
+		items: make([]common.ChangePayload, capacity),
+	}
+}
+
+// push adds a payload to the buffer. If the buffer was full, the oldest payload
+// is evicted and returned, along with true.
+func (r *ringBuffer) push(payload common.ChangePayload) (common.ChangePayload, bool) {
+	if r.size < len(r.items) {
+		r.items[(r.head+r.size)%len(r.items)] = payload
+		r.size++
+		return common.ChangePayload{}, false
+	}
+
+	evicted := r.items[r.head]
+	r.items[r.head] = payload
+	r.head = (r.head + 1) % len(r.items)
+	return evicted, true
+}
+
+// pop removes and returns the oldest payload in the buffer.
+func (r *ringBuffer) pop() (common.ChangePayload, bool) {
+	if r.size == 0 {
+		return common.ChangePayload{}, false
+	}
+	payload := r.items[r.head]
+	// Release the reference to the payload.
+	r.items[r.head] = common.ChangePayload{}
+	r.head = (r.head + 1) % len(r.items)
+	r.size--
+	return payload, true
+}
+
+func (r *ringBuffer) len() int {
+	return r.size
+}
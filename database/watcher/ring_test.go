@@ -0,0 +1,33 @@
+//go:build testing
+
+package watcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudbase/garm/database/common"
+)
+
+func TestRingBufferEvictsOldest(t *testing.T) {
+	r := newRingBuffer(3)
+	for i := uint64(1); i <= 3; i++ {
+		_, evicted := r.push(common.ChangePayload{Seq: i})
+		require.False(t, evicted)
+	}
+	require.Equal(t, 3, r.len())
+
+	old, evicted := r.push(common.ChangePayload{Seq: 4})
+	require.True(t, evicted)
+	require.Equal(t, uint64(1), old.Seq)
+
+	for _, expected := range []uint64{2, 3, 4} {
+		payload, ok := r.pop()
+		require.True(t, ok)
+		require.Equal(t, expected, payload.Seq)
+	}
+	_, ok := r.pop()
+	require.False(t, ok)
+	require.Equal(t, 0, r.len())
+}
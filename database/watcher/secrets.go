@@ -0,0 +1,111 @@
+package watcher
+
+import (
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+// StripSecrets returns a copy of the change payload with all secrets removed. The
+// same payload is sent to all consumers, so it must not be modified in place. Use
+// this function before sending a payload outside of GARM.
+func StripSecrets(payload dbCommon.ChangePayload) dbCommon.ChangePayload {
+	switch p := payload.Payload.(type) {
+	case params.Repository:
+		payload.Payload = stripRepository(p)
+	case params.Organization:
+		payload.Payload = stripOrganization(p)
+	case params.Enterprise:
+		payload.Payload = stripEnterprise(p)
+	case params.Pool:
+		payload.Payload = stripPool(p)
+	case params.Instance:
+		payload.Payload = stripInstance(p)
+	case params.GithubCredentials:
+		payload.Payload = stripCredentials(p)
+	case params.User:
+		p.Password = ""
+		payload.Payload = p
+	case params.NotificationSink:
+		p.URL = ""
+		p.Headers = nil
+		payload.Payload = p
+	}
+	return payload
+}
+
+func stripRepository(repo params.Repository) params.Repository {
+	repo.WebhookSecret = ""
+	repo.Credentials = stripCredentials(repo.Credentials)
+	repo.Pools = stripPools(repo.Pools)
+	return repo
+}
+
+func stripOrganization(org params.Organization) params.Organization {
+	org.WebhookSecret = ""
+	org.Credentials = stripCredentials(org.Credentials)
+	org.Pools = stripPools(org.Pools)
+	return org
+}
+
+func stripEnterprise(ent params.Enterprise) params.Enterprise {
+	ent.WebhookSecret = ""
+	ent.Credentials = stripCredentials(ent.Credentials)
+	ent.Pools = stripPools(ent.Pools)
+	return ent
+}
+
+func stripPools(pools []params.Pool) []params.Pool {
+	if pools == nil {
+		return nil
+	}
+	ret := make([]params.Pool, len(pools))
+	for idx, pool := range pools {
+		ret[idx] = stripPool(pool)
+	}
+	return ret
+}
+
+func stripPool(pool params.Pool) params.Pool {
+	if pool.Instances == nil {
+		return pool
+	}
+	instances := make([]params.Instance, len(pool.Instances))
+	for idx, instance := range pool.Instances {
+		instances[idx] = stripInstance(instance)
+	}
+	pool.Instances = instances
+	return pool
+}
+
+func stripInstance(instance params.Instance) params.Instance {
+	instance.JitConfiguration = nil
+	return instance
+}
+
+// stripCredentials removes the credentials payload. The entities attached to
+// the credentials are reduced to their ID and name.
+func stripCredentials(creds params.GithubCredentials) params.GithubCredentials {
+	creds.CredentialsPayload = nil
+	if creds.Repositories != nil {
+		repos := make([]params.Repository, len(creds.Repositories))
+		for idx, repo := range creds.Repositories {
+			repos[idx] = params.Repository{ID: repo.ID, Owner: repo.Owner, Name: repo.Name}
+		}
+		creds.Repositories = repos
+	}
+	if creds.Organizations != nil {
+		orgs := make([]params.Organization, len(creds.Organizations))
+		for idx, org := range creds.Organizations {
+			orgs[idx] = params.Organization{ID: org.ID, Name: org.Name}
+		}
+		creds.Organizations = orgs
+	}
+	if creds.Enterprises != nil {
+		ents := make([]params.Enterprise, len(creds.Enterprises))
+		for idx, ent := range creds.Enterprises {
+			ents[idx] = params.Enterprise{ID: ent.ID, Name: ent.Name}
+		}
+		creds.Enterprises = ents
+	}
+	return creds
+}
@@ -15,3 +15,12 @@ func SetWatcher(w common.Watcher) {
 func GetWatcher() common.Watcher {
 	return databaseWatcher
 }
+
+// SetConsumerBufferSize sets the size of the event buffer used by consumers
+// registered after this call, and returns the previous value.
+// This function is intended for use in tests only.
+func SetConsumerBufferSize(size int) int {
+	old := consumerBufferSize
+	consumerBufferSize = size
+	return old
+}
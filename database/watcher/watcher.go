@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/metrics"
 	garmUtil "github.com/cloudbase/garm/util"
 )
 
@@ -48,6 +49,8 @@ func RegisterConsumer(ctx context.Context, id string, filters ...common.PayloadF
 type watcher struct {
 	producers map[string]*producer
 	consumers map[string]*consumer
+	// seq is the sequence number of the last event received from a producer.
+	seq uint64
 
 	mux    sync.Mutex
 	closed bool
@@ -95,10 +98,18 @@ func (w *watcher) serviceProducer(prod *producer) {
 		case <-prod.ctx.Done():
 			slog.InfoContext(w.ctx, "closing producer")
 			return
-		case payload := <-prod.messages:
+		case payload, ok := <-prod.messages:
+			if !ok {
+				return
+			}
+			// Sequence numbers are assigned and events are queued for consumers
+			// while holding the lock, so all consumers see events in the same order.
+			// Sending to a consumer never blocks.
 			w.mux.Lock()
+			w.seq++
+			payload.Seq = w.seq
 			for _, c := range w.consumers {
-				go c.Send(payload)
+				c.Send(payload)
 			}
 			w.mux.Unlock()
 		}
@@ -111,14 +122,9 @@ func (w *watcher) RegisterConsumer(ctx context.Context, id string, filters ...co
 	if _, ok := w.consumers[id]; ok {
 		return nil, common.ErrConsumerAlreadyRegistered
 	}
-	c := &consumer{
-		messages: make(chan common.ChangePayload, 1),
-		filters:  filters,
-		quit:     make(chan struct{}),
-		id:       id,
-		ctx:      ctx,
-	}
+	c := newConsumer(ctx, id, filters...)
 	w.consumers[id] = c
+	go c.deliver()
 	go w.serviceConsumer(c)
 	return c, nil
 }
@@ -130,6 +136,9 @@ func (w *watcher) serviceConsumer(consumer *consumer) {
 		consumer.Close()
 		slog.InfoContext(w.ctx, "removing consumer from watcher", "consumer_id", consumer.id)
 		delete(w.consumers, consumer.id)
+		metrics.WatcherConsumerLag.DeleteLabelValues(consumer.id)
+		metrics.WatcherDroppedEvents.DeleteLabelValues(consumer.id)
+		metrics.WatcherResyncs.DeleteLabelValues(consumer.id)
 	}()
 	for {
 		select {
@@ -53,7 +53,7 @@ func (s *WatcherStoreTestSuite) TestJobWatcher() {
 			EntityType: common.JobEntityType,
 			Operation:  common.CreateOperation,
 			Payload:    job,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -68,7 +68,7 @@ func (s *WatcherStoreTestSuite) TestJobWatcher() {
 			EntityType: common.JobEntityType,
 			Operation:  common.UpdateOperation,
 			Payload:    updatedJob,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -189,7 +189,7 @@ func (s *WatcherStoreTestSuite) TestInstanceWatcher() {
 			EntityType: common.InstanceEntityType,
 			Operation:  common.CreateOperation,
 			Payload:    instance,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -207,7 +207,7 @@ func (s *WatcherStoreTestSuite) TestInstanceWatcher() {
 			EntityType: common.InstanceEntityType,
 			Operation:  common.UpdateOperation,
 			Payload:    updatedInstance,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -227,7 +227,7 @@ func (s *WatcherStoreTestSuite) TestInstanceWatcher() {
 				AgentID:    updatedInstance.AgentID,
 				PoolID:     updatedInstance.PoolID,
 			},
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -281,7 +281,7 @@ func (s *WatcherStoreTestSuite) TestPoolWatcher() {
 			EntityType: common.PoolEntityType,
 			Operation:  common.CreateOperation,
 			Payload:    pool,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -299,7 +299,7 @@ func (s *WatcherStoreTestSuite) TestPoolWatcher() {
 			EntityType: common.PoolEntityType,
 			Operation:  common.UpdateOperation,
 			Payload:    updatedPool,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -313,7 +313,7 @@ func (s *WatcherStoreTestSuite) TestPoolWatcher() {
 			EntityType: common.PoolEntityType,
 			Operation:  common.DeleteOperation,
 			Payload:    params.Pool{ID: pool.ID},
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -330,7 +330,7 @@ func (s *WatcherStoreTestSuite) TestPoolWatcher() {
 			EntityType: common.PoolEntityType,
 			Operation:  common.CreateOperation,
 			Payload:    pool,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -344,7 +344,7 @@ func (s *WatcherStoreTestSuite) TestPoolWatcher() {
 			EntityType: common.PoolEntityType,
 			Operation:  common.DeleteOperation,
 			Payload:    params.Pool{ID: pool.ID},
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -366,7 +366,7 @@ func (s *WatcherStoreTestSuite) TestControllerWatcher() {
 		MetadataURL: &metadataURL,
 	}
 
-	controller, err := s.store.UpdateController(updateParams)
+	controller, err := s.store.UpdateController(s.ctx, updateParams)
 	s.Require().NoError(err)
 	s.Require().Equal(metadataURL, controller.MetadataURL)
 
@@ -376,7 +376,7 @@ func (s *WatcherStoreTestSuite) TestControllerWatcher() {
 			EntityType: common.ControllerEntityType,
 			Operation:  common.UpdateOperation,
 			Payload:    controller,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -410,7 +410,7 @@ func (s *WatcherStoreTestSuite) TestEnterpriseWatcher() {
 			EntityType: common.EnterpriseEntityType,
 			Operation:  common.CreateOperation,
 			Payload:    ent,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -429,7 +429,7 @@ func (s *WatcherStoreTestSuite) TestEnterpriseWatcher() {
 			EntityType: common.EnterpriseEntityType,
 			Operation:  common.UpdateOperation,
 			Payload:    updatedEnt,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -443,7 +443,7 @@ func (s *WatcherStoreTestSuite) TestEnterpriseWatcher() {
 			EntityType: common.EnterpriseEntityType,
 			Operation:  common.DeleteOperation,
 			Payload:    updatedEnt,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -477,7 +477,7 @@ func (s *WatcherStoreTestSuite) TestOrgWatcher() {
 			EntityType: common.OrganizationEntityType,
 			Operation:  common.CreateOperation,
 			Payload:    org,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -496,7 +496,7 @@ func (s *WatcherStoreTestSuite) TestOrgWatcher() {
 			EntityType: common.OrganizationEntityType,
 			Operation:  common.UpdateOperation,
 			Payload:    updatedOrg,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -510,7 +510,7 @@ func (s *WatcherStoreTestSuite) TestOrgWatcher() {
 			EntityType: common.OrganizationEntityType,
 			Operation:  common.DeleteOperation,
 			Payload:    updatedOrg,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -544,7 +544,7 @@ func (s *WatcherStoreTestSuite) TestRepoWatcher() {
 			EntityType: common.RepositoryEntityType,
 			Operation:  common.CreateOperation,
 			Payload:    repo,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -564,7 +564,7 @@ func (s *WatcherStoreTestSuite) TestRepoWatcher() {
 			EntityType: common.RepositoryEntityType,
 			Operation:  common.UpdateOperation,
 			Payload:    updatedRepo,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -578,7 +578,7 @@ func (s *WatcherStoreTestSuite) TestRepoWatcher() {
 			EntityType: common.RepositoryEntityType,
 			Operation:  common.DeleteOperation,
 			Payload:    updatedRepo,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -618,7 +618,7 @@ func (s *WatcherStoreTestSuite) TestGithubCredentialsWatcher() {
 			EntityType: common.GithubCredentialsEntityType,
 			Operation:  common.CreateOperation,
 			Payload:    ghCred,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -638,7 +638,7 @@ func (s *WatcherStoreTestSuite) TestGithubCredentialsWatcher() {
 			EntityType: common.GithubCredentialsEntityType,
 			Operation:  common.UpdateOperation,
 			Payload:    updatedGhCred,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -653,7 +653,7 @@ func (s *WatcherStoreTestSuite) TestGithubCredentialsWatcher() {
 			Operation:  common.DeleteOperation,
 			// We only get the ID and Name of the deleted entity
 			Payload: params.GithubCredentials{ID: ghCred.ID, Name: ghCred.Name},
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -691,7 +691,7 @@ func (s *WatcherStoreTestSuite) TestGithubEndpointWatcher() {
 			EntityType: common.GithubEndpointEntityType,
 			Operation:  common.CreateOperation,
 			Payload:    ghEp,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -711,7 +711,7 @@ func (s *WatcherStoreTestSuite) TestGithubEndpointWatcher() {
 			EntityType: common.GithubEndpointEntityType,
 			Operation:  common.UpdateOperation,
 			Payload:    updatedGhEp,
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -726,7 +726,7 @@ func (s *WatcherStoreTestSuite) TestGithubEndpointWatcher() {
 			Operation:  common.DeleteOperation,
 			// We only get the name of the deleted entity
 			Payload: params.GithubEndpoint{Name: ghEp.Name},
-		}, event)
+		}, s.withoutSeq(event))
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
 	}
@@ -743,3 +743,11 @@ consume:
 		}
 	}
 }
+
+// withoutSeq checks that the watcher assigned a sequence number to the event and
+// clears it, so the event can be compared to the expected payload.
+func (s *WatcherStoreTestSuite) withoutSeq(event common.ChangePayload) common.ChangePayload {
+	s.Require().NotZero(event.Seq)
+	event.Seq = 0
+	return event
+}
@@ -25,7 +25,7 @@ type WatcherTestSuite struct {
 func (s *WatcherTestSuite) SetupTest() {
 	ctx := context.TODO()
 	watcher.InitWatcher(ctx)
-	store, err := database.NewDatabase(ctx, garmTesting.GetTestSqliteDBConfig(s.T()))
+	store, err := database.NewDatabase(ctx, garmTesting.GetTestDBConfig(s.T()))
 	if err != nil {
 		s.T().Fatalf("failed to create db connection: %s", err)
 	}
@@ -110,6 +110,8 @@ func (s *WatcherTestSuite) TestProducerAndConsumer() {
 	s.Require().NoError(err)
 
 	receivedPayload := <-consumer.Watch()
+	s.Require().NotZero(receivedPayload.Seq)
+	payload.Seq = receivedPayload.Seq
 	s.Require().Equal(payload, receivedPayload)
 }
 
@@ -135,6 +137,7 @@ func (s *WatcherTestSuite) TestConsumetWithFilter() {
 
 	select {
 	case receivedPayload := <-consumer.Watch():
+		payload.Seq = receivedPayload.Seq
 		s.Require().Equal(payload, receivedPayload)
 	case <-time.After(1 * time.Second):
 		s.T().Fatal("expected payload not received")
@@ -155,6 +158,104 @@ func (s *WatcherTestSuite) TestConsumetWithFilter() {
 	}
 }
 
+func (s *WatcherTestSuite) TestSequenceNumbersIncrease() {
+	producer, err := watcher.RegisterProducer(s.ctx, "test-producer")
+	s.Require().NoError(err)
+
+	consumer, err := watcher.RegisterConsumer(
+		s.ctx, "test-consumer",
+		watcher.WithEntityTypeFilter(common.ControllerEntityType))
+	s.Require().NoError(err)
+
+	for i := 0; i < 10; i++ {
+		err = producer.Notify(common.ChangePayload{
+			EntityType: common.ControllerEntityType,
+			Operation:  common.UpdateOperation,
+			Payload:    i,
+		})
+		s.Require().NoError(err)
+	}
+
+	var lastSeq uint64
+	for i := 0; i < 10; i++ {
+		select {
+		case event := <-consumer.Watch():
+			s.Require().Equal(i, event.Payload)
+			s.Require().Greater(event.Seq, lastSeq)
+			lastSeq = event.Seq
+		case <-time.After(1 * time.Second):
+			s.T().Fatal("expected payload not received")
+		}
+	}
+}
+
+func (s *WatcherTestSuite) TestSlowConsumerReceivesResync() {
+	oldSize := watcher.SetConsumerBufferSize(4)
+	defer watcher.SetConsumerBufferSize(oldSize)
+
+	producer, err := watcher.RegisterProducer(s.ctx, "test-producer")
+	s.Require().NoError(err)
+
+	consumer, err := watcher.RegisterConsumer(
+		s.ctx, "test-consumer",
+		watcher.WithEntityTypeFilter(common.ControllerEntityType))
+	s.Require().NoError(err)
+
+	total := 20
+	for i := 0; i < total; i++ {
+		err = producer.Notify(common.ChangePayload{
+			EntityType: common.ControllerEntityType,
+			Operation:  common.UpdateOperation,
+			Payload:    i,
+		})
+		s.Require().NoError(err)
+	}
+	// Give the watcher time to queue the last event.
+	time.Sleep(100 * time.Millisecond)
+
+	var missed uint64
+	var received int
+	var resyncs int
+	var lastSeq, resyncSeq uint64
+	for received+int(missed) < total {
+		select {
+		case event := <-consumer.Watch():
+			s.Require().Greater(event.Seq, lastSeq)
+			if event.Operation == common.ResyncOperation {
+				resync, ok := event.Payload.(common.ResyncPayload)
+				s.Require().True(ok)
+				s.Require().Equal(event.Seq, resync.LastMissedSeq)
+				missed += resync.Missed
+				resyncs++
+				resyncSeq = resync.LastMissedSeq
+			} else {
+				// Events that were buffered when the resync was sent are not delivered.
+				s.Require().Greater(event.Seq, resyncSeq)
+				received++
+			}
+			lastSeq = event.Seq
+		case <-time.After(1 * time.Second):
+			s.T().Fatalf("expected payload not received (received %d, missed %d)", received, missed)
+		}
+	}
+	s.Require().GreaterOrEqual(resyncs, 1)
+	s.Require().NotZero(missed)
+	s.Require().Equal(total, received+int(missed))
+}
+
+func (s *WatcherTestSuite) TestCloseConsumerClosesChannel() {
+	consumer, err := watcher.RegisterConsumer(s.ctx, "test-consumer")
+	s.Require().NoError(err)
+
+	consumer.Close()
+	select {
+	case _, ok := <-consumer.Watch():
+		s.Require().False(ok)
+	case <-time.After(1 * time.Second):
+		s.T().Fatal("expected channel to be closed")
+	}
+}
+
 func maybeInitController(db common.Store) error {
 	if _, err := db.ControllerInfo(); err == nil {
 		return nil
@@ -177,7 +278,7 @@ func TestWatcherTestSuite(t *testing.T) {
 	ctx := context.Background()
 	watcher.InitWatcher(ctx)
 
-	store, err := database.NewDatabase(ctx, garmTesting.GetTestSqliteDBConfig(t))
+	store, err := database.NewDatabase(ctx, garmTesting.GetTestDBConfig(t))
 	if err != nil {
 		t.Fatalf("failed to create db connection: %s", err)
 	}
@@ -19,6 +19,8 @@ package testing
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -27,6 +29,10 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
 	"github.com/cloudbase/garm/auth"
@@ -182,3 +188,121 @@ func DBEntityMapToSlice[T NameAndIDDBEntity](orgs map[string]T) []T {
 	}
 	return orgsSlice
 }
+
+// GetTestDBConfig returns the database config used by the store tests. A SQLite database
+// is used, unless GARM_TEST_DB_BACKEND is set to "postgres". In that case, a new database
+// is created for the test on the PostgreSQL server given by the GARM_TEST_POSTGRES_HOSTNAME,
+// GARM_TEST_POSTGRES_USERNAME, GARM_TEST_POSTGRES_PASSWORD and GARM_TEST_POSTGRES_SSLMODE
+// environment variables, and dropped once the test is done.
+func GetTestDBConfig(t *testing.T) config.Database {
+	if config.DBBackendType(os.Getenv("GARM_TEST_DB_BACKEND")) != config.PostgresBackend {
+		return GetTestSqliteDBConfig(t)
+	}
+	return GetTestPostgresDBConfig(t)
+}
+
+func GetTestPostgresDBConfig(t *testing.T) config.Database {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		t.Fatalf("failed to generate database name: %s", err)
+	}
+	cfg := config.Postgres{
+		Hostname:     getEnvOrDefault("GARM_TEST_POSTGRES_HOSTNAME", "127.0.0.1:5432"),
+		Username:     getEnvOrDefault("GARM_TEST_POSTGRES_USERNAME", "postgres"),
+		Password:     getEnvOrDefault("GARM_TEST_POSTGRES_PASSWORD", "postgres"),
+		SSLMode:      getEnvOrDefault("GARM_TEST_POSTGRES_SSLMODE", "disable"),
+		DatabaseName: "postgres",
+	}
+
+	connString, err := cfg.ConnectionString()
+	if err != nil {
+		t.Fatalf("failed to get postgres connection string: %s", err)
+	}
+	conn, err := gorm.Open(postgres.Open(connString), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to postgres: %s", err)
+	}
+	sqlDB, err := conn.DB()
+	if err != nil {
+		t.Fatalf("failed to get postgres connection: %s", err)
+	}
+
+	cfg.DatabaseName = fmt.Sprintf("garm_test_%s", hex.EncodeToString(suffix))
+	if err := conn.Exec(fmt.Sprintf("CREATE DATABASE %s", cfg.DatabaseName)).Error; err != nil {
+		sqlDB.Close()
+		t.Fatalf("failed to create database %s: %s", cfg.DatabaseName, err)
+	}
+	t.Cleanup(func() {
+		defer sqlDB.Close()
+		if err := conn.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", cfg.DatabaseName)).Error; err != nil {
+			t.Logf("failed to drop database %s: %s", cfg.DatabaseName, err)
+		}
+	})
+
+	return config.Database{
+		Debug:      false,
+		DbBackend:  config.PostgresBackend,
+		Passphrase: encryptionPassphrase,
+		Postgres:   cfg,
+	}
+}
+
+// GetTestMySQLDBConfig returns the config of a new database created for the test on the
+// MySQL server given by the GARM_TEST_MYSQL_HOSTNAME, GARM_TEST_MYSQL_USERNAME and
+// GARM_TEST_MYSQL_PASSWORD environment variables. The database is dropped once the
+// test is done. The test is skipped if GARM_TEST_MYSQL_HOSTNAME is not set.
+func GetTestMySQLDBConfig(t *testing.T) config.Database {
+	hostname := os.Getenv("GARM_TEST_MYSQL_HOSTNAME")
+	if hostname == "" {
+		t.Skip("GARM_TEST_MYSQL_HOSTNAME is not set")
+	}
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		t.Fatalf("failed to generate database name: %s", err)
+	}
+	cfg := config.MySQL{
+		Hostname:     hostname,
+		Username:     getEnvOrDefault("GARM_TEST_MYSQL_USERNAME", "root"),
+		Password:     getEnvOrDefault("GARM_TEST_MYSQL_PASSWORD", "root"),
+		DatabaseName: "mysql",
+	}
+
+	connString, err := cfg.ConnectionString()
+	if err != nil {
+		t.Fatalf("failed to get mysql connection string: %s", err)
+	}
+	conn, err := gorm.Open(mysql.Open(connString), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to mysql: %s", err)
+	}
+	sqlDB, err := conn.DB()
+	if err != nil {
+		t.Fatalf("failed to get mysql connection: %s", err)
+	}
+
+	cfg.DatabaseName = fmt.Sprintf("garm_test_%s", hex.EncodeToString(suffix))
+	if err := conn.Exec(fmt.Sprintf("CREATE DATABASE %s", cfg.DatabaseName)).Error; err != nil {
+		sqlDB.Close()
+		t.Fatalf("failed to create database %s: %s", cfg.DatabaseName, err)
+	}
+	t.Cleanup(func() {
+		defer sqlDB.Close()
+		if err := conn.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s", cfg.DatabaseName)).Error; err != nil {
+			t.Logf("failed to drop database %s: %s", cfg.DatabaseName, err)
+		}
+	})
+
+	return config.Database{
+		Debug:      false,
+		DbBackend:  config.MySQLBackend,
+		Passphrase: encryptionPassphrase,
+		MySQL:      cfg,
+	}
+}
+
+func getEnvOrDefault(name, defaultValue string) string {
+	if val := os.Getenv(name); val != "" {
+		return val
+	}
+	return defaultValue
+}
@@ -25,4 +25,11 @@ var (
 		Name:      "errors_total",
 		Help:      "Total number of failed instance operation attempts",
 	}, []string{"operation", "provider"})
+
+	InstanceQuotaReachedCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsRunnerSubsystem,
+		Name:      "quota_reached_total",
+		Help:      "Total number of times a runner could not be created because an entity wide limit was reached",
+	}, []string{"pool_owner", "pool_type", "limit"})
 )
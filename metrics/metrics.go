@@ -14,6 +14,7 @@ const (
 	metricsEnterpriseSubsystem   = "enterprise"
 	metricsWebhookSubsystem      = "webhook"
 	metricsGithubSubsystem       = "github"
+	metricsWatcherSubsystem      = "watcher"
 )
 
 // RegisterMetrics registers all the metrics
@@ -42,6 +43,8 @@ func RegisterMetrics() error {
 		PoolMaxRunners,
 		PoolMinIdleRunners,
 		PoolBootstrapTimeout,
+		PoolDegraded,
+		PoolQuarantined,
 		// health metrics
 		GarmHealth,
 
@@ -51,11 +54,16 @@ func RegisterMetrics() error {
 		// runner instances
 		InstanceOperationCount,
 		InstanceOperationFailedCount,
+		InstanceQuotaReachedCount,
 		// github
 		GithubOperationCount,
 		GithubOperationFailedCount,
 		// webhook metrics
 		WebhooksReceived,
+		// watcher metrics
+		WatcherConsumerLag,
+		WatcherDroppedEvents,
+		WatcherResyncs,
 	)
 
 	for _, c := range collectors {
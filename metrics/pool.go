@@ -39,4 +39,18 @@ var (
 		Name:      "bootstrap_timeout",
 		Help:      "Runner bootstrap timeout in the pool",
 	}, []string{"id"})
+
+	PoolDegraded = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsPoolSubsystem,
+		Name:      "degraded",
+		Help:      "Whether runner creation in the pool is suspended after repeated provider failures",
+	}, []string{"id", "provider"})
+
+	PoolQuarantined = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsPoolSubsystem,
+		Name:      "quarantined",
+		Help:      "Whether the pool was disabled after repeated bootstrap failures",
+	}, []string{"id", "provider"})
 )
@@ -0,0 +1,26 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	WatcherConsumerLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsWatcherSubsystem,
+		Name:      "consumer_lag",
+		Help:      "Number of events buffered for a consumer that were not yet read",
+	}, []string{"consumer"})
+
+	WatcherDroppedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsWatcherSubsystem,
+		Name:      "dropped_events_total",
+		Help:      "Total number of events dropped because a consumer fell behind",
+	}, []string{"consumer"})
+
+	WatcherResyncs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsWatcherSubsystem,
+		Name:      "resyncs_total",
+		Help:      "Total number of resync events sent to a consumer",
+	}, []string{"consumer"})
+)
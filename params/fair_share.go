@@ -0,0 +1,118 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package params
+
+import (
+	"fmt"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+)
+
+type FairShareGroupBy string
+
+const (
+	// FairShareGroupByRepository groups queued jobs by the repository in which they
+	// were triggered. The name of a group is <owner>/<repository>.
+	FairShareGroupByRepository FairShareGroupBy = "repository"
+	// FairShareGroupByWorkflow groups queued jobs by the name of the workflow that
+	// triggered them.
+	FairShareGroupByWorkflow FairShareGroupBy = "workflow"
+)
+
+// FairShareGroup overrides the fair share settings of a single group.
+type FairShareGroup struct {
+	// Name is the name of the group.
+	Name string `json:"name"`
+	// Priority dictates the order in which groups are served, in descending order.
+	// Every group is still served once before any group is served again.
+	Priority uint `json:"priority,omitempty"`
+	// MaxRunners overrides the max runners per group value for this group.
+	MaxRunners uint `json:"max_runners,omitempty"`
+}
+
+// FairShareSettings controls how the pool manager of an entity allocates runners
+// to queued jobs. By default, queued jobs are handled oldest first. When fair share
+// is enabled, queued jobs are grouped, and runner creation is interleaved between
+// groups, so a burst of jobs from one group does not starve the other groups.
+type FairShareSettings struct {
+	// Enabled turns on fair share scheduling for the entity.
+	Enabled bool `json:"enabled"`
+	// GroupBy is the key by which queued jobs are grouped. Defaults to repository.
+	GroupBy FairShareGroupBy `json:"group_by,omitempty"`
+	// MaxRunnersPerGroup is the maximum number of jobs of a single group that may be
+	// pending or running at the same time. A value of 0 means no limit.
+	MaxRunnersPerGroup uint `json:"max_runners_per_group,omitempty"`
+	// Groups holds settings for individual groups.
+	Groups []FairShareGroup `json:"groups,omitempty"`
+}
+
+func (f FairShareSettings) Validate() error {
+	switch f.GroupBy {
+	case FairShareGroupByRepository, FairShareGroupByWorkflow, "":
+	default:
+		return runnerErrors.NewBadRequestError("invalid fair share group by %q", f.GroupBy)
+	}
+
+	names := make(map[string]struct{}, len(f.Groups))
+	for _, group := range f.Groups {
+		if group.Name == "" {
+			return runnerErrors.NewBadRequestError("fair share group name may not be empty")
+		}
+		if _, ok := names[group.Name]; ok {
+			return runnerErrors.NewBadRequestError("duplicate fair share group %q", group.Name)
+		}
+		names[group.Name] = struct{}{}
+	}
+	return nil
+}
+
+func (f FairShareSettings) GetGroupBy() FairShareGroupBy {
+	if f.GroupBy == "" {
+		return FairShareGroupByRepository
+	}
+	return f.GroupBy
+}
+
+// GroupName returns the name of the group the job belongs to.
+func (f FairShareSettings) GroupName(job Job) string {
+	if f.GetGroupBy() == FairShareGroupByWorkflow {
+		return job.WorkflowName
+	}
+	return fmt.Sprintf("%s/%s", job.RepositoryOwner, job.RepositoryName)
+}
+
+func (f FairShareSettings) group(name string) (FairShareGroup, bool) {
+	for _, group := range f.Groups {
+		if group.Name == name {
+			return group, true
+		}
+	}
+	return FairShareGroup{}, false
+}
+
+// GroupPriority returns the priority of the given group.
+func (f FairShareSettings) GroupPriority(name string) uint {
+	group, _ := f.group(name)
+	return group.Priority
+}
+
+// GroupMaxRunners returns the maximum number of jobs of the given group that may be
+// pending or running at the same time. A value of 0 means no limit.
+func (f FairShareSettings) GroupMaxRunners(name string) uint {
+	if group, ok := f.group(name); ok && group.MaxRunners > 0 {
+		return group.MaxRunners
+	}
+	return f.MaxRunnersPerGroup
+}
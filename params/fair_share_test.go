@@ -0,0 +1,73 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package params
+
+import "testing"
+
+func TestFairShareSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings FairShareSettings
+		valid    bool
+	}{
+		{name: "empty", settings: FairShareSettings{}, valid: true},
+		{name: "group by workflow", settings: FairShareSettings{Enabled: true, GroupBy: FairShareGroupByWorkflow}, valid: true},
+		{name: "invalid group by", settings: FairShareSettings{Enabled: true, GroupBy: "branch"}, valid: false},
+		{name: "empty group name", settings: FairShareSettings{Groups: []FairShareGroup{{Priority: 1}}}, valid: false},
+		{name: "duplicate group", settings: FairShareSettings{Groups: []FairShareGroup{{Name: "org/repo"}, {Name: "org/repo"}}}, valid: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.settings.Validate()
+			if tc.valid && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestFairShareSettingsGroups(t *testing.T) {
+	settings := FairShareSettings{
+		Enabled:            true,
+		MaxRunnersPerGroup: 2,
+		Groups: []FairShareGroup{
+			{Name: "org/critical", Priority: 10, MaxRunners: 5},
+			{Name: "org/low", Priority: 1},
+		},
+	}
+	job := Job{RepositoryOwner: "org", RepositoryName: "critical", WorkflowName: "build"}
+
+	if name := settings.GroupName(job); name != "org/critical" {
+		t.Fatalf("expected group org/critical, got %s", name)
+	}
+	if limit := settings.GroupMaxRunners("org/critical"); limit != 5 {
+		t.Fatalf("expected group limit of 5, got %d", limit)
+	}
+	if limit := settings.GroupMaxRunners("org/low"); limit != 2 {
+		t.Fatalf("expected default limit of 2, got %d", limit)
+	}
+	if priority := settings.GroupPriority("org/other"); priority != 0 {
+		t.Fatalf("expected no priority for unknown group, got %d", priority)
+	}
+
+	settings.GroupBy = FairShareGroupByWorkflow
+	if name := settings.GroupName(job); name != "build" {
+		t.Fatalf("expected group build, got %s", name)
+	}
+}
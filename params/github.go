@@ -55,6 +55,7 @@ type WorkflowJob struct {
 		RunnerName      string   `json:"runner_name"`
 		RunnerGroupID   int64    `json:"runner_group_id"`
 		RunnerGroupName string   `json:"runner_group_name"`
+		WorkflowName    string   `json:"workflow_name"`
 	} `json:"workflow_job"`
 	Repository struct {
 		ID       int64  `json:"id"`
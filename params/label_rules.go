@@ -0,0 +1,74 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package params
+
+import (
+	"strings"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+)
+
+// PoolLabelRules refine which jobs a pool is eligible for, on top of the requirement
+// that the pool has all the labels requested by a job.
+type PoolLabelRules struct {
+	// RequiredLabels is a list of labels that a job must explicitly request for the
+	// pool to be eligible. This prevents jobs that only request generic labels from
+	// landing on specialized pools (GPU, large instances, etc).
+	RequiredLabels []string `json:"required_labels,omitempty"`
+	// ExcludedLabels is a list of labels that make the pool ineligible, if requested
+	// by a job.
+	ExcludedLabels []string `json:"excluded_labels,omitempty"`
+}
+
+func (r PoolLabelRules) Validate() error {
+	required := make(map[string]struct{}, len(r.RequiredLabels))
+	for _, label := range r.RequiredLabels {
+		if label == "" {
+			return runnerErrors.NewBadRequestError("required labels may not be empty")
+		}
+		required[strings.ToLower(label)] = struct{}{}
+	}
+
+	for _, label := range r.ExcludedLabels {
+		if label == "" {
+			return runnerErrors.NewBadRequestError("excluded labels may not be empty")
+		}
+		if _, ok := required[strings.ToLower(label)]; ok {
+			return runnerErrors.NewBadRequestError("label %q may not be both required and excluded", label)
+		}
+	}
+	return nil
+}
+
+// Allows returns true if the rules allow a job requesting the given labels. Labels
+// are compared case insensitively, like GitHub does.
+func (r PoolLabelRules) Allows(requested []string) bool {
+	asMap := make(map[string]struct{}, len(requested))
+	for _, label := range requested {
+		asMap[strings.ToLower(label)] = struct{}{}
+	}
+
+	for _, label := range r.RequiredLabels {
+		if _, ok := asMap[strings.ToLower(label)]; !ok {
+			return false
+		}
+	}
+	for _, label := range r.ExcludedLabels {
+		if _, ok := asMap[strings.ToLower(label)]; ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package params
+
+import "testing"
+
+func TestPoolHasRequiredLabelsWithRules(t *testing.T) {
+	pool := Pool{
+		Tags: []Tag{{Name: "linux"}, {Name: "x64"}, {Name: "gpu"}, {Name: "spot"}},
+		LabelRules: PoolLabelRules{
+			RequiredLabels: []string{"gpu"},
+			ExcludedLabels: []string{"spot"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		labels []string
+		match  bool
+	}{
+		{name: "generic job", labels: []string{"linux", "x64"}, match: false},
+		{name: "explicit gpu job", labels: []string{"linux", "gpu"}, match: true},
+		{name: "excluded label", labels: []string{"linux", "gpu", "spot"}, match: false},
+		{name: "missing tag", labels: []string{"linux", "gpu", "arm64"}, match: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := pool.HasRequiredLabels(tc.labels); got != tc.match {
+				t.Fatalf("expected %v, got %v", tc.match, got)
+			}
+		})
+	}
+}
+
+func TestPoolLabelRulesAllowsMixedCase(t *testing.T) {
+	rules := PoolLabelRules{
+		RequiredLabels: []string{"GPU"},
+		ExcludedLabels: []string{"spot"},
+	}
+
+	if !rules.Allows([]string{"linux", "gpu"}) {
+		t.Fatalf("expected required label to match regardless of case")
+	}
+	if rules.Allows([]string{"linux", "Gpu", "SPOT"}) {
+		t.Fatalf("expected excluded label to match regardless of case")
+	}
+}
+
+func TestPoolLabelRulesValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules PoolLabelRules
+		valid bool
+	}{
+		{name: "empty", rules: PoolLabelRules{}, valid: true},
+		{name: "required and excluded", rules: PoolLabelRules{RequiredLabels: []string{"gpu"}, ExcludedLabels: []string{"spot"}}, valid: true},
+		{name: "empty label", rules: PoolLabelRules{RequiredLabels: []string{""}}, valid: false},
+		{name: "conflicting labels", rules: PoolLabelRules{RequiredLabels: []string{"gpu"}, ExcludedLabels: []string{"gpu"}}, valid: false},
+		{name: "conflicting mixed case labels", rules: PoolLabelRules{RequiredLabels: []string{"GPU"}, ExcludedLabels: []string{"gpu"}}, valid: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rules.Validate()
+			if tc.valid && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
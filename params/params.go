@@ -23,6 +23,8 @@ import (
 	"encoding/pem"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/bradleyfalzon/ghinstallation/v2"
@@ -30,6 +32,7 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/oauth2"
 
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
 	commonParams "github.com/cloudbase/garm-provider-common/params"
 	"github.com/cloudbase/garm/util/appdefaults"
 )
@@ -44,6 +47,7 @@ type (
 	WebhookEndpointType string
 	GithubAuthType      string
 	PoolBalancerType    string
+	UserRole            string
 )
 
 const (
@@ -56,6 +60,15 @@ const (
 	// PoolBalancerTypePack will try to create instances in the first pool that matches
 	// the required labels. If the pool is full, it will move on to the next pool and so on.
 	PoolBalancerTypePack PoolBalancerType = "pack"
+	// PoolBalancerTypeWeighted will spread the runners across the pools that match the
+	// required labels, proportionally to the weight of each pool. For example, with two
+	// pools of weight 70 and 30, 70% of the runners are created in the first pool. If the
+	// selected pool is full, the remaining pools are tried in order of priority.
+	PoolBalancerTypeWeighted PoolBalancerType = "weighted"
+	// PoolBalancerTypeCapacity will try to create instances in the pool that has the most
+	// free capacity (max runners minus the current number of runners) among the pools
+	// that match the required labels. Ties are broken by priority.
+	PoolBalancerTypeCapacity PoolBalancerType = "capacity"
 	// PoolBalancerTypeNone denotes to the default behavior of the pool manager, which is
 	// to use the round robin balancer.
 	PoolBalancerTypeNone PoolBalancerType = ""
@@ -84,6 +97,42 @@ const (
 	JobStatusCompleted  JobStatus = "completed"
 )
 
+const (
+	// UserRoleViewer can view all resources, except secrets and the audit log.
+	UserRoleViewer UserRole = "viewer"
+	// UserRoleOperator can do everything a viewer can, and can also scale pools
+	// and delete runners.
+	UserRoleOperator UserRole = "operator"
+	// UserRoleAdmin has full access, including to credentials, endpoints, users
+	// and controller settings.
+	UserRoleAdmin UserRole = "admin"
+)
+
+// level returns the rank of the role. Roles with a higher rank include all the
+// permissions of the roles with a lower rank.
+func (r UserRole) level() int {
+	switch r {
+	case UserRoleViewer:
+		return 1
+	case UserRoleOperator:
+		return 2
+	case UserRoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// IsValid returns true if the role is one of the known roles.
+func (r UserRole) IsValid() bool {
+	return r.level() > 0
+}
+
+// Includes returns true if the role grants all the permissions of the other role.
+func (r UserRole) Includes(other UserRole) bool {
+	return other.IsValid() && r.level() >= other.level()
+}
+
 const (
 	GithubEntityTypeRepository   GithubEntityType = "repository"
 	GithubEntityTypeOrganization GithubEntityType = "organization"
@@ -99,6 +148,7 @@ const (
 const (
 	StatusEvent     EventType = "status"
 	FetchTokenEvent EventType = "fetchToken"
+	RecycleEvent    EventType = "recycle"
 )
 
 const (
@@ -186,6 +236,9 @@ type Instance struct {
 	// up.
 	StatusMessages []StatusMessage `json:"status_messages,omitempty"`
 
+	// CreatedAt is the timestamp of the creation of this runner.
+	CreatedAt time.Time `json:"created_at"`
+
 	// UpdatedAt is the timestamp of the last update to this runner.
 	UpdatedAt time.Time `json:"updated_at"`
 
@@ -319,6 +372,45 @@ type Pool struct {
 	// When fetching matching pools for a set of tags, the result will be sorted in descending
 	// order of priority.
 	Priority uint `json:"priority"`
+
+	// Weight is the relative share of runners created in this pool, when the entity uses
+	// the weighted pool balancer. A weight of 0 is the same as a weight of 1.
+	Weight uint `json:"weight,omitempty"`
+
+	// MaxIdleAge is the amount of time, in minutes, a runner may stay idle before it is
+	// recycled. A value of 0 means idle runners are never recycled because of their idle age.
+	MaxIdleAge uint `json:"max_idle_age,omitempty"`
+	// MaxLifetime is the maximum age of a runner, in minutes, counted from its creation,
+	// whether it was idle or not. A runner that is past it is recycled as soon as it is
+	// idle, so jobs are never interrupted. A value of 0 means runners are never recycled
+	// because of their age.
+	MaxLifetime uint `json:"max_lifetime,omitempty"`
+
+	// MaxConcurrentCreates and CreatesPerMinute override the instance creation limits of
+	// the provider for this pool. A value of 0 means the limit of the provider applies.
+	MaxConcurrentCreates uint `json:"max_concurrent_creates,omitempty"`
+	CreatesPerMinute     uint `json:"creates_per_minute,omitempty"`
+
+	// QuarantineThreshold is the number of consecutive bootstrap failures after which the
+	// pool is automatically disabled. A value of 0 disables quarantine.
+	QuarantineThreshold uint `json:"quarantine_threshold,omitempty"`
+	// QuarantineReason records why the pool was quarantined. QuarantinedAt is set while
+	// the pool is quarantined and cleared when the pool is enabled again.
+	QuarantineReason string     `json:"quarantine_reason,omitempty"`
+	QuarantinedAt    *time.Time `json:"quarantined_at,omitempty"`
+
+	// ScalingSchedules is a list of time windows during which the pool uses different
+	// min_idle_runners and max_runners values. The first active schedule wins. Outside
+	// of any schedule, the MinIdleRunners and MaxRunners values of the pool apply.
+	ScalingSchedules []PoolScalingSchedule `json:"scaling_schedules,omitempty"`
+
+	// ScaleDownPolicy controls how idle runners are removed from the pool once
+	// the number of idle runners exceeds min_idle_runners.
+	ScaleDownPolicy PoolScaleDownPolicy `json:"scale_down_policy"`
+
+	// LabelRules refine which jobs the pool is eligible for, on top of matching the
+	// labels requested by a job against the tags of the pool.
+	LabelRules PoolLabelRules `json:"label_rules"`
 }
 
 func (p Pool) GithubEntity() (GithubEntity, error) {
@@ -353,6 +445,31 @@ func (p *Pool) RunnerTimeout() uint {
 	return p.RunnerBootstrapTimeout
 }
 
+// GetWeight returns the weight of the pool, as used by the weighted pool balancer.
+func (p *Pool) GetWeight() uint {
+	if p.Weight == 0 {
+		return 1
+	}
+	return p.Weight
+}
+
+// GetMaxIdleAge returns the amount of time a runner may stay idle before it is recycled.
+// A value of 0 means there is no limit.
+func (p *Pool) GetMaxIdleAge() time.Duration {
+	return time.Duration(p.MaxIdleAge) * time.Minute
+}
+
+// GetMaxLifetime returns the maximum age of a runner, after which it is recycled once idle.
+// A value of 0 means there is no limit.
+func (p *Pool) GetMaxLifetime() time.Duration {
+	return time.Duration(p.MaxLifetime) * time.Minute
+}
+
+// IsQuarantined returns true if the pool was disabled after repeated bootstrap failures.
+func (p *Pool) IsQuarantined() bool {
+	return p.QuarantinedAt != nil
+}
+
 func (p *Pool) PoolType() GithubEntityType {
 	switch {
 	case p.RepoID != "":
@@ -365,6 +482,35 @@ func (p *Pool) PoolType() GithubEntityType {
 	return ""
 }
 
+// ActiveScalingSchedule returns the first scaling schedule of the pool that is
+// active at the given time.
+func (p *Pool) ActiveScalingSchedule(t time.Time) (PoolScalingSchedule, bool) {
+	for _, schedule := range p.ScalingSchedules {
+		if schedule.IsActive(t) {
+			return schedule, true
+		}
+	}
+	return PoolScalingSchedule{}, false
+}
+
+// MinIdleRunnersAt returns the minimum number of idle runners the pool must
+// maintain at the given time, taking scaling schedules into account.
+func (p *Pool) MinIdleRunnersAt(t time.Time) uint {
+	if schedule, ok := p.ActiveScalingSchedule(t); ok {
+		return schedule.MinIdleRunners
+	}
+	return p.MinIdleRunners
+}
+
+// MaxRunnersAt returns the maximum number of runners the pool may have at the
+// given time, taking scaling schedules into account.
+func (p *Pool) MaxRunnersAt(t time.Time) uint {
+	if schedule, ok := p.ActiveScalingSchedule(t); ok {
+		return schedule.ScheduledMaxRunners(p.MaxRunners)
+	}
+	return p.MaxRunners
+}
+
 func (p *Pool) HasRequiredLabels(set []string) bool {
 	asMap := make(map[string]struct{}, len(p.Tags))
 	for _, t := range p.Tags {
@@ -376,7 +522,7 @@ func (p *Pool) HasRequiredLabels(set []string) bool {
 			return false
 		}
 	}
-	return true
+	return p.LabelRules.Allows(set)
 }
 
 // used by swagger client generated code
@@ -396,6 +542,47 @@ type Internal struct {
 	PoolBalancerType         PoolBalancerType  `json:"pool_balancing_type"`
 }
 
+// JobBackfillSettings controls whether a pool manager asks GitHub for queued
+// workflow jobs when it starts. Jobs that were queued while GARM was down are
+// never delivered via webhooks, so without a backfill they are only picked up
+// by idle runners.
+type JobBackfillSettings struct {
+	// Enabled turns on the backfill of queued jobs for the entity.
+	Enabled bool `json:"enabled"`
+	// Repositories is an explicit list of repositories to scan for queued jobs. It
+	// only applies to organizations.
+	Repositories []string `json:"repositories,omitempty"`
+	// MaxRepositories is the maximum number of repositories to scan for queued jobs,
+	// if no explicit list of repositories is set. Repositories that were most recently
+	// pushed to are scanned first. It only applies to organizations.
+	MaxRepositories uint `json:"max_repositories,omitempty"`
+}
+
+func (j JobBackfillSettings) Validate(entityType GithubEntityType) error {
+	if !j.Enabled {
+		return nil
+	}
+
+	switch entityType {
+	case GithubEntityTypeRepository:
+		if len(j.Repositories) > 0 || j.MaxRepositories > 0 {
+			return runnerErrors.NewBadRequestError("repository list and max repositories are only valid for organizations")
+		}
+	case GithubEntityTypeOrganization:
+		if len(j.Repositories) == 0 && j.MaxRepositories == 0 {
+			return runnerErrors.NewBadRequestError("job backfill for organizations requires a list of repositories or a max number of repositories to scan")
+		}
+		for _, repo := range j.Repositories {
+			if repo == "" || strings.Contains(repo, "/") {
+				return runnerErrors.NewBadRequestError("invalid repository name %q", repo)
+			}
+		}
+	default:
+		return runnerErrors.NewBadRequestError("job backfill is not supported for %s", entityType)
+	}
+	return nil
+}
+
 type Repository struct {
 	ID    string `json:"id"`
 	Owner string `json:"owner"`
@@ -404,12 +591,20 @@ type Repository struct {
 	// CredentialName is the name of the credentials associated with the enterprise.
 	// This field is now deprecated. Use CredentialsID instead. This field will be
 	// removed in v0.2.0.
-	CredentialsName   string            `json:"credentials_name,omitempty"`
-	CredentialsID     uint              `json:"credentials_id"`
-	Credentials       GithubCredentials `json:"credentials"`
-	PoolManagerStatus PoolManagerStatus `json:"pool_manager_status,omitempty"`
-	PoolBalancerType  PoolBalancerType  `json:"pool_balancing_type"`
-	Endpoint          GithubEndpoint    `json:"endpoint"`
+	CredentialsName   string              `json:"credentials_name,omitempty"`
+	CredentialsID     uint                `json:"credentials_id"`
+	Credentials       GithubCredentials   `json:"credentials"`
+	PoolManagerStatus PoolManagerStatus   `json:"pool_manager_status,omitempty"`
+	PoolBalancerType  PoolBalancerType    `json:"pool_balancing_type"`
+	Endpoint          GithubEndpoint      `json:"endpoint"`
+	JobBackfill       JobBackfillSettings `json:"job_backfill"`
+	FairShare         FairShareSettings   `json:"fair_share"`
+	// MaxConcurrentRunners is the maximum number of runners this repository may have
+	// across all of its pools. A value of 0 means no limit.
+	MaxConcurrentRunners uint `json:"max_concurrent_runners,omitempty"`
+	// MaxPendingRunners is the maximum number of runners of this repository that may be
+	// pending (being created or still installing) at the same time. A value of 0 means no limit.
+	MaxPendingRunners uint `json:"max_pending_runners,omitempty"`
 	// Do not serialize sensitive info.
 	WebhookSecret string `json:"-"`
 }
@@ -426,6 +621,11 @@ func (r Repository) GetEntity() (GithubEntity, error) {
 		PoolBalancerType: r.PoolBalancerType,
 		Credentials:      r.Credentials,
 		WebhookSecret:    r.WebhookSecret,
+		JobBackfill:      r.JobBackfill,
+		FairShare:        r.FairShare,
+
+		MaxConcurrentRunners: r.MaxConcurrentRunners,
+		MaxPendingRunners:    r.MaxPendingRunners,
 	}, nil
 }
 
@@ -458,12 +658,20 @@ type Organization struct {
 	// CredentialName is the name of the credentials associated with the enterprise.
 	// This field is now deprecated. Use CredentialsID instead. This field will be
 	// removed in v0.2.0.
-	CredentialsName   string            `json:"credentials_name,omitempty"`
-	Credentials       GithubCredentials `json:"credentials"`
-	CredentialsID     uint              `json:"credentials_id"`
-	PoolManagerStatus PoolManagerStatus `json:"pool_manager_status,omitempty"`
-	PoolBalancerType  PoolBalancerType  `json:"pool_balancing_type"`
-	Endpoint          GithubEndpoint    `json:"endpoint"`
+	CredentialsName   string              `json:"credentials_name,omitempty"`
+	Credentials       GithubCredentials   `json:"credentials"`
+	CredentialsID     uint                `json:"credentials_id"`
+	PoolManagerStatus PoolManagerStatus   `json:"pool_manager_status,omitempty"`
+	PoolBalancerType  PoolBalancerType    `json:"pool_balancing_type"`
+	Endpoint          GithubEndpoint      `json:"endpoint"`
+	JobBackfill       JobBackfillSettings `json:"job_backfill"`
+	FairShare         FairShareSettings   `json:"fair_share"`
+	// MaxConcurrentRunners is the maximum number of runners this organization may have
+	// across all of its pools. A value of 0 means no limit.
+	MaxConcurrentRunners uint `json:"max_concurrent_runners,omitempty"`
+	// MaxPendingRunners is the maximum number of runners of this organization that may be
+	// pending (being created or still installing) at the same time. A value of 0 means no limit.
+	MaxPendingRunners uint `json:"max_pending_runners,omitempty"`
 	// Do not serialize sensitive info.
 	WebhookSecret string `json:"-"`
 }
@@ -479,6 +687,11 @@ func (o Organization) GetEntity() (GithubEntity, error) {
 		WebhookSecret:    o.WebhookSecret,
 		PoolBalancerType: o.PoolBalancerType,
 		Credentials:      o.Credentials,
+		JobBackfill:      o.JobBackfill,
+		FairShare:        o.FairShare,
+
+		MaxConcurrentRunners: o.MaxConcurrentRunners,
+		MaxPendingRunners:    o.MaxPendingRunners,
 	}, nil
 }
 
@@ -513,6 +726,13 @@ type Enterprise struct {
 	PoolManagerStatus PoolManagerStatus `json:"pool_manager_status,omitempty"`
 	PoolBalancerType  PoolBalancerType  `json:"pool_balancing_type"`
 	Endpoint          GithubEndpoint    `json:"endpoint"`
+	FairShare         FairShareSettings `json:"fair_share"`
+	// MaxConcurrentRunners is the maximum number of runners this enterprise may have
+	// across all of its pools. A value of 0 means no limit.
+	MaxConcurrentRunners uint `json:"max_concurrent_runners,omitempty"`
+	// MaxPendingRunners is the maximum number of runners of this enterprise that may be
+	// pending (being created or still installing) at the same time. A value of 0 means no limit.
+	MaxPendingRunners uint `json:"max_pending_runners,omitempty"`
 	// Do not serialize sensitive info.
 	WebhookSecret string `json:"-"`
 }
@@ -528,6 +748,10 @@ func (e Enterprise) GetEntity() (GithubEntity, error) {
 		WebhookSecret:    e.WebhookSecret,
 		PoolBalancerType: e.PoolBalancerType,
 		Credentials:      e.Credentials,
+		FairShare:        e.FairShare,
+
+		MaxConcurrentRunners: e.MaxConcurrentRunners,
+		MaxPendingRunners:    e.MaxPendingRunners,
 	}, nil
 }
 
@@ -559,9 +783,14 @@ type User struct {
 	FullName  string    `json:"full_name"`
 	Password  string    `json:"-"`
 	Enabled   bool      `json:"enabled"`
-	IsAdmin   bool      `json:"is_admin"`
+	// IsAdmin is true for users that have the admin role.
+	IsAdmin bool     `json:"is_admin"`
+	Role    UserRole `json:"role"`
 }
 
+// used by swagger client generated code
+type Users []User
+
 // JWTResponse holds the JWT token returned as a result of a
 // successful auth
 type JWTResponse struct {
@@ -575,6 +804,28 @@ type ControllerInfo struct {
 	CallbackURL          string    `json:"callback_url"`
 	WebhookURL           string    `json:"webhook_url"`
 	ControllerWebhookURL string    `json:"controller_webhook_url"`
+	// NodeID is the ID of the controller node that served this request. It is
+	// only set when high availability is enabled.
+	NodeID string `json:"node_id,omitempty"`
+	// LeaderNodeID is the ID of the controller node that currently holds the
+	// controller lease. It is only set when high availability is enabled.
+	LeaderNodeID string `json:"leader_node_id,omitempty"`
+}
+
+// ControllerLease is a time limited lock held by one of the GARM controllers
+// that share a database. It is used to elect the controller that reconciles pools.
+type ControllerLease struct {
+	Name       string    `json:"name"`
+	HolderID   string    `json:"holder_id"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	RenewedAt  time.Time `json:"renewed_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// IsHeldBy returns true if the lease is held by the given holder and has not
+// expired at the given time.
+func (c ControllerLease) IsHeldBy(holderID string, now time.Time) bool {
+	return c.HolderID == holderID && now.Before(c.ExpiresAt)
 }
 
 type GithubCredentials struct {
@@ -687,6 +938,12 @@ type Provider struct {
 	Name         string       `json:"name"`
 	ProviderType ProviderType `json:"type"`
 	Description  string       `json:"description"`
+	// MaxConcurrentCreates is the maximum number of instances the provider may be
+	// creating at the same time. A value of 0 means no limit.
+	MaxConcurrentCreates uint `json:"max_concurrent_creates,omitempty"`
+	// CreatesPerMinute is the maximum number of instance creations the provider may
+	// start in one minute. A value of 0 means no limit.
+	CreatesPerMinute uint `json:"creates_per_minute,omitempty"`
 }
 
 // used by swagger client generated code
@@ -695,6 +952,26 @@ type Providers []Provider
 type PoolManagerStatus struct {
 	IsRunning     bool   `json:"running"`
 	FailureReason string `json:"failure_reason,omitempty"`
+	// DegradedPools is the list of pools that are currently skipped when creating
+	// runners, because creating runners in them has repeatedly failed.
+	DegradedPools []DegradedPool `json:"degraded_pools,omitempty"`
+}
+
+// DegradedPool holds details about a pool in which runners are temporarily not
+// created, because the provider repeatedly failed to create them. Once RetryAt
+// is reached, a single runner is created to probe the provider. If that succeeds,
+// the pool is no longer degraded.
+type DegradedPool struct {
+	PoolID       string `json:"pool_id"`
+	ProviderName string `json:"provider_name"`
+	// Reason describes why the pool was marked as degraded, including the last
+	// error returned by the provider.
+	Reason string `json:"reason"`
+	// ConsecutiveFailures is the number of failed attempts to create a runner since
+	// the last successful one.
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	DegradedSince       time.Time `json:"degraded_since"`
+	RetryAt             time.Time `json:"retry_at"`
 }
 
 type RunnerInfo struct {
@@ -729,6 +1006,8 @@ type Job struct {
 	Status string `json:"status"`
 	// Name is the name if the job that was triggered.
 	Name string `json:"name"`
+	// WorkflowName is the name of the workflow the job is part of.
+	WorkflowName string `json:"workflow_name,omitempty"`
 
 	StartedAt   time.Time
 	CompletedAt time.Time
@@ -766,6 +1045,323 @@ type Job struct {
 // used by swagger client generated code
 type Jobs []Job
 
+// JobHistory is the record GARM keeps of a workflow job. Unlike jobs, which are
+// removed once they complete, history entries are kept for the configured
+// retention period.
+type JobHistory struct {
+	// ID is the ID of the job.
+	ID int64 `json:"id"`
+	// RunID is the ID of the workflow run.
+	RunID        int64  `json:"run_id"`
+	Name         string `json:"name"`
+	WorkflowName string `json:"workflow_name,omitempty"`
+	Status       string `json:"status"`
+	Conclusion   string `json:"conclusion,omitempty"`
+
+	RepositoryName  string   `json:"repository_name"`
+	RepositoryOwner string   `json:"repository_owner"`
+	Labels          []string `json:"labels"`
+
+	// PoolID, ProviderName and RunnerName identify the GARM runner that
+	// picked up the job. They are empty if the job ran on a runner that
+	// is not managed by GARM.
+	PoolID       string `json:"pool_id,omitempty"`
+	ProviderName string `json:"provider_name,omitempty"`
+	RunnerName   string `json:"runner_name,omitempty"`
+
+	// QueuedAt is the time GARM first recorded the job.
+	QueuedAt    time.Time  `json:"queued_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WaitTime returns the amount of time the job waited for a runner. It returns
+// 0 if the job was not yet picked up.
+func (j JobHistory) WaitTime() time.Duration {
+	if j.StartedAt == nil || j.StartedAt.Before(j.QueuedAt) {
+		return 0
+	}
+	return j.StartedAt.Sub(j.QueuedAt)
+}
+
+// RunTime returns the amount of time the job ran for. It returns 0 if the
+// job has not yet completed.
+func (j JobHistory) RunTime() time.Duration {
+	if j.StartedAt == nil || j.CompletedAt == nil || j.CompletedAt.Before(*j.StartedAt) {
+		return 0
+	}
+	return j.CompletedAt.Sub(*j.StartedAt)
+}
+
+// used by swagger client generated code
+type JobHistories []JobHistory
+
+type AuditAction string
+
+const (
+	AuditActionCreate     AuditAction = "create"
+	AuditActionUpdate     AuditAction = "update"
+	AuditActionDelete     AuditAction = "delete"
+	AuditActionQuarantine AuditAction = "quarantine"
+)
+
+const (
+	// AuditActorController is the actor of changes made by GARM itself.
+	AuditActorController = "controller"
+	// AuditActorInstancePrefix is the prefix of the actor of changes reported by
+	// a runner. It is followed by the name of the runner.
+	AuditActorInstancePrefix = "instance:"
+)
+
+// AuditChange holds the old and new value of a field changed by an audited
+// operation. Secrets are redacted.
+type AuditChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// AuditEntry records a change made to GARM, either through the API or by
+// GARM itself.
+type AuditEntry struct {
+	ID uint `json:"id"`
+	// UserID is the ID of the user that made the change. It is empty for
+	// changes made by GARM itself.
+	UserID string `json:"user_id,omitempty"`
+	// Actor is set for changes that were not made by a user. It is "controller"
+	// for changes made by GARM itself, and "instance:<name>" for changes reported
+	// by a runner.
+	Actor      string        `json:"actor,omitempty"`
+	Action     AuditAction   `json:"action"`
+	EntityType string        `json:"entity_type"`
+	EntityID   string        `json:"entity_id"`
+	EntityName string        `json:"entity_name,omitempty"`
+	Changes    []AuditChange `json:"changes,omitempty"`
+	// SourceIP is the address the API request came from. It is empty for
+	// changes made by GARM itself.
+	SourceIP  string    `json:"source_ip,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// used by swagger client generated code
+type AuditEntries []AuditEntry
+
+type NotificationEventType string
+
+const (
+	// PoolDegradedEvent is sent when runners are no longer created in a pool,
+	// after too many consecutive failures of the provider.
+	PoolDegradedEvent NotificationEventType = "pool_degraded"
+	// PoolRecoveredEvent is sent when a degraded pool creates a runner again.
+	PoolRecoveredEvent NotificationEventType = "pool_recovered"
+	// ProviderDegradedEvent is sent when a provider fails in more than one pool.
+	ProviderDegradedEvent NotificationEventType = "provider_degraded"
+	// ProviderRecoveredEvent is sent when a degraded provider creates a runner again.
+	ProviderRecoveredEvent NotificationEventType = "provider_recovered"
+	// PoolQuarantinedEvent is sent when a pool is disabled after too many runners
+	// failed to bootstrap.
+	PoolQuarantinedEvent NotificationEventType = "pool_quarantined"
+	// RunnerBootstrapTimeoutEvent is sent when a runner did not come online within
+	// the bootstrap timeout of its pool.
+	RunnerBootstrapTimeoutEvent NotificationEventType = "runner_bootstrap_timeout"
+	// PoolManagerStoppedEvent is sent when the pool manager of a repository,
+	// organization or enterprise stops, because of an error.
+	PoolManagerStoppedEvent NotificationEventType = "pool_manager_stopped"
+	// PoolManagerRecoveredEvent is sent when a stopped pool manager runs again.
+	PoolManagerRecoveredEvent NotificationEventType = "pool_manager_recovered"
+	// TestNotificationEvent is sent when testing a notification sink.
+	TestNotificationEvent NotificationEventType = "test"
+)
+
+// NotificationEventTypes holds all the event types that can be sent to
+// notification sinks.
+var NotificationEventTypes = []NotificationEventType{
+	PoolDegradedEvent,
+	PoolRecoveredEvent,
+	ProviderDegradedEvent,
+	ProviderRecoveredEvent,
+	PoolQuarantinedEvent,
+	RunnerBootstrapTimeoutEvent,
+	PoolManagerStoppedEvent,
+	PoolManagerRecoveredEvent,
+	TestNotificationEvent,
+}
+
+// NotificationEvent is an event about the operation of GARM, that is sent to
+// the notification sinks with a matching rule.
+type NotificationEvent struct {
+	Type    NotificationEventType `json:"type"`
+	Level   EventLevel            `json:"level"`
+	Message string                `json:"message"`
+	// EntityType, EntityID and EntityName identify the repository, organization
+	// or enterprise the event is about. They are empty for provider events.
+	EntityType   GithubEntityType `json:"entity_type,omitempty"`
+	EntityID     string           `json:"entity_id,omitempty"`
+	EntityName   string           `json:"entity_name,omitempty"`
+	PoolID       string           `json:"pool_id,omitempty"`
+	ProviderName string           `json:"provider_name,omitempty"`
+	InstanceName string           `json:"instance_name,omitempty"`
+	Timestamp    time.Time        `json:"timestamp"`
+}
+
+// GetID returns the ID of the pool the event is about, or the ID of the
+// entity, if the event is not about a pool.
+func (n NotificationEvent) GetID() string {
+	if n.PoolID != "" {
+		return n.PoolID
+	}
+	return n.EntityID
+}
+
+type NotificationFormat string
+
+const (
+	// NotificationFormatJSON sends the notification event as a JSON object.
+	NotificationFormatJSON NotificationFormat = "json"
+	// NotificationFormatSlack sends a message compatible with Slack incoming webhooks.
+	NotificationFormatSlack NotificationFormat = "slack"
+	// NotificationFormatTeams sends a message card compatible with Microsoft Teams
+	// incoming webhooks.
+	NotificationFormatTeams NotificationFormat = "teams"
+)
+
+// NotificationRule selects the events sent to a notification sink. All the
+// fields that are set must match the event.
+type NotificationRule struct {
+	// EventTypes matches any of the given event types.
+	EventTypes []NotificationEventType `toml:"event_types" json:"event_types,omitempty"`
+	// MinLevel matches events of this level or higher.
+	MinLevel EventLevel `toml:"min_level" json:"min_level,omitempty"`
+	// EntityType matches events about a type of entity (repository, organization
+	// or enterprise).
+	EntityType GithubEntityType `toml:"entity_type" json:"entity_type,omitempty"`
+	// EntityID matches events about the repository, organization, enterprise
+	// or pool with this ID.
+	EntityID string `toml:"entity_id" json:"entity_id,omitempty"`
+}
+
+// eventLevelSeverity orders event levels, from the least to the most severe.
+var eventLevelSeverity = map[EventLevel]int{
+	EventInfo:    0,
+	EventWarning: 1,
+	EventError:   2,
+}
+
+// Matches returns true if the event matches all the fields set in the rule.
+func (n NotificationRule) Matches(event NotificationEvent) bool {
+	if len(n.EventTypes) > 0 {
+		var found bool
+		for _, eventType := range n.EventTypes {
+			if eventType == event.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if n.MinLevel != "" && eventLevelSeverity[event.Level] < eventLevelSeverity[n.MinLevel] {
+		return false
+	}
+	if n.EntityType != "" && n.EntityType != event.EntityType {
+		return false
+	}
+	if n.EntityID != "" && n.EntityID != event.EntityID && n.EntityID != event.PoolID {
+		return false
+	}
+	return true
+}
+
+func (n NotificationRule) Validate() error {
+	for _, eventType := range n.EventTypes {
+		var found bool
+		for _, known := range NotificationEventTypes {
+			if eventType == known {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return runnerErrors.NewBadRequestError("invalid event type: %q", eventType)
+		}
+	}
+	if _, ok := eventLevelSeverity[n.MinLevel]; n.MinLevel != "" && !ok {
+		return runnerErrors.NewBadRequestError("invalid min_level: %q", n.MinLevel)
+	}
+	switch n.EntityType {
+	case "", GithubEntityTypeRepository, GithubEntityTypeOrganization, GithubEntityTypeEnterprise:
+	default:
+		return runnerErrors.NewBadRequestError("invalid entity_type: %q", n.EntityType)
+	}
+	return nil
+}
+
+const (
+	// NotificationSinkSourceConfig marks sinks defined in the config file.
+	NotificationSinkSourceConfig = "config"
+	// NotificationSinkSourceAPI marks sinks created through the API.
+	NotificationSinkSourceAPI = "api"
+)
+
+// NotificationSink is an HTTP endpoint that receives notification events.
+type NotificationSink struct {
+	ID          uint               `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Format      NotificationFormat `json:"format"`
+	// URLHost is the scheme and host of the URL notifications are sent to.
+	// The full URL is never returned, as webhook URLs usually hold a secret.
+	URLHost string `json:"url_host"`
+	// HeaderNames are the names of the HTTP headers sent with each notification.
+	// Their values are never returned.
+	HeaderNames []string           `json:"header_names,omitempty"`
+	Rules       []NotificationRule `json:"rules,omitempty"`
+	Enabled     bool               `json:"enabled"`
+	// Source is "config" for sinks defined in the config file, and "api"
+	// for sinks created through the API.
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+
+	// Do not serialize sensitive info.
+	URL     string            `json:"-"`
+	Headers map[string]string `json:"-"`
+}
+
+// NotificationURLHost returns the scheme and host of a notification sink URL,
+// which can be shown without revealing the secrets a webhook URL may hold.
+func NotificationURLHost(val string) string {
+	u, err := url.Parse(val)
+	if err != nil {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// Matches returns true if the sink is enabled and the event matches one of
+// its rules. A sink without rules receives all events.
+func (n NotificationSink) Matches(event NotificationEvent) bool {
+	if !n.Enabled {
+		return false
+	}
+	if len(n.Rules) == 0 {
+		return true
+	}
+	for _, rule := range n.Rules {
+		if rule.Matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// used by swagger client generated code
+type NotificationSinks []NotificationSink
+
 type InstallWebhookParams struct {
 	WebhookEndpointType WebhookEndpointType `json:"webhook_endpoint_type"`
 	InsecureSSL         bool                `json:"insecure_ssl"`
@@ -790,12 +1386,17 @@ type UpdateSystemInfoParams struct {
 }
 
 type GithubEntity struct {
-	Owner            string            `json:"owner"`
-	Name             string            `json:"name"`
-	ID               string            `json:"id"`
-	EntityType       GithubEntityType  `json:"entity_type"`
-	Credentials      GithubCredentials `json:"credentials"`
-	PoolBalancerType PoolBalancerType  `json:"pool_balancing_type"`
+	Owner            string              `json:"owner"`
+	Name             string              `json:"name"`
+	ID               string              `json:"id"`
+	EntityType       GithubEntityType    `json:"entity_type"`
+	Credentials      GithubCredentials   `json:"credentials"`
+	PoolBalancerType PoolBalancerType    `json:"pool_balancing_type"`
+	JobBackfill      JobBackfillSettings `json:"job_backfill"`
+	FairShare        FairShareSettings   `json:"fair_share"`
+
+	MaxConcurrentRunners uint `json:"max_concurrent_runners,omitempty"`
+	MaxPendingRunners    uint `json:"max_pending_runners,omitempty"`
 
 	WebhookSecret string `json:"-"`
 }
@@ -0,0 +1,107 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package params
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJobBackfillSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		entityType GithubEntityType
+		settings   JobBackfillSettings
+		valid      bool
+	}{
+		{name: "disabled repo", entityType: GithubEntityTypeRepository, settings: JobBackfillSettings{}, valid: true},
+		{name: "enabled repo", entityType: GithubEntityTypeRepository, settings: JobBackfillSettings{Enabled: true}, valid: true},
+		{name: "repo with repository list", entityType: GithubEntityTypeRepository, settings: JobBackfillSettings{Enabled: true, Repositories: []string{"test"}}, valid: false},
+		{name: "repo with max repositories", entityType: GithubEntityTypeRepository, settings: JobBackfillSettings{Enabled: true, MaxRepositories: 10}, valid: false},
+		{name: "disabled org", entityType: GithubEntityTypeOrganization, settings: JobBackfillSettings{}, valid: true},
+		{name: "org with repository list", entityType: GithubEntityTypeOrganization, settings: JobBackfillSettings{Enabled: true, Repositories: []string{"test"}}, valid: true},
+		{name: "org with max repositories", entityType: GithubEntityTypeOrganization, settings: JobBackfillSettings{Enabled: true, MaxRepositories: 10}, valid: true},
+		{name: "org without repositories", entityType: GithubEntityTypeOrganization, settings: JobBackfillSettings{Enabled: true}, valid: false},
+		{name: "org with empty repository name", entityType: GithubEntityTypeOrganization, settings: JobBackfillSettings{Enabled: true, Repositories: []string{""}}, valid: false},
+		{name: "org with full repository name", entityType: GithubEntityTypeOrganization, settings: JobBackfillSettings{Enabled: true, Repositories: []string{"org/test"}}, valid: false},
+		{name: "enterprise", entityType: GithubEntityTypeEnterprise, settings: JobBackfillSettings{Enabled: true}, valid: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.settings.Validate(tc.entityType)
+			if tc.valid && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestJobHistoryTimings(t *testing.T) {
+	queued := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	started := queued.Add(90 * time.Second)
+	completed := started.Add(10 * time.Minute)
+
+	tests := []struct {
+		name    string
+		job     JobHistory
+		wait    time.Duration
+		runTime time.Duration
+	}{
+		{name: "queued", job: JobHistory{QueuedAt: queued}},
+		{name: "in progress", job: JobHistory{QueuedAt: queued, StartedAt: &started}, wait: 90 * time.Second},
+		{name: "completed", job: JobHistory{QueuedAt: queued, StartedAt: &started, CompletedAt: &completed}, wait: 90 * time.Second, runTime: 10 * time.Minute},
+		{name: "started before queued", job: JobHistory{QueuedAt: started, StartedAt: &queued}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if wait := tc.job.WaitTime(); wait != tc.wait {
+				t.Fatalf("expected wait time %s, got %s", tc.wait, wait)
+			}
+			if runTime := tc.job.RunTime(); runTime != tc.runTime {
+				t.Fatalf("expected run time %s, got %s", tc.runTime, runTime)
+			}
+		})
+	}
+}
+
+func TestUserRoleIncludes(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     UserRole
+		other    UserRole
+		includes bool
+	}{
+		{name: "viewer includes viewer", role: UserRoleViewer, other: UserRoleViewer, includes: true},
+		{name: "viewer excludes operator", role: UserRoleViewer, other: UserRoleOperator, includes: false},
+		{name: "operator includes viewer", role: UserRoleOperator, other: UserRoleViewer, includes: true},
+		{name: "operator excludes admin", role: UserRoleOperator, other: UserRoleAdmin, includes: false},
+		{name: "admin includes operator", role: UserRoleAdmin, other: UserRoleOperator, includes: true},
+		{name: "admin excludes unknown role", role: UserRoleAdmin, other: UserRole("superuser"), includes: false},
+		{name: "empty role excludes viewer", role: UserRole(""), other: UserRoleViewer, includes: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.role.Includes(tc.other); got != tc.includes {
+				t.Fatalf("expected %v, got %v", tc.includes, got)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -64,7 +65,7 @@ func (c *CreateRepoParams) Validate() error {
 	}
 
 	switch c.PoolBalancerType {
-	case PoolBalancerTypeRoundRobin, PoolBalancerTypePack, PoolBalancerTypeNone:
+	case PoolBalancerTypeRoundRobin, PoolBalancerTypePack, PoolBalancerTypeWeighted, PoolBalancerTypeCapacity, PoolBalancerTypeNone:
 	default:
 		return runnerErrors.NewBadRequestError("invalid pool balancer type")
 	}
@@ -92,7 +93,7 @@ func (c *CreateOrgParams) Validate() error {
 	}
 
 	switch c.PoolBalancerType {
-	case PoolBalancerTypeRoundRobin, PoolBalancerTypePack, PoolBalancerTypeNone:
+	case PoolBalancerTypeRoundRobin, PoolBalancerTypePack, PoolBalancerTypeWeighted, PoolBalancerTypeCapacity, PoolBalancerTypeNone:
 	default:
 		return runnerErrors.NewBadRequestError("invalid pool balancer type")
 	}
@@ -118,7 +119,7 @@ func (c *CreateEnterpriseParams) Validate() error {
 	}
 
 	switch c.PoolBalancerType {
-	case PoolBalancerTypeRoundRobin, PoolBalancerTypePack, PoolBalancerTypeNone:
+	case PoolBalancerTypeRoundRobin, PoolBalancerTypePack, PoolBalancerTypeWeighted, PoolBalancerTypeCapacity, PoolBalancerTypeNone:
 	default:
 		return runnerErrors.NewBadRequestError("invalid pool balancer type")
 	}
@@ -132,8 +133,12 @@ type NewUserParams struct {
 	Username string `json:"username"`
 	FullName string `json:"full_name"`
 	Password string `json:"password"`
-	IsAdmin  bool   `json:"-"`
-	Enabled  bool   `json:"-"`
+	// Role is the role of the new user. Defaults to viewer.
+	Role UserRole `json:"role,omitempty"`
+	// IsAdmin is set when creating the first admin user, during controller
+	// initialization.
+	IsAdmin bool `json:"-"`
+	Enabled bool `json:"-"`
 }
 
 type UpdatePoolParams struct {
@@ -154,6 +159,46 @@ type UpdatePoolParams struct {
 	// The runner group must be created by someone with access to the enterprise.
 	GitHubRunnerGroup *string `json:"github-runner-group,omitempty"`
 	Priority          *uint   `json:"priority,omitempty"`
+	Weight            *uint   `json:"weight,omitempty"`
+	MaxIdleAge        *uint   `json:"max_idle_age,omitempty"`
+	MaxLifetime       *uint   `json:"max_lifetime,omitempty"`
+	// MaxConcurrentCreates and CreatesPerMinute override the instance creation limits
+	// of the provider for this pool. Set them to 0 to use the limits of the provider.
+	MaxConcurrentCreates *uint `json:"max_concurrent_creates,omitempty"`
+	CreatesPerMinute     *uint `json:"creates_per_minute,omitempty"`
+	// QuarantineThreshold is the number of consecutive bootstrap failures after which
+	// the pool is disabled. Set it to 0 to disable quarantine.
+	QuarantineThreshold *uint `json:"quarantine_threshold,omitempty"`
+	// ScalingSchedules replaces the scaling schedules of the pool. A null value
+	// leaves the existing schedules untouched, while an empty list removes them.
+	ScalingSchedules []PoolScalingSchedule `json:"scaling_schedules"`
+	// ScaleDownPolicy replaces the scale down policy of the pool.
+	ScaleDownPolicy *PoolScaleDownPolicy `json:"scale_down_policy,omitempty"`
+	// LabelRules replaces the label rules of the pool.
+	LabelRules *PoolLabelRules `json:"label_rules,omitempty"`
+}
+
+func (p *UpdatePoolParams) Validate() error {
+	for _, schedule := range p.ScalingSchedules {
+		// The max runners value of the pool may not be part of this update. Limits are
+		// checked against the resulting pool, once the update is applied.
+		if err := schedule.Validate(0); err != nil {
+			return err
+		}
+	}
+
+	if p.ScaleDownPolicy != nil {
+		if err := p.ScaleDownPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if p.LabelRules != nil {
+		if err := p.LabelRules.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 type CreateInstanceParams struct {
@@ -190,8 +235,22 @@ type CreatePoolParams struct {
 	// GithubRunnerGroup is the github runner group in which the runners of this
 	// pool will be added to.
 	// The runner group must be created by someone with access to the enterprise.
-	GitHubRunnerGroup string `json:"github-runner-group"`
-	Priority          uint   `json:"priority"`
+	GitHubRunnerGroup string                `json:"github-runner-group"`
+	Priority          uint                  `json:"priority"`
+	Weight            uint                  `json:"weight,omitempty"`
+	MaxIdleAge        uint                  `json:"max_idle_age,omitempty"`
+	MaxLifetime       uint                  `json:"max_lifetime,omitempty"`
+	ScalingSchedules  []PoolScalingSchedule `json:"scaling_schedules,omitempty"`
+	ScaleDownPolicy   PoolScaleDownPolicy   `json:"scale_down_policy,omitempty"`
+	LabelRules        PoolLabelRules        `json:"label_rules,omitempty"`
+
+	// MaxConcurrentCreates and CreatesPerMinute override the instance creation limits
+	// of the provider for this pool.
+	MaxConcurrentCreates uint `json:"max_concurrent_creates,omitempty"`
+	CreatesPerMinute     uint `json:"creates_per_minute,omitempty"`
+	// QuarantineThreshold is the number of consecutive bootstrap failures after which
+	// the pool is disabled.
+	QuarantineThreshold uint `json:"quarantine_threshold,omitempty"`
 }
 
 func (p *CreatePoolParams) Validate() error {
@@ -219,6 +278,20 @@ func (p *CreatePoolParams) Validate() error {
 		return fmt.Errorf("missing image")
 	}
 
+	for _, schedule := range p.ScalingSchedules {
+		if err := schedule.Validate(p.MaxRunners); err != nil {
+			return err
+		}
+	}
+
+	if err := p.ScaleDownPolicy.Validate(); err != nil {
+		return err
+	}
+
+	if err := p.LabelRules.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -242,9 +315,10 @@ type UpdateInstanceParams struct {
 }
 
 type UpdateUserParams struct {
-	FullName string `json:"full_name"`
-	Password string `json:"password"`
-	Enabled  *bool  `json:"enabled"`
+	FullName string    `json:"full_name"`
+	Password string    `json:"password"`
+	Enabled  *bool     `json:"enabled"`
+	Role     *UserRole `json:"role,omitempty"`
 }
 
 // PasswordLoginParams holds information used during
@@ -267,6 +341,17 @@ type UpdateEntityParams struct {
 	CredentialsName  string           `json:"credentials_name"`
 	WebhookSecret    string           `json:"webhook_secret"`
 	PoolBalancerType PoolBalancerType `json:"pool_balancer_type"`
+	// JobBackfill replaces the job backfill settings of the entity. Only
+	// repositories and organizations support job backfill.
+	JobBackfill *JobBackfillSettings `json:"job_backfill,omitempty"`
+	// FairShare replaces the fair share scheduling settings of the entity.
+	FairShare *FairShareSettings `json:"fair_share,omitempty"`
+	// MaxConcurrentRunners limits the number of runners the entity may have across
+	// all of its pools. Set it to 0 to remove the limit.
+	MaxConcurrentRunners *uint `json:"max_concurrent_runners,omitempty"`
+	// MaxPendingRunners limits the number of runners of the entity that may be pending
+	// at the same time. Set it to 0 to remove the limit.
+	MaxPendingRunners *uint `json:"max_pending_runners,omitempty"`
 }
 
 type InstanceUpdateMessage struct {
@@ -502,6 +587,86 @@ func (u UpdateGithubCredentialsParams) Validate() error {
 	return nil
 }
 
+type CreateNotificationSinkParams struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	URL         string             `json:"url"`
+	Format      NotificationFormat `json:"format"`
+	// Headers are sent with each notification. They are stored encrypted.
+	Headers map[string]string  `json:"headers,omitempty"`
+	Rules   []NotificationRule `json:"rules,omitempty"`
+	// Enabled defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+func (c CreateNotificationSinkParams) Validate() error {
+	if c.Name == "" {
+		return runnerErrors.NewBadRequestError("missing name")
+	}
+	if err := validateNotificationURL(c.URL); err != nil {
+		return err
+	}
+	if err := validateNotificationFormat(c.Format); err != nil {
+		return err
+	}
+	return validateNotificationRules(c.Rules)
+}
+
+type UpdateNotificationSinkParams struct {
+	Name        *string             `json:"name,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	URL         *string             `json:"url,omitempty"`
+	Format      *NotificationFormat `json:"format,omitempty"`
+	// Headers replaces all the headers of the sink, if set.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Rules replaces all the rules of the sink, if set.
+	Rules   []NotificationRule `json:"rules,omitempty"`
+	Enabled *bool              `json:"enabled,omitempty"`
+}
+
+func (u UpdateNotificationSinkParams) Validate() error {
+	if u.Name != nil && *u.Name == "" {
+		return runnerErrors.NewBadRequestError("missing name")
+	}
+	if u.URL != nil {
+		if err := validateNotificationURL(*u.URL); err != nil {
+			return err
+		}
+	}
+	if u.Format != nil {
+		if err := validateNotificationFormat(*u.Format); err != nil {
+			return err
+		}
+	}
+	return validateNotificationRules(u.Rules)
+}
+
+func validateNotificationURL(val string) error {
+	u, err := url.Parse(val)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return runnerErrors.NewBadRequestError("invalid url")
+	}
+	return nil
+}
+
+func validateNotificationFormat(format NotificationFormat) error {
+	switch format {
+	case "", NotificationFormatJSON, NotificationFormatSlack, NotificationFormatTeams:
+		return nil
+	default:
+		return runnerErrors.NewBadRequestError("invalid format: %q", format)
+	}
+}
+
+func validateNotificationRules(rules []NotificationRule) error {
+	for idx, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return errors.Wrapf(err, "rule %d", idx)
+		}
+	}
+	return nil
+}
+
 type UpdateControllerParams struct {
 	MetadataURL *string `json:"metadata_url,omitempty"`
 	CallbackURL *string `json:"callback_url,omitempty"`
@@ -532,3 +697,116 @@ func (u UpdateControllerParams) Validate() error {
 
 	return nil
 }
+
+// ListParams holds the pagination options shared by all list calls.
+type ListParams struct {
+	// Cursor is the opaque value returned by a previous call. When set,
+	// results start right after the last item of the previous page.
+	Cursor string `json:"cursor,omitempty"`
+	// Limit is the maximum number of results to return. A value of 0
+	// returns all results.
+	Limit uint `json:"limit,omitempty"`
+}
+
+// TimeRangeFilter restricts results to a range of creation and update times.
+// Unset values are ignored.
+type TimeRangeFilter struct {
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+	UpdatedAfter  *time.Time `json:"updated_after,omitempty"`
+	UpdatedBefore *time.Time `json:"updated_before,omitempty"`
+}
+
+func (t TimeRangeFilter) Validate() error {
+	if t.CreatedAfter != nil && t.CreatedBefore != nil && !t.CreatedAfter.Before(*t.CreatedBefore) {
+		return runnerErrors.NewBadRequestError("created_after must be before created_before")
+	}
+	if t.UpdatedAfter != nil && t.UpdatedBefore != nil && !t.UpdatedAfter.Before(*t.UpdatedBefore) {
+		return runnerErrors.NewBadRequestError("updated_after must be before updated_before")
+	}
+	return nil
+}
+
+type InstanceFilter struct {
+	ListParams
+	TimeRangeFilter
+
+	Status       commonParams.InstanceStatus `json:"status,omitempty"`
+	RunnerStatus RunnerStatus                `json:"runner_status,omitempty"`
+	PoolID       string                      `json:"pool_id,omitempty"`
+	ProviderName string                      `json:"provider_name,omitempty"`
+	// Label matches instances belonging to pools that have this tag.
+	Label string `json:"label,omitempty"`
+}
+
+func (f InstanceFilter) Validate() error {
+	return f.TimeRangeFilter.Validate()
+}
+
+type PoolFilter struct {
+	ListParams
+	TimeRangeFilter
+
+	ProviderName string `json:"provider_name,omitempty"`
+	// Label matches pools that have this tag.
+	Label   string `json:"label,omitempty"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
+
+func (f PoolFilter) Validate() error {
+	return f.TimeRangeFilter.Validate()
+}
+
+type JobFilter struct {
+	ListParams
+	TimeRangeFilter
+
+	Status JobStatus `json:"status,omitempty"`
+	// PoolID and ProviderName match jobs that were picked up by
+	// a runner belonging to the given pool or provider.
+	PoolID       string `json:"pool_id,omitempty"`
+	ProviderName string `json:"provider_name,omitempty"`
+	// Label matches jobs that requested this label.
+	Label string `json:"label,omitempty"`
+}
+
+func (f JobFilter) Validate() error {
+	return f.TimeRangeFilter.Validate()
+}
+
+type JobHistoryFilter struct {
+	ListParams
+	// TimeRangeFilter applies to the time the job was first recorded
+	// and the time it was last updated.
+	TimeRangeFilter
+
+	Status       JobStatus `json:"status,omitempty"`
+	Conclusion   string    `json:"conclusion,omitempty"`
+	PoolID       string    `json:"pool_id,omitempty"`
+	ProviderName string    `json:"provider_name,omitempty"`
+	// Label matches jobs that requested this label.
+	Label string `json:"label,omitempty"`
+}
+
+func (f JobHistoryFilter) Validate() error {
+	return f.TimeRangeFilter.Validate()
+}
+
+type AuditFilter struct {
+	ListParams
+	// TimeRangeFilter applies to the time the entry was recorded. Audit
+	// entries are never updated, so the update times can not be used.
+	TimeRangeFilter
+
+	UserID     string      `json:"user_id,omitempty"`
+	Action     AuditAction `json:"action,omitempty"`
+	EntityType string      `json:"entity_type,omitempty"`
+	EntityID   string      `json:"entity_id,omitempty"`
+}
+
+func (f AuditFilter) Validate() error {
+	if f.UpdatedAfter != nil || f.UpdatedBefore != nil {
+		return runnerErrors.NewBadRequestError("audit entries can only be filtered by creation time")
+	}
+	return f.TimeRangeFilter.Validate()
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package params
+
+import (
+	"time"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/util/appdefaults"
+)
+
+type ScaleDownOrder string
+
+const (
+	// ScaleDownOrderOldestFirst removes the idle runners that were created first.
+	ScaleDownOrderOldestFirst ScaleDownOrder = "oldest"
+	// ScaleDownOrderNewestFirst removes the idle runners that were created last.
+	ScaleDownOrderNewestFirst ScaleDownOrder = "newest"
+)
+
+type ScaleDownMode string
+
+const (
+	// ScaleDownModeDelete deletes surplus idle runners.
+	ScaleDownModeDelete ScaleDownMode = "delete"
+	// ScaleDownModeHibernate stops surplus idle runners in the provider, instead of
+	// deleting them. Stopped runners are started again when they are needed.
+	ScaleDownModeHibernate ScaleDownMode = "hibernate"
+)
+
+// PoolScaleDownPolicy controls how idle runners are removed from a pool. Unset
+// values fall back to the defaults.
+type PoolScaleDownPolicy struct {
+	// Factor is the fraction (0 < factor <= 1) of surplus idle runners that are
+	// removed in one scale down iteration. A factor of 1 removes all surplus idle
+	// runners at once. Defaults to 0.5.
+	Factor float64 `json:"factor,omitempty"`
+	// IdleGracePeriod is the amount of time, in minutes, a runner needs to be idle
+	// before it is considered for scale down. When not set, it defaults to 2 minutes.
+	// Setting it to 0 disables the grace period.
+	IdleGracePeriod *uint `json:"idle_grace_period,omitempty"`
+	// MinRunnerAge is the minimum age, in minutes, a runner needs to have before it
+	// is considered for scale down. Defaults to 0.
+	MinRunnerAge uint `json:"min_runner_age,omitempty"`
+	// Order dictates whether the oldest or the newest idle runners are removed
+	// first. Defaults to oldest.
+	Order ScaleDownOrder `json:"order,omitempty"`
+	// Mode dictates whether surplus idle runners are deleted or stopped. Stopped
+	// runners are started again to serve queued jobs, or to maintain min_idle_runners,
+	// before any new runner is created. Defaults to delete.
+	Mode ScaleDownMode `json:"mode,omitempty"`
+}
+
+func (p PoolScaleDownPolicy) Validate() error {
+	if p.Factor < 0 || p.Factor > 1 {
+		return runnerErrors.NewBadRequestError("scale down factor must be between 0 and 1")
+	}
+
+	switch p.Order {
+	case ScaleDownOrderOldestFirst, ScaleDownOrderNewestFirst, "":
+	default:
+		return runnerErrors.NewBadRequestError("invalid scale down order %q", p.Order)
+	}
+
+	switch p.Mode {
+	case ScaleDownModeDelete, ScaleDownModeHibernate, "":
+	default:
+		return runnerErrors.NewBadRequestError("invalid scale down mode %q", p.Mode)
+	}
+	return nil
+}
+
+func (p PoolScaleDownPolicy) GetFactor() float64 {
+	if p.Factor == 0 {
+		return appdefaults.DefaultScaleDownFactor
+	}
+	return p.Factor
+}
+
+func (p PoolScaleDownPolicy) GetIdleGracePeriod() time.Duration {
+	if p.IdleGracePeriod == nil {
+		return appdefaults.DefaultScaleDownIdleGracePeriod * time.Minute
+	}
+	return time.Duration(*p.IdleGracePeriod) * time.Minute
+}
+
+func (p PoolScaleDownPolicy) GetMinRunnerAge() time.Duration {
+	return time.Duration(p.MinRunnerAge) * time.Minute
+}
+
+func (p PoolScaleDownPolicy) GetOrder() ScaleDownOrder {
+	if p.Order == "" {
+		return ScaleDownOrderOldestFirst
+	}
+	return p.Order
+}
+
+func (p PoolScaleDownPolicy) GetMode() ScaleDownMode {
+	if p.Mode == "" {
+		return ScaleDownModeDelete
+	}
+	return p.Mode
+}
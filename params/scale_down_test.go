@@ -0,0 +1,82 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package params
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScaleDownPolicyDefaults(t *testing.T) {
+	policy := PoolScaleDownPolicy{}
+
+	if got := policy.GetFactor(); got != 0.5 {
+		t.Fatalf("expected default factor 0.5, got %v", got)
+	}
+	if got := policy.GetIdleGracePeriod(); got != 2*time.Minute {
+		t.Fatalf("expected default idle grace period of 2m, got %s", got)
+	}
+	if got := policy.GetMinRunnerAge(); got != 0 {
+		t.Fatalf("expected default min runner age of 0, got %s", got)
+	}
+	if got := policy.GetOrder(); got != ScaleDownOrderOldestFirst {
+		t.Fatalf("expected default order %q, got %q", ScaleDownOrderOldestFirst, got)
+	}
+	if got := policy.GetMode(); got != ScaleDownModeDelete {
+		t.Fatalf("expected default mode %q, got %q", ScaleDownModeDelete, got)
+	}
+}
+
+func TestScaleDownPolicyIdleGracePeriod(t *testing.T) {
+	disabled := uint(0)
+	policy := PoolScaleDownPolicy{IdleGracePeriod: &disabled}
+	if got := policy.GetIdleGracePeriod(); got != 0 {
+		t.Fatalf("expected no idle grace period, got %s", got)
+	}
+
+	custom := uint(10)
+	policy = PoolScaleDownPolicy{IdleGracePeriod: &custom}
+	if got := policy.GetIdleGracePeriod(); got != 10*time.Minute {
+		t.Fatalf("expected idle grace period of 10m, got %s", got)
+	}
+}
+
+func TestScaleDownPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PoolScaleDownPolicy
+		valid  bool
+	}{
+		{name: "defaults", policy: PoolScaleDownPolicy{}, valid: true},
+		{name: "remove all surplus", policy: PoolScaleDownPolicy{Factor: 1, Order: ScaleDownOrderNewestFirst}, valid: true},
+		{name: "negative factor", policy: PoolScaleDownPolicy{Factor: -0.1}, valid: false},
+		{name: "factor above 1", policy: PoolScaleDownPolicy{Factor: 1.5}, valid: false},
+		{name: "invalid order", policy: PoolScaleDownPolicy{Order: "random"}, valid: false},
+		{name: "hibernate", policy: PoolScaleDownPolicy{Mode: ScaleDownModeHibernate}, valid: true},
+		{name: "invalid mode", policy: PoolScaleDownPolicy{Mode: "sleep"}, valid: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Validate()
+			if tc.valid && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
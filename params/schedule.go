@@ -0,0 +1,245 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package params
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// PoolScalingSchedule defines a recurring time window during which a pool uses
+// a different min_idle_runners and, optionally, max_runners value than the one
+// set on the pool itself.
+type PoolScalingSchedule struct {
+	// Name is an optional, human readable name for this schedule.
+	Name string `json:"name,omitempty"`
+	// Days is a cron style day of week expression. It accepts "*", day numbers
+	// (0-7, where both 0 and 7 are Sunday), three letter day names, ranges and
+	// comma separated lists. For example: "mon-fri", "1-5" or "sat,sun".
+	// An empty value is equivalent to "*".
+	Days string `json:"days,omitempty"`
+	// Start is the time of day (HH:MM, 24h format) at which this schedule becomes
+	// active. If End is before Start, the window extends past midnight into
+	// the next day. If both Start and End are empty, the schedule is active for
+	// the entire day.
+	Start string `json:"start,omitempty"`
+	// End is the time of day (HH:MM, 24h format) at which this schedule stops
+	// being active.
+	End string `json:"end,omitempty"`
+	// Timezone is the IANA name of the time zone in which Start and End are
+	// interpreted. Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+	// MinIdleRunners is the minimum number of idle runners the pool will maintain
+	// while this schedule is active.
+	MinIdleRunners uint `json:"min_idle_runners"`
+	// MaxRunners is the maximum number of runners the pool may have while this
+	// schedule is active. If not set, the max_runners value of the pool is used.
+	MaxRunners *uint `json:"max_runners,omitempty"`
+}
+
+// Validate checks that the schedule is well formed. If maxRunners is not 0, it
+// also checks that the limits of the schedule are consistent with it.
+func (s PoolScalingSchedule) Validate(maxRunners uint) error {
+	if _, err := parseDays(s.Days); err != nil {
+		return runnerErrors.NewBadRequestError("invalid days in scaling schedule %q: %s", s.Name, err)
+	}
+
+	if (s.Start == "") != (s.End == "") {
+		return runnerErrors.NewBadRequestError("scaling schedule %q must set both start and end, or neither", s.Name)
+	}
+
+	start, err := parseTimeOfDay(s.Start)
+	if err != nil {
+		return runnerErrors.NewBadRequestError("invalid start in scaling schedule %q: %s", s.Name, err)
+	}
+	end, err := parseTimeOfDay(s.End)
+	if err != nil {
+		return runnerErrors.NewBadRequestError("invalid end in scaling schedule %q: %s", s.Name, err)
+	}
+	if s.Start != "" && start == end {
+		return runnerErrors.NewBadRequestError("scaling schedule %q has the same start and end time", s.Name)
+	}
+
+	if _, err := s.location(); err != nil {
+		return runnerErrors.NewBadRequestError("invalid timezone in scaling schedule %q: %s", s.Name, err)
+	}
+
+	if s.MaxRunners != nil {
+		if *s.MaxRunners == 0 {
+			return runnerErrors.NewBadRequestError("max_runners in scaling schedule %q cannot be 0", s.Name)
+		}
+		maxRunners = *s.MaxRunners
+	}
+
+	if maxRunners != 0 && s.MinIdleRunners > maxRunners {
+		return runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners in scaling schedule %q", s.Name)
+	}
+	return nil
+}
+
+// IsActive returns true if the schedule is active at the given time. Schedules
+// that fail to validate are never active.
+func (s PoolScalingSchedule) IsActive(t time.Time) bool {
+	days, err := parseDays(s.Days)
+	if err != nil {
+		return false
+	}
+	start, err := parseTimeOfDay(s.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseTimeOfDay(s.End)
+	if err != nil {
+		return false
+	}
+	loc, err := s.location()
+	if err != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	now := local.Hour()*60 + local.Minute()
+	today := local.Weekday()
+
+	switch {
+	case s.Start == "" && s.End == "":
+		return days[today]
+	case start < end:
+		return days[today] && now >= start && now < end
+	case start > end:
+		// The window wraps past midnight. It is active either after the start time on
+		// a matching day, or before the end time on the day that follows a matching day.
+		yesterday := (today + 6) % 7
+		return (days[today] && now >= start) || (days[yesterday] && now < end)
+	}
+	return false
+}
+
+// ScheduledMaxRunners returns the max runners value of this schedule, falling back
+// to the given default if the schedule does not override it.
+func (s PoolScalingSchedule) ScheduledMaxRunners(defaultMax uint) uint {
+	if s.MaxRunners == nil {
+		return defaultMax
+	}
+	return *s.MaxRunners
+}
+
+func (s PoolScalingSchedule) String() string {
+	days := s.Days
+	if days == "" {
+		days = "*"
+	}
+	window := "all day"
+	if s.Start != "" {
+		window = fmt.Sprintf("%s-%s", s.Start, s.End)
+	}
+	tz := s.Timezone
+	if tz == "" {
+		tz = "UTC"
+	}
+	ret := fmt.Sprintf("%s %s %s: min_idle_runners=%d", days, window, tz, s.MinIdleRunners)
+	if s.MaxRunners != nil {
+		ret = fmt.Sprintf("%s max_runners=%d", ret, *s.MaxRunners)
+	}
+	if s.Name != "" {
+		ret = fmt.Sprintf("%s (%s)", ret, s.Name)
+	}
+	return ret
+}
+
+func (s PoolScalingSchedule) location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// parseTimeOfDay parses a HH:MM value and returns the number of minutes since
+// midnight. An empty value is treated as midnight.
+func parseTimeOfDay(val string) (int, error) {
+	if val == "" {
+		return 0, nil
+	}
+	parsed, err := time.Parse("15:04", val)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", val)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func parseWeekday(val string) (time.Weekday, error) {
+	if day, ok := weekdayNames[strings.ToLower(val)]; ok {
+		return day, nil
+	}
+	num, err := strconv.Atoi(val)
+	if err != nil || num < 0 || num > 7 {
+		return 0, fmt.Errorf("invalid day %q", val)
+	}
+	return time.Weekday(num % 7), nil
+}
+
+// parseDays parses a cron style day of week expression and returns the set of
+// days it matches, indexed by time.Weekday.
+func parseDays(expr string) ([7]bool, error) {
+	var days [7]bool
+	expr = strings.TrimSpace(expr)
+	if expr == "" || expr == "*" {
+		for i := range days {
+			days[i] = true
+		}
+		return days, nil
+	}
+
+	for _, field := range strings.Split(expr, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			return days, fmt.Errorf("empty day in %q", expr)
+		}
+		bounds := strings.SplitN(field, "-", 2)
+		first, err := parseWeekday(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return days, err
+		}
+		last := first
+		if len(bounds) == 2 {
+			last, err = parseWeekday(strings.TrimSpace(bounds[1]))
+			if err != nil {
+				return days, err
+			}
+		}
+		// Ranges are allowed to wrap around the end of the week (fri-mon).
+		for day := first; ; day = (day + 1) % 7 {
+			days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
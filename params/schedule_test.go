@@ -0,0 +1,170 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package params
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScalingScheduleIsActive(t *testing.T) {
+	// 2024-06-07 is a Friday.
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2024, 6, 7, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		schedule PoolScalingSchedule
+		at       time.Time
+		expected bool
+	}{
+		{
+			name:     "all day, every day",
+			schedule: PoolScalingSchedule{},
+			at:       friday(3, 0),
+			expected: true,
+		},
+		{
+			name:     "inside business hours",
+			schedule: PoolScalingSchedule{Days: "mon-fri", Start: "08:00", End: "18:00"},
+			at:       friday(8, 0),
+			expected: true,
+		},
+		{
+			name:     "end is exclusive",
+			schedule: PoolScalingSchedule{Days: "mon-fri", Start: "08:00", End: "18:00"},
+			at:       friday(18, 0),
+			expected: false,
+		},
+		{
+			name:     "day does not match",
+			schedule: PoolScalingSchedule{Days: "sat,sun"},
+			at:       friday(12, 0),
+			expected: false,
+		},
+		{
+			name:     "numeric range wrapping the week",
+			schedule: PoolScalingSchedule{Days: "5-7"},
+			at:       friday(12, 0).AddDate(0, 0, 2),
+			expected: true,
+		},
+		{
+			name:     "window past midnight, before midnight",
+			schedule: PoolScalingSchedule{Days: "fri", Start: "22:00", End: "06:00"},
+			at:       friday(23, 0),
+			expected: true,
+		},
+		{
+			name:     "window past midnight, on the following day",
+			schedule: PoolScalingSchedule{Days: "fri", Start: "22:00", End: "06:00"},
+			at:       friday(5, 0).AddDate(0, 0, 1),
+			expected: true,
+		},
+		{
+			name:     "window past midnight, previous day does not match",
+			schedule: PoolScalingSchedule{Days: "fri", Start: "22:00", End: "06:00"},
+			at:       friday(5, 0),
+			expected: false,
+		},
+		{
+			name:     "timezone is honored",
+			schedule: PoolScalingSchedule{Days: "fri", Start: "08:00", End: "09:00", Timezone: "Asia/Tokyo"},
+			at:       friday(8, 30).Add(-9 * time.Hour),
+			expected: true,
+		},
+		{
+			name:     "invalid schedule is never active",
+			schedule: PoolScalingSchedule{Days: "someday"},
+			at:       friday(12, 0),
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.schedule.IsActive(tc.at); got != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestScalingScheduleValidate(t *testing.T) {
+	var zero uint
+	var three uint = 3
+
+	tests := []struct {
+		name       string
+		schedule   PoolScalingSchedule
+		maxRunners uint
+		valid      bool
+	}{
+		{name: "valid", schedule: PoolScalingSchedule{Days: "mon-fri", Start: "08:00", End: "18:00", MinIdleRunners: 2}, maxRunners: 5, valid: true},
+		{name: "invalid day", schedule: PoolScalingSchedule{Days: "mon-funday"}, valid: false},
+		{name: "invalid time", schedule: PoolScalingSchedule{Start: "25:00", End: "08:00"}, valid: false},
+		{name: "missing end", schedule: PoolScalingSchedule{Start: "08:00"}, valid: false},
+		{name: "empty window", schedule: PoolScalingSchedule{Start: "08:00", End: "08:00"}, valid: false},
+		{name: "invalid timezone", schedule: PoolScalingSchedule{Timezone: "Mars/Olympus_Mons"}, valid: false},
+		{name: "zero max runners", schedule: PoolScalingSchedule{MaxRunners: &zero}, valid: false},
+		{name: "min idle above pool max", schedule: PoolScalingSchedule{MinIdleRunners: 6}, maxRunners: 5, valid: false},
+		{name: "min idle above schedule max", schedule: PoolScalingSchedule{MinIdleRunners: 4, MaxRunners: &three}, maxRunners: 5, valid: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.schedule.Validate(tc.maxRunners)
+			if tc.valid && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestPoolLimitsAt(t *testing.T) {
+	var maxRunners uint = 20
+	pool := Pool{
+		MinIdleRunners: 1,
+		MaxRunners:     5,
+		ScalingSchedules: []PoolScalingSchedule{
+			{Days: "mon-fri", Start: "08:00", End: "18:00", MinIdleRunners: 10, MaxRunners: &maxRunners},
+			{Days: "*", MinIdleRunners: 2},
+		},
+	}
+
+	weekday := time.Date(2024, 6, 7, 12, 0, 0, 0, time.UTC)
+	if got := pool.MinIdleRunnersAt(weekday); got != 10 {
+		t.Fatalf("expected 10 min idle runners, got %d", got)
+	}
+	if got := pool.MaxRunnersAt(weekday); got != 20 {
+		t.Fatalf("expected 20 max runners, got %d", got)
+	}
+
+	night := time.Date(2024, 6, 7, 22, 0, 0, 0, time.UTC)
+	if got := pool.MinIdleRunnersAt(night); got != 2 {
+		t.Fatalf("expected 2 min idle runners, got %d", got)
+	}
+	if got := pool.MaxRunnersAt(night); got != 5 {
+		t.Fatalf("expected 5 max runners, got %d", got)
+	}
+
+	pool.ScalingSchedules = nil
+	if got := pool.MinIdleRunnersAt(weekday); got != 1 {
+		t.Fatalf("expected 1 min idle runner, got %d", got)
+	}
+}
@@ -262,6 +262,45 @@ func (_m *GithubClient) ListEntityHooks(ctx context.Context, opts *github.ListOp
 	return r0, r1, r2
 }
 
+// ListEntityRepositories provides a mock function with given fields: ctx, opts
+func (_m *GithubClient) ListEntityRepositories(ctx context.Context, opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntityRepositories")
+	}
+
+	var r0 []*github.Repository
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *github.ListOptions) ([]*github.Repository, *github.Response, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *github.ListOptions) []*github.Repository); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.Repository)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *github.ListOptions) *github.Response); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *github.ListOptions) error); ok {
+		r2 = rf(ctx, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListEntityRunnerApplicationDownloads provides a mock function with given fields: ctx
 func (_m *GithubClient) ListEntityRunnerApplicationDownloads(ctx context.Context) ([]*github.RunnerApplicationDownload, *github.Response, error) {
 	ret := _m.Called(ctx)
@@ -340,6 +379,84 @@ func (_m *GithubClient) ListEntityRunners(ctx context.Context, opts *github.List
 	return r0, r1, r2
 }
 
+// ListRepositoryWorkflowRuns provides a mock function with given fields: ctx, owner, repo, opts
+func (_m *GithubClient) ListRepositoryWorkflowRuns(ctx context.Context, owner string, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRepositoryWorkflowRuns")
+	}
+
+	var r0 *github.WorkflowRuns
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *github.ListWorkflowRunsOptions) *github.WorkflowRuns); ok {
+		r0 = rf(ctx, owner, repo, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.WorkflowRuns)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *github.ListWorkflowRunsOptions) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *github.ListWorkflowRunsOptions) error); ok {
+		r2 = rf(ctx, owner, repo, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListWorkflowJobs provides a mock function with given fields: ctx, owner, repo, runID, opts
+func (_m *GithubClient) ListWorkflowJobs(ctx context.Context, owner string, repo string, runID int64, opts *github.ListWorkflowJobsOptions) (*github.Jobs, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, runID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWorkflowJobs")
+	}
+
+	var r0 *github.Jobs
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, *github.ListWorkflowJobsOptions) (*github.Jobs, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, runID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64, *github.ListWorkflowJobsOptions) *github.Jobs); ok {
+		r0 = rf(ctx, owner, repo, runID, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Jobs)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64, *github.ListWorkflowJobsOptions) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, runID, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int64, *github.ListWorkflowJobsOptions) error); ok {
+		r2 = rf(ctx, owner, repo, runID, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // PingEntityHook provides a mock function with given fields: ctx, id
 func (_m *GithubClient) PingEntityHook(ctx context.Context, id int64) (*github.Response, error) {
 	ret := _m.Called(ctx, id)
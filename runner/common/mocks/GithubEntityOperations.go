@@ -223,6 +223,45 @@ func (_m *GithubEntityOperations) ListEntityHooks(ctx context.Context, opts *git
 	return r0, r1, r2
 }
 
+// ListEntityRepositories provides a mock function with given fields: ctx, opts
+func (_m *GithubEntityOperations) ListEntityRepositories(ctx context.Context, opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntityRepositories")
+	}
+
+	var r0 []*github.Repository
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *github.ListOptions) ([]*github.Repository, *github.Response, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *github.ListOptions) []*github.Repository); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.Repository)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *github.ListOptions) *github.Response); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *github.ListOptions) error); ok {
+		r2 = rf(ctx, opts)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListEntityRunnerApplicationDownloads provides a mock function with given fields: ctx
 func (_m *GithubEntityOperations) ListEntityRunnerApplicationDownloads(ctx context.Context) ([]*github.RunnerApplicationDownload, *github.Response, error) {
 	ret := _m.Called(ctx)
@@ -0,0 +1,42 @@
+// Code generated by mockery v2.42.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// LeaderElector is an autogenerated mock type for the LeaderElector type
+type LeaderElector struct {
+	mock.Mock
+}
+
+// IsLeader provides a mock function with given fields:
+func (_m *LeaderElector) IsLeader() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLeader")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// NewLeaderElector creates a new instance of LeaderElector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLeaderElector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LeaderElector {
+	mock := &LeaderElector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -25,6 +25,7 @@ const (
 	PoolScaleDownInterval     = 1 * time.Minute
 	PoolConsilitationInterval = 5 * time.Second
 	PoolReapTimeoutInterval   = 5 * time.Minute
+	PoolRecycleInterval       = 1 * time.Minute
 	// Temporary tools download token is valid for 1 hour by default.
 	// There is no point in making an API call to get available tools, for every runner
 	// we spin up. We cache the tools for 5 minutes. This should save us a lot of API calls
@@ -34,6 +35,26 @@ const (
 	// BackoffTimer is the time we wait before attempting to make another request
 	// to the github API.
 	BackoffTimer = 1 * time.Minute
+
+	// EntityRefreshInterval is the interval at which pool managers reload their entity
+	// from the database when multiple controllers share the same database.
+	EntityRefreshInterval = 30 * time.Second
+
+	// PoolStateResyncInterval is the interval at which pool managers reload the in-memory
+	// state of their pools, runners and queued jobs from the database. The state is kept
+	// up to date by the database watcher in between. When multiple controllers share the
+	// same database, changes made by other controllers are not seen by the watcher, so
+	// EntityRefreshInterval is used instead.
+	PoolStateResyncInterval = 5 * time.Minute
+
+	// PoolCircuitBreakerThreshold is the number of consecutive failures to create runners
+	// after which a pool, or all pools of a provider, are marked as degraded.
+	PoolCircuitBreakerThreshold = 5
+	// PoolCircuitBreakerBackoff is the time a degraded pool is skipped, before a new attempt
+	// to create a runner is made. The back-off doubles every time that attempt fails.
+	PoolCircuitBreakerBackoff = 1 * time.Minute
+	// PoolCircuitBreakerMaxBackoff is the maximum time a degraded pool is skipped.
+	PoolCircuitBreakerMaxBackoff = 30 * time.Minute
 )
 
 //go:generate mockery --all
@@ -83,3 +104,10 @@ type PoolManager interface {
 	// Wait will block until the pool manager has stopped.
 	Wait() error
 }
+
+// LeaderElector decides which of the GARM controllers that share a database is
+// allowed to reconcile pools.
+type LeaderElector interface {
+	// IsLeader returns true if this controller currently holds the controller lease.
+	IsLeader() bool
+}
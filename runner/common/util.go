@@ -19,6 +19,9 @@ type GithubEntityOperations interface {
 	RemoveEntityRunner(ctx context.Context, runnerID int64) (*github.Response, error)
 	CreateEntityRegistrationToken(ctx context.Context) (*github.RegistrationToken, *github.Response, error)
 	GetEntityJITConfig(ctx context.Context, instance string, pool params.Pool, labels []string) (jitConfigMap map[string]string, runner *github.Runner, err error)
+	// ListEntityRepositories lists the repositories of an organization, most recently
+	// pushed to first.
+	ListEntityRepositories(ctx context.Context, opts *github.ListOptions) ([]*github.Repository, *github.Response, error)
 }
 
 // GithubClient that describes the minimum list of functions we need to interact with github.
@@ -30,4 +33,8 @@ type GithubClient interface {
 
 	// GetWorkflowJobByID gets details about a single workflow job.
 	GetWorkflowJobByID(ctx context.Context, owner, repo string, jobID int64) (*github.WorkflowJob, *github.Response, error)
+	// ListRepositoryWorkflowRuns lists the workflow runs of a repository.
+	ListRepositoryWorkflowRuns(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error)
+	// ListWorkflowJobs lists the jobs of a workflow run.
+	ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64, opts *github.ListWorkflowJobsOptions) (*github.Jobs, *github.Response, error)
 }
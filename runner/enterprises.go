@@ -20,6 +20,9 @@ func (r *Runner) CreateEnterprise(ctx context.Context, param params.CreateEnterp
 		return enterprise, runnerErrors.ErrUnauthorized
 	}
 
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
 	err = param.Validate()
 	if err != nil {
 		return params.Enterprise{}, errors.Wrap(err, "validating params")
@@ -73,7 +76,7 @@ func (r *Runner) CreateEnterprise(ctx context.Context, param params.CreateEnterp
 }
 
 func (r *Runner) ListEnterprises(ctx context.Context) ([]params.Enterprise, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return nil, runnerErrors.ErrUnauthorized
 	}
 
@@ -99,7 +102,7 @@ func (r *Runner) ListEnterprises(ctx context.Context) ([]params.Enterprise, erro
 }
 
 func (r *Runner) GetEnterpriseByID(ctx context.Context, enterpriseID string) (params.Enterprise, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.Enterprise{}, runnerErrors.ErrUnauthorized
 	}
 
@@ -121,6 +124,9 @@ func (r *Runner) DeleteEnterprise(ctx context.Context, enterpriseID string) erro
 		return runnerErrors.ErrUnauthorized
 	}
 
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
 	enterprise, err := r.store.GetEnterpriseByID(ctx, enterpriseID)
 	if err != nil {
 		return errors.Wrap(err, "fetching enterprise")
@@ -164,11 +170,21 @@ func (r *Runner) UpdateEnterprise(ctx context.Context, enterpriseID string, para
 	defer r.mux.Unlock()
 
 	switch param.PoolBalancerType {
-	case params.PoolBalancerTypeRoundRobin, params.PoolBalancerTypePack, params.PoolBalancerTypeNone:
+	case params.PoolBalancerTypeRoundRobin, params.PoolBalancerTypePack, params.PoolBalancerTypeWeighted, params.PoolBalancerTypeCapacity, params.PoolBalancerTypeNone:
 	default:
 		return params.Enterprise{}, runnerErrors.NewBadRequestError("invalid pool balancer type: %s", param.PoolBalancerType)
 	}
 
+	if param.JobBackfill != nil {
+		return params.Enterprise{}, runnerErrors.NewBadRequestError("job backfill is not supported for enterprises")
+	}
+
+	if param.FairShare != nil {
+		if err := param.FairShare.Validate(); err != nil {
+			return params.Enterprise{}, err
+		}
+	}
+
 	enterprise, err := r.store.UpdateEnterprise(ctx, enterpriseID, param)
 	if err != nil {
 		return params.Enterprise{}, errors.Wrap(err, "updating enterprise")
@@ -211,7 +227,7 @@ func (r *Runner) CreateEnterprisePool(ctx context.Context, enterpriseID string,
 }
 
 func (r *Runner) GetEnterprisePoolByID(ctx context.Context, enterpriseID, poolID string) (params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.Pool{}, runnerErrors.ErrUnauthorized
 	}
 	entity := params.GithubEntity{
@@ -257,7 +273,7 @@ func (r *Runner) DeleteEnterprisePool(ctx context.Context, enterpriseID, poolID
 }
 
 func (r *Runner) ListEnterprisePools(ctx context.Context, enterpriseID string) ([]params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return []params.Pool{}, runnerErrors.ErrUnauthorized
 	}
 
@@ -273,8 +289,8 @@ func (r *Runner) ListEnterprisePools(ctx context.Context, enterpriseID string) (
 }
 
 func (r *Runner) UpdateEnterprisePool(ctx context.Context, enterpriseID, poolID string, param params.UpdatePoolParams) (params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
-		return params.Pool{}, runnerErrors.ErrUnauthorized
+	if err := checkPoolUpdateRole(ctx, param); err != nil {
+		return params.Pool{}, err
 	}
 
 	entity := params.GithubEntity{
@@ -286,18 +302,8 @@ func (r *Runner) UpdateEnterprisePool(ctx context.Context, enterpriseID, poolID
 		return params.Pool{}, errors.Wrap(err, "fetching pool")
 	}
 
-	maxRunners := pool.MaxRunners
-	minIdleRunners := pool.MinIdleRunners
-
-	if param.MaxRunners != nil {
-		maxRunners = *param.MaxRunners
-	}
-	if param.MinIdleRunners != nil {
-		minIdleRunners = *param.MinIdleRunners
-	}
-
-	if minIdleRunners > maxRunners {
-		return params.Pool{}, runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+	if err := validatePoolUpdate(pool, param); err != nil {
+		return params.Pool{}, err
 	}
 
 	newPool, err := r.store.UpdateEntityPool(ctx, entity, poolID, param)
@@ -308,7 +314,7 @@ func (r *Runner) UpdateEnterprisePool(ctx context.Context, enterpriseID, poolID
 }
 
 func (r *Runner) ListEnterpriseInstances(ctx context.Context, enterpriseID string) ([]params.Instance, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return nil, runnerErrors.ErrUnauthorized
 	}
 	entity := params.GithubEntity{
@@ -315,6 +315,16 @@ func (s *EnterpriseTestSuite) TestUpdateEnterpriseErrUnauthorized() {
 	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
 }
 
+func (s *EnterpriseTestSuite) TestUpdateEnterpriseJobBackfillNotSupported() {
+	param := s.Fixtures.UpdateRepoParams
+	param.JobBackfill = &params.JobBackfillSettings{
+		Enabled: true,
+	}
+	_, err := s.Runner.UpdateEnterprise(s.Fixtures.AdminContext, s.Fixtures.StoreEnterprises["test-enterprise-1"].ID, param)
+
+	s.Require().Equal(runnerErrors.NewBadRequestError("job backfill is not supported for enterprises"), err)
+}
+
 func (s *EnterpriseTestSuite) TestUpdateEnterpriseInvalidCreds() {
 	s.Fixtures.UpdateRepoParams.CredentialsName = invalidCredentialsName
 
@@ -28,7 +28,7 @@ func (r *Runner) CreateGithubEndpoint(ctx context.Context, param params.CreateGi
 }
 
 func (r *Runner) GetGithubEndpoint(ctx context.Context, name string) (params.GithubEndpoint, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.GithubEndpoint{}, runnerErrors.ErrUnauthorized
 	}
 	endpoint, err := r.store.GetGithubEndpoint(ctx, name)
@@ -69,7 +69,7 @@ func (r *Runner) UpdateGithubEndpoint(ctx context.Context, name string, param pa
 }
 
 func (r *Runner) ListGithubEndpoints(ctx context.Context) ([]params.GithubEndpoint, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return nil, runnerErrors.ErrUnauthorized
 	}
 
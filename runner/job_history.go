@@ -0,0 +1,39 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/params"
+)
+
+// ListJobHistory returns a page of job history entries matching the filter and
+// the cursor of the next page.
+func (r *Runner) ListJobHistory(ctx context.Context, filter params.JobHistoryFilter) ([]params.JobHistory, string, error) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
+		return nil, "", runnerErrors.ErrUnauthorized
+	}
+
+	history, next, err := r.store.ListJobHistory(ctx, filter)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fetching job history")
+	}
+	return history, next, nil
+}
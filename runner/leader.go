@@ -0,0 +1,314 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/config"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+)
+
+// controllerLeaseName is the name of the lease held by the controller that
+// reconciles pools.
+const controllerLeaseName = "pool-reconciler"
+
+func newLeaderElector(ctx context.Context, cfg config.HighAvailability, store dbCommon.Store) (*leaderElector, error) {
+	nodeID, err := cfg.GetNodeID()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting node ID")
+	}
+
+	return &leaderElector{
+		ctx:               ctx,
+		store:             store,
+		nodeID:            nodeID,
+		leaseDuration:     cfg.GetLeaseDuration(),
+		heartbeatInterval: cfg.GetHeartbeatInterval(),
+		quit:              make(chan struct{}),
+		done:              make(chan struct{}),
+	}, nil
+}
+
+// leaderElector elects the controller that reconciles pools, among all controllers
+// that share the same database. The leader periodically renews a lease stored in
+// the database, while the other controllers attempt to acquire it. If the leader
+// stops renewing the lease, another controller takes over once it expires.
+type leaderElector struct {
+	ctx   context.Context
+	store dbCommon.Store
+
+	nodeID            string
+	leaseDuration     time.Duration
+	heartbeatInterval time.Duration
+
+	mux          sync.Mutex
+	isLeader     bool
+	leaderNodeID string
+	// renewedAt is the time at which this controller started the last successful
+	// attempt to renew the lease.
+	renewedAt time.Time
+
+	quit     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// IsLeader returns true if this controller holds the lease. The leader steps down
+// one heartbeat before the lease expires if it fails to renew it, so that the
+// controller that takes over never overlaps with it, even if their clocks are
+// slightly out of sync.
+func (l *leaderElector) IsLeader() bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if !l.isLeader {
+		return false
+	}
+	return time.Since(l.renewedAt) < l.leaseDuration-l.heartbeatInterval
+}
+
+// LeaderNodeID returns the ID of the controller that held the lease at the last heartbeat.
+func (l *leaderElector) LeaderNodeID() string {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.leaderNodeID
+}
+
+func (l *leaderElector) NodeID() string {
+	return l.nodeID
+}
+
+func (l *leaderElector) heartbeat() {
+	start := time.Now()
+	lease, err := l.store.AcquireLease(l.ctx, controllerLeaseName, l.nodeID, l.leaseDuration)
+	if err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			l.ctx, "failed to acquire controller lease")
+		return
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	wasLeader := l.isLeader
+	l.leaderNodeID = lease.HolderID
+	l.isLeader = lease.HolderID == l.nodeID
+	if l.isLeader {
+		l.renewedAt = start
+	}
+
+	switch {
+	case l.isLeader && !wasLeader:
+		slog.InfoContext(l.ctx, "acquired controller lease; this controller is now the leader", "node_id", l.nodeID)
+	case !l.isLeader && wasLeader:
+		slog.WarnContext(l.ctx, "lost controller lease", "node_id", l.nodeID, "leader_node_id", lease.HolderID)
+	}
+}
+
+func (l *leaderElector) loop() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.heartbeatInterval)
+	defer ticker.Stop()
+
+	l.heartbeat()
+	for {
+		select {
+		case <-ticker.C:
+			l.heartbeat()
+		case <-l.ctx.Done():
+			return
+		case <-l.quit:
+			return
+		}
+	}
+}
+
+func (l *leaderElector) Start() {
+	slog.InfoContext(l.ctx, "starting leader election", "node_id", l.nodeID)
+	go l.loop()
+}
+
+// Stop stops renewing the lease and releases it, if held. It must only be called
+// after the pool managers have stopped, as releasing the lease allows another
+// controller to start reconciling pools immediately.
+func (l *leaderElector) Stop() {
+	l.stopOnce.Do(func() {
+		close(l.quit)
+		<-l.done
+
+		l.mux.Lock()
+		defer l.mux.Unlock()
+		if !l.isLeader {
+			return
+		}
+		l.isLeader = false
+		// The daemon context is most likely canceled at this point.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := l.store.ReleaseLease(ctx, controllerLeaseName, l.nodeID); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				l.ctx, "failed to release controller lease")
+			return
+		}
+		slog.InfoContext(l.ctx, "released controller lease", "node_id", l.nodeID)
+	})
+}
+
+// syncPoolManagers creates pool managers for entities that were added through
+// other controllers, and removes the ones for entities that were removed. The
+// watcher only sees changes made by this controller.
+func (r *Runner) syncPoolManagers() error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	repos, err := r.store.ListRepositories(r.ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetching repositories")
+	}
+	repoManagers, err := r.poolManagerCtrl.GetRepoPoolManagers()
+	if err != nil {
+		return errors.Wrap(err, "fetching repo pool managers")
+	}
+	knownRepos := map[string]params.Repository{}
+	for _, repo := range repos {
+		knownRepos[repo.ID] = repo
+		if _, ok := repoManagers[repo.ID]; ok {
+			continue
+		}
+		slog.InfoContext(
+			r.ctx, "creating pool manager for repo",
+			"repo_owner", repo.Owner, "repo_name", repo.Name)
+		poolMgr, err := r.poolManagerCtrl.CreateRepoPoolManager(r.ctx, repo, r.providers, r.store)
+		if err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to create repo pool manager", "repository_id", repo.ID)
+			continue
+		}
+		if err := poolMgr.Start(); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to start repo pool manager", "repository_id", repo.ID)
+		}
+	}
+	for _, id := range staleIDs(repoManagers, knownRepos) {
+		slog.InfoContext(r.ctx, "removing pool manager for repo", "repo_id", id)
+		if err := r.poolManagerCtrl.DeleteRepoPoolManager(params.Repository{ID: id}); err != nil {
+			return errors.Wrap(err, "deleting repo pool manager")
+		}
+	}
+
+	orgs, err := r.store.ListOrganizations(r.ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetching organizations")
+	}
+	orgManagers, err := r.poolManagerCtrl.GetOrgPoolManagers()
+	if err != nil {
+		return errors.Wrap(err, "fetching org pool managers")
+	}
+	knownOrgs := map[string]params.Organization{}
+	for _, org := range orgs {
+		knownOrgs[org.ID] = org
+		if _, ok := orgManagers[org.ID]; ok {
+			continue
+		}
+		slog.InfoContext(r.ctx, "creating pool manager for organization", "org_name", org.Name)
+		poolMgr, err := r.poolManagerCtrl.CreateOrgPoolManager(r.ctx, org, r.providers, r.store)
+		if err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to create org pool manager", "org_id", org.ID)
+			continue
+		}
+		if err := poolMgr.Start(); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to start org pool manager", "org_id", org.ID)
+		}
+	}
+	for _, id := range staleIDs(orgManagers, knownOrgs) {
+		slog.InfoContext(r.ctx, "removing pool manager for organization", "org_id", id)
+		if err := r.poolManagerCtrl.DeleteOrgPoolManager(params.Organization{ID: id}); err != nil {
+			return errors.Wrap(err, "deleting org pool manager")
+		}
+	}
+
+	enterprises, err := r.store.ListEnterprises(r.ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetching enterprises")
+	}
+	enterpriseManagers, err := r.poolManagerCtrl.GetEnterprisePoolManagers()
+	if err != nil {
+		return errors.Wrap(err, "fetching enterprise pool managers")
+	}
+	knownEnterprises := map[string]params.Enterprise{}
+	for _, enterprise := range enterprises {
+		knownEnterprises[enterprise.ID] = enterprise
+		if _, ok := enterpriseManagers[enterprise.ID]; ok {
+			continue
+		}
+		slog.InfoContext(r.ctx, "creating pool manager for enterprise", "enterprise_name", enterprise.Name)
+		poolMgr, err := r.poolManagerCtrl.CreateEnterprisePoolManager(r.ctx, enterprise, r.providers, r.store)
+		if err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to create enterprise pool manager", "enterprise_id", enterprise.ID)
+			continue
+		}
+		if err := poolMgr.Start(); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to start enterprise pool manager", "enterprise_id", enterprise.ID)
+		}
+	}
+	for _, id := range staleIDs(enterpriseManagers, knownEnterprises) {
+		slog.InfoContext(r.ctx, "removing pool manager for enterprise", "enterprise_id", id)
+		if err := r.poolManagerCtrl.DeleteEnterprisePoolManager(params.Enterprise{ID: id}); err != nil {
+			return errors.Wrap(err, "deleting enterprise pool manager")
+		}
+	}
+	return nil
+}
+
+// staleIDs returns the IDs of the pool managers that have no matching entity.
+func staleIDs[T any](managers map[string]common.PoolManager, entities map[string]T) []string {
+	var ret []string
+	for id := range managers {
+		if _, ok := entities[id]; !ok {
+			ret = append(ret, id)
+		}
+	}
+	return ret
+}
+
+func (r *Runner) runPoolManagerSync() {
+	ticker := time.NewTicker(common.EntityRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.syncPoolManagers(); err != nil {
+				slog.With(slog.Any("error", err)).ErrorContext(
+					r.ctx, "failed to sync pool managers")
+			}
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
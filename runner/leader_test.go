@@ -0,0 +1,97 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/database"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing"
+)
+
+type LeaderTestSuite struct {
+	suite.Suite
+	Store dbCommon.Store
+}
+
+func (s *LeaderTestSuite) SetupTest() {
+	dbCfg := garmTesting.GetTestSqliteDBConfig(s.T())
+	db, err := database.NewDatabase(context.Background(), dbCfg)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+}
+
+func (s *LeaderTestSuite) newElector(nodeID string) *leaderElector {
+	cfg := config.HighAvailability{
+		Enable:            true,
+		NodeID:            nodeID,
+		LeaseDuration:     time.Minute,
+		HeartbeatInterval: 10 * time.Second,
+	}
+	elector, err := newLeaderElector(context.Background(), cfg, s.Store)
+	s.Require().Nil(err)
+	return elector
+}
+
+func (s *LeaderTestSuite) TestOnlyOneLeader() {
+	first := s.newElector("node-1")
+	second := s.newElector("node-2")
+
+	first.heartbeat()
+	second.heartbeat()
+
+	s.Require().True(first.IsLeader())
+	s.Require().False(second.IsLeader())
+	s.Require().Equal("node-1", second.LeaderNodeID())
+}
+
+func (s *LeaderTestSuite) TestLeaderStepsDownBeforeLeaseExpires() {
+	elector := s.newElector("node-1")
+
+	elector.heartbeat()
+	s.Require().True(elector.IsLeader())
+
+	// Simulate a leader that failed to renew the lease for longer than the
+	// lease duration minus one heartbeat.
+	elector.renewedAt = time.Now().Add(-51 * time.Second)
+	s.Require().False(elector.IsLeader())
+}
+
+func (s *LeaderTestSuite) TestStopReleasesLease() {
+	first := s.newElector("node-1")
+	second := s.newElector("node-2")
+
+	first.Start()
+	s.Require().Eventually(first.IsLeader, 5*time.Second, 10*time.Millisecond)
+
+	first.Stop()
+	s.Require().False(first.IsLeader())
+
+	second.heartbeat()
+	s.Require().True(second.IsLeader())
+}
+
+func TestLeaderTestSuite(t *testing.T) {
+	suite.Run(t, new(LeaderTestSuite))
+}
@@ -17,12 +17,23 @@ func CollectPoolMetric(ctx context.Context, r *runner.Runner) error {
 	metrics.PoolMaxRunners.Reset()
 	metrics.PoolMinIdleRunners.Reset()
 	metrics.PoolBootstrapTimeout.Reset()
+	metrics.PoolDegraded.Reset()
+	metrics.PoolQuarantined.Reset()
 
 	pools, err := r.ListAllPools(ctx)
 	if err != nil {
 		return err
 	}
 
+	degradedPools, err := r.ListDegradedPools(ctx)
+	if err != nil {
+		return err
+	}
+	degraded := make(map[string]bool, len(degradedPools))
+	for _, pool := range degradedPools {
+		degraded[pool.PoolID] = true
+	}
+
 	type poolInfo struct {
 		Name string
 		Type string
@@ -82,6 +93,16 @@ func CollectPoolMetric(ctx context.Context, r *runner.Runner) error {
 		metrics.PoolBootstrapTimeout.WithLabelValues(
 			pool.ID, // label: id
 		).Set(float64(pool.RunnerBootstrapTimeout))
+
+		metrics.PoolDegraded.WithLabelValues(
+			pool.ID,           // label: id
+			pool.ProviderName, // label: provider
+		).Set(metrics.Bool2float64(degraded[pool.ID]))
+
+		metrics.PoolQuarantined.WithLabelValues(
+			pool.ID,           // label: id
+			pool.ProviderName, // label: provider
+		).Set(metrics.Bool2float64(pool.IsQuarantined()))
 	}
 	return nil
 }
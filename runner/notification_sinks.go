@@ -0,0 +1,132 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/notifications"
+)
+
+// checkConfigSinkName returns a conflict error if a sink with the given name is
+// defined in the config file.
+func (r *Runner) checkConfigSinkName(name string) error {
+	for _, sink := range r.config.Notifications.Sinks {
+		if sink.Name == name {
+			return runnerErrors.NewConflictError("notification sink %s is defined in the config file", name)
+		}
+	}
+	return nil
+}
+
+func (r *Runner) CreateNotificationSink(ctx context.Context, param params.CreateNotificationSinkParams) (params.NotificationSink, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.NotificationSink{}, runnerErrors.ErrUnauthorized
+	}
+
+	if err := param.Validate(); err != nil {
+		return params.NotificationSink{}, errors.Wrap(err, "validating params")
+	}
+	if err := r.checkConfigSinkName(param.Name); err != nil {
+		return params.NotificationSink{}, err
+	}
+
+	sink, err := r.store.CreateNotificationSink(ctx, param)
+	if err != nil {
+		return params.NotificationSink{}, errors.Wrap(err, "creating notification sink")
+	}
+	return sink, nil
+}
+
+func (r *Runner) GetNotificationSink(ctx context.Context, sinkID uint) (params.NotificationSink, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.NotificationSink{}, runnerErrors.ErrUnauthorized
+	}
+
+	sink, err := r.store.GetNotificationSink(ctx, sinkID)
+	if err != nil {
+		return params.NotificationSink{}, errors.Wrap(err, "fetching notification sink")
+	}
+	return sink, nil
+}
+
+// ListNotificationSinks returns the sinks defined in the config file, followed by
+// the sinks created through the API.
+func (r *Runner) ListNotificationSinks(ctx context.Context) ([]params.NotificationSink, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, runnerErrors.ErrUnauthorized
+	}
+
+	sinks, err := r.store.ListNotificationSinks(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching notification sinks")
+	}
+	return append(notifications.ConfigSinks(r.config.Notifications), sinks...), nil
+}
+
+func (r *Runner) UpdateNotificationSink(ctx context.Context, sinkID uint, param params.UpdateNotificationSinkParams) (params.NotificationSink, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.NotificationSink{}, runnerErrors.ErrUnauthorized
+	}
+
+	if err := param.Validate(); err != nil {
+		return params.NotificationSink{}, errors.Wrap(err, "validating params")
+	}
+	if param.Name != nil {
+		if err := r.checkConfigSinkName(*param.Name); err != nil {
+			return params.NotificationSink{}, err
+		}
+	}
+
+	sink, err := r.store.UpdateNotificationSink(ctx, sinkID, param)
+	if err != nil {
+		return params.NotificationSink{}, errors.Wrap(err, "updating notification sink")
+	}
+	return sink, nil
+}
+
+func (r *Runner) DeleteNotificationSink(ctx context.Context, sinkID uint) error {
+	if !auth.IsAdmin(ctx) {
+		return runnerErrors.ErrUnauthorized
+	}
+
+	if err := r.store.DeleteNotificationSink(ctx, sinkID); err != nil {
+		return errors.Wrap(err, "deleting notification sink")
+	}
+	return nil
+}
+
+// TestNotificationSink sends a test notification to the sink. Unlike regular
+// notifications, the test notification is sent once and delivery errors are
+// returned to the caller.
+func (r *Runner) TestNotificationSink(ctx context.Context, sinkID uint) error {
+	if !auth.IsAdmin(ctx) {
+		return runnerErrors.ErrUnauthorized
+	}
+
+	sink, err := r.store.GetNotificationSink(ctx, sinkID)
+	if err != nil {
+		return errors.Wrap(err, "fetching notification sink")
+	}
+	if err := notifications.SendTestNotification(ctx, sink); err != nil {
+		return runnerErrors.NewBadRequestError("failed to send test notification: %s", err)
+	}
+	return nil
+}
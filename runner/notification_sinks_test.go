@@ -0,0 +1,138 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/config"
+	"github.com/cloudbase/garm/database"
+	garmTesting "github.com/cloudbase/garm/internal/testing"
+	"github.com/cloudbase/garm/params"
+)
+
+type NotificationSinkTestSuite struct {
+	suite.Suite
+	Runner   *Runner
+	adminCtx context.Context
+}
+
+func (s *NotificationSinkTestSuite) SetupTest() {
+	db, err := database.NewDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.adminCtx = garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+
+	s.Runner = &Runner{
+		ctx:   s.adminCtx,
+		store: db,
+		config: config.Config{
+			Notifications: config.Notifications{
+				Sinks: []config.NotificationSink{
+					{Name: "from-config", URL: "https://hooks.example.com/config"},
+				},
+			},
+		},
+	}
+}
+
+func (s *NotificationSinkTestSuite) TestCreateNotificationSink() {
+	sink, err := s.Runner.CreateNotificationSink(s.adminCtx, params.CreateNotificationSinkParams{
+		Name: "ops",
+		URL:  "https://hooks.example.com/ops",
+	})
+
+	s.Require().Nil(err)
+	s.Require().Equal("ops", sink.Name)
+	s.Require().Equal(params.NotificationSinkSourceAPI, sink.Source)
+}
+
+func (s *NotificationSinkTestSuite) TestCreateNotificationSinkConfigNameConflict() {
+	_, err := s.Runner.CreateNotificationSink(s.adminCtx, params.CreateNotificationSinkParams{
+		Name: "from-config",
+		URL:  "https://hooks.example.com/ops",
+	})
+
+	s.Require().Equal(runnerErrors.NewConflictError("notification sink from-config is defined in the config file"), err)
+}
+
+func (s *NotificationSinkTestSuite) TestCreateNotificationSinkErrUnauthorized() {
+	_, err := s.Runner.CreateNotificationSink(context.Background(), params.CreateNotificationSinkParams{})
+
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func (s *NotificationSinkTestSuite) TestListNotificationSinksIncludesConfigSinks() {
+	_, err := s.Runner.CreateNotificationSink(s.adminCtx, params.CreateNotificationSinkParams{
+		Name: "ops",
+		URL:  "https://hooks.example.com/ops",
+	})
+	s.Require().Nil(err)
+
+	sinks, err := s.Runner.ListNotificationSinks(s.adminCtx)
+
+	s.Require().Nil(err)
+	s.Require().Len(sinks, 2)
+	s.Require().Equal("from-config", sinks[0].Name)
+	s.Require().Equal(params.NotificationSinkSourceConfig, sinks[0].Source)
+	s.Require().Equal("https://hooks.example.com", sinks[0].URLHost)
+	s.Require().Equal("ops", sinks[1].Name)
+}
+
+func (s *NotificationSinkTestSuite) TestTestNotificationSink() {
+	received := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		received <- struct{}{}
+	}))
+	defer srv.Close()
+	sink, err := s.Runner.CreateNotificationSink(s.adminCtx, params.CreateNotificationSinkParams{
+		Name: "ops",
+		URL:  srv.URL,
+	})
+	s.Require().Nil(err)
+
+	err = s.Runner.TestNotificationSink(s.adminCtx, sink.ID)
+
+	s.Require().Nil(err)
+	s.Require().Len(received, 1)
+}
+
+func (s *NotificationSinkTestSuite) TestTestNotificationSinkFails() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	sink, err := s.Runner.CreateNotificationSink(s.adminCtx, params.CreateNotificationSinkParams{
+		Name: "ops",
+		URL:  srv.URL,
+	})
+	s.Require().Nil(err)
+
+	err = s.Runner.TestNotificationSink(s.adminCtx, sink.ID)
+
+	s.Require().Equal(runnerErrors.NewBadRequestError("failed to send test notification: sink returned status 404"), err)
+}
+
+func TestNotificationSinkTestSuite(t *testing.T) {
+	suite.Run(t, new(NotificationSinkTestSuite))
+}
@@ -0,0 +1,373 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+// Package notifications delivers notification events, sent by the pool managers,
+// to external systems like chat tools or incident management services.
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cloudbase/garm/config"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/database/watcher"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+)
+
+const (
+	// queueSize is the number of notifications that can wait to be sent to a sink.
+	// Notifications are dropped once the queue of a sink is full.
+	queueSize = 100
+	// defaultRetryDelay is the delay before the first retry of a failed delivery.
+	defaultRetryDelay = 5 * time.Second
+)
+
+// delivery is a notification waiting to be sent to a sink.
+type delivery struct {
+	sink  params.NotificationSink
+	event params.NotificationEvent
+	// dedupKey and queuedAt identify the entry recorded in lastSent for this delivery.
+	dedupKey string
+	queuedAt time.Time
+}
+
+// sinkWorker sends notifications to one sink, in order.
+type sinkWorker struct {
+	queue chan delivery
+	quit  chan struct{}
+}
+
+// ConfigSinks returns the sinks defined in the config file.
+func ConfigSinks(cfg config.Notifications) []params.NotificationSink {
+	ret := make([]params.NotificationSink, 0, len(cfg.Sinks))
+	for _, sink := range cfg.Sinks {
+		format := sink.Format
+		if format == "" {
+			format = params.NotificationFormatJSON
+		}
+		headerNames := make([]string, 0, len(sink.Headers))
+		for name := range sink.Headers {
+			headerNames = append(headerNames, name)
+		}
+		sort.Strings(headerNames)
+
+		ret = append(ret, params.NotificationSink{
+			Name:        sink.Name,
+			Format:      format,
+			URLHost:     params.NotificationURLHost(sink.URL),
+			HeaderNames: headerNames,
+			Rules:       sink.Rules,
+			Enabled:     !sink.Disabled,
+			Source:      params.NotificationSinkSourceConfig,
+			URL:         sink.URL,
+			Headers:     sink.Headers,
+		})
+	}
+	return ret
+}
+
+// NewDispatcher returns a dispatcher that sends the notification events published
+// through the watcher to the sinks defined in the config and in the database.
+// The leader may be nil, if high availability is disabled. Otherwise, only the
+// leader sends notifications.
+func NewDispatcher(ctx context.Context, cfg config.Notifications, store dbCommon.Store, leader common.LeaderElector) *Dispatcher {
+	return &Dispatcher{
+		ctx:         ctx,
+		store:       store,
+		leader:      leader,
+		configSinks: ConfigSinks(cfg),
+		dedupWindow: cfg.GetDedupWindow(),
+		maxAttempts: cfg.GetMaxAttempts(),
+		retryDelay:  defaultRetryDelay,
+		client:      newHTTPClient(),
+		now:         time.Now,
+		workers:     map[string]*sinkWorker{},
+		lastSent:    map[string]time.Time{},
+		quit:        make(chan struct{}),
+	}
+}
+
+type Dispatcher struct {
+	ctx         context.Context
+	store       dbCommon.Store
+	leader      common.LeaderElector
+	configSinks []params.NotificationSink
+	dedupWindow time.Duration
+	maxAttempts int
+	retryDelay  time.Duration
+	client      *http.Client
+	now         func() time.Time
+
+	mux      sync.Mutex
+	sinks    []params.NotificationSink
+	workers  map[string]*sinkWorker
+	lastSent map[string]time.Time
+	consumer dbCommon.Consumer
+	quit     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// Start loads the sinks and starts watching for notification events.
+func (d *Dispatcher) Start() error {
+	if err := d.loadSinks(); err != nil {
+		return errors.Wrap(err, "loading notification sinks")
+	}
+
+	consumer, err := watcher.RegisterConsumer(
+		d.ctx, "notification-dispatcher",
+		watcher.WithAny(
+			watcher.WithEntityTypeFilter(dbCommon.NotificationEventEntityType),
+			watcher.WithEntityTypeFilter(dbCommon.NotificationSinkEntityType),
+		),
+	)
+	if err != nil {
+		return errors.Wrap(err, "registering consumer")
+	}
+
+	d.mux.Lock()
+	d.consumer = consumer
+	d.mux.Unlock()
+
+	d.wg.Add(1)
+	go d.loop()
+	return nil
+}
+
+// Stop stops watching for events and stops all sink workers. Notifications
+// that were not yet sent are dropped.
+func (d *Dispatcher) Stop() {
+	d.mux.Lock()
+	select {
+	case <-d.quit:
+		d.mux.Unlock()
+		return
+	default:
+	}
+	close(d.quit)
+	if d.consumer != nil {
+		d.consumer.Close()
+	}
+	for key, worker := range d.workers {
+		close(worker.quit)
+		delete(d.workers, key)
+	}
+	d.mux.Unlock()
+
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) loop() {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.quit:
+			return
+		case <-d.ctx.Done():
+			return
+		case payload, ok := <-d.consumer.Watch():
+			if !ok {
+				return
+			}
+			d.handlePayload(payload)
+		}
+	}
+}
+
+func (d *Dispatcher) handlePayload(payload dbCommon.ChangePayload) {
+	if payload.Operation == dbCommon.ResyncOperation {
+		// Notification events that were dropped are lost, but sink changes are
+		// picked up from the database.
+		slog.WarnContext(d.ctx, "missed watcher events; reloading notification sinks", "payload", payload.Payload)
+		if err := d.loadSinks(); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				d.ctx, "failed to reload notification sinks")
+		}
+		return
+	}
+
+	switch payload.EntityType {
+	case dbCommon.NotificationSinkEntityType:
+		if err := d.loadSinks(); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				d.ctx, "failed to reload notification sinks")
+		}
+	case dbCommon.NotificationEventEntityType:
+		event, ok := payload.Payload.(params.NotificationEvent)
+		if !ok {
+			slog.ErrorContext(d.ctx, "invalid payload for notification event", "payload", payload.Payload)
+			return
+		}
+		d.dispatch(event)
+	}
+}
+
+// sinkKey uniquely identifies a sink, including sinks from the config file, which
+// have no ID.
+func sinkKey(sink params.NotificationSink) string {
+	if sink.Source == params.NotificationSinkSourceConfig {
+		return fmt.Sprintf("config/%s", sink.Name)
+	}
+	return fmt.Sprintf("api/%d", sink.ID)
+}
+
+// dedupKey identifies repeated notifications about the same problem, sent to the same sink.
+func dedupKey(sink params.NotificationSink, event params.NotificationEvent) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s/%s", sinkKey(sink), event.Type, event.EntityID, event.PoolID, event.ProviderName, event.InstanceName)
+}
+
+// loadSinks merges the sinks from the config file with the sinks in the database.
+// Workers of sinks that no longer exist are stopped.
+func (d *Dispatcher) loadSinks() error {
+	dbSinks, err := d.store.ListNotificationSinks(d.ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetching notification sinks")
+	}
+	sinks := append([]params.NotificationSink{}, d.configSinks...)
+	sinks = append(sinks, dbSinks...)
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	d.sinks = sinks
+	current := map[string]bool{}
+	for _, sink := range sinks {
+		if sink.Enabled {
+			current[sinkKey(sink)] = true
+		}
+	}
+	for key, worker := range d.workers {
+		if !current[key] {
+			close(worker.quit)
+			delete(d.workers, key)
+		}
+	}
+	return nil
+}
+
+// dispatch queues the event for all the sinks it matches. Notifications that were
+// already sent to a sink during the dedup window are skipped. A notification that is
+// dropped, or could not be delivered, does not count as sent.
+func (d *Dispatcher) dispatch(event params.NotificationEvent) {
+	if d.leader != nil && !d.leader.IsLeader() {
+		return
+	}
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	now := d.now()
+	for key, sentAt := range d.lastSent {
+		if now.Sub(sentAt) >= d.dedupWindow {
+			delete(d.lastSent, key)
+		}
+	}
+
+	for _, sink := range d.sinks {
+		if !sink.Matches(event) {
+			continue
+		}
+		key := dedupKey(sink, event)
+		if _, ok := d.lastSent[key]; ok {
+			slog.DebugContext(
+				d.ctx, "skipping duplicate notification",
+				"sink", sink.Name, "event_type", event.Type)
+			continue
+		}
+		d.lastSent[key] = now
+
+		worker := d.getWorker(sink)
+		select {
+		case worker.queue <- delivery{sink: sink, event: event, dedupKey: key, queuedAt: now}:
+		default:
+			delete(d.lastSent, key)
+			slog.WarnContext(
+				d.ctx, "notification queue is full; dropping notification",
+				"sink", sink.Name, "event_type", event.Type)
+		}
+	}
+}
+
+// forgetSent removes the dedup entry of a delivery that failed, so the same notification
+// is sent again the next time it is dispatched. Entries recorded by later deliveries are
+// kept.
+func (d *Dispatcher) forgetSent(item delivery) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	if sentAt, ok := d.lastSent[item.dedupKey]; ok && sentAt.Equal(item.queuedAt) {
+		delete(d.lastSent, item.dedupKey)
+	}
+}
+
+// getWorker returns the worker of the sink, starting it if needed. Must be called
+// with the lock held.
+func (d *Dispatcher) getWorker(sink params.NotificationSink) *sinkWorker {
+	key := sinkKey(sink)
+	if worker, ok := d.workers[key]; ok {
+		return worker
+	}
+	worker := &sinkWorker{
+		queue: make(chan delivery, queueSize),
+		quit:  make(chan struct{}),
+	}
+	d.workers[key] = worker
+	d.wg.Add(1)
+	go d.runWorker(worker)
+	return worker
+}
+
+func (d *Dispatcher) runWorker(worker *sinkWorker) {
+	defer d.wg.Done()
+
+	ctx, cancel := context.WithCancel(d.ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-worker.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case item := <-worker.queue:
+			err := sendWithRetry(ctx, d.client, item.sink, item.event, d.maxAttempts, d.retryDelay)
+			if err != nil {
+				slog.With(slog.Any("error", err)).ErrorContext(
+					d.ctx, "failed to send notification",
+					"sink", item.sink.Name,
+					"event_type", item.event.Type)
+				d.forgetSent(item)
+				continue
+			}
+			slog.DebugContext(
+				d.ctx, "sent notification",
+				"sink", item.sink.Name,
+				"event_type", item.event.Type)
+		}
+	}
+}
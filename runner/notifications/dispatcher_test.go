@@ -0,0 +1,280 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudbase/garm/config"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/database/common/mocks"
+	"github.com/cloudbase/garm/params"
+)
+
+type fakeLeader struct {
+	leader bool
+}
+
+func (f *fakeLeader) IsLeader() bool {
+	return f.leader
+}
+
+// recordingServer records the bodies of the requests it receives.
+type recordingServer struct {
+	*httptest.Server
+	mux      sync.Mutex
+	bodies   [][]byte
+	headers  []http.Header
+	received chan struct{}
+}
+
+func newRecordingServer(t *testing.T) *recordingServer {
+	srv := &recordingServer{received: make(chan struct{}, 100)}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		srv.mux.Lock()
+		srv.bodies = append(srv.bodies, body)
+		srv.headers = append(srv.headers, r.Header.Clone())
+		srv.mux.Unlock()
+		srv.received <- struct{}{}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func (r *recordingServer) wait(t *testing.T) {
+	select {
+	case <-r.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for notification")
+	}
+}
+
+func (r *recordingServer) count() int {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return len(r.bodies)
+}
+
+func newTestDispatcher(t *testing.T, leader *fakeLeader, sinks ...config.NotificationSink) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := config.Notifications{Sinks: sinks}
+	var d *Dispatcher
+	if leader == nil {
+		d = NewDispatcher(ctx, cfg, nil, nil)
+	} else {
+		d = NewDispatcher(ctx, cfg, nil, leader)
+	}
+	d.retryDelay = time.Millisecond
+	d.sinks = d.configSinks
+	t.Cleanup(d.Stop)
+	return d
+}
+
+func poolDegradedEvent(poolID string) params.NotificationEvent {
+	return params.NotificationEvent{
+		Type:         params.PoolDegradedEvent,
+		Level:        params.EventError,
+		Message:      "pool stopped creating runners",
+		EntityType:   params.GithubEntityTypeRepository,
+		EntityID:     "repo-id",
+		EntityName:   "owner/repo",
+		PoolID:       poolID,
+		ProviderName: "lxd",
+		Timestamp:    time.Now().UTC(),
+	}
+}
+
+func TestDispatchSendsMatchingEvents(t *testing.T) {
+	srv := newRecordingServer(t)
+	d := newTestDispatcher(t, nil, config.NotificationSink{
+		Name:    "ops",
+		URL:     srv.URL,
+		Headers: map[string]string{"X-Api-Key": "secret"},
+		Rules:   []params.NotificationRule{{MinLevel: params.EventError}},
+	})
+
+	d.dispatch(params.NotificationEvent{Type: params.PoolRecoveredEvent, Level: params.EventInfo, PoolID: "pool-1"})
+	d.dispatch(poolDegradedEvent("pool-1"))
+	srv.wait(t)
+
+	require.Equal(t, 1, srv.count())
+	var event params.NotificationEvent
+	require.Nil(t, json.Unmarshal(srv.bodies[0], &event))
+	require.Equal(t, params.PoolDegradedEvent, event.Type)
+	require.Equal(t, "pool-1", event.PoolID)
+	require.Equal(t, "secret", srv.headers[0].Get("X-Api-Key"))
+	require.Equal(t, "application/json", srv.headers[0].Get("Content-Type"))
+}
+
+func TestDispatchDeduplicatesEvents(t *testing.T) {
+	srv := newRecordingServer(t)
+	d := newTestDispatcher(t, nil, config.NotificationSink{Name: "ops", URL: srv.URL})
+	now := time.Now()
+	d.now = func() time.Time { return now }
+
+	d.dispatch(poolDegradedEvent("pool-1"))
+	srv.wait(t)
+	// Same problem, within the dedup window.
+	d.dispatch(poolDegradedEvent("pool-1"))
+	// A different pool is not a duplicate.
+	d.dispatch(poolDegradedEvent("pool-2"))
+	srv.wait(t)
+	require.Equal(t, 2, srv.count())
+
+	now = now.Add(d.dedupWindow)
+	d.dispatch(poolDegradedEvent("pool-1"))
+	srv.wait(t)
+	require.Equal(t, 3, srv.count())
+}
+
+func TestDispatchRetriesFailedEvents(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+	d := newTestDispatcher(t, nil, config.NotificationSink{Name: "ops", URL: srv.URL})
+	d.maxAttempts = 1
+
+	// A notification that could not be delivered is not a duplicate.
+	d.dispatch(poolDegradedEvent("pool-1"))
+	require.Eventually(t, func() bool {
+		d.mux.Lock()
+		defer d.mux.Unlock()
+		return hits.Load() == 1 && len(d.lastSent) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	d.dispatch(poolDegradedEvent("pool-1"))
+	require.Eventually(t, func() bool {
+		return hits.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestDispatchSkipsDisabledSinks(t *testing.T) {
+	srv := newRecordingServer(t)
+	d := newTestDispatcher(t, nil, config.NotificationSink{Name: "ops", URL: srv.URL, Disabled: true})
+
+	d.dispatch(poolDegradedEvent("pool-1"))
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	require.Empty(t, d.workers)
+}
+
+func TestDispatchOnlyOnLeader(t *testing.T) {
+	srv := newRecordingServer(t)
+	leader := &fakeLeader{}
+	d := newTestDispatcher(t, leader, config.NotificationSink{Name: "ops", URL: srv.URL})
+
+	d.dispatch(poolDegradedEvent("pool-1"))
+	d.mux.Lock()
+	require.Empty(t, d.workers)
+	d.mux.Unlock()
+
+	leader.leader = true
+	d.dispatch(poolDegradedEvent("pool-1"))
+	srv.wait(t)
+	require.Equal(t, 1, srv.count())
+}
+
+func TestResyncReloadsSinks(t *testing.T) {
+	d := newTestDispatcher(t, nil, config.NotificationSink{Name: "ops", URL: "https://hooks.example.com"})
+	store := &mocks.Store{}
+	store.On("ListNotificationSinks", mock.Anything).Return([]params.NotificationSink{
+		{ID: 1, Name: "api-sink", Source: params.NotificationSinkSourceAPI, Enabled: true},
+	}, nil).Once()
+	d.store = store
+
+	d.handlePayload(dbCommon.ChangePayload{
+		Seq:       10,
+		Operation: dbCommon.ResyncOperation,
+		Payload:   dbCommon.ResyncPayload{Missed: 3, LastMissedSeq: 10},
+	})
+	store.AssertExpectations(t)
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	require.Len(t, d.sinks, 2)
+	require.Equal(t, "ops", d.sinks[0].Name)
+	require.Equal(t, "api-sink", d.sinks[1].Name)
+}
+
+func TestSendWithRetry(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	sink := params.NotificationSink{Name: "ops", URL: srv.URL}
+
+	err := sendWithRetry(context.Background(), srv.Client(), sink, poolDegradedEvent("pool-1"), 5, time.Millisecond)
+
+	require.Nil(t, err)
+	require.Equal(t, int32(3), attempts.Load())
+}
+
+func TestSendWithRetryGivesUp(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	sink := params.NotificationSink{Name: "ops", URL: srv.URL}
+
+	err := sendWithRetry(context.Background(), srv.Client(), sink, poolDegradedEvent("pool-1"), 3, time.Millisecond)
+
+	require.NotNil(t, err)
+	require.Regexp(t, "giving up after 3 attempts", err.Error())
+	require.Equal(t, int32(3), attempts.Load())
+}
+
+func TestSendDoesNotRetryClientErrors(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+	sink := params.NotificationSink{Name: "ops", URL: srv.URL}
+
+	err := sendWithRetry(context.Background(), srv.Client(), sink, poolDegradedEvent("pool-1"), 5, time.Millisecond)
+
+	require.NotNil(t, err)
+	require.Regexp(t, "sink returned status 403", err.Error())
+	require.Equal(t, int32(1), attempts.Load())
+}
+
+func TestRetryDelay(t *testing.T) {
+	require.Equal(t, time.Second, retryDelay(time.Second, 1))
+	require.Equal(t, 4*time.Second, retryDelay(time.Second, 3))
+	require.Equal(t, maxRetryDelay, retryDelay(time.Second, 20))
+}
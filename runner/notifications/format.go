@@ -0,0 +1,124 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/cloudbase/garm/params"
+)
+
+// messageTemplate renders the human readable text of an event, used by the
+// chat formats.
+var messageTemplate = template.Must(template.New("message").Parse(
+	`[{{ .Level }}] {{ .Type }}: {{ .Message }}` +
+		`{{ with .EntityName }}
+Entity: {{ . }}{{ end }}` +
+		`{{ with .PoolID }}
+Pool: {{ . }}{{ end }}` +
+		`{{ with .ProviderName }}
+Provider: {{ . }}{{ end }}` +
+		`{{ with .InstanceName }}
+Runner: {{ . }}{{ end }}`))
+
+// levelColors are the theme colors of Teams cards, for each event level.
+var levelColors = map[params.EventLevel]string{
+	params.EventInfo:    "2EB67D",
+	params.EventWarning: "ECB22E",
+	params.EventError:   "E01E5A",
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+type teamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type teamsSection struct {
+	ActivityTitle string      `json:"activityTitle"`
+	Text          string      `json:"text"`
+	Facts         []teamsFact `json:"facts,omitempty"`
+}
+
+// teamsMessage is a legacy actionable message card, which is accepted by
+// Teams incoming webhooks and workflows.
+type teamsMessage struct {
+	Type       string         `json:"@type"`
+	Context    string         `json:"@context"`
+	ThemeColor string         `json:"themeColor,omitempty"`
+	Summary    string         `json:"summary"`
+	Sections   []teamsSection `json:"sections"`
+}
+
+func renderText(event params.NotificationEvent) (string, error) {
+	var buf bytes.Buffer
+	if err := messageTemplate.Execute(&buf, event); err != nil {
+		return "", fmt.Errorf("rendering message: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func teamsFacts(event params.NotificationEvent) []teamsFact {
+	var facts []teamsFact
+	for _, fact := range []teamsFact{
+		{Name: "Level", Value: string(event.Level)},
+		{Name: "Entity", Value: event.EntityName},
+		{Name: "Pool", Value: event.PoolID},
+		{Name: "Provider", Value: event.ProviderName},
+		{Name: "Runner", Value: event.InstanceName},
+	} {
+		if fact.Value != "" {
+			facts = append(facts, fact)
+		}
+	}
+	return facts
+}
+
+// renderBody returns the body of the request sent to a sink, in the format of the sink.
+func renderBody(format params.NotificationFormat, event params.NotificationEvent) ([]byte, error) {
+	switch format {
+	case params.NotificationFormatSlack:
+		text, err := renderText(event)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(slackMessage{Text: text})
+	case params.NotificationFormatTeams:
+		title := fmt.Sprintf("GARM: %s", event.Type)
+		return json.Marshal(teamsMessage{
+			Type:       "MessageCard",
+			Context:    "https://schema.org/extensions",
+			ThemeColor: levelColors[event.Level],
+			Summary:    title,
+			Sections: []teamsSection{
+				{
+					ActivityTitle: title,
+					Text:          event.Message,
+					Facts:         teamsFacts(event),
+				},
+			},
+		})
+	case params.NotificationFormatJSON, "":
+		return json.Marshal(event)
+	default:
+		return nil, fmt.Errorf("unknown notification format %q", format)
+	}
+}
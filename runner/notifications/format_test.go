@@ -0,0 +1,67 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package notifications
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudbase/garm/params"
+)
+
+func TestRenderBodySlack(t *testing.T) {
+	body, err := renderBody(params.NotificationFormatSlack, poolDegradedEvent("pool-1"))
+	require.Nil(t, err)
+
+	var msg slackMessage
+	require.Nil(t, json.Unmarshal(body, &msg))
+	require.Equal(t, "[error] pool_degraded: pool stopped creating runners\nEntity: owner/repo\nPool: pool-1\nProvider: lxd", msg.Text)
+}
+
+func TestRenderBodyTeams(t *testing.T) {
+	body, err := renderBody(params.NotificationFormatTeams, poolDegradedEvent("pool-1"))
+	require.Nil(t, err)
+
+	var msg teamsMessage
+	require.Nil(t, json.Unmarshal(body, &msg))
+	require.Equal(t, "MessageCard", msg.Type)
+	require.Equal(t, levelColors[params.EventError], msg.ThemeColor)
+	require.Len(t, msg.Sections, 1)
+	require.Equal(t, "pool stopped creating runners", msg.Sections[0].Text)
+	require.Equal(t, []teamsFact{
+		{Name: "Level", Value: "error"},
+		{Name: "Entity", Value: "owner/repo"},
+		{Name: "Pool", Value: "pool-1"},
+		{Name: "Provider", Value: "lxd"},
+	}, msg.Sections[0].Facts)
+}
+
+func TestRenderBodyJSON(t *testing.T) {
+	event := poolDegradedEvent("pool-1")
+	body, err := renderBody(params.NotificationFormatJSON, event)
+	require.Nil(t, err)
+
+	var decoded params.NotificationEvent
+	require.Nil(t, json.Unmarshal(body, &decoded))
+	require.Equal(t, event.Type, decoded.Type)
+	require.Equal(t, event.EntityName, decoded.EntityName)
+}
+
+func TestRenderBodyUnknownFormat(t *testing.T) {
+	_, err := renderBody("xml", poolDegradedEvent("pool-1"))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cloudbase/garm/params"
+)
+
+const (
+	// requestTimeout is the timeout of one attempt to deliver a notification.
+	requestTimeout = 15 * time.Second
+	// maxRetryDelay caps the delay between delivery attempts.
+	maxRetryDelay = 5 * time.Minute
+)
+
+// deliveryError is returned when a sink could not be reached or rejected a
+// notification. Only temporary errors are retried.
+type deliveryError struct {
+	err       error
+	retryable bool
+}
+
+func (d *deliveryError) Error() string {
+	return d.err.Error()
+}
+
+func (d *deliveryError) Unwrap() error {
+	return d.err
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: requestTimeout}
+}
+
+// send makes one attempt to deliver the event to the sink.
+func send(ctx context.Context, client *http.Client, sink params.NotificationSink, event params.NotificationEvent) error {
+	body, err := renderBody(sink.Format, event)
+	if err != nil {
+		return &deliveryError{err: err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.URL, bytes.NewReader(body))
+	if err != nil {
+		return &deliveryError{err: fmt.Errorf("creating request: %w", err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "garm")
+	for name, value := range sink.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return &deliveryError{err: fmt.Errorf("sending request: %w", err), retryable: true}
+	}
+	defer resp.Body.Close()
+	// Drain the body, so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return &deliveryError{err: fmt.Errorf("sink returned status %d", resp.StatusCode), retryable: true}
+	default:
+		return &deliveryError{err: fmt.Errorf("sink returned status %d", resp.StatusCode)}
+	}
+}
+
+// retryDelay returns the delay before the given attempt. The delay doubles after
+// each failed attempt.
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+// sendWithRetry delivers the event to the sink, retrying temporary errors up to
+// maxAttempts times.
+func sendWithRetry(ctx context.Context, client *http.Client, sink params.NotificationSink, event params.NotificationEvent, maxAttempts int, baseDelay time.Duration) error {
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(retryDelay(baseDelay, attempt-1)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		err = send(ctx, client, sink, event)
+		if err == nil {
+			return nil
+		}
+		if delivery, ok := err.(*deliveryError); ok && !delivery.retryable {
+			return err
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", maxAttempts, err)
+}
+
+// SendTestNotification sends a test event to the sink. The event is sent once,
+// and any error is returned to the caller.
+func SendTestNotification(ctx context.Context, sink params.NotificationSink) error {
+	event := params.NotificationEvent{
+		Type:      params.TestNotificationEvent,
+		Level:     params.EventInfo,
+		Message:   fmt.Sprintf("test notification for sink %s", sink.Name),
+		Timestamp: time.Now().UTC(),
+	}
+	return send(ctx, newHTTPClient(), sink, event)
+}
@@ -34,6 +34,9 @@ func (r *Runner) CreateOrganization(ctx context.Context, param params.CreateOrgP
 		return org, runnerErrors.ErrUnauthorized
 	}
 
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
 	if err := param.Validate(); err != nil {
 		return params.Organization{}, errors.Wrap(err, "validating params")
 	}
@@ -85,7 +88,7 @@ func (r *Runner) CreateOrganization(ctx context.Context, param params.CreateOrgP
 }
 
 func (r *Runner) ListOrganizations(ctx context.Context) ([]params.Organization, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return nil, runnerErrors.ErrUnauthorized
 	}
 
@@ -112,7 +115,7 @@ func (r *Runner) ListOrganizations(ctx context.Context) ([]params.Organization,
 }
 
 func (r *Runner) GetOrganizationByID(ctx context.Context, orgID string) (params.Organization, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.Organization{}, runnerErrors.ErrUnauthorized
 	}
 
@@ -135,6 +138,9 @@ func (r *Runner) DeleteOrganization(ctx context.Context, orgID string, keepWebho
 		return runnerErrors.ErrUnauthorized
 	}
 
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
 	org, err := r.store.GetOrganizationByID(ctx, orgID)
 	if err != nil {
 		return errors.Wrap(err, "fetching org")
@@ -193,11 +199,23 @@ func (r *Runner) UpdateOrganization(ctx context.Context, orgID string, param par
 	defer r.mux.Unlock()
 
 	switch param.PoolBalancerType {
-	case params.PoolBalancerTypeRoundRobin, params.PoolBalancerTypePack, params.PoolBalancerTypeNone:
+	case params.PoolBalancerTypeRoundRobin, params.PoolBalancerTypePack, params.PoolBalancerTypeWeighted, params.PoolBalancerTypeCapacity, params.PoolBalancerTypeNone:
 	default:
 		return params.Organization{}, runnerErrors.NewBadRequestError("invalid pool balancer type: %s", param.PoolBalancerType)
 	}
 
+	if param.JobBackfill != nil {
+		if err := param.JobBackfill.Validate(params.GithubEntityTypeOrganization); err != nil {
+			return params.Organization{}, err
+		}
+	}
+
+	if param.FairShare != nil {
+		if err := param.FairShare.Validate(); err != nil {
+			return params.Organization{}, err
+		}
+	}
+
 	org, err := r.store.UpdateOrganization(ctx, orgID, param)
 	if err != nil {
 		return params.Organization{}, errors.Wrap(err, "updating org")
@@ -240,7 +258,7 @@ func (r *Runner) CreateOrgPool(ctx context.Context, orgID string, param params.C
 }
 
 func (r *Runner) GetOrgPoolByID(ctx context.Context, orgID, poolID string) (params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.Pool{}, runnerErrors.ErrUnauthorized
 	}
 
@@ -292,7 +310,7 @@ func (r *Runner) DeleteOrgPool(ctx context.Context, orgID, poolID string) error
 }
 
 func (r *Runner) ListOrgPools(ctx context.Context, orgID string) ([]params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return []params.Pool{}, runnerErrors.ErrUnauthorized
 	}
 	entity := params.GithubEntity{
@@ -307,8 +325,8 @@ func (r *Runner) ListOrgPools(ctx context.Context, orgID string) ([]params.Pool,
 }
 
 func (r *Runner) UpdateOrgPool(ctx context.Context, orgID, poolID string, param params.UpdatePoolParams) (params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
-		return params.Pool{}, runnerErrors.ErrUnauthorized
+	if err := checkPoolUpdateRole(ctx, param); err != nil {
+		return params.Pool{}, err
 	}
 
 	entity := params.GithubEntity{
@@ -321,18 +339,8 @@ func (r *Runner) UpdateOrgPool(ctx context.Context, orgID, poolID string, param
 		return params.Pool{}, errors.Wrap(err, "fetching pool")
 	}
 
-	maxRunners := pool.MaxRunners
-	minIdleRunners := pool.MinIdleRunners
-
-	if param.MaxRunners != nil {
-		maxRunners = *param.MaxRunners
-	}
-	if param.MinIdleRunners != nil {
-		minIdleRunners = *param.MinIdleRunners
-	}
-
-	if minIdleRunners > maxRunners {
-		return params.Pool{}, runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+	if err := validatePoolUpdate(pool, param); err != nil {
+		return params.Pool{}, err
 	}
 
 	newPool, err := r.store.UpdateEntityPool(ctx, entity, poolID, param)
@@ -343,7 +351,7 @@ func (r *Runner) UpdateOrgPool(ctx context.Context, orgID, poolID string, param
 }
 
 func (r *Runner) ListOrgInstances(ctx context.Context, orgID string) ([]params.Instance, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return nil, runnerErrors.ErrUnauthorized
 	}
 
@@ -419,7 +427,7 @@ func (r *Runner) UninstallOrgWebhook(ctx context.Context, orgID string) error {
 }
 
 func (r *Runner) GetOrgWebhookInfo(ctx context.Context, orgID string) (params.HookInfo, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.HookInfo{}, runnerErrors.ErrUnauthorized
 	}
 
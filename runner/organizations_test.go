@@ -335,6 +335,33 @@ func (s *OrgTestSuite) TestUpdateRepositoryBalancingType() {
 	s.Require().Equal(params.PoolBalancerTypePack, org.PoolBalancerType)
 }
 
+func (s *OrgTestSuite) TestUpdateOrganizationJobBackfill() {
+	s.Fixtures.PoolMgrCtrlMock.On("GetOrgPoolManager", mock.AnythingOfType("params.Organization")).Return(s.Fixtures.PoolMgrMock, nil)
+	s.Fixtures.PoolMgrMock.On("Status").Return(params.PoolManagerStatus{IsRunning: true}, nil)
+
+	param := s.Fixtures.UpdateRepoParams
+	param.JobBackfill = &params.JobBackfillSettings{
+		Enabled:      true,
+		Repositories: []string{"test-repo"},
+	}
+	org, err := s.Runner.UpdateOrganization(s.Fixtures.AdminContext, s.Fixtures.StoreOrgs["test-org-1"].ID, param)
+
+	s.Fixtures.PoolMgrMock.AssertExpectations(s.T())
+	s.Fixtures.PoolMgrCtrlMock.AssertExpectations(s.T())
+	s.Require().Nil(err)
+	s.Require().Equal(*param.JobBackfill, org.JobBackfill)
+}
+
+func (s *OrgTestSuite) TestUpdateOrganizationInvalidJobBackfill() {
+	param := s.Fixtures.UpdateRepoParams
+	param.JobBackfill = &params.JobBackfillSettings{
+		Enabled: true,
+	}
+	_, err := s.Runner.UpdateOrganization(s.Fixtures.AdminContext, s.Fixtures.StoreOrgs["test-org-1"].ID, param)
+
+	s.Require().Equal(runnerErrors.NewBadRequestError("job backfill for organizations requires a list of repositories or a max number of repositories to scan"), err)
+}
+
 func (s *OrgTestSuite) TestUpdateOrganizationErrUnauthorized() {
 	_, err := s.Runner.UpdateOrganization(context.Background(), "dummy-org-id", s.Fixtures.UpdateRepoParams)
 
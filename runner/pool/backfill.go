@@ -0,0 +1,268 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/params"
+)
+
+const (
+	// backfillPageSize is the number of items requested per page when listing
+	// repositories, workflow runs and workflow jobs.
+	backfillPageSize = 100
+)
+
+type backfillRepository struct {
+	owner string
+	name  string
+}
+
+// backfillQueuedJobs asks GitHub for workflow jobs that are currently queued and records
+// them in the database, the same way a "queued" webhook would. Webhooks for jobs that were
+// queued while GARM was down are never delivered, so without this step those jobs are only
+// picked up if the pools have idle runners. See the comment on consumeQueuedJobs().
+// This runs every time the controller becomes the leader.
+//
+// Backfilling is opt-in. For organizations, the repositories to scan must be given explicitly,
+// or capped to a maximum number, as an organization may have thousands of repositories.
+func (r *basePoolManager) backfillQueuedJobs() error {
+	entity := r.entity
+	if !entity.JobBackfill.Enabled {
+		return nil
+	}
+
+	if status := r.Status(); !status.IsRunning {
+		return fmt.Errorf("pool manager is not running: %s", status.FailureReason)
+	}
+
+	repos, err := r.backfillRepositories(entity)
+	if err != nil {
+		return errors.Wrap(err, "listing repositories")
+	}
+
+	var recorded int
+	for _, repo := range repos {
+		select {
+		case <-r.quit:
+			return nil
+		case <-r.ctx.Done():
+			return nil
+		default:
+		}
+
+		count, err := r.backfillRepositoryQueuedJobs(repo)
+		if err != nil {
+			slog.With(slog.Any("error", err)).WarnContext(
+				r.ctx, "failed to backfill queued jobs",
+				"repository", fmt.Sprintf("%s/%s", repo.owner, repo.name))
+			if errors.Is(err, runnerErrors.ErrUnauthorized) {
+				return err
+			}
+			continue
+		}
+		recorded += count
+	}
+
+	slog.InfoContext(
+		r.ctx, "finished backfilling queued jobs",
+		"scanned_repositories", len(repos),
+		"recorded_jobs", recorded)
+	return nil
+}
+
+// backfillRepositories returns the list of repositories that need to be scanned
+// for queued jobs.
+func (r *basePoolManager) backfillRepositories(entity params.GithubEntity) ([]backfillRepository, error) {
+	switch entity.EntityType {
+	case params.GithubEntityTypeRepository:
+		return []backfillRepository{{owner: entity.Owner, name: entity.Name}}, nil
+	case params.GithubEntityTypeOrganization:
+	default:
+		return nil, fmt.Errorf("job backfill is not supported for %s", entity.EntityType)
+	}
+
+	settings := entity.JobBackfill
+	if len(settings.Repositories) > 0 {
+		repos := make([]backfillRepository, 0, len(settings.Repositories))
+		for _, name := range settings.Repositories {
+			repos = append(repos, backfillRepository{owner: entity.Owner, name: name})
+		}
+		return repos, nil
+	}
+
+	if settings.MaxRepositories == 0 {
+		return nil, fmt.Errorf("no repositories to scan")
+	}
+
+	repos := []backfillRepository{}
+	opts := &github.ListOptions{
+		PerPage: min(backfillPageSize, int(settings.MaxRepositories)),
+	}
+	for {
+		ghRepos, ghResp, err := r.ghcli.ListEntityRepositories(r.ctx, opts)
+		if err != nil {
+			if ghResp != nil && ghResp.StatusCode == http.StatusUnauthorized {
+				return nil, errors.Wrap(runnerErrors.ErrUnauthorized, "listing repositories")
+			}
+			return nil, errors.Wrap(err, "listing repositories")
+		}
+		for _, repo := range ghRepos {
+			if repo.GetArchived() {
+				continue
+			}
+			repos = append(repos, backfillRepository{owner: entity.Owner, name: repo.GetName()})
+			if uint(len(repos)) >= settings.MaxRepositories {
+				return repos, nil
+			}
+		}
+		if ghResp == nil || ghResp.NextPage == 0 {
+			break
+		}
+		opts.Page = ghResp.NextPage
+	}
+	return repos, nil
+}
+
+// backfillRepositoryQueuedJobs records the queued jobs of a single repository and
+// returns the number of jobs that were recorded.
+func (r *basePoolManager) backfillRepositoryQueuedJobs(repo backfillRepository) (int, error) {
+	var recorded int
+	// Queued jobs may belong to workflow runs that are themselves queued, or to runs that
+	// are in progress, but still have jobs waiting for a runner.
+	for _, status := range []string{"queued", "in_progress"} {
+		opts := &github.ListWorkflowRunsOptions{
+			Status: status,
+			ListOptions: github.ListOptions{
+				PerPage: backfillPageSize,
+			},
+		}
+		for {
+			runs, ghResp, err := r.ghcli.ListRepositoryWorkflowRuns(r.ctx, repo.owner, repo.name, opts)
+			if err != nil {
+				if ghResp != nil && ghResp.StatusCode == http.StatusUnauthorized {
+					return recorded, errors.Wrap(runnerErrors.ErrUnauthorized, "listing workflow runs")
+				}
+				return recorded, errors.Wrap(err, "listing workflow runs")
+			}
+			for _, run := range runs.WorkflowRuns {
+				count, err := r.backfillWorkflowRunQueuedJobs(repo, run.GetID())
+				if err != nil {
+					return recorded, errors.Wrapf(err, "backfilling jobs of workflow run %d", run.GetID())
+				}
+				recorded += count
+			}
+			if ghResp == nil || ghResp.NextPage == 0 {
+				break
+			}
+			opts.Page = ghResp.NextPage
+		}
+	}
+	return recorded, nil
+}
+
+func (r *basePoolManager) backfillWorkflowRunQueuedJobs(repo backfillRepository, runID int64) (int, error) {
+	var recorded int
+	opts := &github.ListWorkflowJobsOptions{
+		Filter: "latest",
+		ListOptions: github.ListOptions{
+			PerPage: backfillPageSize,
+		},
+	}
+	for {
+		jobs, ghResp, err := r.ghcli.ListWorkflowJobs(r.ctx, repo.owner, repo.name, runID, opts)
+		if err != nil {
+			return recorded, errors.Wrap(err, "listing workflow jobs")
+		}
+		for _, job := range jobs.Jobs {
+			if job.GetStatus() != string(params.JobStatusQueued) {
+				continue
+			}
+			ok, err := r.recordBackfilledJob(repo, job)
+			if err != nil {
+				return recorded, errors.Wrapf(err, "recording job %d", job.GetID())
+			}
+			if ok {
+				recorded++
+			}
+		}
+		if ghResp == nil || ghResp.NextPage == 0 {
+			break
+		}
+		opts.Page = ghResp.NextPage
+	}
+	return recorded, nil
+}
+
+// recordBackfilledJob records a queued job in the database, if it is not already known and
+// at least one pool of this entity can handle it. Returns true if the job was recorded.
+func (r *basePoolManager) recordBackfilledJob(repo backfillRepository, job *github.WorkflowJob) (bool, error) {
+	if _, err := r.store.GetJobByID(r.ctx, job.GetID()); err == nil {
+		// We already know about this job.
+		return false, nil
+	} else if !errors.Is(err, runnerErrors.ErrNotFound) {
+		return false, errors.Wrap(err, "fetching job")
+	}
+
+	if len(job.Labels) == 0 {
+		return false, nil
+	}
+
+	potentialPools, err := r.store.FindPoolsMatchingAllTags(r.ctx, r.entity.EntityType, r.entity.ID, job.Labels)
+	if err != nil {
+		return false, errors.Wrap(err, "finding pools matching tags")
+	}
+	if len(potentialPools) == 0 {
+		slog.DebugContext(
+			r.ctx, "no pools matching tags; not recording job",
+			"job_id", job.GetID(),
+			"requested_tags", strings.Join(job.Labels, ", "))
+		return false, nil
+	}
+
+	var workflowJob params.WorkflowJob
+	workflowJob.Action = string(params.JobStatusQueued)
+	workflowJob.WorkflowJob.ID = job.GetID()
+	workflowJob.WorkflowJob.RunID = job.GetRunID()
+	workflowJob.WorkflowJob.Status = job.GetStatus()
+	workflowJob.WorkflowJob.Name = job.GetName()
+	workflowJob.WorkflowJob.WorkflowName = job.GetWorkflowName()
+	workflowJob.WorkflowJob.Labels = job.Labels
+	workflowJob.WorkflowJob.StartedAt = job.GetStartedAt().Time
+	workflowJob.Repository.Name = repo.name
+	workflowJob.Repository.Owner.Login = repo.owner
+
+	jobParams, err := r.paramsWorkflowJobToParamsJob(workflowJob)
+	if err != nil {
+		return false, errors.Wrap(err, "converting job to params")
+	}
+
+	if _, err := r.store.CreateOrUpdateJob(r.ctx, jobParams); err != nil {
+		return false, errors.Wrap(err, "recording job")
+	}
+	slog.InfoContext(
+		r.ctx, "recorded backfilled queued job",
+		"job_id", job.GetID(),
+		"repository", fmt.Sprintf("%s/%s", repo.owner, repo.name))
+	return true, nil
+}
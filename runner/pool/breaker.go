@@ -0,0 +1,269 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+)
+
+// providerBreaker tracks failures of providers, across all pool managers. A provider
+// is only considered degraded if failures happen in more than one pool. Failures that
+// only happen in one pool are most likely caused by the pool settings (a missing image,
+// a wrong flavor), and are handled by the circuit breaker of that pool.
+var providerBreaker = newCircuitBreaker(common.PoolCircuitBreakerThreshold, 2)
+
+// breakerState is the state of the circuit breaker for one key.
+type breakerState struct {
+	failures  int
+	lastError string
+	// sources holds the distinct sources of failures recorded since the last success.
+	sources map[string]struct{}
+
+	open      bool
+	openSince time.Time
+	retryAt   time.Time
+	backoff   time.Duration
+	// probeStarted is set when a single attempt is allowed through an open breaker.
+	probeStarted time.Time
+}
+
+// circuitBreaker is a set of circuit breakers, identified by a key. After threshold
+// consecutive failures, coming from at least minSources distinct sources, the breaker
+// of a key opens. While open, attempts are rejected until the back-off period passes.
+// After that, a single attempt is allowed through. If it succeeds, the breaker closes.
+// If it fails, the breaker stays open and the back-off period doubles.
+type circuitBreaker struct {
+	mux        sync.Mutex
+	threshold  int
+	minSources int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	states     map[string]*breakerState
+
+	now func() time.Time
+}
+
+func newCircuitBreaker(threshold, minSources int) *circuitBreaker {
+	return &circuitBreaker{
+		threshold:  threshold,
+		minSources: minSources,
+		backoff:    common.PoolCircuitBreakerBackoff,
+		maxBackoff: common.PoolCircuitBreakerMaxBackoff,
+		states:     map[string]*breakerState{},
+		now:        time.Now,
+	}
+}
+
+// isOpen returns true if attempts for the given key should currently be skipped.
+// Unlike allow(), it does not start a probe once the back-off period has passed.
+func (c *circuitBreaker) isOpen(key string) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	state, ok := c.states[key]
+	if !ok || !state.open {
+		return false
+	}
+	now := c.now()
+	return now.Before(state.retryAt) || c.probeInFlight(state, now)
+}
+
+// allow returns true if an attempt may be made for the given key. If the breaker
+// is open and the back-off period passed, only one attempt is allowed through,
+// until its result is recorded.
+func (c *circuitBreaker) allow(key string) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	state, ok := c.states[key]
+	if !ok || !state.open {
+		return true
+	}
+	now := c.now()
+	if now.Before(state.retryAt) || c.probeInFlight(state, now) {
+		return false
+	}
+	state.probeStarted = now
+	return true
+}
+
+// probeInFlight returns true if a probe was let through and its result is still pending.
+// A probe whose result was never recorded expires after one back-off period.
+func (c *circuitBreaker) probeInFlight(state *breakerState, now time.Time) bool {
+	if state.probeStarted.IsZero() {
+		return false
+	}
+	return now.Before(state.probeStarted.Add(state.backoff))
+}
+
+// recordSuccess closes the breaker of the given key. Returns true if the breaker was open.
+func (c *circuitBreaker) recordSuccess(key string) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	state, ok := c.states[key]
+	if !ok {
+		return false
+	}
+	delete(c.states, key)
+	return state.open
+}
+
+// recordFailure records a failed attempt for the given key, coming from source. Returns
+// true if this failure opened the breaker.
+func (c *circuitBreaker) recordFailure(key, source string, err error) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	state, ok := c.states[key]
+	if !ok {
+		state = &breakerState{
+			sources: map[string]struct{}{},
+		}
+		c.states[key] = state
+	}
+	now := c.now()
+	state.failures++
+	state.sources[source] = struct{}{}
+	if err != nil {
+		state.lastError = err.Error()
+	}
+
+	if state.open {
+		// The probe failed. Back off some more.
+		state.backoff = min(state.backoff*2, c.maxBackoff)
+		state.retryAt = now.Add(state.backoff)
+		state.probeStarted = time.Time{}
+		return false
+	}
+
+	if state.failures < c.threshold || len(state.sources) < c.minSources {
+		return false
+	}
+	state.open = true
+	state.openSince = now
+	state.backoff = c.backoff
+	state.retryAt = now.Add(state.backoff)
+	return true
+}
+
+// status returns a copy of the state of the given key, if the breaker is open.
+func (c *circuitBreaker) status(key string) (breakerState, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	state, ok := c.states[key]
+	if !ok || !state.open {
+		return breakerState{}, false
+	}
+	return *state, true
+}
+
+// poolDegraded returns true if runners should currently not be created in the given pool.
+func (r *basePoolManager) poolDegraded(pool params.Pool) bool {
+	return r.poolBreaker.isOpen(pool.ID) || providerBreaker.isOpen(pool.ProviderName)
+}
+
+// allowProviderCall returns true if the provider may be asked to create a runner in the given
+// pool. When a pool is degraded, this lets through a single probe once the back-off period passed.
+func (r *basePoolManager) allowProviderCall(pool params.Pool) bool {
+	if !r.poolBreaker.allow(pool.ID) {
+		return false
+	}
+	return providerBreaker.allow(pool.ProviderName)
+}
+
+// recordProviderResult updates the circuit breakers of a pool and of its provider with
+// the result of an attempt to create a runner.
+func (r *basePoolManager) recordProviderResult(pool params.Pool, err error) {
+	if err == nil {
+		if r.poolBreaker.recordSuccess(pool.ID) {
+			slog.InfoContext(
+				r.ctx, "pool is no longer degraded",
+				"pool_id", pool.ID)
+			r.notifyEntity(
+				params.PoolRecoveredEvent, params.EventInfo, pool.ID,
+				fmt.Sprintf("pool %s is creating runners again", pool.ID))
+		}
+		if providerBreaker.recordSuccess(pool.ProviderName) {
+			slog.InfoContext(
+				r.ctx, "provider is no longer degraded",
+				"provider", pool.ProviderName)
+			r.notifyProvider(
+				params.ProviderRecoveredEvent, params.EventInfo, pool.ProviderName,
+				fmt.Sprintf("provider %s is creating runners again", pool.ProviderName))
+		}
+		return
+	}
+
+	if r.poolBreaker.recordFailure(pool.ID, pool.ID, err) {
+		slog.With(slog.Any("error", err)).WarnContext(
+			r.ctx, "too many consecutive failures; marking pool as degraded",
+			"pool_id", pool.ID,
+			"provider", pool.ProviderName)
+		r.notifyEntity(
+			params.PoolDegradedEvent, params.EventError, pool.ID,
+			fmt.Sprintf("pool %s stopped creating runners after %d consecutive failures: %s", pool.ID, common.PoolCircuitBreakerThreshold, err))
+	}
+	if providerBreaker.recordFailure(pool.ProviderName, pool.ID, err) {
+		slog.With(slog.Any("error", err)).WarnContext(
+			r.ctx, "too many consecutive failures in multiple pools; marking provider as degraded",
+			"provider", pool.ProviderName)
+		r.notifyProvider(
+			params.ProviderDegradedEvent, params.EventError, pool.ProviderName,
+			fmt.Sprintf("provider %s failed to create runners in multiple pools: %s", pool.ProviderName, err))
+	}
+}
+
+// degradedPools returns the pools of this entity that are currently degraded, either
+// because of the pool itself, or because of its provider.
+func (r *basePoolManager) degradedPools() []params.DegradedPool {
+	ret := []params.DegradedPool{}
+	for _, pool := range r.state.getPools() {
+		var degraded *params.DegradedPool
+		if state, ok := r.poolBreaker.status(pool.ID); ok {
+			degraded = &params.DegradedPool{
+				Reason:              fmt.Sprintf("%d consecutive failures to create runners: %s", state.failures, state.lastError),
+				ConsecutiveFailures: state.failures,
+				DegradedSince:       state.openSince,
+				RetryAt:             state.retryAt,
+			}
+		} else if state, ok := providerBreaker.status(pool.ProviderName); ok {
+			degraded = &params.DegradedPool{
+				Reason:              fmt.Sprintf("provider %s failed %d consecutive times to create runners: %s", pool.ProviderName, state.failures, state.lastError),
+				ConsecutiveFailures: state.failures,
+				DegradedSince:       state.openSince,
+				RetryAt:             state.retryAt,
+			}
+		}
+		if degraded == nil {
+			continue
+		}
+		degraded.PoolID = pool.ID
+		degraded.ProviderName = pool.ProviderName
+		ret = append(ret, *degraded)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].PoolID < ret[j].PoolID
+	})
+	return ret
+}
@@ -0,0 +1,138 @@
+package pool
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(threshold, minSources int) (*circuitBreaker, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(threshold, minSources)
+	breaker.backoff = time.Minute
+	breaker.maxBackoff = 3 * time.Minute
+	breaker.now = func() time.Time { return now }
+	return breaker, &now
+}
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	breaker, _ := newTestBreaker(3, 1)
+	failure := fmt.Errorf("quota exceeded")
+
+	for i := 0; i < 2; i++ {
+		if breaker.recordFailure("pool", "pool", failure) {
+			t.Fatalf("breaker opened after %d failures", i+1)
+		}
+		if breaker.isOpen("pool") {
+			t.Fatalf("expected breaker to be closed after %d failures", i+1)
+		}
+	}
+	if !breaker.recordFailure("pool", "pool", failure) {
+		t.Fatalf("expected breaker to open after 3 failures")
+	}
+	if !breaker.isOpen("pool") || breaker.allow("pool") {
+		t.Fatalf("expected breaker to reject attempts")
+	}
+
+	state, ok := breaker.status("pool")
+	if !ok {
+		t.Fatalf("expected breaker status")
+	}
+	if state.failures != 3 || state.lastError != failure.Error() {
+		t.Fatalf("unexpected breaker status: %d failures, last error %q", state.failures, state.lastError)
+	}
+
+	// A success anywhere resets the count.
+	breaker.recordSuccess("pool")
+	if breaker.isOpen("pool") {
+		t.Fatalf("expected breaker to be closed after success")
+	}
+	if _, ok := breaker.status("pool"); ok {
+		t.Fatalf("expected no status for closed breaker")
+	}
+}
+
+func TestCircuitBreakerProbe(t *testing.T) {
+	breaker, now := newTestBreaker(1, 1)
+	failure := fmt.Errorf("api outage")
+
+	breaker.recordFailure("pool", "pool", failure)
+	if breaker.allow("pool") {
+		t.Fatalf("expected attempts to be rejected during back-off")
+	}
+
+	*now = now.Add(time.Minute)
+	if breaker.isOpen("pool") {
+		t.Fatalf("expected pool to be selectable once the back-off passed")
+	}
+	if !breaker.allow("pool") {
+		t.Fatalf("expected a probe to be allowed once the back-off passed")
+	}
+	if breaker.allow("pool") {
+		t.Fatalf("expected only one probe to be allowed")
+	}
+	if !breaker.isOpen("pool") {
+		t.Fatalf("expected pool to be skipped while a probe is in flight")
+	}
+
+	// The probe fails. The back-off doubles.
+	breaker.recordFailure("pool", "pool", failure)
+	state, _ := breaker.status("pool")
+	if state.backoff != 2*time.Minute {
+		t.Fatalf("expected back-off to double, got %s", state.backoff)
+	}
+	*now = now.Add(time.Minute)
+	if breaker.allow("pool") {
+		t.Fatalf("expected attempts to be rejected during the longer back-off")
+	}
+
+	// Back-off is capped.
+	*now = now.Add(time.Minute)
+	breaker.allow("pool")
+	breaker.recordFailure("pool", "pool", failure)
+	state, _ = breaker.status("pool")
+	if state.backoff != 3*time.Minute {
+		t.Fatalf("expected back-off to be capped, got %s", state.backoff)
+	}
+
+	// The probe succeeds.
+	*now = now.Add(3 * time.Minute)
+	if !breaker.allow("pool") {
+		t.Fatalf("expected a probe to be allowed")
+	}
+	if !breaker.recordSuccess("pool") {
+		t.Fatalf("expected breaker to report it was open")
+	}
+	if !breaker.allow("pool") || !breaker.allow("pool") {
+		t.Fatalf("expected breaker to be closed")
+	}
+}
+
+func TestCircuitBreakerStaleProbeExpires(t *testing.T) {
+	breaker, now := newTestBreaker(1, 1)
+	breaker.recordFailure("pool", "pool", fmt.Errorf("failure"))
+
+	*now = now.Add(time.Minute)
+	if !breaker.allow("pool") {
+		t.Fatalf("expected a probe to be allowed")
+	}
+	// The result of the probe is never recorded.
+	*now = now.Add(time.Minute)
+	if !breaker.allow("pool") {
+		t.Fatalf("expected a new probe to be allowed once the previous one expired")
+	}
+}
+
+func TestCircuitBreakerMinSources(t *testing.T) {
+	breaker, _ := newTestBreaker(2, 2)
+	failure := fmt.Errorf("failure")
+
+	for i := 0; i < 5; i++ {
+		if breaker.recordFailure("provider", "pool1", failure) {
+			t.Fatalf("breaker opened with failures from a single source")
+		}
+	}
+	if !breaker.recordFailure("provider", "pool2", failure) {
+		t.Fatalf("expected breaker to open with failures from two sources")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package pool
+
+import (
+	"sort"
+
+	"github.com/cloudbase/garm/params"
+)
+
+// fairShareScheduler keeps track of the number of jobs of each group that are pending
+// or running, while queued jobs are being consumed.
+type fairShareScheduler struct {
+	settings params.FairShareSettings
+	usage    map[string]uint
+}
+
+func newFairShareScheduler(settings params.FairShareSettings) *fairShareScheduler {
+	return &fairShareScheduler{
+		settings: settings,
+		usage:    map[string]uint{},
+	}
+}
+
+// add counts a job against the limit of its group.
+func (f *fairShareScheduler) add(job params.Job) {
+	f.usage[f.settings.GroupName(job)]++
+}
+
+// allow returns true if a runner may be allocated to the given job, without exceeding
+// the limit of its group.
+func (f *fairShareScheduler) allow(job params.Job) bool {
+	group := f.settings.GroupName(job)
+	limit := f.settings.GroupMaxRunners(group)
+	if limit == 0 {
+		return true
+	}
+	return f.usage[group] < limit
+}
+
+// order interleaves the given jobs between their groups. Groups are served in rounds,
+// one job per group in each round. Within a round, groups with a higher priority are
+// served first. Groups with the same priority are served in the order of their oldest
+// job. Within a group, jobs keep their original order.
+func (f *fairShareScheduler) order(jobs []params.Job) []params.Job {
+	groups := map[string][]params.Job{}
+	names := []string{}
+	for _, job := range jobs {
+		name := f.settings.GroupName(job)
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], job)
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		return f.settings.GroupPriority(names[i]) > f.settings.GroupPriority(names[j])
+	})
+
+	ret := make([]params.Job, 0, len(jobs))
+	for len(ret) < len(jobs) {
+		for _, name := range names {
+			if len(groups[name]) == 0 {
+				continue
+			}
+			ret = append(ret, groups[name][0])
+			groups[name] = groups[name][1:]
+		}
+	}
+	return ret
+}
+
+// fairShareScheduler returns a scheduler for the queued jobs of the entity, or nil if
+// fair share scheduling is disabled. Jobs that are in progress, and queued jobs for which
+// we already have a runner, count against the limits of their groups. Both are taken
+// from the in-memory state.
+func (r *basePoolManager) fairShareScheduler(queued []params.Job) *fairShareScheduler {
+	settings := r.entity.FairShare
+	if !settings.Enabled {
+		return nil
+	}
+
+	scheduler := newFairShareScheduler(settings)
+	for _, job := range r.state.getInProgressJobs() {
+		scheduler.add(job)
+	}
+	for _, job := range queued {
+		if job.LockedBy.String() == r.ID() && r.state.hasRunnerForJob(job.ID) {
+			scheduler.add(job)
+		}
+	}
+	return scheduler
+}
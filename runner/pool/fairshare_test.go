@@ -0,0 +1,116 @@
+package pool
+
+import (
+	"testing"
+
+	"github.com/cloudbase/garm/params"
+)
+
+func fairShareJob(id int64, repo string) params.Job {
+	return params.Job{ID: id, RepositoryOwner: "org", RepositoryName: repo}
+}
+
+func jobIDs(jobs []params.Job) []int64 {
+	ret := make([]int64, len(jobs))
+	for idx, job := range jobs {
+		ret[idx] = job.ID
+	}
+	return ret
+}
+
+func TestFairShareOrderInterleavesGroups(t *testing.T) {
+	scheduler := newFairShareScheduler(params.FairShareSettings{Enabled: true})
+	jobs := []params.Job{
+		fairShareJob(1, "busy"),
+		fairShareJob(2, "busy"),
+		fairShareJob(3, "busy"),
+		fairShareJob(4, "quiet"),
+		fairShareJob(5, "other"),
+		fairShareJob(6, "quiet"),
+	}
+
+	got := jobIDs(scheduler.order(jobs))
+	expected := []int64{1, 4, 5, 2, 6, 3}
+	for idx := range expected {
+		if got[idx] != expected[idx] {
+			t.Fatalf("expected order %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestFairShareOrderPriority(t *testing.T) {
+	scheduler := newFairShareScheduler(params.FairShareSettings{
+		Enabled: true,
+		Groups:  []params.FairShareGroup{{Name: "org/critical", Priority: 10}},
+	})
+	jobs := []params.Job{
+		fairShareJob(1, "busy"),
+		fairShareJob(2, "busy"),
+		fairShareJob(3, "critical"),
+		fairShareJob(4, "critical"),
+		fairShareJob(5, "critical"),
+	}
+
+	got := jobIDs(scheduler.order(jobs))
+	expected := []int64{3, 1, 4, 2, 5}
+	for idx := range expected {
+		if got[idx] != expected[idx] {
+			t.Fatalf("expected order %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestFairShareGroupLimits(t *testing.T) {
+	scheduler := newFairShareScheduler(params.FairShareSettings{
+		Enabled:            true,
+		MaxRunnersPerGroup: 1,
+		Groups:             []params.FairShareGroup{{Name: "org/critical", MaxRunners: 2}},
+	})
+
+	busy := fairShareJob(1, "busy")
+	if !scheduler.allow(busy) {
+		t.Fatalf("expected job to be allowed")
+	}
+	scheduler.add(busy)
+	if scheduler.allow(fairShareJob(2, "busy")) {
+		t.Fatalf("expected group busy to be at its limit")
+	}
+
+	critical := fairShareJob(3, "critical")
+	scheduler.add(critical)
+	if !scheduler.allow(fairShareJob(4, "critical")) {
+		t.Fatalf("expected group override to allow a second runner")
+	}
+	scheduler.add(fairShareJob(4, "critical"))
+	if scheduler.allow(fairShareJob(5, "critical")) {
+		t.Fatalf("expected group critical to be at its limit")
+	}
+
+	unlimited := newFairShareScheduler(params.FairShareSettings{Enabled: true})
+	for i := 0; i < 10; i++ {
+		unlimited.add(busy)
+	}
+	if !unlimited.allow(busy) {
+		t.Fatalf("expected no limit when max runners per group is 0")
+	}
+}
+
+func TestFairShareSchedulerCountsInProgressJobs(t *testing.T) {
+	busy := fairShareJob(1, "busy")
+	busy.Status = string(params.JobStatusInProgress)
+	r := &basePoolManager{
+		entity: params.GithubEntity{
+			FairShare: params.FairShareSettings{Enabled: true, MaxRunnersPerGroup: 1},
+		},
+		state: newPoolManagerState(),
+	}
+	r.state.reset(nil, nil, []params.Job{busy})
+
+	scheduler := r.fairShareScheduler(nil)
+	if scheduler.allow(fairShareJob(2, "busy")) {
+		t.Fatalf("expected group busy to be at its limit")
+	}
+	if !scheduler.allow(fairShareJob(3, "quiet")) {
+		t.Fatalf("expected group quiet to be allowed")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+)
+
+// isHibernatedRunner returns true if the runner was idle when its instance was stopped
+// by the scale down loop. Such runners are still registered in github, and will pick
+// up jobs as soon as the instance is started again.
+func isHibernatedRunner(instance params.Instance) bool {
+	return instance.Status == commonParams.InstanceStopped && instance.RunnerStatus == params.RunnerIdle
+}
+
+// hibernateRunner stops the instance of an idle runner, instead of deleting it. The
+// caller must hold the lock of the instance.
+func (r *basePoolManager) hibernateRunner(pool params.Pool, instance params.Instance) error {
+	provider, ok := r.providers[pool.ProviderName]
+	if !ok {
+		return fmt.Errorf("unknown provider %s for pool %s", pool.ProviderName, pool.ID)
+	}
+
+	if err := provider.Stop(r.ctx, instance.ProviderID); err != nil {
+		return fmt.Errorf("failed to stop instance %s: %w", instance.Name, err)
+	}
+
+	updated, err := r.setInstanceStatus(instance.Name, commonParams.InstanceStopped, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update instance %s: %w", instance.Name, err)
+	}
+	r.state.setInstance(updated)
+	return nil
+}
+
+// startHibernatedRunner starts the instance of a hibernated runner. The caller must
+// hold the lock of the instance.
+func (r *basePoolManager) startHibernatedRunner(instance params.Instance) error {
+	pool, ok := r.state.getPool(instance.PoolID)
+	if !ok {
+		return fmt.Errorf("unknown pool %s", instance.PoolID)
+	}
+	provider, ok := r.providers[pool.ProviderName]
+	if !ok {
+		return fmt.Errorf("unknown provider %s for pool %s", pool.ProviderName, pool.ID)
+	}
+
+	if err := provider.Start(r.ctx, instance.ProviderID); err != nil {
+		return fmt.Errorf("failed to start instance %s: %w", instance.Name, err)
+	}
+
+	updated, err := r.setInstanceStatus(instance.Name, commonParams.InstanceRunning, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update instance %s: %w", instance.Name, err)
+	}
+	r.state.setInstance(updated)
+	return nil
+}
+
+// wakeHibernatedRunner starts one of the hibernated runners of the given pools. Pools
+// are tried in order. Runners that fail to start are removed, and the next one is tried.
+// Returns false if no hibernated runner could be started.
+func (r *basePoolManager) wakeHibernatedRunner(pools []params.Pool) (params.Instance, bool) {
+	for _, instance := range r.state.hibernatedRunners(pools) {
+		if !r.keyMux.TryLock(instance.Name) {
+			continue
+		}
+
+		slog.InfoContext(
+			r.ctx, "starting hibernated runner",
+			"runner_name", instance.Name,
+			"pool_id", instance.PoolID)
+		err := r.startHibernatedRunner(instance)
+		if err == nil {
+			r.keyMux.Unlock(instance.Name, false)
+			return instance, true
+		}
+
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to start hibernated runner; removing it",
+			"runner_name", instance.Name)
+		if err := r.DeleteRunner(instance, false, false); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to remove hibernated runner",
+				"runner_name", instance.Name)
+		}
+		r.keyMux.Unlock(instance.Name, false)
+	}
+	return params.Instance{}, false
+}
+
+// deleteHibernatedRunners removes the given hibernated runners.
+func (r *basePoolManager) deleteHibernatedRunners(instances []params.Instance) error {
+	for _, instance := range instances {
+		if !r.keyMux.TryLock(instance.Name) {
+			continue
+		}
+		slog.InfoContext(
+			r.ctx, "removing hibernated runner",
+			"runner_name", instance.Name,
+			"pool_id", instance.PoolID)
+		err := r.DeleteRunner(instance, false, false)
+		r.keyMux.Unlock(instance.Name, false)
+		if err != nil {
+			return fmt.Errorf("failed to delete instance %s: %w", instance.ID, err)
+		}
+	}
+	return nil
+}
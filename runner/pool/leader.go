@@ -0,0 +1,166 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/params"
+)
+
+// isLeader returns true if this controller is allowed to reconcile pools. Without
+// high availability there is only one controller, which is always the leader.
+func (r *basePoolManager) isLeader() bool {
+	if r.leader == nil {
+		return true
+	}
+	return r.leader.IsLeader()
+}
+
+// leaderOnly wraps a loop function, so that it only runs while this controller
+// is the leader.
+func (r *basePoolManager) leaderOnly(f func() error) func() error {
+	return func() error {
+		if !r.isLeader() {
+			return nil
+		}
+		return f()
+	}
+}
+
+// handleLeadershipChange runs every time this controller becomes the leader, including
+// when the pool manager first starts. Webhooks may have been lost while no controller
+// was reconciling this entity, and the previous leader may have locked jobs without
+// getting the chance to create runners for them. If the reconciliation fails, it is
+// retried on the next run.
+func (r *basePoolManager) handleLeadershipChange() error {
+	if !r.isLeader() {
+		r.wasLeader = false
+		return nil
+	}
+	if r.wasLeader {
+		return nil
+	}
+
+	if r.leader != nil {
+		slog.InfoContext(r.ctx, "controller became leader; reconciling entity")
+		// The previous leader made changes this controller's watcher did not see.
+		if err := r.resyncState(); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to sync pool manager state")
+		}
+	}
+
+	if err := r.releaseStrandedJobs(); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(
+			r.ctx, "failed to release stranded jobs")
+	}
+
+	if err := r.backfillQueuedJobs(); err != nil {
+		return fmt.Errorf("failed to backfill queued jobs: %w", err)
+	}
+	r.wasLeader = true
+	return nil
+}
+
+// releaseStrandedJobs unlocks queued jobs that are locked by this entity, but for which
+// no runner was created. Jobs are locked before the runner is added to the database, so
+// a controller that stops in between leaves the job locked until the lock times out.
+func (r *basePoolManager) releaseStrandedJobs() error {
+	queued, err := r.store.ListEntityJobsByStatus(r.ctx, r.entity.EntityType, r.entity.ID, params.JobStatusQueued)
+	if err != nil {
+		return errors.Wrap(err, "listing queued jobs")
+	}
+
+	instances, err := r.store.ListEntityInstances(r.ctx, r.entity)
+	if err != nil {
+		return errors.Wrap(err, "listing instances")
+	}
+
+	jobsWithRunners := map[int64]struct{}{}
+	for _, instance := range instances {
+		if jobID := jobIDFromLabels(instance.AditionalLabels); jobID != 0 {
+			jobsWithRunners[jobID] = struct{}{}
+		}
+	}
+
+	for _, job := range queued {
+		if job.LockedBy.String() != r.ID() {
+			continue
+		}
+		if _, ok := jobsWithRunners[job.ID]; ok {
+			continue
+		}
+		slog.InfoContext(
+			r.ctx, "unlocking job with no runner",
+			"job_id", job.ID)
+		if err := r.store.UnlockJob(r.ctx, job.ID, r.ID()); err != nil {
+			if errors.Is(err, runnerErrors.ErrNotFound) {
+				continue
+			}
+			return errors.Wrapf(err, "unlocking job %d", job.ID)
+		}
+	}
+	return nil
+}
+
+// refreshEntity reloads the entity from the database. The watcher only sees changes made
+// by this controller, so when multiple controllers share the database, changes made through
+// the API of another controller are picked up here.
+func (r *basePoolManager) refreshEntity() error {
+	var getter entityGetter
+	var err error
+	switch r.entity.EntityType {
+	case params.GithubEntityTypeRepository:
+		getter, err = r.store.GetRepositoryByID(r.ctx, r.entity.ID)
+	case params.GithubEntityTypeOrganization:
+		getter, err = r.store.GetOrganizationByID(r.ctx, r.entity.ID)
+	case params.GithubEntityTypeEnterprise:
+		getter, err = r.store.GetEnterpriseByID(r.ctx, r.entity.ID)
+	default:
+		return fmt.Errorf("unknown entity type %s", r.entity.EntityType)
+	}
+	if err != nil {
+		if errors.Is(err, runnerErrors.ErrNotFound) {
+			// The entity was removed. The pool manager will be stopped.
+			return nil
+		}
+		return errors.Wrap(err, "fetching entity")
+	}
+
+	entity, err := getter.GetEntity()
+	if err != nil {
+		return errors.Wrap(err, "getting entity")
+	}
+
+	r.mux.Lock()
+	current := r.entity
+	r.mux.Unlock()
+
+	if reflect.DeepEqual(current, entity) {
+		return nil
+	}
+
+	r.handleEntityUpdate(entity)
+	if current.Credentials.ID == entity.Credentials.ID && !reflect.DeepEqual(current.Credentials, entity.Credentials) {
+		r.handleCredentialsUpdate(entity.Credentials)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+// notify publishes a notification event through the watcher. The notification
+// dispatcher picks it up and delivers it to the sinks that match it.
+func (r *basePoolManager) notify(event params.NotificationEvent) {
+	if r.producer == nil {
+		return
+	}
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	payload := dbCommon.ChangePayload{
+		EntityType: dbCommon.NotificationEventEntityType,
+		Operation:  dbCommon.CreateOperation,
+		Payload:    event,
+	}
+	if err := r.producer.Notify(payload); err != nil {
+		slog.With(slog.Any("error", err)).WarnContext(
+			r.ctx, "failed to send notification event",
+			"event_type", event.Type)
+	}
+}
+
+// notifyEntity sends a notification event about the entity of this pool manager,
+// or about one of its pools.
+func (r *basePoolManager) notifyEntity(eventType params.NotificationEventType, level params.EventLevel, poolID, message string) {
+	r.notify(params.NotificationEvent{
+		Type:       eventType,
+		Level:      level,
+		Message:    message,
+		EntityType: r.entity.EntityType,
+		EntityID:   r.entity.ID,
+		EntityName: r.entity.String(),
+		PoolID:     poolID,
+	})
+}
+
+// notifyProvider sends a notification event about a provider. Providers are shared by
+// all entities, so these events are not about any one entity.
+func (r *basePoolManager) notifyProvider(eventType params.NotificationEventType, level params.EventLevel, providerName, message string) {
+	r.notify(params.NotificationEvent{
+		Type:         eventType,
+		Level:        level,
+		Message:      message,
+		ProviderName: providerName,
+	})
+}
+
+// notifyRunningStateChange sends a notification when the pool manager stops because
+// of an error, or starts again after an error.
+func (r *basePoolManager) notifyRunningStateChange(wasRunning bool, previousReason string, isRunning bool, reason string) {
+	switch {
+	case wasRunning && !isRunning && reason != "":
+		r.notifyEntity(
+			params.PoolManagerStoppedEvent, params.EventError, "",
+			fmt.Sprintf("pool manager for %s stopped: %s", r.entity.String(), reason))
+	case !wasRunning && isRunning && previousReason != "":
+		r.notifyEntity(
+			params.PoolManagerRecoveredEvent, params.EventInfo, "",
+			fmt.Sprintf("pool manager for %s is running again", r.entity.String()))
+	}
+}
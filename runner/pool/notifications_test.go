@@ -0,0 +1,81 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/database/common/mocks"
+	"github.com/cloudbase/garm/params"
+)
+
+// recordingProducer keeps the notification events sent by a pool manager.
+type recordingProducer struct {
+	events []params.NotificationEvent
+}
+
+func (p *recordingProducer) Notify(payload dbCommon.ChangePayload) error {
+	if event, ok := payload.Payload.(params.NotificationEvent); ok {
+		p.events = append(p.events, event)
+	}
+	return nil
+}
+
+func (p *recordingProducer) IsClosed() bool {
+	return false
+}
+
+func (p *recordingProducer) Close() {}
+
+func (p *recordingProducer) types() []params.NotificationEventType {
+	ret := make([]params.NotificationEventType, len(p.events))
+	for idx, event := range p.events {
+		ret[idx] = event.Type
+	}
+	return ret
+}
+
+func TestSetPoolRunningStateNotifiesTransitions(t *testing.T) {
+	producer := &recordingProducer{}
+	r := &basePoolManager{
+		ctx:      context.Background(),
+		entity:   params.GithubEntity{ID: "entity", EntityType: params.GithubEntityTypeRepository},
+		producer: producer,
+	}
+
+	// Starting the pool manager for the first time is not a recovery.
+	r.setPoolRunningState(true, "")
+	r.setPoolRunningState(false, "bad credentials")
+	// Repeated failures while stopped do not send more notifications.
+	r.setPoolRunningState(false, "bad credentials")
+	r.setPoolRunningState(true, "")
+
+	expected := []params.NotificationEventType{params.PoolManagerStoppedEvent, params.PoolManagerRecoveredEvent}
+	if got := producer.types(); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Fatalf("expected events %v, got %v", expected, got)
+	}
+	if producer.events[0].Level != params.EventError || producer.events[0].EntityID != "entity" {
+		t.Fatalf("unexpected stopped event: %+v", producer.events[0])
+	}
+}
+
+func TestRecordBootstrapFailureNotifiesQuarantine(t *testing.T) {
+	store := &mocks.Store{}
+	producer := &recordingProducer{}
+	r := newQuarantineTestManager(store)
+	r.producer = producer
+	pool := params.Pool{ID: "pool", Enabled: true, QuarantineThreshold: 1}
+	store.On("QuarantineEntityPool", mock.Anything, r.entity, pool.ID, mock.Anything).Return(params.Pool{}, nil).Once()
+
+	r.recordBootstrapFailure(pool, fmt.Errorf("boom"))
+
+	if len(producer.events) != 1 || producer.events[0].Type != params.PoolQuarantinedEvent {
+		t.Fatalf("expected a pool quarantined event, got %v", producer.types())
+	}
+	if producer.events[0].PoolID != pool.ID {
+		t.Fatalf("expected event for pool %s, got %s", pool.ID, producer.events[0].PoolID)
+	}
+}
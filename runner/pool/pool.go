@@ -21,6 +21,7 @@ import (
 	"math"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -40,6 +41,7 @@ import (
 	"github.com/cloudbase/garm/params"
 	"github.com/cloudbase/garm/runner/common"
 	garmUtil "github.com/cloudbase/garm/util"
+	"github.com/cloudbase/garm/util/appdefaults"
 )
 
 var (
@@ -63,7 +65,7 @@ const (
 	maxCreateAttempts = 5
 )
 
-func NewEntityPoolManager(ctx context.Context, entity params.GithubEntity, instanceTokenGetter auth.InstanceTokenGetter, providers map[string]common.Provider, store dbCommon.Store) (common.PoolManager, error) {
+func NewEntityPoolManager(ctx context.Context, entity params.GithubEntity, instanceTokenGetter auth.InstanceTokenGetter, providers map[string]common.Provider, store dbCommon.Store, leader common.LeaderElector) (common.PoolManager, error) {
 	ctx = garmUtil.WithContext(ctx, slog.Any("pool_mgr", entity.String()), slog.Any("pool_type", entity.EntityType))
 	ghc, err := garmUtil.GithubClient(ctx, entity, entity.Credentials)
 	if err != nil {
@@ -79,15 +81,22 @@ func NewEntityPoolManager(ctx context.Context, entity params.GithubEntity, insta
 		return nil, errors.Wrap(err, "getting controller info")
 	}
 
+	state := newPoolManagerState()
 	consumerID := fmt.Sprintf("pool-manager-%s", entity.String())
 	consumer, err := watcher.RegisterConsumer(
 		ctx, consumerID,
-		composeWatcherFilters(entity),
+		composeWatcherFilters(entity, state),
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "registering consumer")
 	}
 
+	producer, err := watcher.RegisterProducer(ctx, fmt.Sprintf("pool-manager-events-%s", entity.String()))
+	if err != nil {
+		consumer.Close()
+		return nil, errors.Wrap(err, "registering producer")
+	}
+
 	wg := &sync.WaitGroup{}
 	keyMuxes := &keyMutex{}
 
@@ -104,6 +113,13 @@ func NewEntityPoolManager(ctx context.Context, entity params.GithubEntity, insta
 		wg:        wg,
 		keyMux:    keyMuxes,
 		consumer:  consumer,
+		producer:  producer,
+		leader:    leader,
+		state:     state,
+
+		poolBreaker:       newCircuitBreaker(common.PoolCircuitBreakerThreshold, 1),
+		bootstrapFailures: newBootstrapFailureCounter(),
+		weightedPools:     newWeightedBalancers(),
 	}
 	return repo, nil
 }
@@ -115,6 +131,8 @@ type basePoolManager struct {
 	controllerInfo      params.ControllerInfo
 	instanceTokenGetter auth.InstanceTokenGetter
 	consumer            dbCommon.Consumer
+	// producer publishes notification events about the pools of this entity.
+	producer dbCommon.Producer
 
 	store dbCommon.Store
 
@@ -125,6 +143,20 @@ type basePoolManager struct {
 	managerIsRunning   bool
 	managerErrorReason string
 
+	// leader is nil if high availability is not enabled.
+	leader    common.LeaderElector
+	wasLeader bool
+
+	// state is the in-memory view of the pools, runners and queued jobs of the entity.
+	state *poolManagerState
+	// poolBreaker tracks consecutive failures to create runners in each pool.
+	poolBreaker *circuitBreaker
+	// bootstrapFailures tracks consecutive failures to bootstrap runners in each pool.
+	bootstrapFailures *bootstrapFailureCounter
+	// weightedPools keeps the state of the weighted pool balancer between runs of
+	// consumeQueuedJobs().
+	weightedPools *weightedBalancers
+
 	mux    sync.Mutex
 	wg     *sync.WaitGroup
 	keyMux *keyMutex
@@ -479,6 +511,17 @@ func (r *basePoolManager) reapTimedOutRunners(runners []*github.Runner) error {
 		}
 		defer r.keyMux.Unlock(instance.Name, false)
 
+		if isHibernatedRunner(instance) {
+			// The runner is offline in github because we stopped its instance.
+			continue
+		}
+
+		if instance.Status == commonParams.InstancePendingCreate {
+			// The instance is waiting for the pool or its provider to allow new instances
+			// to be created. The bootstrap timeout only applies once the instance is created.
+			continue
+		}
+
 		pool, err := r.store.GetEntityPool(r.ctx, r.entity, instance.PoolID)
 		if err != nil {
 			return errors.Wrap(err, "fetching instance pool info")
@@ -502,6 +545,21 @@ func (r *basePoolManager) reapTimedOutRunners(runners []*github.Runner) error {
 					"runner_name", instance.Name)
 				return errors.Wrap(err, "updating runner")
 			}
+			if instance.RunnerStatus != params.RunnerIdle && instance.RunnerStatus != params.RunnerActive {
+				// The runner never came online.
+				r.notify(params.NotificationEvent{
+					Type:         params.RunnerBootstrapTimeoutEvent,
+					Level:        params.EventWarning,
+					Message:      fmt.Sprintf("runner %s did not come online within %d minutes", instance.Name, pool.RunnerTimeout()),
+					EntityType:   r.entity.EntityType,
+					EntityID:     r.entity.ID,
+					EntityName:   r.entity.String(),
+					PoolID:       pool.ID,
+					ProviderName: pool.ProviderName,
+					InstanceName: instance.Name,
+				})
+				r.recordBootstrapFailure(pool, fmt.Errorf("runner %s timed out while bootstrapping", instance.Name))
+			}
 		}
 	}
 	return nil
@@ -549,6 +607,14 @@ func (r *basePoolManager) cleanupOrphanedGithubRunners(runners []*github.Runner)
 			continue
 		}
 
+		if isHibernatedRunner(dbInstance) {
+			// We stopped this instance on scale down. It will be started when needed.
+			slog.DebugContext(
+				r.ctx, "runner is hibernated, skipping check",
+				"runner_name", dbInstance.Name)
+			continue
+		}
+
 		switch dbInstance.Status {
 		case commonParams.InstancePendingDelete, commonParams.InstanceDeleting:
 			// already marked for deletion or is in the process of being deleted.
@@ -757,6 +823,7 @@ func (r *basePoolManager) AddRunner(ctx context.Context, poolID string, aditiona
 	if err != nil {
 		return errors.Wrap(err, "creating instance")
 	}
+	r.state.setInstance(instance)
 
 	defer func() {
 		if err != nil {
@@ -788,6 +855,7 @@ func (r *basePoolManager) Status() params.PoolManagerStatus {
 	return params.PoolManagerStatus{
 		IsRunning:     r.managerIsRunning,
 		FailureReason: r.managerErrorReason,
+		DegradedPools: r.degradedPools(),
 	}
 }
 
@@ -803,9 +871,12 @@ func (r *basePoolManager) waitForTimeoutOrCancelled(timeout time.Duration) {
 
 func (r *basePoolManager) setPoolRunningState(isRunning bool, failureReason string) {
 	r.mux.Lock()
+	wasRunning, previousReason := r.managerIsRunning, r.managerErrorReason
 	r.managerErrorReason = failureReason
 	r.managerIsRunning = isRunning
 	r.mux.Unlock()
+
+	r.notifyRunningStateChange(wasRunning, previousReason, isRunning, failureReason)
 }
 
 func (r *basePoolManager) getLabelsForInstance(pool params.Pool) []string {
@@ -880,15 +951,19 @@ func (r *basePoolManager) addInstanceToProvider(instance params.Instance) error
 
 	providerInstance, err := provider.CreateInstance(r.ctx, bootstrapArgs)
 	if err != nil {
+		r.recordProviderResult(pool, err)
 		instanceIDToDelete = instance.Name
 		return errors.Wrap(err, "creating instance")
 	}
 
 	if providerInstance.Status == commonParams.InstanceError {
+		r.recordProviderResult(pool, fmt.Errorf("provider returned instance in error state: %s", providerInstance.ProviderFault))
 		instanceIDToDelete = instance.ProviderID
 		if instanceIDToDelete == "" {
 			instanceIDToDelete = instance.Name
 		}
+	} else {
+		r.recordProviderResult(pool, nil)
 	}
 
 	updateInstanceArgs := r.updateArgsFromProviderInstance(providerInstance)
@@ -926,6 +1001,7 @@ func (r *basePoolManager) paramsWorkflowJobToParamsJob(job params.WorkflowJob) (
 		StartedAt:       job.WorkflowJob.StartedAt,
 		CompletedAt:     job.WorkflowJob.CompletedAt,
 		Name:            job.WorkflowJob.Name,
+		WorkflowName:    job.WorkflowJob.WorkflowName,
 		GithubRunnerID:  job.WorkflowJob.RunnerID,
 		RunnerName:      job.WorkflowJob.RunnerName,
 		RunnerGroupID:   job.WorkflowJob.RunnerGroupID,
@@ -984,34 +1060,62 @@ func (r *basePoolManager) scaleDownOnePool(ctx context.Context, pool params.Pool
 		return fmt.Errorf("failed to ensure minimum idle workers for pool %s: %w", pool.ID, err)
 	}
 
+	policy := pool.ScaleDownPolicy
+	idleGracePeriod := policy.GetIdleGracePeriod()
+	minRunnerAge := policy.GetMinRunnerAge()
+
 	idleWorkers := []params.Instance{}
+	hibernatedWorkers := []params.Instance{}
 	for _, inst := range existingInstances {
-		// Idle runners that have been spawned and are still idle after 5 minutes, are take into
-		// consideration for scale-down. The 5 minute grace period prevents a situation where a
+		if isHibernatedRunner(inst) {
+			hibernatedWorkers = append(hibernatedWorkers, inst)
+			continue
+		}
+		// Idle runners that have been spawned and are still idle after the grace period, are taken
+		// into consideration for scale-down. The grace period prevents a situation where a
 		// "queued" workflow triggers the creation of a new idle runner, and this routine reaps
 		// an idle runner before they have a chance to pick up a job.
-		if inst.RunnerStatus == params.RunnerIdle && inst.Status == commonParams.InstanceRunning && time.Since(inst.UpdatedAt).Minutes() > 2 {
+		if inst.RunnerStatus != params.RunnerIdle || inst.Status != commonParams.InstanceRunning {
+			continue
+		}
+		if time.Since(inst.UpdatedAt) > idleGracePeriod && time.Since(inst.CreatedAt) >= minRunnerAge {
 			idleWorkers = append(idleWorkers, inst)
 		}
 	}
 
+	hibernate := policy.GetMode() == params.ScaleDownModeHibernate
+	if !hibernate && len(hibernatedWorkers) > 0 {
+		// The pool no longer hibernates idle runners. Remove the ones that were stopped
+		// while it did.
+		if err := r.deleteHibernatedRunners(hibernatedWorkers); err != nil {
+			return fmt.Errorf("failed to remove hibernated runners of pool %s: %w", pool.ID, err)
+		}
+	}
+
 	if len(idleWorkers) == 0 {
 		return nil
 	}
 
-	surplus := float64(len(idleWorkers) - int(pool.MinIdleRunners))
+	surplus := float64(len(idleWorkers) - int(pool.MinIdleRunnersAt(time.Now())))
 
 	if surplus <= 0 {
 		return nil
 	}
 
-	scaleDownFactor := 0.5 // could be configurable
+	scaleDownFactor := policy.GetFactor()
 	numScaleDown := int(math.Ceil(surplus * scaleDownFactor))
 
 	if numScaleDown <= 0 || numScaleDown > len(idleWorkers) {
 		return fmt.Errorf("invalid number of instances to scale down: %v, check your scaleDownFactor: %v", numScaleDown, scaleDownFactor)
 	}
 
+	sort.SliceStable(idleWorkers, func(i, j int) bool {
+		if policy.GetOrder() == params.ScaleDownOrderNewestFirst {
+			return idleWorkers[i].CreatedAt.After(idleWorkers[j].CreatedAt)
+		}
+		return idleWorkers[i].CreatedAt.Before(idleWorkers[j].CreatedAt)
+	})
+
 	g, _ := errgroup.WithContext(ctx)
 
 	for _, instanceToDelete := range idleWorkers[:numScaleDown] {
@@ -1027,6 +1131,16 @@ func (r *basePoolManager) scaleDownOnePool(ctx context.Context, pool params.Pool
 		defer r.keyMux.Unlock(instanceToDelete.Name, false)
 
 		g.Go(func() error {
+			if hibernate {
+				slog.InfoContext(
+					ctx, "hibernating idle worker from pool",
+					"runner_name", instanceToDelete.Name,
+					"pool_id", pool.ID)
+				if err := r.hibernateRunner(pool, instanceToDelete); err != nil {
+					return fmt.Errorf("failed to hibernate instance %s: %w", instanceToDelete.ID, err)
+				}
+				return nil
+			}
 			slog.InfoContext(
 				ctx, "scaling down idle worker from pool",
 				"runner_name", instanceToDelete.Name,
@@ -1042,7 +1156,7 @@ func (r *basePoolManager) scaleDownOnePool(ctx context.Context, pool params.Pool
 		// We just scaled down a runner for this pool. That means that if we have jobs that are
 		// still queued in our DB, and those jobs should match this pool but have not been picked
 		// up by a runner, they are most likely stale and can be removed. For now, we can simply
-		// remove jobs older than 10 minutes.
+		// remove jobs older than DefaultStaleJobTimeout.
 		//
 		// nolint:golangci-lint,godox
 		// TODO: should probably allow aditional filters to list functions. Would help to filter by date
@@ -1053,7 +1167,7 @@ func (r *basePoolManager) scaleDownOnePool(ctx context.Context, pool params.Pool
 		}
 
 		for _, job := range queued {
-			if time.Since(job.CreatedAt).Minutes() > 10 && pool.HasRequiredLabels(job.Labels) {
+			if time.Since(job.CreatedAt) > appdefaults.DefaultStaleJobTimeout && pool.HasRequiredLabels(job.Labels) {
 				if err := r.store.DeleteJob(ctx, job.ID); err != nil && !errors.Is(err, runnerErrors.ErrNotFound) {
 					slog.With(slog.Any("error", err)).ErrorContext(
 						ctx, "failed to delete job",
@@ -1074,13 +1188,27 @@ func (r *basePoolManager) addRunnerToPool(pool params.Pool, aditionalLabels []st
 		return fmt.Errorf("pool %s is disabled", pool.ID)
 	}
 
-	poolInstanceCount, err := r.store.PoolInstanceCount(r.ctx, pool.ID)
+	if r.poolDegraded(pool) {
+		return fmt.Errorf("pool %s is degraded", pool.ID)
+	}
+
+	poolInstances, err := r.poolInstances(pool.ID)
 	if err != nil {
 		return fmt.Errorf("failed to list pool instances: %w", err)
 	}
 
-	if poolInstanceCount >= int64(pool.MaxRunners) {
-		return fmt.Errorf("max workers (%d) reached for pool %s", pool.MaxRunners, pool.ID)
+	maxRunners := pool.MaxRunnersAt(time.Now())
+	if uint(len(poolInstances)) >= maxRunners {
+		return fmt.Errorf("max workers (%d) reached for pool %s", maxRunners, pool.ID)
+	}
+
+	quota, err := r.runnerQuota()
+	if err != nil {
+		return fmt.Errorf("failed to get entity runner quota: %w", err)
+	}
+	if quota.available <= 0 {
+		r.recordQuotaReached(quota, "pool_id", pool.ID)
+		return fmt.Errorf("entity limit %s (%d) reached", quota.limit, quota.value)
 	}
 
 	if err := r.AddRunner(r.ctx, pool.ID, aditionalLabels); err != nil {
@@ -1090,43 +1218,84 @@ func (r *basePoolManager) addRunnerToPool(pool params.Pool, aditionalLabels []st
 }
 
 func (r *basePoolManager) ensureIdleRunnersForOnePool(pool params.Pool) error {
-	if !pool.Enabled || pool.MinIdleRunners == 0 {
-		return nil
-	}
+	// Scaling schedules may override the limits set on the pool, depending on the
+	// time of day.
+	now := time.Now()
+	minIdleRunners := pool.MinIdleRunnersAt(now)
+	maxRunners := pool.MaxRunnersAt(now)
 
-	existingInstances, err := r.store.ListPoolInstances(r.ctx, pool.ID)
-	if err != nil {
-		return fmt.Errorf("failed to ensure minimum idle workers for pool %s: %w", pool.ID, err)
+	if !pool.Enabled || minIdleRunners == 0 {
+		return nil
 	}
 
-	if uint(len(existingInstances)) >= pool.MaxRunners {
+	if r.poolDegraded(pool) {
 		slog.DebugContext(
-			r.ctx, "max workers reached for pool, skipping idle worker creation",
-			"max_runners", pool.MaxRunners,
+			r.ctx, "pool is degraded, skipping idle worker creation",
 			"pool_id", pool.ID)
 		return nil
 	}
 
+	existingInstances, err := r.poolInstances(pool.ID)
+	if err != nil {
+		return fmt.Errorf("failed to ensure minimum idle workers for pool %s: %w", pool.ID, err)
+	}
+
 	idleOrPendingWorkers := []params.Instance{}
 	for _, inst := range existingInstances {
+		if isHibernatedRunner(inst) {
+			continue
+		}
+		if _, expired := runnerExpiryReason(pool, inst, now); expired {
+			// Expired runners are replaced before being recycled.
+			continue
+		}
 		if inst.RunnerStatus != params.RunnerActive && inst.RunnerStatus != params.RunnerTerminated {
 			idleOrPendingWorkers = append(idleOrPendingWorkers, inst)
 		}
 	}
 
+	// Hibernated runners already count towards max runners. Start them before
+	// creating new ones.
+	missing := int(minIdleRunners) - len(idleOrPendingWorkers)
+	for ; missing > 0; missing-- {
+		if _, ok := r.wakeHibernatedRunner([]params.Pool{pool}); !ok {
+			break
+		}
+	}
+
+	if uint(len(existingInstances)) >= maxRunners {
+		slog.DebugContext(
+			r.ctx, "max workers reached for pool, skipping idle worker creation",
+			"max_runners", maxRunners,
+			"pool_id", pool.ID)
+		return nil
+	}
+
 	var required int
-	if len(idleOrPendingWorkers) < int(pool.MinIdleRunners) {
+	if missing > 0 {
 		// get the needed delta.
-		required = int(pool.MinIdleRunners) - len(idleOrPendingWorkers)
+		required = missing
 
 		projectedInstanceCount := len(existingInstances) + required
-		if uint(projectedInstanceCount) > pool.MaxRunners {
+		if uint(projectedInstanceCount) > maxRunners {
 			// ensure we don't go above max workers
-			delta := projectedInstanceCount - int(pool.MaxRunners)
+			delta := projectedInstanceCount - int(maxRunners)
 			required -= delta
 		}
 	}
 
+	if required > 0 {
+		// Pools of the same entity share the entity wide limits, if any are set.
+		quota, err := r.runnerQuota()
+		if err != nil {
+			return fmt.Errorf("failed to ensure minimum idle workers for pool %s: %w", pool.ID, err)
+		}
+		if required > quota.available {
+			r.recordQuotaReached(quota, "pool_id", pool.ID)
+			required = quota.available
+		}
+	}
+
 	for i := 0; i < required; i++ {
 		slog.InfoContext(
 			r.ctx, "adding new idle worker to pool",
@@ -1139,7 +1308,7 @@ func (r *basePoolManager) ensureIdleRunnersForOnePool(pool params.Pool) error {
 }
 
 func (r *basePoolManager) retryFailedInstancesForOnePool(ctx context.Context, pool params.Pool) error {
-	if !pool.Enabled {
+	if !pool.Enabled || r.poolDegraded(pool) {
 		return nil
 	}
 	slog.DebugContext(
@@ -1414,12 +1583,37 @@ func (r *basePoolManager) addPendingInstances() error {
 	if err != nil {
 		return fmt.Errorf("failed to fetch instances from store: %w", err)
 	}
+	// Instances that exceed the creation limits stay pending. Create the ones that
+	// were requested first.
+	sort.SliceStable(instances, func(i, j int) bool {
+		return instances[i].CreatedAt.Before(instances[j].CreatedAt)
+	})
 	for _, instance := range instances {
 		if instance.Status != commonParams.InstancePendingCreate {
 			// not in pending_create status. Skip.
 			continue
 		}
 
+		pool, hasPool := r.state.getPool(instance.PoolID)
+		if hasPool && pool.IsQuarantined() {
+			// The instance will be created once the pool is released from quarantine.
+			slog.DebugContext(
+				r.ctx, "pool is quarantined, not creating instance",
+				"runner_name", instance.Name,
+				"pool_id", instance.PoolID)
+			continue
+		}
+
+		if hasPool && r.poolDegraded(pool) {
+			// The pool or its provider is degraded. The instance will be created once
+			// the provider recovers.
+			slog.DebugContext(
+				r.ctx, "pool is degraded, not creating instance",
+				"runner_name", instance.Name,
+				"pool_id", instance.PoolID)
+			continue
+		}
+
 		slog.DebugContext(
 			r.ctx, "attempting to acquire lock for instance",
 			"runner_name", instance.Name,
@@ -1432,12 +1626,38 @@ func (r *basePoolManager) addPendingInstances() error {
 			continue
 		}
 
+		var limits *creationLimits
+		var creationStarted time.Time
+		if hasPool {
+			poolLimits := r.instanceCreationLimits(pool)
+			started, ok := instanceCreationLimiter.acquire(poolLimits)
+			if !ok {
+				slog.DebugContext(
+					r.ctx, "instance creation limit reached; instance stays pending",
+					"runner_name", instance.Name,
+					"pool_id", instance.PoolID)
+				r.keyMux.Unlock(instance.Name, false)
+				continue
+			}
+			limits = &poolLimits
+			creationStarted = started
+
+			if !r.allowProviderCall(pool) {
+				instanceCreationLimiter.cancel(poolLimits, creationStarted)
+				r.keyMux.Unlock(instance.Name, false)
+				continue
+			}
+		}
+
 		// Set the instance to "creating" before launching the goroutine. This will ensure that addPendingInstances()
 		// won't attempt to create the runner a second time.
 		if _, err := r.setInstanceStatus(instance.Name, commonParams.InstanceCreating, nil); err != nil {
 			slog.With(slog.Any("error", err)).ErrorContext(
 				r.ctx, "failed to update runner status",
 				"runner_name", instance.Name)
+			if limits != nil {
+				instanceCreationLimiter.cancel(*limits, creationStarted)
+			}
 			r.keyMux.Unlock(instance.Name, false)
 			// We failed to transition the instance to Creating. This means that garm will retry to create this instance
 			// when the loop runs again and we end up with multiple instances.
@@ -1446,6 +1666,9 @@ func (r *basePoolManager) addPendingInstances() error {
 
 		go func(instance params.Instance) {
 			defer r.keyMux.Unlock(instance.Name, false)
+			if limits != nil {
+				defer instanceCreationLimiter.release(*limits)
+			}
 			slog.InfoContext(
 				r.ctx, "creating instance in pool",
 				"runner_name", instance.Name,
@@ -1535,21 +1758,42 @@ func (r *basePoolManager) Start() error {
 		case <-initialToolUpdate:
 		}
 		defer close(initialToolUpdate)
-		go r.startLoopForFunction(r.runnerCleanup, common.PoolReapTimeoutInterval, "timeout_reaper", false)
-		go r.startLoopForFunction(r.scaleDown, common.PoolScaleDownInterval, "scale_down", false)
+		if err := r.resyncState(); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(r.ctx, "failed to sync pool manager state")
+		}
+		stateResyncInterval := common.PoolStateResyncInterval
+		if r.leader != nil {
+			stateResyncInterval = common.EntityRefreshInterval
+		}
+		// Loops that create, delete or otherwise change runners only run on the leader. The tools
+		// are kept up to date on all controllers, as they also serve the API.
+		go r.startLoopForFunction(r.leaderOnly(r.runnerCleanup), common.PoolReapTimeoutInterval, "timeout_reaper", false)
+		go r.startLoopForFunction(r.leaderOnly(r.scaleDown), common.PoolScaleDownInterval, "scale_down", false)
+		go r.startLoopForFunction(r.leaderOnly(r.recycleExpiredRunners), common.PoolRecycleInterval, "recycle_expired_runners", false)
 		// always run the delete pending instances routine. This way we can still remove existing runners, even if the pool is not running.
-		go r.startLoopForFunction(r.deletePendingInstances, common.PoolConsilitationInterval, "consolidate[delete_pending]", true)
-		go r.startLoopForFunction(r.addPendingInstances, common.PoolConsilitationInterval, "consolidate[add_pending]", false)
-		go r.startLoopForFunction(r.ensureMinIdleRunners, common.PoolConsilitationInterval, "consolidate[ensure_min_idle]", false)
-		go r.startLoopForFunction(r.retryFailedInstances, common.PoolConsilitationInterval, "consolidate[retry_failed]", false)
+		go r.startLoopForFunction(r.leaderOnly(r.deletePendingInstances), common.PoolConsilitationInterval, "consolidate[delete_pending]", true)
+		go r.startLoopForFunction(r.leaderOnly(r.addPendingInstances), common.PoolConsilitationInterval, "consolidate[add_pending]", false)
+		go r.startLoopForFunction(r.leaderOnly(r.ensureMinIdleRunners), common.PoolConsilitationInterval, "consolidate[ensure_min_idle]", false)
+		go r.startLoopForFunction(r.leaderOnly(r.retryFailedInstances), common.PoolConsilitationInterval, "consolidate[retry_failed]", false)
 		go r.startLoopForFunction(r.updateTools, common.PoolToolUpdateInterval, "update_tools", true)
-		go r.startLoopForFunction(r.consumeQueuedJobs, common.PoolConsilitationInterval, "job_queue_consumer", false)
+		go r.startLoopForFunction(r.leaderOnly(r.consumeQueuedJobs), common.PoolConsilitationInterval, "job_queue_consumer", false)
+		go r.startLoopForFunction(r.handleLeadershipChange, common.PoolConsilitationInterval, "leadership", false)
+		go r.startLoopForFunction(r.resyncState, stateResyncInterval, "state_resync", true)
+		if r.leader != nil {
+			go r.startLoopForFunction(r.refreshEntity, common.EntityRefreshInterval, "entity_refresh", true)
+		}
 	}()
 	return nil
 }
 
 func (r *basePoolManager) Stop() error {
 	close(r.quit)
+	if r.consumer != nil {
+		r.consumer.Close()
+	}
+	if r.producer != nil {
+		r.producer.Close()
+	}
 	return nil
 }
 
@@ -1639,8 +1883,10 @@ func (r *basePoolManager) getMinimumJobAge() time.Duration {
 	return time.Duration(delay)
 }
 
-// consumeQueuedJobs will pull all the known jobs from the database and attempt to create a new
-// runner in one of the pools it manages, if it matches the requested labels.
+// consumeQueuedJobs will go through all the known queued jobs and attempt to create a new
+// runner in one of the pools it manages, if it matches the requested labels. Jobs, pools and runners
+// are looked up in the in-memory state of the pool manager (see poolManagerState), which is fed by
+// the database watcher.
 // This is a best effort attempt to consume queued jobs. We do not have any real way to know which
 // runner from which pool will pick up a job we react to here. For example, the same job may be received
 // by an enterprise manager, an org manager AND a repo manager. If an idle runner from another pool
@@ -1650,6 +1896,14 @@ func (r *basePoolManager) getMinimumJobAge() time.Duration {
 // picked up a job within a certain time frame. Also, the logic here should ensure that eventually, all known
 // queued jobs will be consumed sooner or later.
 //
+// Jobs updated less than MINIMUM_JOB_AGE seconds ago are only left for later if an idle or pending runner
+// that was not created for a particular job can pick them up. Each such runner is counted against at most
+// one job. If no such runner exists, a runner is created for the job right away, without waiting.
+// A job we locked stays locked as long as the runner we created or started for it is pending or idle. New
+// runners are added to the in-memory state as soon as they are created, so this is the case right after
+// the job is locked. Once that runner is gone, or picked up a different job, the job is unlocked and a new
+// runner is requested for it in the same run, no matter how recently the job was updated.
+//
 // NOTE: jobs that were created while the garm instance was down, will be unknown to garm itself and will linger
 // in queued state if the pools defined in garm have a minimum idle runner value set to 0. Simply put, garm won't
 // know about the queued jobs that we didn't get a webhook for. Listing all jobs on startup is not feasible, as
@@ -1657,19 +1911,29 @@ func (r *basePoolManager) getMinimumJobAge() time.Duration {
 // enterprise, we'd have to list all repos, and for each repo list all jobs currently in queued state. This is
 // not desirable by any measure.
 //
-// One way to handle situations where garm comes up after a longer period of time, is to temporarily max out the
+// Repositories and organizations can opt in to a job backfill on startup (see backfillQueuedJobs()). For organizations,
+// the repositories that get scanned must be listed explicitly or capped to a maximum number.
+//
+// Another way to handle situations where garm comes up after a longer period of time, is to temporarily max out the
 // min-idle-runner setting on pools, or at least raise it above 0. The idle runners will start to consume jobs, and
 // as they do so, new idle runners will be spun up in their stead. New jobs will record in the DB as they come in,
 // so those will trigger the creation of a runner. The jobs we don't know about will be dealt with by the idle runners.
 // Once jobs are consumed, you can set min-idle-runners to 0 again.
 func (r *basePoolManager) consumeQueuedJobs() error {
-	queued, err := r.store.ListEntityJobsByStatus(r.ctx, r.entity.EntityType, r.entity.ID, params.JobStatusQueued)
-	if err != nil {
-		return errors.Wrap(err, "listing queued jobs")
+	if err := r.ensureStateSynced(); err != nil {
+		return errors.Wrap(err, "syncing state")
+	}
+	queued := r.state.getQueuedJobs()
+
+	fairShare := r.fairShareScheduler(queued)
+	if fairShare != nil {
+		queued = fairShare.order(queued)
 	}
 
 	poolsCache := poolsForTags{
 		poolCacheType: r.entity.GetPoolBalancerType(),
+		freeCapacity:  r.poolFreeCapacity,
+		weighted:      r.weightedPools,
 	}
 
 	slog.DebugContext(
@@ -1677,6 +1941,9 @@ func (r *basePoolManager) consumeQueuedJobs() error {
 		"job_count", len(queued))
 
 	jobTimeout := r.getMinimumJobAge()
+	// Runners that are idle, or will soon be idle, and were not created for a particular
+	// job. Each such runner is counted against at most one queued job.
+	availableRunners := r.state.availableRunners()
 
 	for _, job := range queued {
 		if job.LockedBy != uuid.Nil && job.LockedBy.String() != r.ID() {
@@ -1688,10 +1955,17 @@ func (r *basePoolManager) consumeQueuedJobs() error {
 			continue
 		}
 
-		if time.Since(job.UpdatedAt) < time.Second * jobTimeout {
-			// give the idle runners a chance to pick up the job.
+		potentialPools := r.state.poolsMatchingLabels(job.Labels)
+		if len(potentialPools) == 0 {
+			slog.DebugContext(r.ctx, "could not find pools with labels", "requested_labels", strings.Join(job.Labels, ","))
+			continue
+		}
+
+		if time.Since(job.UpdatedAt) < time.Second*jobTimeout && claimAvailableRunner(availableRunners, potentialPools) {
+			// give the available runner a chance to pick up the job. Jobs no runner can
+			// pick up are handled right away.
 			slog.DebugContext(
-				r.ctx, "job was updated less than 30 seconds ago. Skipping",
+				r.ctx, "job was updated recently and a runner is available to pick it up. Skipping",
 				"job_id", job.ID)
 			continue
 		}
@@ -1701,8 +1975,6 @@ func (r *basePoolManager) consumeQueuedJobs() error {
 			// was spawned. Unlock it and try again. A different job may have picked up
 			// the runner.
 			if err := r.store.UnlockJob(r.ctx, job.ID, r.ID()); err != nil {
-				// nolint:golangci-lint,godox
-				// TODO: Implament a cache? Should we return here?
 				slog.With(slog.Any("error", err)).ErrorContext(
 					r.ctx, "failed to unlock job",
 					"job_id", job.ID)
@@ -1711,32 +1983,67 @@ func (r *basePoolManager) consumeQueuedJobs() error {
 		}
 
 		if job.LockedBy.String() == r.ID() {
-			// nolint:golangci-lint,godox
-			// Job is locked by us. We must have already attepted to create a runner for it. Skip.
-			// TODO(gabriel-samfira): create an in-memory state of existing runners that we can easily
-			// check for existing pending or idle runners. If we can't find any, attempt to allocate another
-			// runner.
-			slog.DebugContext(
-				r.ctx, "job is locked by us",
+			if r.state.hasRunnerForJob(job.ID) {
+				// Job is locked by us and the runner we created or started for it is still pending or idle.
+				slog.DebugContext(
+					r.ctx, "job is locked by us",
+					"job_id", job.ID)
+				continue
+			}
+			// The runner we created for this job is gone, or picked up a different job.
+			// Release the job and allocate another runner.
+			slog.InfoContext(
+				r.ctx, "no runner is available for job locked by us; unlocking",
 				"job_id", job.ID)
+			if err := r.store.UnlockJob(r.ctx, job.ID, r.ID()); err != nil {
+				slog.With(slog.Any("error", err)).ErrorContext(
+					r.ctx, "failed to unlock job",
+					"job_id", job.ID)
+				continue
+			}
+		}
+
+		if fairShare != nil && !fairShare.allow(job) {
+			slog.DebugContext(
+				r.ctx, "fair share group reached its runner limit; skipping job",
+				"job_id", job.ID,
+				"group", fairShare.settings.GroupName(job))
 			continue
 		}
 
-		poolRR, ok := poolsCache.Get(job.Labels)
-		if !ok {
-			potentialPools, err := r.store.FindPoolsMatchingAllTags(r.ctx, r.entity.EntityType, r.entity.ID, job.Labels)
-			if err != nil {
+		// Starting a hibernated runner is much faster than creating a new one, and does
+		// not count against the entity wide limits, as the runner already exists.
+		if instance, ok := r.wakeHibernatedRunner(potentialPools); ok {
+			slog.InfoContext(
+				r.ctx, "started a hibernated runner as a response to queued job",
+				"runner_name", instance.Name,
+				"job_id", job.ID)
+			if err := r.store.LockJob(r.ctx, job.ID, r.ID()); err != nil {
 				slog.With(slog.Any("error", err)).ErrorContext(
-					r.ctx, "error finding pools matching labels")
+					r.ctx, "could not lock job",
+					"job_id", job.ID)
 				continue
 			}
-			poolRR = poolsCache.Add(job.Labels, potentialPools)
+			r.state.reserveRunner(instance.ID, job.ID)
+			if fairShare != nil {
+				fairShare.add(job)
+			}
+			continue
 		}
 
-		if poolRR.Len() == 0 {
-			slog.DebugContext(r.ctx, "could not find pools with labels", "requested_labels", strings.Join(job.Labels, ","))
+		// Check the entity wide limits before locking the job. If a limit was reached, the job
+		// stays queued and unlocked, and will be retried in the next run.
+		quota, err := r.runnerQuota()
+		if err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to get entity runner quota",
+				"job_id", job.ID)
 			continue
 		}
+		if quota.available <= 0 {
+			r.recordQuotaReached(quota, "job_id", job.ID)
+			break
+		}
 
 		runnerCreated := false
 		if err := r.store.LockJob(r.ctx, job.ID, r.ID()); err != nil {
@@ -1749,6 +2056,12 @@ func (r *basePoolManager) consumeQueuedJobs() error {
 		jobLabels := []string{
 			fmt.Sprintf("%s%d", jobLabelPrefix, job.ID),
 		}
+		// Getting the balancer selects the pools to try for this job, so only do it once
+		// we know a runner is needed.
+		poolRR, ok := poolsCache.Get(job.Labels)
+		if !ok {
+			poolRR = poolsCache.Add(job.Labels, potentialPools)
+		}
 		for i := 0; i < poolRR.Len(); i++ {
 			pool, err := poolRR.Next()
 			if err != nil {
@@ -1772,6 +2085,9 @@ func (r *basePoolManager) consumeQueuedJobs() error {
 				"pool_id", pool.ID,
 				"job_id", job.ID)
 			runnerCreated = true
+			if fairShare != nil {
+				fairShare.add(job)
+			}
 			break
 		}
 
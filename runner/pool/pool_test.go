@@ -0,0 +1,145 @@
+package pool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/database/common/mocks"
+	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
+)
+
+const queuedJobsTestEntityID = "9f2f5b0e-6b8a-4c1e-9d53-2a3c4b5d6e7f"
+
+// newQueuedJobsTestManager returns a pool manager with a single pool of at most one
+// runner, that matches the "linux" label. Creating a runner fails if the pool is full.
+func newQueuedJobsTestManager(store *mocks.Store, instances []params.Instance, jobs []params.Job) *basePoolManager {
+	r := &basePoolManager{
+		ctx:           context.Background(),
+		entity:        params.GithubEntity{ID: queuedJobsTestEntityID, EntityType: params.GithubEntityTypeRepository},
+		store:         store,
+		state:         newPoolManagerState(),
+		poolBreaker:   newCircuitBreaker(common.PoolCircuitBreakerThreshold, 1),
+		weightedPools: newWeightedBalancers(),
+	}
+	pools := []params.Pool{{ID: "pool", Enabled: true, MaxRunners: 1, Tags: []params.Tag{{Name: "linux"}}}}
+	r.state.reset(pools, instances, jobs)
+	store.On("DeleteCompletedJobs", mock.Anything).Return(nil)
+	return r
+}
+
+func newQueuedJob(id int64, lockedBy string) params.Job {
+	job := params.Job{
+		ID:        id,
+		Status:    string(params.JobStatusQueued),
+		Labels:    []string{"linux"},
+		UpdatedAt: time.Now(),
+	}
+	if lockedBy != "" {
+		job.LockedBy = uuid.MustParse(lockedBy)
+	}
+	return job
+}
+
+func TestConsumeQueuedJobsWaitsForAvailableRunner(t *testing.T) {
+	store := &mocks.Store{}
+	idle := params.Instance{ID: "idle", PoolID: "pool", Status: commonParams.InstanceRunning, RunnerStatus: params.RunnerIdle}
+	r := newQueuedJobsTestManager(store, []params.Instance{idle}, []params.Job{newQueuedJob(1, "")})
+
+	if err := r.consumeQueuedJobs(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	store.AssertNotCalled(t, "LockJob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConsumeQueuedJobsWithoutAvailableRunner(t *testing.T) {
+	store := &mocks.Store{}
+	active := params.Instance{ID: "active", PoolID: "pool", Status: commonParams.InstanceRunning, RunnerStatus: params.RunnerActive}
+	r := newQueuedJobsTestManager(store, []params.Instance{active}, []params.Job{newQueuedJob(1, "")})
+	store.On("LockJob", mock.Anything, int64(1), r.ID()).Return(nil).Once()
+	store.On("UnlockJob", mock.Anything, int64(1), r.ID()).Return(nil).Once()
+
+	if err := r.consumeQueuedJobs(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The job was updated recently, but no runner can pick it up, so we do not wait.
+	// The pool is full, so the runner can not be created and the job is unlocked.
+	store.AssertExpectations(t)
+}
+
+func TestConsumeQueuedJobsLockedJobWithRunner(t *testing.T) {
+	store := &mocks.Store{}
+	forJob := params.Instance{
+		ID: "for-job", PoolID: "pool", Status: commonParams.InstancePendingCreate, RunnerStatus: params.RunnerPending,
+		AditionalLabels: []string{jobLabelPrefix + "1"},
+	}
+	r := newQueuedJobsTestManager(store, []params.Instance{forJob}, []params.Job{newQueuedJob(1, queuedJobsTestEntityID)})
+
+	if err := r.consumeQueuedJobs(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	store.AssertNotCalled(t, "UnlockJob", mock.Anything, mock.Anything, mock.Anything)
+	store.AssertNotCalled(t, "LockJob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConsumeQueuedJobsLockedJobWithoutRunner(t *testing.T) {
+	store := &mocks.Store{}
+	active := params.Instance{ID: "active", PoolID: "pool", Status: commonParams.InstanceRunning, RunnerStatus: params.RunnerActive}
+	r := newQueuedJobsTestManager(store, []params.Instance{active}, []params.Job{newQueuedJob(1, queuedJobsTestEntityID)})
+	store.On("UnlockJob", mock.Anything, int64(1), r.ID()).Return(nil).Twice()
+	store.On("LockJob", mock.Anything, int64(1), r.ID()).Return(nil).Once()
+
+	if err := r.consumeQueuedJobs(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The runner created for the job is gone, so the job is released right away and
+	// another runner is requested for it.
+	store.AssertExpectations(t)
+}
+
+func TestConsumeQueuedJobsWeightedAcrossRuns(t *testing.T) {
+	store := &mocks.Store{}
+	job := newQueuedJob(1, "")
+	// Old enough to not wait for a runner, recent enough to not be unlocked first.
+	job.UpdatedAt = time.Now().Add(-5 * time.Minute)
+	r := newQueuedJobsTestManager(store, nil, nil)
+	r.entity.PoolBalancerType = params.PoolBalancerTypeWeighted
+	pools := []params.Pool{
+		{ID: "heavy", Enabled: true, MaxRunners: 10, Weight: 3, Tags: []params.Tag{{Name: "linux"}}},
+		{ID: "light", Enabled: true, MaxRunners: 10, Weight: 1, Tags: []params.Tag{{Name: "linux"}}},
+	}
+	r.state.reset(pools, nil, []params.Job{job})
+
+	// Creating the runner fails, so the job is handled again on every run, and each
+	// run tries both pools. The first pool tried is the one selected by the balancer.
+	var attempts []string
+	store.On("LockJob", mock.Anything, int64(1), r.ID()).Return(nil)
+	store.On("UnlockJob", mock.Anything, int64(1), r.ID()).Return(nil)
+	store.On("GetEntityPool", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		attempts = append(attempts, args.String(2))
+	}).Return(params.Pool{ProviderName: "unknown"}, nil)
+
+	counts := map[string]int{}
+	for i := 0; i < 8; i++ {
+		attempts = nil
+		if err := r.consumeQueuedJobs(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(attempts) != 2 {
+			t.Fatalf("expected both pools to be tried, got %v", attempts)
+		}
+		counts[attempts[0]]++
+	}
+	if counts["heavy"] != 6 || counts["light"] != 2 {
+		t.Fatalf("expected 6/2 distribution, got %d/%d", counts["heavy"], counts["light"])
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/cloudbase/garm/params"
+)
+
+// bootstrapFailureCounter counts consecutive failures to bootstrap runners in each pool.
+// A runner fails to bootstrap if the provider fails to create its instance, or if it
+// never comes online within the bootstrap timeout of the pool.
+type bootstrapFailureCounter struct {
+	mux      sync.Mutex
+	failures map[string]uint
+}
+
+func newBootstrapFailureCounter() *bootstrapFailureCounter {
+	return &bootstrapFailureCounter{
+		failures: map[string]uint{},
+	}
+}
+
+// record records a bootstrap failure in the given pool and returns the number of
+// consecutive failures.
+func (b *bootstrapFailureCounter) record(poolID string) uint {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.failures[poolID]++
+	return b.failures[poolID]
+}
+
+// reset clears the failures of the given pool.
+func (b *bootstrapFailureCounter) reset(poolID string) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	delete(b.failures, poolID)
+}
+
+// recordBootstrapFailure records a failure to bootstrap a runner in the given pool. Once the
+// quarantine threshold of the pool is reached, the pool is disabled.
+func (r *basePoolManager) recordBootstrapFailure(pool params.Pool, err error) {
+	if pool.QuarantineThreshold == 0 {
+		return
+	}
+
+	failures := r.bootstrapFailures.record(pool.ID)
+	if failures < pool.QuarantineThreshold {
+		return
+	}
+
+	if !pool.Enabled {
+		// The pool was disabled in the meantime. There is nothing to quarantine.
+		r.bootstrapFailures.reset(pool.ID)
+		return
+	}
+
+	reason := fmt.Sprintf("%d consecutive bootstrap failures: %s", failures, err)
+	if _, qErr := r.store.QuarantineEntityPool(r.ctx, r.entity, pool.ID, reason); qErr != nil {
+		slog.With(slog.Any("error", qErr)).ErrorContext(
+			r.ctx, "failed to quarantine pool",
+			"pool_id", pool.ID)
+		return
+	}
+	r.bootstrapFailures.reset(pool.ID)
+	slog.With(slog.Any("error", err)).WarnContext(
+		r.ctx, "too many consecutive bootstrap failures; pool was quarantined",
+		"pool_id", pool.ID,
+		"failures", failures)
+	r.notifyEntity(
+		params.PoolQuarantinedEvent, params.EventError, pool.ID,
+		fmt.Sprintf("pool %s was disabled after %s", pool.ID, reason))
+}
+
+// recordBootstrapSuccess clears the bootstrap failures of the pool of the given runner,
+// when the runner comes online. Later updates of a runner that is already online do not
+// reset the failures.
+func (r *basePoolManager) recordBootstrapSuccess(previous, instance params.Instance) {
+	switch previous.RunnerStatus {
+	case params.RunnerPending, params.RunnerInstalling:
+	default:
+		return
+	}
+	switch instance.RunnerStatus {
+	case params.RunnerIdle, params.RunnerActive:
+		r.bootstrapFailures.reset(instance.PoolID)
+	}
+}
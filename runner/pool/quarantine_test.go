@@ -0,0 +1,103 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/cloudbase/garm/database/common/mocks"
+	"github.com/cloudbase/garm/params"
+)
+
+func newQuarantineTestManager(store *mocks.Store) *basePoolManager {
+	return &basePoolManager{
+		ctx:               context.Background(),
+		entity:            params.GithubEntity{ID: "entity", EntityType: params.GithubEntityTypeRepository},
+		store:             store,
+		bootstrapFailures: newBootstrapFailureCounter(),
+	}
+}
+
+func TestRecordBootstrapFailureQuarantinesPool(t *testing.T) {
+	store := &mocks.Store{}
+	r := newQuarantineTestManager(store)
+	pool := params.Pool{ID: "pool", Enabled: true, QuarantineThreshold: 3}
+	failure := fmt.Errorf("image not found")
+
+	r.recordBootstrapFailure(pool, failure)
+	r.recordBootstrapFailure(pool, failure)
+	store.AssertNotCalled(t, "QuarantineEntityPool", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	store.On("QuarantineEntityPool", mock.Anything, r.entity, pool.ID, "3 consecutive bootstrap failures: image not found").
+		Return(params.Pool{}, nil).Once()
+	r.recordBootstrapFailure(pool, failure)
+	store.AssertExpectations(t)
+
+	if failures := r.bootstrapFailures.failures[pool.ID]; failures != 0 {
+		t.Fatalf("expected failures to be reset after quarantine, got %d", failures)
+	}
+}
+
+func TestRecordBootstrapFailureQuarantineDisabled(t *testing.T) {
+	store := &mocks.Store{}
+	r := newQuarantineTestManager(store)
+	pool := params.Pool{ID: "pool", Enabled: true}
+
+	for i := 0; i < 10; i++ {
+		r.recordBootstrapFailure(pool, fmt.Errorf("boom"))
+	}
+	store.AssertNotCalled(t, "QuarantineEntityPool", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecordBootstrapFailureDisabledPool(t *testing.T) {
+	store := &mocks.Store{}
+	r := newQuarantineTestManager(store)
+	pool := params.Pool{ID: "pool", QuarantineThreshold: 1}
+
+	r.recordBootstrapFailure(pool, fmt.Errorf("boom"))
+	store.AssertNotCalled(t, "QuarantineEntityPool", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	if failures := r.bootstrapFailures.failures[pool.ID]; failures != 0 {
+		t.Fatalf("expected no failures for a disabled pool, got %d", failures)
+	}
+}
+
+func TestRecordBootstrapSuccessResetsFailures(t *testing.T) {
+	r := newQuarantineTestManager(&mocks.Store{})
+	pool := params.Pool{ID: "pool", Enabled: true, QuarantineThreshold: 3}
+
+	r.recordBootstrapFailure(pool, fmt.Errorf("boom"))
+	r.recordBootstrapFailure(pool, fmt.Errorf("boom"))
+
+	pending := params.Instance{PoolID: pool.ID, RunnerStatus: params.RunnerPending}
+	installing := params.Instance{PoolID: pool.ID, RunnerStatus: params.RunnerInstalling}
+	idle := params.Instance{PoolID: pool.ID, RunnerStatus: params.RunnerIdle}
+
+	r.recordBootstrapSuccess(pending, installing)
+	if failures := r.bootstrapFailures.failures[pool.ID]; failures != 2 {
+		t.Fatalf("expected 2 failures while the runner is installing, got %d", failures)
+	}
+
+	r.recordBootstrapSuccess(installing, idle)
+	if failures := r.bootstrapFailures.failures[pool.ID]; failures != 0 {
+		t.Fatalf("expected failures to be reset once a runner is idle, got %d", failures)
+	}
+}
+
+func TestRecordBootstrapSuccessIgnoresOnlineRunners(t *testing.T) {
+	r := newQuarantineTestManager(&mocks.Store{})
+	pool := params.Pool{ID: "pool", Enabled: true, QuarantineThreshold: 3}
+
+	r.recordBootstrapFailure(pool, fmt.Errorf("boom"))
+
+	// Updates of runners that were already online do not reset the failures.
+	idle := params.Instance{PoolID: pool.ID, RunnerStatus: params.RunnerIdle}
+	active := params.Instance{PoolID: pool.ID, RunnerStatus: params.RunnerActive}
+	r.recordBootstrapSuccess(idle, idle)
+	r.recordBootstrapSuccess(idle, active)
+	r.recordBootstrapSuccess(active, idle)
+	if failures := r.bootstrapFailures.failures[pool.ID]; failures != 1 {
+		t.Fatalf("expected 1 failure, got %d", failures)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/metrics"
+	"github.com/cloudbase/garm/params"
+)
+
+const (
+	quotaLimitMaxConcurrentRunners = "max_concurrent_runners"
+	quotaLimitMaxPendingRunners    = "max_pending_runners"
+)
+
+// entityRunnerQuota holds the number of runners that may still be created for an
+// entity, before one of the entity wide limits is reached.
+type entityRunnerQuota struct {
+	// available is the number of runners that may still be created.
+	available int
+	// limit is the name of the limit that is closest to being reached.
+	limit string
+	// value is the value of that limit.
+	value uint
+}
+
+// isPendingInstance returns true if the instance is being created, or was created
+// but the runner has not yet come online.
+func isPendingInstance(instance params.Instance) bool {
+	switch instance.Status {
+	case commonParams.InstancePendingCreate, commonParams.InstanceCreating:
+		return true
+	case commonParams.InstanceRunning:
+		switch instance.RunnerStatus {
+		case params.RunnerPending, params.RunnerInstalling:
+			return true
+		}
+	}
+	return false
+}
+
+// runnerQuotaFromInstances computes the quota of an entity, given its limits and the
+// instances it currently has across all of its pools.
+func runnerQuotaFromInstances(entity params.GithubEntity, instances []params.Instance) entityRunnerQuota {
+	quota := entityRunnerQuota{
+		available: math.MaxInt,
+	}
+
+	if entity.MaxConcurrentRunners > 0 {
+		quota.available = max(int(entity.MaxConcurrentRunners)-len(instances), 0)
+		quota.limit = quotaLimitMaxConcurrentRunners
+		quota.value = entity.MaxConcurrentRunners
+	}
+
+	if entity.MaxPendingRunners > 0 {
+		var pending int
+		for _, instance := range instances {
+			if isPendingInstance(instance) {
+				pending++
+			}
+		}
+		available := max(int(entity.MaxPendingRunners)-pending, 0)
+		if available < quota.available {
+			quota.available = available
+			quota.limit = quotaLimitMaxPendingRunners
+			quota.value = entity.MaxPendingRunners
+		}
+	}
+	return quota
+}
+
+// runnerQuota returns the number of runners that may still be created for the entity.
+func (r *basePoolManager) runnerQuota() (entityRunnerQuota, error) {
+	entity := r.entity
+	if entity.MaxConcurrentRunners == 0 && entity.MaxPendingRunners == 0 {
+		return entityRunnerQuota{available: math.MaxInt}, nil
+	}
+
+	instances, err := r.entityInstances()
+	if err != nil {
+		return entityRunnerQuota{}, fmt.Errorf("failed to list entity instances: %w", err)
+	}
+	return runnerQuotaFromInstances(entity, instances), nil
+}
+
+// recordQuotaReached makes a limit that prevented the creation of a runner visible,
+// both in the logs and in the metrics.
+func (r *basePoolManager) recordQuotaReached(quota entityRunnerQuota, args ...any) {
+	args = append([]any{"limit", quota.limit, "limit_value", quota.value}, args...)
+	slog.WarnContext(r.ctx, "entity runner limit reached; not creating runner", args...)
+	metrics.InstanceQuotaReachedCount.WithLabelValues(
+		r.entity.String(),     // label: pool_owner
+		r.entity.LabelScope(), // label: pool_type
+		quota.limit,           // label: limit
+	).Inc()
+}
@@ -0,0 +1,50 @@
+package pool
+
+import (
+	"math"
+	"testing"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+)
+
+func TestRunnerQuotaFromInstances(t *testing.T) {
+	instances := []params.Instance{
+		{Status: commonParams.InstancePendingCreate, RunnerStatus: params.RunnerPending},
+		{Status: commonParams.InstanceCreating, RunnerStatus: params.RunnerPending},
+		{Status: commonParams.InstanceRunning, RunnerStatus: params.RunnerInstalling},
+		{Status: commonParams.InstanceRunning, RunnerStatus: params.RunnerIdle},
+		{Status: commonParams.InstanceRunning, RunnerStatus: params.RunnerActive},
+	}
+
+	tests := []struct {
+		name          string
+		maxConcurrent uint
+		maxPending    uint
+		available     int
+		limit         string
+	}{
+		{name: "no limits", available: math.MaxInt},
+		{name: "concurrent limit", maxConcurrent: 8, available: 3, limit: quotaLimitMaxConcurrentRunners},
+		{name: "concurrent limit reached", maxConcurrent: 5, available: 0, limit: quotaLimitMaxConcurrentRunners},
+		{name: "concurrent limit exceeded", maxConcurrent: 2, available: 0, limit: quotaLimitMaxConcurrentRunners},
+		{name: "pending limit", maxPending: 5, available: 2, limit: quotaLimitMaxPendingRunners},
+		{name: "pending limit reached", maxPending: 3, available: 0, limit: quotaLimitMaxPendingRunners},
+		{name: "pending limit is tighter", maxConcurrent: 10, maxPending: 4, available: 1, limit: quotaLimitMaxPendingRunners},
+		{name: "concurrent limit is tighter", maxConcurrent: 6, maxPending: 10, available: 1, limit: quotaLimitMaxConcurrentRunners},
+	}
+
+	for _, tc := range tests {
+		entity := params.GithubEntity{
+			MaxConcurrentRunners: tc.maxConcurrent,
+			MaxPendingRunners:    tc.maxPending,
+		}
+		quota := runnerQuotaFromInstances(entity, instances)
+		if quota.available != tc.available {
+			t.Fatalf("%s: expected %d available runners, got %d", tc.name, tc.available, quota.available)
+		}
+		if quota.limit != tc.limit {
+			t.Fatalf("%s: expected limit %q, got %q", tc.name, tc.limit, quota.limit)
+		}
+	}
+}
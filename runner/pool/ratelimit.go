@@ -0,0 +1,138 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package pool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cloudbase/garm/params"
+)
+
+// instanceCreationLimiter limits the rate at which instances are created, across all
+// pool managers. Limits are set in the provider config, and can be overridden per pool.
+var instanceCreationLimiter = newCreationLimiter()
+
+// creationLimits are the instance creation limits that apply to one pool. Each limit is
+// tracked under a key. Pools that use the limits of their provider share the key of the
+// provider, while pools that override a limit have a key of their own.
+type creationLimits struct {
+	concurrencyKey       string
+	maxConcurrentCreates uint
+	rateKey              string
+	createsPerMinute     uint
+}
+
+// creationLimitsForPool returns the instance creation limits of a pool. A limit set on the
+// pool overrides the limit of its provider.
+func creationLimitsForPool(pool params.Pool, provider params.Provider) creationLimits {
+	limits := creationLimits{
+		concurrencyKey:       "provider:" + pool.ProviderName,
+		maxConcurrentCreates: provider.MaxConcurrentCreates,
+		rateKey:              "provider:" + pool.ProviderName,
+		createsPerMinute:     provider.CreatesPerMinute,
+	}
+	if pool.MaxConcurrentCreates > 0 {
+		limits.concurrencyKey = "pool:" + pool.ID
+		limits.maxConcurrentCreates = pool.MaxConcurrentCreates
+	}
+	if pool.CreatesPerMinute > 0 {
+		limits.rateKey = "pool:" + pool.ID
+		limits.createsPerMinute = pool.CreatesPerMinute
+	}
+	return limits
+}
+
+// creationLimiter keeps track of the instances that are being created, and of the
+// creations started during the last minute.
+type creationLimiter struct {
+	mux      sync.Mutex
+	inFlight map[string]int
+	started  map[string][]time.Time
+
+	now func() time.Time
+}
+
+func newCreationLimiter() *creationLimiter {
+	return &creationLimiter{
+		inFlight: map[string]int{},
+		started:  map[string][]time.Time{},
+		now:      time.Now,
+	}
+}
+
+// acquire returns true if an instance may be created without exceeding the given limits,
+// and records the creation, along with the time it was recorded at. Every successful call
+// must be followed by a call to release() once the creation is done, or to cancel() with
+// the returned time, if the creation never reached the provider.
+func (c *creationLimiter) acquire(limits creationLimits) (time.Time, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	now := c.now()
+	started := c.started[limits.rateKey]
+	for len(started) > 0 && now.Sub(started[0]) >= time.Minute {
+		started = started[1:]
+	}
+	c.started[limits.rateKey] = started
+
+	if limits.maxConcurrentCreates > 0 && c.inFlight[limits.concurrencyKey] >= int(limits.maxConcurrentCreates) {
+		return time.Time{}, false
+	}
+	if limits.createsPerMinute > 0 && len(started) >= int(limits.createsPerMinute) {
+		return time.Time{}, false
+	}
+
+	c.inFlight[limits.concurrencyKey]++
+	c.started[limits.rateKey] = append(started, now)
+	return now, true
+}
+
+// release records that an instance creation started by acquire() is done.
+func (c *creationLimiter) release(limits creationLimits) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.inFlight[limits.concurrencyKey]--
+	if c.inFlight[limits.concurrencyKey] <= 0 {
+		delete(c.inFlight, limits.concurrencyKey)
+	}
+}
+
+// cancel releases a creation started by acquire() that never reached the provider. Unlike
+// release(), it does not count against the creations of the last minute. The time returned
+// by acquire() identifies the creation, as other creations may have started since.
+func (c *creationLimiter) cancel(limits creationLimits, startedAt time.Time) {
+	c.release(limits)
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	started := c.started[limits.rateKey]
+	for idx, t := range started {
+		if t.Equal(startedAt) {
+			c.started[limits.rateKey] = append(started[:idx], started[idx+1:]...)
+			return
+		}
+	}
+}
+
+// instanceCreationLimits returns the instance creation limits of the given pool.
+func (r *basePoolManager) instanceCreationLimits(pool params.Pool) creationLimits {
+	var provider params.Provider
+	if p, ok := r.providers[pool.ProviderName]; ok {
+		provider = p.AsParams()
+	}
+	return creationLimitsForPool(pool, provider)
+}
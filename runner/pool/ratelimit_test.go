@@ -0,0 +1,118 @@
+package pool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudbase/garm/params"
+)
+
+func newTestCreationLimiter() (*creationLimiter, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newCreationLimiter()
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+// acquired calls acquire() and only returns whether the creation was allowed.
+func acquired(limiter *creationLimiter, limits creationLimits) bool {
+	_, ok := limiter.acquire(limits)
+	return ok
+}
+
+func TestCreationLimitsForPool(t *testing.T) {
+	provider := params.Provider{Name: "lxd", MaxConcurrentCreates: 5, CreatesPerMinute: 10}
+
+	limits := creationLimitsForPool(params.Pool{ID: "pool", ProviderName: "lxd"}, provider)
+	if limits.concurrencyKey != "provider:lxd" || limits.maxConcurrentCreates != 5 {
+		t.Fatalf("expected provider concurrency limit, got %s=%d", limits.concurrencyKey, limits.maxConcurrentCreates)
+	}
+	if limits.rateKey != "provider:lxd" || limits.createsPerMinute != 10 {
+		t.Fatalf("expected provider rate limit, got %s=%d", limits.rateKey, limits.createsPerMinute)
+	}
+
+	limits = creationLimitsForPool(params.Pool{ID: "pool", ProviderName: "lxd", CreatesPerMinute: 2}, provider)
+	if limits.concurrencyKey != "provider:lxd" || limits.maxConcurrentCreates != 5 {
+		t.Fatalf("expected provider concurrency limit, got %s=%d", limits.concurrencyKey, limits.maxConcurrentCreates)
+	}
+	if limits.rateKey != "pool:pool" || limits.createsPerMinute != 2 {
+		t.Fatalf("expected pool rate limit, got %s=%d", limits.rateKey, limits.createsPerMinute)
+	}
+}
+
+func TestCreationLimiterConcurrency(t *testing.T) {
+	limiter, _ := newTestCreationLimiter()
+	limits := creationLimits{concurrencyKey: "provider:lxd", maxConcurrentCreates: 2, rateKey: "provider:lxd"}
+
+	if !acquired(limiter, limits) || !acquired(limiter, limits) {
+		t.Fatalf("expected 2 creations to be allowed")
+	}
+	if acquired(limiter, limits) {
+		t.Fatalf("expected third concurrent creation to be rejected")
+	}
+
+	// Pools that override the limit are tracked separately.
+	override := creationLimits{concurrencyKey: "pool:pool", maxConcurrentCreates: 1, rateKey: "pool:pool"}
+	if !acquired(limiter, override) {
+		t.Fatalf("expected creation in pool with its own limit to be allowed")
+	}
+
+	limiter.release(limits)
+	if !acquired(limiter, limits) {
+		t.Fatalf("expected creation to be allowed once another one is done")
+	}
+}
+
+func TestCreationLimiterRate(t *testing.T) {
+	limiter, now := newTestCreationLimiter()
+	limits := creationLimits{concurrencyKey: "provider:lxd", rateKey: "provider:lxd", createsPerMinute: 2}
+
+	for i := 0; i < 2; i++ {
+		if !acquired(limiter, limits) {
+			t.Fatalf("expected creation %d to be allowed", i)
+		}
+		limiter.release(limits)
+		*now = now.Add(10 * time.Second)
+	}
+	if acquired(limiter, limits) {
+		t.Fatalf("expected third creation in the same minute to be rejected")
+	}
+
+	// Cancelled creations do not count.
+	*now = now.Add(40 * time.Second)
+	started, ok := limiter.acquire(limits)
+	if !ok {
+		t.Fatalf("expected creation to be allowed once the first one is older than a minute")
+	}
+	limiter.cancel(limits, started)
+	if !acquired(limiter, limits) {
+		t.Fatalf("expected cancelled creation to not count against the limit")
+	}
+	if acquired(limiter, limits) {
+		t.Fatalf("expected creation to be rejected")
+	}
+}
+
+func TestCreationLimiterCancelRemovesOwnCreation(t *testing.T) {
+	limiter, now := newTestCreationLimiter()
+	limits := creationLimits{concurrencyKey: "provider:lxd", rateKey: "provider:lxd", createsPerMinute: 2}
+
+	first, ok := limiter.acquire(limits)
+	if !ok {
+		t.Fatalf("expected first creation to be allowed")
+	}
+	*now = now.Add(30 * time.Second)
+	if !acquired(limiter, limits) {
+		t.Fatalf("expected second creation to be allowed")
+	}
+
+	// Cancelling the first creation must not remove the second one, which started later.
+	limiter.cancel(limits, first)
+	*now = now.Add(40 * time.Second)
+	if !acquired(limiter, limits) {
+		t.Fatalf("expected creation to be allowed")
+	}
+	if acquired(limiter, limits) {
+		t.Fatalf("expected the second creation to still count against the limit")
+	}
+}
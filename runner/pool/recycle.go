@@ -0,0 +1,150 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+)
+
+// expiredRunner is an idle runner that exceeded the max idle age or the max lifetime
+// of its pool.
+type expiredRunner struct {
+	instance params.Instance
+	reason   string
+}
+
+// runnerExpiryReason returns the reason the given runner should be recycled, if it is
+// idle and exceeded the max lifetime or the max idle age of its pool. Runners that are
+// running a job are never recycled. They are removed once the job is done.
+func runnerExpiryReason(pool params.Pool, instance params.Instance, now time.Time) (string, bool) {
+	if instance.RunnerStatus != params.RunnerIdle {
+		return "", false
+	}
+	if instance.Status != commonParams.InstanceRunning && !isHibernatedRunner(instance) {
+		return "", false
+	}
+
+	if maxLifetime := pool.GetMaxLifetime(); maxLifetime > 0 && now.Sub(instance.CreatedAt) >= maxLifetime {
+		return fmt.Sprintf(
+			"runner reached the max lifetime of %s (created at %s)",
+			maxLifetime, instance.CreatedAt.Format(time.RFC3339)), true
+	}
+	if maxIdleAge := pool.GetMaxIdleAge(); maxIdleAge > 0 && now.Sub(instance.UpdatedAt) >= maxIdleAge {
+		return fmt.Sprintf(
+			"runner was idle for longer than the max idle age of %s (idle since %s)",
+			maxIdleAge, instance.UpdatedAt.Format(time.RFC3339)), true
+	}
+	return "", false
+}
+
+// runnersToRecycle returns the expired runners of a pool that can be removed without
+// dropping the number of idle runners below min_idle_runners. Expired runners are not
+// counted as idle by ensureIdleRunnersForOnePool(), so a replacement is created for each
+// of them. An expired runner is only removed once its replacement is idle. Hibernated
+// runners are not warm, and are removed as soon as they expire.
+//
+// If the pool is full, replacements cannot be created. In that case, expired runners are
+// removed one at a time, to make room for their replacement.
+func runnersToRecycle(pool params.Pool, instances []params.Instance, reserved func(instanceID string) bool, now time.Time) []expiredRunner {
+	ret := []expiredRunner{}
+	expired := []expiredRunner{}
+	var healthyIdle, pendingReplacements int
+	for _, instance := range instances {
+		if reserved(instance.ID) {
+			// Started to serve a job. It will not stay idle for long.
+			continue
+		}
+		reason, ok := runnerExpiryReason(pool, instance, now)
+		switch {
+		case ok && isHibernatedRunner(instance):
+			ret = append(ret, expiredRunner{instance: instance, reason: reason})
+		case ok:
+			expired = append(expired, expiredRunner{instance: instance, reason: reason})
+		case instance.Status == commonParams.InstanceRunning && instance.RunnerStatus == params.RunnerIdle:
+			healthyIdle++
+		case isPendingInstance(instance) && jobIDFromLabels(instance.AditionalLabels) == 0:
+			pendingReplacements++
+		}
+	}
+	if len(expired) == 0 {
+		return ret
+	}
+
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].instance.CreatedAt.Before(expired[j].instance.CreatedAt)
+	})
+
+	keep := max(int(pool.MinIdleRunnersAt(now))-healthyIdle, 0)
+	removable := max(len(expired)-keep, 0)
+	if removable == 0 && pendingReplacements == 0 && uint(len(instances)) >= pool.MaxRunnersAt(now) {
+		removable = 1
+	}
+	return append(ret, expired[:removable]...)
+}
+
+// recycleExpiredRunnersForOnePool removes the idle runners of a pool that exceeded the
+// max idle age or the max lifetime of the pool, once a replacement is available.
+func (r *basePoolManager) recycleExpiredRunnersForOnePool(pool params.Pool) error {
+	if !pool.Enabled || (pool.MaxIdleAge == 0 && pool.MaxLifetime == 0) {
+		return nil
+	}
+
+	instances, err := r.poolInstances(pool.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list instances for pool %s: %w", pool.ID, err)
+	}
+
+	for _, runner := range runnersToRecycle(pool, instances, r.state.isReserved, time.Now()) {
+		if !r.keyMux.TryLock(runner.instance.Name) {
+			continue
+		}
+		slog.InfoContext(
+			r.ctx, "recycling expired runner",
+			"runner_name", runner.instance.Name,
+			"pool_id", pool.ID,
+			"reason", runner.reason)
+		if err := r.store.AddInstanceEvent(r.ctx, runner.instance.Name, params.RecycleEvent, params.EventInfo, runner.reason); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to add instance event",
+				"runner_name", runner.instance.Name)
+		}
+		err := r.DeleteRunner(runner.instance, false, false)
+		r.keyMux.Unlock(runner.instance.Name, false)
+		if err != nil {
+			return fmt.Errorf("failed to delete instance %s: %w", runner.instance.ID, err)
+		}
+	}
+	return nil
+}
+
+func (r *basePoolManager) recycleExpiredRunners() error {
+	if err := r.ensureStateSynced(); err != nil {
+		return fmt.Errorf("failed to sync state: %w", err)
+	}
+	for _, pool := range r.state.getPools() {
+		if err := r.recycleExpiredRunnersForOnePool(pool); err != nil {
+			slog.With(slog.Any("error", err)).ErrorContext(
+				r.ctx, "failed to recycle expired runners",
+				"pool_id", pool.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package pool
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	"github.com/cloudbase/garm/params"
+)
+
+func notReserved(string) bool {
+	return false
+}
+
+func idleRunner(id string, created, updated time.Time) params.Instance {
+	return params.Instance{
+		ID:           id,
+		Name:         id,
+		Status:       commonParams.InstanceRunning,
+		RunnerStatus: params.RunnerIdle,
+		CreatedAt:    created,
+		UpdatedAt:    updated,
+	}
+}
+
+func recycledIDs(runners []expiredRunner) []string {
+	ret := make([]string, len(runners))
+	for idx, runner := range runners {
+		ret[idx] = runner.instance.ID
+	}
+	return ret
+}
+
+func TestRunnerExpiryReason(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	pool := params.Pool{MaxIdleAge: 60, MaxLifetime: 24 * 60}
+
+	if _, ok := runnerExpiryReason(pool, idleRunner("fresh", now.Add(-time.Hour), now.Add(-time.Minute)), now); ok {
+		t.Fatalf("expected fresh runner to not be expired")
+	}
+
+	reason, ok := runnerExpiryReason(pool, idleRunner("idle", now.Add(-2*time.Hour), now.Add(-61*time.Minute)), now)
+	if !ok || !strings.Contains(reason, "max idle age") {
+		t.Fatalf("expected runner to exceed the max idle age, got %q", reason)
+	}
+
+	reason, ok = runnerExpiryReason(pool, idleRunner("old", now.Add(-25*time.Hour), now), now)
+	if !ok || !strings.Contains(reason, "max lifetime") {
+		t.Fatalf("expected runner to exceed the max lifetime, got %q", reason)
+	}
+
+	active := idleRunner("active", now.Add(-25*time.Hour), now.Add(-2*time.Hour))
+	active.RunnerStatus = params.RunnerActive
+	if _, ok := runnerExpiryReason(pool, active, now); ok {
+		t.Fatalf("expected active runner to never be recycled")
+	}
+
+	if _, ok := runnerExpiryReason(params.Pool{}, idleRunner("old", now.Add(-25*time.Hour), now.Add(-25*time.Hour)), now); ok {
+		t.Fatalf("expected no expiry without limits")
+	}
+}
+
+func TestRunnersToRecycleKeepsWarmCount(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	pool := params.Pool{MinIdleRunners: 2, MaxRunners: 10, MaxLifetime: 60}
+	old := now.Add(-2 * time.Hour)
+	instances := []params.Instance{
+		idleRunner("expired-1", old, old),
+		idleRunner("expired-2", old.Add(time.Minute), old),
+	}
+
+	// No replacement is up yet.
+	if recycled := runnersToRecycle(pool, instances, notReserved, now); len(recycled) != 0 {
+		t.Fatalf("expected no runners to be recycled, got %v", recycledIDs(recycled))
+	}
+
+	// One replacement is up. The oldest expired runner can go.
+	instances = append(instances, idleRunner("fresh-1", now, now))
+	recycled := runnersToRecycle(pool, instances, notReserved, now)
+	if len(recycled) != 1 || recycled[0].instance.ID != "expired-1" {
+		t.Fatalf("expected expired-1 to be recycled, got %v", recycledIDs(recycled))
+	}
+
+	// Both replacements are up.
+	instances = append(instances, idleRunner("fresh-2", now, now))
+	if recycled := runnersToRecycle(pool, instances, notReserved, now); len(recycled) != 2 {
+		t.Fatalf("expected 2 runners to be recycled, got %v", recycledIDs(recycled))
+	}
+}
+
+func TestRunnersToRecycleHibernatedAndFullPools(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-2 * time.Hour)
+	pool := params.Pool{MinIdleRunners: 1, MaxRunners: 2, MaxIdleAge: 60}
+
+	hibernated := idleRunner("hibernated", old, old)
+	hibernated.Status = commonParams.InstanceStopped
+	instances := []params.Instance{hibernated, idleRunner("expired", old, old)}
+
+	// The pool is full. Hibernated runners go right away, and the expired runner is
+	// removed to make room for its replacement.
+	recycled := runnersToRecycle(pool, instances, notReserved, now)
+	if ids := recycledIDs(recycled); len(ids) != 2 || ids[0] != "hibernated" || ids[1] != "expired" {
+		t.Fatalf("expected hibernated and expired runners to be recycled, got %v", ids)
+	}
+
+	// A replacement is already being created.
+	pending := params.Instance{ID: "pending", Status: commonParams.InstanceCreating, RunnerStatus: params.RunnerPending}
+	instances = []params.Instance{idleRunner("expired", old, old), pending}
+	if recycled := runnersToRecycle(pool, instances, notReserved, now); len(recycled) != 0 {
+		t.Fatalf("expected no runners to be recycled while the replacement is pending, got %v", recycledIDs(recycled))
+	}
+
+	// Reserved runners are about to pick up a job.
+	pool.MinIdleRunners = 0
+	reserved := func(id string) bool { return id == "expired" }
+	if recycled := runnersToRecycle(pool, instances, reserved, now); len(recycled) != 0 {
+		t.Fatalf("expected reserved runner to be kept, got %v", recycledIDs(recycled))
+	}
+}
@@ -0,0 +1,452 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package pool
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+// poolManagerState is an in-memory view of the pools, runners and queued jobs of
+// an entity. It is loaded from the database when the pool manager starts, kept up
+// to date by the database watcher and periodically reloaded, to account for any
+// event that was missed. Scheduling decisions are made using this state, instead
+// of querying the database on every iteration of the pool manager loops.
+type poolManagerState struct {
+	mux sync.RWMutex
+
+	synced     bool
+	pools      map[string]params.Pool
+	instances  map[string]params.Instance
+	queuedJobs map[int64]params.Job
+	// inProgressJobs holds the jobs of the entity that are running. They count against
+	// the fair share limits of their groups.
+	inProgressJobs map[int64]params.Job
+	// reservedRunners maps the IDs of hibernated runners that were started to serve
+	// a particular queued job, to the ID of that job.
+	reservedRunners map[string]int64
+}
+
+func newPoolManagerState() *poolManagerState {
+	return &poolManagerState{
+		pools:           map[string]params.Pool{},
+		instances:       map[string]params.Instance{},
+		queuedJobs:      map[int64]params.Job{},
+		inProgressJobs:  map[int64]params.Job{},
+		reservedRunners: map[string]int64{},
+	}
+}
+
+// reset replaces the state with the given pools, instances and jobs. Jobs that are
+// neither queued nor in progress are ignored.
+func (s *poolManagerState) reset(pools []params.Pool, instances []params.Instance, jobs []params.Job) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.pools = make(map[string]params.Pool, len(pools))
+	for _, pool := range pools {
+		s.pools[pool.ID] = pool
+	}
+	s.instances = make(map[string]params.Instance, len(instances))
+	for _, instance := range instances {
+		s.instances[instance.ID] = instance
+	}
+	s.queuedJobs = make(map[int64]params.Job, len(jobs))
+	s.inProgressJobs = map[int64]params.Job{}
+	for _, job := range jobs {
+		switch params.JobStatus(job.Status) {
+		case params.JobStatusQueued:
+			s.queuedJobs[job.ID] = job
+		case params.JobStatusInProgress:
+			s.inProgressJobs[job.ID] = job
+		}
+	}
+	for instanceID, jobID := range s.reservedRunners {
+		_, hasInstance := s.instances[instanceID]
+		_, hasJob := s.queuedJobs[jobID]
+		if !hasInstance || !hasJob {
+			delete(s.reservedRunners, instanceID)
+		}
+	}
+	s.synced = true
+}
+
+// isSynced returns true if the state was loaded from the database at least once.
+func (s *poolManagerState) isSynced() bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return s.synced
+}
+
+func (s *poolManagerState) hasPool(poolID string) bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	_, ok := s.pools[poolID]
+	return ok
+}
+
+func (s *poolManagerState) hasQueuedJob(jobID int64) bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	_, ok := s.queuedJobs[jobID]
+	return ok
+}
+
+// hasJob returns true if the job is part of the state, either queued or in progress.
+func (s *poolManagerState) hasJob(jobID int64) bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	_, queued := s.queuedJobs[jobID]
+	_, inProgress := s.inProgressJobs[jobID]
+	return queued || inProgress
+}
+
+// handleEvent applies a change recorded by the database watcher. Events are expected
+// to have already been filtered, so that they only refer to this entity.
+func (s *poolManagerState) handleEvent(event dbCommon.ChangePayload) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	switch event.EntityType {
+	case dbCommon.PoolEntityType:
+		pool, ok := event.Payload.(params.Pool)
+		if !ok {
+			return fmt.Errorf("failed to cast payload to pool")
+		}
+		if event.Operation == dbCommon.DeleteOperation {
+			delete(s.pools, pool.ID)
+			for id, instance := range s.instances {
+				if instance.PoolID == pool.ID {
+					delete(s.instances, id)
+					delete(s.reservedRunners, id)
+				}
+			}
+			return nil
+		}
+		s.pools[pool.ID] = pool
+	case dbCommon.InstanceEntityType:
+		instance, ok := event.Payload.(params.Instance)
+		if !ok {
+			return fmt.Errorf("failed to cast payload to instance")
+		}
+		if event.Operation == dbCommon.DeleteOperation {
+			delete(s.instances, instance.ID)
+			delete(s.reservedRunners, instance.ID)
+			return nil
+		}
+		if _, ok := s.pools[instance.PoolID]; !ok {
+			return nil
+		}
+		s.instances[instance.ID] = instance
+	case dbCommon.JobEntityType:
+		job, ok := event.Payload.(params.Job)
+		if !ok {
+			return fmt.Errorf("failed to cast payload to job")
+		}
+		delete(s.inProgressJobs, job.ID)
+		if event.Operation != dbCommon.DeleteOperation && job.Status == string(params.JobStatusInProgress) {
+			s.inProgressJobs[job.ID] = job
+		}
+		if event.Operation == dbCommon.DeleteOperation || job.Status != string(params.JobStatusQueued) {
+			delete(s.queuedJobs, job.ID)
+			for instanceID, jobID := range s.reservedRunners {
+				if jobID == job.ID {
+					delete(s.reservedRunners, instanceID)
+				}
+			}
+			return nil
+		}
+		s.queuedJobs[job.ID] = job
+	}
+	return nil
+}
+
+func (s *poolManagerState) getInstance(instanceID string) (params.Instance, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	instance, ok := s.instances[instanceID]
+	return instance, ok
+}
+
+// setInstance records an instance that this pool manager just created. The watcher
+// will deliver the same instance, but this makes it visible to the next scheduling
+// decision right away.
+func (s *poolManagerState) setInstance(instance params.Instance) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.instances[instance.ID] = instance
+}
+
+// getQueuedJobs returns the queued jobs of the entity, oldest first.
+func (s *poolManagerState) getQueuedJobs() []params.Job {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	ret := make([]params.Job, 0, len(s.queuedJobs))
+	for _, job := range s.queuedJobs {
+		ret = append(ret, job)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].CreatedAt.Equal(ret[j].CreatedAt) {
+			return ret[i].ID < ret[j].ID
+		}
+		return ret[i].CreatedAt.Before(ret[j].CreatedAt)
+	})
+	return ret
+}
+
+// getInProgressJobs returns the jobs of the entity that are running.
+func (s *poolManagerState) getInProgressJobs() []params.Job {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	ret := make([]params.Job, 0, len(s.inProgressJobs))
+	for _, job := range s.inProgressJobs {
+		ret = append(ret, job)
+	}
+	return ret
+}
+
+// poolsMatchingLabels returns the enabled pools that have all the requested labels and
+// whose label rules allow them, highest priority first. This mirrors
+// FindPoolsMatchingAllTags() in the store.
+func (s *poolManagerState) poolsMatchingLabels(labels []string) []params.Pool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	ret := []params.Pool{}
+	if len(labels) == 0 {
+		return ret
+	}
+	for _, pool := range s.pools {
+		if pool.Enabled && pool.HasRequiredLabels(labels) {
+			ret = append(ret, pool)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Priority == ret[j].Priority {
+			return ret[i].ID < ret[j].ID
+		}
+		return ret[i].Priority > ret[j].Priority
+	})
+	return ret
+}
+
+// getPool returns a pool of the entity.
+func (s *poolManagerState) getPool(poolID string) (params.Pool, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	pool, ok := s.pools[poolID]
+	return pool, ok
+}
+
+// getPools returns all pools of the entity.
+func (s *poolManagerState) getPools() []params.Pool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	ret := make([]params.Pool, 0, len(s.pools))
+	for _, pool := range s.pools {
+		ret = append(ret, pool)
+	}
+	return ret
+}
+
+// getEntityInstances returns all instances of the entity, across all pools.
+func (s *poolManagerState) getEntityInstances() []params.Instance {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	ret := make([]params.Instance, 0, len(s.instances))
+	for _, instance := range s.instances {
+		ret = append(ret, instance)
+	}
+	return ret
+}
+
+// getPoolInstances returns the instances of a single pool.
+func (s *poolManagerState) getPoolInstances(poolID string) []params.Instance {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	ret := []params.Instance{}
+	for _, instance := range s.instances {
+		if instance.PoolID == poolID {
+			ret = append(ret, instance)
+		}
+	}
+	return ret
+}
+
+// isAvailableRunner returns true if the runner is idle, or will soon be idle and was
+// not created for a particular job.
+func isAvailableRunner(instance params.Instance) bool {
+	if instance.Status == commonParams.InstanceRunning && instance.RunnerStatus == params.RunnerIdle {
+		return true
+	}
+	return isPendingInstance(instance) && jobIDFromLabels(instance.AditionalLabels) == 0
+}
+
+// availableRunners returns the number of runners in each pool that can pick up
+// a new job.
+func (s *poolManagerState) availableRunners() map[string]int {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	ret := map[string]int{}
+	for _, instance := range s.instances {
+		if _, ok := s.reservedRunners[instance.ID]; ok {
+			continue
+		}
+		if isAvailableRunner(instance) {
+			ret[instance.PoolID]++
+		}
+	}
+	return ret
+}
+
+// claimAvailableRunner decrements the number of available runners of the first pool
+// that has one. Returns false if none of the pools have an available runner.
+func claimAvailableRunner(available map[string]int, pools []params.Pool) bool {
+	for _, pool := range pools {
+		if available[pool.ID] > 0 {
+			available[pool.ID]--
+			return true
+		}
+	}
+	return false
+}
+
+// hasRunnerForJob returns true if a runner created for the given job is still
+// pending or idle, and may still pick up the job.
+func (s *poolManagerState) hasRunnerForJob(jobID int64) bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, instance := range s.instances {
+		reservedFor, reserved := s.reservedRunners[instance.ID]
+		if jobIDFromLabels(instance.AditionalLabels) != jobID && (!reserved || reservedFor != jobID) {
+			continue
+		}
+		if isPendingInstance(instance) || instance.RunnerStatus == params.RunnerIdle {
+			return true
+		}
+	}
+	return false
+}
+
+// reserveRunner records that a hibernated runner was started to serve the given job.
+// The runner will not be counted as available for other queued jobs.
+func (s *poolManagerState) reserveRunner(instanceID string, jobID int64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.reservedRunners[instanceID] = jobID
+}
+
+// isReserved returns true if the given runner was started to serve a particular job.
+func (s *poolManagerState) isReserved(instanceID string) bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	_, ok := s.reservedRunners[instanceID]
+	return ok
+}
+
+// hibernatedRunners returns the hibernated runners of the given pools. Runners are
+// returned in the order of the pools, oldest first within each pool.
+func (s *poolManagerState) hibernatedRunners(pools []params.Pool) []params.Instance {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	ret := []params.Instance{}
+	for _, pool := range pools {
+		poolRunners := []params.Instance{}
+		for _, instance := range s.instances {
+			if instance.PoolID == pool.ID && isHibernatedRunner(instance) {
+				poolRunners = append(poolRunners, instance)
+			}
+		}
+		sort.Slice(poolRunners, func(i, j int) bool {
+			return poolRunners[i].CreatedAt.Before(poolRunners[j].CreatedAt)
+		})
+		ret = append(ret, poolRunners...)
+	}
+	return ret
+}
+
+// resyncState reloads the in-memory state of the pool manager from the database.
+func (r *basePoolManager) resyncState() error {
+	pools, err := r.store.ListEntityPools(r.ctx, r.entity)
+	if err != nil {
+		return fmt.Errorf("failed to list pools: %w", err)
+	}
+	instances, err := r.store.ListEntityInstances(r.ctx, r.entity)
+	if err != nil {
+		return fmt.Errorf("failed to list instances: %w", err)
+	}
+	queued, err := r.store.ListEntityJobsByStatus(r.ctx, r.entity.EntityType, r.entity.ID, params.JobStatusQueued)
+	if err != nil {
+		return fmt.Errorf("failed to list queued jobs: %w", err)
+	}
+	inProgress, err := r.store.ListEntityJobsByStatus(r.ctx, r.entity.EntityType, r.entity.ID, params.JobStatusInProgress)
+	if err != nil {
+		return fmt.Errorf("failed to list in progress jobs: %w", err)
+	}
+	r.state.reset(pools, instances, append(queued, inProgress...))
+	slog.DebugContext(
+		r.ctx, "pool manager state synced",
+		"pools", len(pools),
+		"instances", len(instances),
+		"queued_jobs", len(queued),
+		"in_progress_jobs", len(inProgress))
+	return nil
+}
+
+// ensureStateSynced loads the state from the database, if that did not happen yet.
+func (r *basePoolManager) ensureStateSynced() error {
+	if r.state.isSynced() {
+		return nil
+	}
+	return r.resyncState()
+}
+
+// entityInstances returns the instances of the entity from the in-memory state.
+func (r *basePoolManager) entityInstances() ([]params.Instance, error) {
+	if err := r.ensureStateSynced(); err != nil {
+		return nil, err
+	}
+	return r.state.getEntityInstances(), nil
+}
+
+// poolInstances returns the instances of a pool from the in-memory state.
+func (r *basePoolManager) poolInstances(poolID string) ([]params.Instance, error) {
+	if err := r.ensureStateSynced(); err != nil {
+		return nil, err
+	}
+	return r.state.getPoolInstances(poolID), nil
+}
+
+// poolFreeCapacity returns the number of runners that may still be created in a pool,
+// before it reaches its max runners.
+func (r *basePoolManager) poolFreeCapacity(pool params.Pool) int {
+	maxRunners := int(pool.MaxRunnersAt(time.Now()))
+	return max(maxRunners-len(r.state.getPoolInstances(pool.ID)), 0)
+}
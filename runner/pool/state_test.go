@@ -0,0 +1,257 @@
+package pool
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	commonParams "github.com/cloudbase/garm-provider-common/params"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/params"
+)
+
+func newTestState() *poolManagerState {
+	state := newPoolManagerState()
+	state.reset(
+		[]params.Pool{
+			{ID: "low", Enabled: true, Priority: 1, Tags: []params.Tag{{Name: "linux"}, {Name: "x64"}}},
+			{ID: "high", Enabled: true, Priority: 10, Tags: []params.Tag{{Name: "linux"}, {Name: "x64"}, {Name: "gpu"}}},
+			{ID: "disabled", Enabled: false, Tags: []params.Tag{{Name: "linux"}, {Name: "x64"}}},
+		},
+		[]params.Instance{
+			{ID: "idle", PoolID: "low", Status: commonParams.InstanceRunning, RunnerStatus: params.RunnerIdle},
+			{ID: "active", PoolID: "low", Status: commonParams.InstanceRunning, RunnerStatus: params.RunnerActive},
+			{ID: "pending", PoolID: "high", Status: commonParams.InstancePendingCreate, RunnerStatus: params.RunnerPending},
+			{
+				ID: "for-job", PoolID: "high", Status: commonParams.InstanceCreating, RunnerStatus: params.RunnerPending,
+				AditionalLabels: []string{jobLabelPrefix + "42"},
+			},
+		},
+		[]params.Job{
+			{ID: 2, Status: string(params.JobStatusQueued)},
+			{ID: 1, Status: string(params.JobStatusQueued)},
+			{ID: 3, Status: string(params.JobStatusInProgress)},
+		},
+	)
+	return state
+}
+
+func TestPoolManagerStatePoolsMatchingLabels(t *testing.T) {
+	state := newTestState()
+
+	pools := state.poolsMatchingLabels([]string{"linux", "x64"})
+	if len(pools) != 2 {
+		t.Fatalf("expected 2 pools, got %d", len(pools))
+	}
+	if pools[0].ID != "high" || pools[1].ID != "low" {
+		t.Fatalf("expected pools sorted by priority, got %s, %s", pools[0].ID, pools[1].ID)
+	}
+
+	pools = state.poolsMatchingLabels([]string{"linux", "gpu"})
+	if len(pools) != 1 || pools[0].ID != "high" {
+		t.Fatalf("expected only the gpu pool to match, got %v", pools)
+	}
+
+	if pools := state.poolsMatchingLabels([]string{"windows"}); len(pools) != 0 {
+		t.Fatalf("expected no pools to match, got %d", len(pools))
+	}
+	if pools := state.poolsMatchingLabels(nil); len(pools) != 0 {
+		t.Fatalf("expected no pools to match empty labels, got %d", len(pools))
+	}
+}
+
+func TestPoolManagerStateQueuedJobs(t *testing.T) {
+	state := newTestState()
+
+	jobs := state.getQueuedJobs()
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 queued jobs, got %d", len(jobs))
+	}
+	if jobs[0].ID != 1 || jobs[1].ID != 2 {
+		t.Fatalf("expected jobs to be sorted, got %d, %d", jobs[0].ID, jobs[1].ID)
+	}
+
+	if err := state.handleEvent(dbCommon.ChangePayload{
+		EntityType: dbCommon.JobEntityType,
+		Operation:  dbCommon.UpdateOperation,
+		Payload:    params.Job{ID: 1, Status: string(params.JobStatusInProgress)},
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if state.hasQueuedJob(1) {
+		t.Fatalf("expected job 1 to be removed once in progress")
+	}
+
+	if err := state.handleEvent(dbCommon.ChangePayload{
+		EntityType: dbCommon.JobEntityType,
+		Operation:  dbCommon.CreateOperation,
+		Payload:    params.Job{ID: 4, Status: string(params.JobStatusQueued), LockedBy: uuid.New()},
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !state.hasQueuedJob(4) {
+		t.Fatalf("expected job 4 to be queued")
+	}
+
+	if err := state.handleEvent(dbCommon.ChangePayload{
+		EntityType: dbCommon.JobEntityType,
+		Operation:  dbCommon.DeleteOperation,
+		Payload:    params.Job{ID: 2},
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if state.hasQueuedJob(2) {
+		t.Fatalf("expected job 2 to be deleted")
+	}
+}
+
+func TestPoolManagerStateInProgressJobs(t *testing.T) {
+	state := newTestState()
+
+	if jobs := state.getInProgressJobs(); len(jobs) != 1 || jobs[0].ID != 3 {
+		t.Fatalf("expected job 3 to be in progress, got %v", jobs)
+	}
+
+	for _, event := range []dbCommon.ChangePayload{
+		{
+			EntityType: dbCommon.JobEntityType,
+			Operation:  dbCommon.UpdateOperation,
+			Payload:    params.Job{ID: 1, Status: string(params.JobStatusInProgress)},
+		},
+		{
+			EntityType: dbCommon.JobEntityType,
+			Operation:  dbCommon.UpdateOperation,
+			Payload:    params.Job{ID: 3, Status: string(params.JobStatusCompleted)},
+		},
+	} {
+		if err := state.handleEvent(event); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if jobs := state.getInProgressJobs(); len(jobs) != 1 || jobs[0].ID != 1 {
+		t.Fatalf("expected job 1 to be in progress, got %v", jobs)
+	}
+	if !state.hasJob(1) || state.hasJob(3) {
+		t.Fatalf("expected only job 1 to be tracked once job 3 completed")
+	}
+
+	if err := state.handleEvent(dbCommon.ChangePayload{
+		EntityType: dbCommon.JobEntityType,
+		Operation:  dbCommon.DeleteOperation,
+		Payload:    params.Job{ID: 1},
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if jobs := state.getInProgressJobs(); len(jobs) != 0 {
+		t.Fatalf("expected no jobs in progress, got %v", jobs)
+	}
+}
+
+func TestPoolManagerStateInstanceEvents(t *testing.T) {
+	state := newTestState()
+
+	// Instances of unknown pools are ignored.
+	if err := state.handleEvent(dbCommon.ChangePayload{
+		EntityType: dbCommon.InstanceEntityType,
+		Operation:  dbCommon.CreateOperation,
+		Payload:    params.Instance{ID: "other", PoolID: "unknown"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(state.getEntityInstances()) != 4 {
+		t.Fatalf("expected instance of unknown pool to be ignored")
+	}
+
+	if err := state.handleEvent(dbCommon.ChangePayload{
+		EntityType: dbCommon.InstanceEntityType,
+		Operation:  dbCommon.UpdateOperation,
+		Payload:    params.Instance{ID: "idle", PoolID: "low", Status: commonParams.InstanceRunning, RunnerStatus: params.RunnerActive},
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if available := state.availableRunners(); available["low"] != 0 {
+		t.Fatalf("expected no available runners in pool low, got %d", available["low"])
+	}
+
+	// Deleting a pool removes its instances.
+	if err := state.handleEvent(dbCommon.ChangePayload{
+		EntityType: dbCommon.PoolEntityType,
+		Operation:  dbCommon.DeleteOperation,
+		Payload:    params.Pool{ID: "low"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if state.hasPool("low") {
+		t.Fatalf("expected pool low to be removed")
+	}
+	if instances := state.getPoolInstances("low"); len(instances) != 0 {
+		t.Fatalf("expected instances of pool low to be removed, got %d", len(instances))
+	}
+}
+
+func TestPoolManagerStateAvailableRunners(t *testing.T) {
+	state := newTestState()
+
+	available := state.availableRunners()
+	if available["low"] != 1 {
+		t.Fatalf("expected 1 available runner in pool low, got %d", available["low"])
+	}
+	// The runner created for job 42 does not count.
+	if available["high"] != 1 {
+		t.Fatalf("expected 1 available runner in pool high, got %d", available["high"])
+	}
+
+	pools := state.poolsMatchingLabels([]string{"linux"})
+	for i := 0; i < 2; i++ {
+		if !claimAvailableRunner(available, pools) {
+			t.Fatalf("expected to claim runner %d", i)
+		}
+	}
+	if claimAvailableRunner(available, pools) {
+		t.Fatalf("expected no more runners to claim")
+	}
+
+	if !state.hasRunnerForJob(42) {
+		t.Fatalf("expected a runner for job 42")
+	}
+	if state.hasRunnerForJob(43) {
+		t.Fatalf("expected no runner for job 43")
+	}
+}
+
+func TestPoolManagerStateHibernatedRunners(t *testing.T) {
+	state := newTestState()
+	state.setInstance(params.Instance{ID: "stopped", PoolID: "low", Status: commonParams.InstanceStopped, RunnerStatus: params.RunnerIdle})
+	state.setInstance(params.Instance{ID: "stopped-high", PoolID: "high", Status: commonParams.InstanceStopped, RunnerStatus: params.RunnerIdle})
+
+	runners := state.hibernatedRunners(state.poolsMatchingLabels([]string{"linux"}))
+	if len(runners) != 2 || runners[0].ID != "stopped-high" || runners[1].ID != "stopped" {
+		t.Fatalf("expected hibernated runners in pool order, got %v", runners)
+	}
+
+	// Hibernated runners are not available until started.
+	if available := state.availableRunners(); available["low"] != 1 {
+		t.Fatalf("expected 1 available runner in pool low, got %d", available["low"])
+	}
+
+	state.setInstance(params.Instance{ID: "stopped", PoolID: "low", Status: commonParams.InstanceRunning, RunnerStatus: params.RunnerIdle})
+	state.reserveRunner("stopped", 1)
+	if available := state.availableRunners(); available["low"] != 1 {
+		t.Fatalf("expected the reserved runner to not be available, got %d", available["low"])
+	}
+	if !state.hasRunnerForJob(1) {
+		t.Fatalf("expected the reserved runner to be counted for job 1")
+	}
+
+	// The reservation is released once the job is no longer queued.
+	if err := state.handleEvent(dbCommon.ChangePayload{
+		EntityType: dbCommon.JobEntityType,
+		Operation:  dbCommon.UpdateOperation,
+		Payload:    params.Job{ID: 1, Status: string(params.JobStatusInProgress)},
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if available := state.availableRunners(); available["low"] != 2 {
+		t.Fatalf("expected 2 available runners in pool low, got %d", available["low"])
+	}
+}
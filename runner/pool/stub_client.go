@@ -55,3 +55,15 @@ func (s *stubGithubClient) GetEntityJITConfig(_ context.Context, _ string, _ par
 func (s *stubGithubClient) GetWorkflowJobByID(_ context.Context, _, _ string, _ int64) (*github.WorkflowJob, *github.Response, error) {
 	return nil, nil, s.err
 }
+
+func (s *stubGithubClient) ListEntityRepositories(_ context.Context, _ *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	return nil, nil, s.err
+}
+
+func (s *stubGithubClient) ListRepositoryWorkflowRuns(_ context.Context, _, _ string, _ *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	return nil, nil, s.err
+}
+
+func (s *stubGithubClient) ListWorkflowJobs(_ context.Context, _, _ string, _ int64, _ *github.ListWorkflowJobsOptions) (*github.Jobs, *github.Response, error) {
+	return nil, nil, s.err
+}
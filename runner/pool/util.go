@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 
 	"github.com/google/go-github/v57/github"
+	"github.com/google/uuid"
 
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
 	commonParams "github.com/cloudbase/garm-provider-common/params"
@@ -43,9 +44,186 @@ func (p *poolRoundRobin) Reset() {
 	atomic.StoreUint32(&p.next, 0)
 }
 
+// poolWeighted spreads the runners across pools, proportionally to the weight of each
+// pool. On every Reset(), a pool is selected using a smooth weighted round robin, which
+// yields an even distribution over time. Next() returns the selected pool first, followed
+// by the remaining pools in order of priority, so a full pool falls back to the others.
+type poolWeighted struct {
+	mux   sync.Mutex
+	pools []params.Pool
+	// current holds the current weight of each pool, keyed by pool ID.
+	current map[string]int
+	order   []params.Pool
+	next    int
+}
+
+func newPoolWeighted(pools []params.Pool) *poolWeighted {
+	p := &poolWeighted{
+		pools:   pools,
+		current: make(map[string]int, len(pools)),
+	}
+	p.Reset()
+	return p
+}
+
+// setPools replaces the pools of the balancer. The current weights of pools that are
+// still part of the balancer are kept.
+func (p *poolWeighted) setPools(pools []params.Pool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	current := make(map[string]int, len(pools))
+	for _, pool := range pools {
+		current[pool.ID] = p.current[pool.ID]
+	}
+	p.pools = pools
+	p.current = current
+}
+
+func (p *poolWeighted) Next() (params.Pool, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if len(p.order) == 0 {
+		return params.Pool{}, runnerErrors.ErrNoPoolsAvailable
+	}
+	pool := p.order[p.next%len(p.order)]
+	p.next++
+	return pool, nil
+}
+
+func (p *poolWeighted) Len() int {
+	return len(p.pools)
+}
+
+func (p *poolWeighted) Reset() {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.next = 0
+	if len(p.pools) == 0 {
+		p.order = nil
+		return
+	}
+
+	var total int
+	selected := 0
+	for idx, pool := range p.pools {
+		weight := int(pool.GetWeight())
+		p.current[pool.ID] += weight
+		total += weight
+		if p.current[pool.ID] > p.current[p.pools[selected].ID] {
+			selected = idx
+		}
+	}
+	p.current[p.pools[selected].ID] -= total
+
+	p.order = make([]params.Pool, 0, len(p.pools))
+	p.order = append(p.order, p.pools[selected])
+	for idx, pool := range p.pools {
+		if idx != selected {
+			p.order = append(p.order, pool)
+		}
+	}
+}
+
+// weightedBalancers keeps the weighted balancer of each set of tags between runs of
+// the queued jobs loop. Without it, the selection would start over on every run, and
+// the first job of each run would always go to the heaviest pool.
+type weightedBalancers struct {
+	mux       sync.Mutex
+	balancers map[string]*poolWeighted
+}
+
+func newWeightedBalancers() *weightedBalancers {
+	return &weightedBalancers{
+		balancers: map[string]*poolWeighted{},
+	}
+}
+
+// get returns the balancer for the given key, updated with the current pools, and
+// selects the pool to try first.
+func (w *weightedBalancers) get(key string, pools []params.Pool) *poolWeighted {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	balancer, ok := w.balancers[key]
+	if !ok {
+		balancer = newPoolWeighted(pools)
+		w.balancers[key] = balancer
+		return balancer
+	}
+	balancer.setPools(pools)
+	balancer.Reset()
+	return balancer
+}
+
+// poolCapacity returns the pool with the most free capacity first. The free capacity
+// of the pools is evaluated on every Reset().
+type poolCapacity struct {
+	mux          sync.Mutex
+	pools        []params.Pool
+	freeCapacity func(pool params.Pool) int
+	order        []params.Pool
+	next         int
+}
+
+func newPoolCapacity(pools []params.Pool, freeCapacity func(pool params.Pool) int) *poolCapacity {
+	p := &poolCapacity{
+		pools:        pools,
+		freeCapacity: freeCapacity,
+	}
+	p.Reset()
+	return p
+}
+
+func (p *poolCapacity) Next() (params.Pool, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if len(p.order) == 0 {
+		return params.Pool{}, runnerErrors.ErrNoPoolsAvailable
+	}
+	pool := p.order[p.next%len(p.order)]
+	p.next++
+	return pool, nil
+}
+
+func (p *poolCapacity) Len() int {
+	return len(p.pools)
+}
+
+func (p *poolCapacity) Reset() {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.next = 0
+	p.order = make([]params.Pool, len(p.pools))
+	copy(p.order, p.pools)
+	if p.freeCapacity == nil {
+		return
+	}
+
+	capacity := make(map[string]int, len(p.order))
+	for _, pool := range p.order {
+		capacity[pool.ID] = p.freeCapacity(pool)
+	}
+	// Pools are already sorted by priority. A stable sort keeps that order for pools
+	// with the same free capacity.
+	sort.SliceStable(p.order, func(i, j int) bool {
+		return capacity[p.order[i].ID] > capacity[p.order[j].ID]
+	})
+}
+
 type poolsForTags struct {
 	pools         sync.Map
 	poolCacheType params.PoolBalancerType
+	// freeCapacity returns the number of runners that may still be created in a pool.
+	// It is used by the capacity balancer.
+	freeCapacity func(pool params.Pool) int
+	// weighted holds the state of the weighted balancer between runs. If nil, the
+	// weighted balancer starts over every time it is added.
+	weighted *weightedBalancers
 }
 
 func (p *poolsForTags) Get(tags []string) (poolCacheStore, bool) {
@@ -56,12 +234,16 @@ func (p *poolsForTags) Get(tags []string) (poolCacheStore, bool) {
 	if !ok {
 		return nil, false
 	}
-	poolCache := v.(*poolRoundRobin)
-	if p.poolCacheType == params.PoolBalancerTypePack {
+	poolCache := v.(poolCacheStore)
+	switch p.poolCacheType {
+	case params.PoolBalancerTypePack:
 		// When we service a list of jobs, we want to try each pool in turn
 		// for each job. Pools are sorted by priority so we always start from the
 		// highest priority pool and move on to the next if the first one is full.
 		poolCache.Reset()
+	case params.PoolBalancerTypeWeighted, params.PoolBalancerTypeCapacity:
+		// Select the pools to try first for this job.
+		poolCache.Reset()
 	}
 	return poolCache, true
 }
@@ -74,9 +256,21 @@ func (p *poolsForTags) Add(tags []string, pools []params.Pool) poolCacheStore {
 	sort.Strings(tags)
 	key := strings.Join(tags, "^")
 
-	poolRR := &poolRoundRobin{pools: pools}
-	v, _ := p.pools.LoadOrStore(key, poolRR)
-	return v.(*poolRoundRobin)
+	var poolCache poolCacheStore
+	switch p.poolCacheType {
+	case params.PoolBalancerTypeWeighted:
+		if p.weighted != nil {
+			poolCache = p.weighted.get(key, pools)
+		} else {
+			poolCache = newPoolWeighted(pools)
+		}
+	case params.PoolBalancerTypeCapacity:
+		poolCache = newPoolCapacity(pools, p.freeCapacity)
+	default:
+		poolCache = &poolRoundRobin{pools: pools}
+	}
+	v, _ := p.pools.LoadOrStore(key, poolCache)
+	return v.(poolCacheStore)
 }
 
 func instanceInList(instanceName string, instances []commonParams.ProviderInstance) (commonParams.ProviderInstance, bool) {
@@ -119,7 +313,7 @@ func isManagedRunner(labels []string, controllerID string) bool {
 	return runnerControllerID == controllerID
 }
 
-func composeWatcherFilters(entity params.GithubEntity) dbCommon.PayloadFilterFunc {
+func composeWatcherFilters(entity params.GithubEntity, state *poolManagerState) dbCommon.PayloadFilterFunc {
 	// We want to watch for changes in either the controller or the
 	// entity itself.
 	return watcher.WithAny(
@@ -132,5 +326,69 @@ func composeWatcherFilters(entity params.GithubEntity) dbCommon.PayloadFilterFun
 		watcher.WithEntityFilter(entity),
 		// Watch for changes to the github credentials
 		watcher.WithGithubCredentialsFilter(entity.Credentials),
+		// Pools, runners and jobs of the entity, which feed the in-memory state.
+		watcher.WithEntityPoolFilter(entity),
+		withStatePoolFilter(state),
+		withStateInstanceFilter(state),
+		withEntityJobFilter(entity, state),
 	)
 }
+
+// withStatePoolFilter matches pool events for pools that are already part of the
+// state. Delete events only carry the ID of the pool, so they can't be matched to
+// the entity otherwise.
+func withStatePoolFilter(state *poolManagerState) dbCommon.PayloadFilterFunc {
+	return func(payload dbCommon.ChangePayload) bool {
+		if payload.EntityType != dbCommon.PoolEntityType {
+			return false
+		}
+		pool, ok := payload.Payload.(params.Pool)
+		if !ok {
+			return false
+		}
+		return state.hasPool(pool.ID)
+	}
+}
+
+// withStateInstanceFilter matches events for instances that belong to one of the pools
+// of the entity.
+func withStateInstanceFilter(state *poolManagerState) dbCommon.PayloadFilterFunc {
+	return func(payload dbCommon.ChangePayload) bool {
+		if payload.EntityType != dbCommon.InstanceEntityType {
+			return false
+		}
+		instance, ok := payload.Payload.(params.Instance)
+		if !ok {
+			return false
+		}
+		return state.hasPool(instance.PoolID)
+	}
+}
+
+// withEntityJobFilter matches events for jobs that were recorded for the entity, and
+// events for jobs that are part of the state.
+func withEntityJobFilter(entity params.GithubEntity, state *poolManagerState) dbCommon.PayloadFilterFunc {
+	return func(payload dbCommon.ChangePayload) bool {
+		if payload.EntityType != dbCommon.JobEntityType {
+			return false
+		}
+		job, ok := payload.Payload.(params.Job)
+		if !ok {
+			return false
+		}
+
+		var entityID *uuid.UUID
+		switch entity.EntityType {
+		case params.GithubEntityTypeRepository:
+			entityID = job.RepoID
+		case params.GithubEntityTypeOrganization:
+			entityID = job.OrgID
+		case params.GithubEntityTypeEnterprise:
+			entityID = job.EnterpriseID
+		}
+		if entityID != nil && entityID.String() == entity.ID {
+			return true
+		}
+		return state.hasJob(job.ID)
+	}
+}
@@ -226,3 +226,126 @@ func TestPoolsForTagsBalancerTypePack(t *testing.T) {
 		t.Fatalf("expected 0, got %d", poolCache.next)
 	}
 }
+
+func TestPoolWeightedDistribution(t *testing.T) {
+	p := &poolsForTags{
+		poolCacheType: params.PoolBalancerTypeWeighted,
+	}
+
+	pools := []params.Pool{
+		{
+			ID:     "onprem",
+			Weight: 7,
+		},
+		{
+			ID:     "cloud",
+			Weight: 3,
+		},
+	}
+	_ = p.Add([]string{"key"}, pools)
+
+	counts := map[string]int{}
+	for i := 0; i < 100; i++ {
+		cache, ok := p.Get([]string{"key"})
+		if !ok {
+			t.Fatalf("expected true, got false")
+		}
+		pool, err := cache.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		counts[pool.ID]++
+
+		// The other pool is the fallback.
+		fallback, err := cache.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if fallback.ID == pool.ID {
+			t.Fatalf("expected fallback to be a different pool, got %s", fallback.ID)
+		}
+	}
+	if counts["onprem"] != 70 || counts["cloud"] != 30 {
+		t.Fatalf("expected 70/30 distribution, got %d/%d", counts["onprem"], counts["cloud"])
+	}
+}
+
+func TestPoolWeightedDefaultWeight(t *testing.T) {
+	p := newPoolWeighted([]params.Pool{{ID: "1"}, {ID: "2"}})
+
+	counts := map[string]int{}
+	for i := 0; i < 10; i++ {
+		pool, err := p.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		counts[pool.ID]++
+		p.Reset()
+	}
+	if counts["1"] != 5 || counts["2"] != 5 {
+		t.Fatalf("expected even distribution, got %d/%d", counts["1"], counts["2"])
+	}
+}
+
+func TestPoolCapacityOrder(t *testing.T) {
+	capacity := map[string]int{
+		"1": 2,
+		"2": 5,
+		"3": 5,
+	}
+	p := &poolsForTags{
+		poolCacheType: params.PoolBalancerTypeCapacity,
+		freeCapacity: func(pool params.Pool) int {
+			return capacity[pool.ID]
+		},
+	}
+
+	pools := []params.Pool{
+		{
+			ID:       "1",
+			Priority: 100,
+		},
+		{
+			ID:       "2",
+			Priority: 0,
+		},
+		{
+			ID:       "3",
+			Priority: 10,
+		},
+	}
+	_ = p.Add([]string{"key"}, pools)
+	cache, ok := p.Get([]string{"key"})
+	if !ok {
+		t.Fatalf("expected true, got false")
+	}
+
+	// Pools with the same free capacity are ordered by priority.
+	for _, expected := range []string{"3", "2", "1"} {
+		pool, err := cache.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if pool.ID != expected {
+			t.Fatalf("expected pool %s, got %s", expected, pool.ID)
+		}
+	}
+
+	// Free capacity is evaluated again for the next job.
+	capacity["1"] = 10
+	cache, _ = p.Get([]string{"key"})
+	pool, err := cache.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pool.ID != "1" {
+		t.Fatalf("expected pool 1, got %s", pool.ID)
+	}
+}
+
+func TestPoolWeightedNoPools(t *testing.T) {
+	p := newPoolWeighted(nil)
+	if _, err := p.Next(); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
@@ -59,7 +59,7 @@ func (r *basePoolManager) handleEntityUpdate(entity params.GithubEntity) {
 	r.entity = entity
 	if credentialsUpdate {
 		if r.consumer != nil {
-			filters := composeWatcherFilters(r.entity)
+			filters := composeWatcherFilters(r.entity, r.state)
 			r.consumer.SetFilters(filters)
 		}
 		slog.DebugContext(r.ctx, "credentials update", "entity", entity.ID)
@@ -105,7 +105,28 @@ func (r *basePoolManager) handleCredentialsUpdate(credentials params.GithubCrede
 	r.mux.Unlock()
 }
 
+// handleResync reloads the entity, its credentials and the controller info from the
+// database. The watcher sends a resync event when it had to drop events for this pool
+// manager, so any of these may have changed without us being notified.
+func (r *basePoolManager) handleResync() {
+	if err := r.refreshEntity(); err != nil {
+		slog.ErrorContext(r.ctx, "failed to reload entity", "error", err)
+	}
+
+	controllerInfo, err := r.store.ControllerInfo()
+	if err != nil {
+		slog.ErrorContext(r.ctx, "failed to reload controller info", "error", err)
+		return
+	}
+	r.handleControllerUpdateEvent(controllerInfo)
+}
+
 func (r *basePoolManager) handleWatcherEvent(event common.ChangePayload) {
+	if event.Operation == common.ResyncOperation {
+		r.handleResync()
+		return
+	}
+
 	dbEntityType := common.DatabaseEntityType(r.entity.EntityType)
 	switch event.EntityType {
 	case common.GithubCredentialsEntityType:
@@ -137,6 +158,21 @@ func (r *basePoolManager) handleWatcherEvent(event common.ChangePayload) {
 	}
 }
 
+// cachedInstanceUpdate returns the runner from an instance update event, along with the
+// state of that runner before the update, as recorded in the pool manager state. It must
+// be called before the event is applied to the state.
+func (r *basePoolManager) cachedInstanceUpdate(event common.ChangePayload) (previous, instance params.Instance, ok bool) {
+	if event.EntityType != common.InstanceEntityType || event.Operation != common.UpdateOperation {
+		return params.Instance{}, params.Instance{}, false
+	}
+	instance, ok = event.Payload.(params.Instance)
+	if !ok {
+		return params.Instance{}, params.Instance{}, false
+	}
+	previous, ok = r.state.getInstance(instance.ID)
+	return previous, instance, ok
+}
+
 func (r *basePoolManager) runWatcher() {
 	for {
 		select {
@@ -148,6 +184,23 @@ func (r *basePoolManager) runWatcher() {
 			if !ok {
 				return
 			}
+			if event.Operation == common.ResyncOperation {
+				// Some events were dropped. Reload the state from the database before
+				// applying any of the events that follow.
+				slog.WarnContext(r.ctx, "missed watcher events; resyncing pool manager", "payload", event.Payload)
+				if err := r.resyncState(); err != nil {
+					slog.With(slog.Any("error", err)).ErrorContext(r.ctx, "failed to resync pool manager state")
+				}
+			}
+			previous, instance, isInstanceUpdate := r.cachedInstanceUpdate(event)
+			// The state is updated in the order in which events are received. This is
+			// cheap, and does not need to be done in a separate goroutine.
+			if err := r.state.handleEvent(event); err != nil {
+				slog.With(slog.Any("error", err)).ErrorContext(r.ctx, "failed to update pool manager state")
+			}
+			if isInstanceUpdate {
+				r.recordBootstrapSuccess(previous, instance)
+			}
 			go r.handleWatcherEvent(event)
 		}
 	}
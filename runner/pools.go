@@ -16,16 +16,18 @@ package runner
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/pkg/errors"
 
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
 	"github.com/cloudbase/garm/auth"
 	"github.com/cloudbase/garm/params"
+	"github.com/cloudbase/garm/runner/common"
 )
 
 func (r *Runner) ListAllPools(ctx context.Context) ([]params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return []params.Pool{}, runnerErrors.ErrUnauthorized
 	}
 
@@ -36,8 +38,46 @@ func (r *Runner) ListAllPools(ctx context.Context) ([]params.Pool, error) {
 	return pools, nil
 }
 
+// ListPools returns a page of pools matching the filter and the cursor
+// of the next page.
+func (r *Runner) ListPools(ctx context.Context, filter params.PoolFilter) ([]params.Pool, string, error) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
+		return []params.Pool{}, "", runnerErrors.ErrUnauthorized
+	}
+
+	pools, next, err := r.store.ListPools(ctx, filter)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fetching pools")
+	}
+	return pools, next, nil
+}
+
+// ListDegradedPools returns the pools in which runners are currently not created, because
+// the provider repeatedly failed to create them.
+func (r *Runner) ListDegradedPools(ctx context.Context) ([]params.DegradedPool, error) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
+		return nil, runnerErrors.ErrUnauthorized
+	}
+
+	ret := []params.DegradedPool{}
+	for _, getManagers := range []func() (map[string]common.PoolManager, error){
+		r.poolManagerCtrl.GetRepoPoolManagers,
+		r.poolManagerCtrl.GetOrgPoolManagers,
+		r.poolManagerCtrl.GetEnterprisePoolManagers,
+	} {
+		managers, err := getManagers()
+		if err != nil {
+			return nil, errors.Wrap(err, "fetching pool managers")
+		}
+		for _, mgr := range managers {
+			ret = append(ret, mgr.Status().DegradedPools...)
+		}
+	}
+	return ret, nil
+}
+
 func (r *Runner) GetPoolByID(ctx context.Context, poolID string) (params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.Pool{}, runnerErrors.ErrUnauthorized
 	}
 
@@ -72,8 +112,8 @@ func (r *Runner) DeletePoolByID(ctx context.Context, poolID string) error {
 }
 
 func (r *Runner) UpdatePoolByID(ctx context.Context, poolID string, param params.UpdatePoolParams) (params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
-		return params.Pool{}, runnerErrors.ErrUnauthorized
+	if err := checkPoolUpdateRole(ctx, param); err != nil {
+		return params.Pool{}, err
 	}
 
 	pool, err := r.store.GetPoolByID(ctx, poolID)
@@ -81,22 +121,12 @@ func (r *Runner) UpdatePoolByID(ctx context.Context, poolID string, param params
 		return params.Pool{}, errors.Wrap(err, "fetching pool")
 	}
 
-	maxRunners := pool.MaxRunners
-	minIdleRunners := pool.MinIdleRunners
-
-	if param.MaxRunners != nil {
-		maxRunners = *param.MaxRunners
-	}
-	if param.MinIdleRunners != nil {
-		minIdleRunners = *param.MinIdleRunners
-	}
-
 	if param.RunnerBootstrapTimeout != nil && *param.RunnerBootstrapTimeout == 0 {
 		return params.Pool{}, runnerErrors.NewBadRequestError("runner_bootstrap_timeout cannot be 0")
 	}
 
-	if minIdleRunners > maxRunners {
-		return params.Pool{}, runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+	if err := validatePoolUpdate(pool, param); err != nil {
+		return params.Pool{}, err
 	}
 
 	entity, err := pool.GithubEntity()
@@ -111,8 +141,36 @@ func (r *Runner) UpdatePoolByID(ctx context.Context, poolID string, param params
 	return newPool, nil
 }
 
+// ReleasePoolQuarantine enables a pool that was disabled after repeated bootstrap failures.
+func (r *Runner) ReleasePoolQuarantine(ctx context.Context, poolID string) (params.Pool, error) {
+	if !auth.HasRole(ctx, params.UserRoleOperator) {
+		return params.Pool{}, runnerErrors.ErrUnauthorized
+	}
+
+	pool, err := r.store.GetPoolByID(ctx, poolID)
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "fetching pool")
+	}
+
+	if !pool.IsQuarantined() {
+		return params.Pool{}, runnerErrors.NewBadRequestError("pool is not quarantined")
+	}
+
+	entity, err := pool.GithubEntity()
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "getting entity")
+	}
+
+	enabled := true
+	newPool, err := r.store.UpdateEntityPool(ctx, entity, poolID, params.UpdatePoolParams{Enabled: &enabled})
+	if err != nil {
+		return params.Pool{}, errors.Wrap(err, "enabling pool")
+	}
+	return newPool, nil
+}
+
 func (r *Runner) ListAllJobs(ctx context.Context) ([]params.Job, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return []params.Job{}, runnerErrors.ErrUnauthorized
 	}
 
@@ -122,3 +180,72 @@ func (r *Runner) ListAllJobs(ctx context.Context) ([]params.Job, error) {
 	}
 	return jobs, nil
 }
+
+// ListJobs returns a page of jobs matching the filter and the cursor
+// of the next page.
+func (r *Runner) ListJobs(ctx context.Context, filter params.JobFilter) ([]params.Job, string, error) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
+		return []params.Job{}, "", runnerErrors.ErrUnauthorized
+	}
+
+	jobs, next, err := r.store.ListJobs(ctx, filter)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fetching jobs")
+	}
+	return jobs, next, nil
+}
+
+// checkPoolUpdateRole returns an error if the user is not allowed to make the
+// requested changes to a pool. Admins can change any pool setting, while operators
+// can only scale pools, by changing max_runners, min_idle_runners and enabled.
+func checkPoolUpdateRole(ctx context.Context, param params.UpdatePoolParams) error {
+	if auth.IsAdmin(ctx) {
+		return nil
+	}
+	if !auth.HasRole(ctx, params.UserRoleOperator) {
+		return runnerErrors.ErrUnauthorized
+	}
+
+	scaling := params.UpdatePoolParams{
+		MaxRunners:     param.MaxRunners,
+		MinIdleRunners: param.MinIdleRunners,
+		Enabled:        param.Enabled,
+	}
+	if !reflect.DeepEqual(param, scaling) {
+		return runnerErrors.NewBadRequestError("operators can only change max_runners, min_idle_runners and enabled")
+	}
+	return nil
+}
+
+// validatePoolUpdate checks that the pool resulting from applying the update
+// params on top of the current pool is consistent.
+func validatePoolUpdate(pool params.Pool, param params.UpdatePoolParams) error {
+	if err := param.Validate(); err != nil {
+		return err
+	}
+
+	maxRunners := pool.MaxRunners
+	minIdleRunners := pool.MinIdleRunners
+
+	if param.MaxRunners != nil {
+		maxRunners = *param.MaxRunners
+	}
+	if param.MinIdleRunners != nil {
+		minIdleRunners = *param.MinIdleRunners
+	}
+
+	if minIdleRunners > maxRunners {
+		return runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+	}
+
+	schedules := pool.ScalingSchedules
+	if param.ScalingSchedules != nil {
+		schedules = param.ScalingSchedules
+	}
+	for _, schedule := range schedules {
+		if err := schedule.Validate(maxRunners); err != nil {
+			return err
+		}
+	}
+	return nil
+}
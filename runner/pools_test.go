@@ -149,6 +149,35 @@ func (s *PoolTestSuite) TestListAllPoolsErrUnauthorized() {
 	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
 }
 
+func (s *PoolTestSuite) TestListPools() {
+	pools, next, err := s.Runner.ListPools(s.Fixtures.AdminContext, params.PoolFilter{ListParams: params.ListParams{Limit: 1}})
+
+	s.Require().Nil(err)
+	s.Require().Len(pools, 1)
+	s.Require().NotEmpty(next)
+}
+
+func (s *PoolTestSuite) TestListPoolsErrUnauthorized() {
+	_, _, err := s.Runner.ListPools(context.Background(), params.PoolFilter{})
+
+	s.Require().NotNil(err)
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func (s *PoolTestSuite) TestListJobsErrUnauthorized() {
+	_, _, err := s.Runner.ListJobs(context.Background(), params.JobFilter{})
+
+	s.Require().NotNil(err)
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func (s *PoolTestSuite) TestListJobHistoryErrUnauthorized() {
+	_, _, err := s.Runner.ListJobHistory(context.Background(), params.JobHistoryFilter{})
+
+	s.Require().NotNil(err)
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
 func (s *PoolTestSuite) TestGetPoolByID() {
 	pool, err := s.Runner.GetPoolByID(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID)
 
@@ -209,6 +238,34 @@ func (s *PoolTestSuite) TestUpdatePoolByID() {
 	s.Require().Equal(s.Fixtures.UpdatePoolParams.Flavor, pool.Flavor)
 }
 
+func (s *PoolTestSuite) TestReleasePoolQuarantine() {
+	pool := s.Fixtures.Pools[0]
+	entity, err := pool.GithubEntity()
+	s.Require().Nil(err)
+	_, err = s.Fixtures.Store.QuarantineEntityPool(s.Fixtures.AdminContext, entity, pool.ID, "too many bootstrap failures")
+	s.Require().Nil(err)
+
+	released, err := s.Runner.ReleasePoolQuarantine(s.Fixtures.AdminContext, pool.ID)
+
+	s.Require().Nil(err)
+	s.Require().True(released.Enabled)
+	s.Require().False(released.IsQuarantined())
+}
+
+func (s *PoolTestSuite) TestReleasePoolQuarantineNotQuarantined() {
+	_, err := s.Runner.ReleasePoolQuarantine(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID)
+
+	s.Require().NotNil(err)
+	s.Require().Equal("pool is not quarantined", err.Error())
+}
+
+func (s *PoolTestSuite) TestReleasePoolQuarantineErrUnauthorized() {
+	_, err := s.Runner.ReleasePoolQuarantine(context.Background(), "dummy-pool-id")
+
+	s.Require().NotNil(err)
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
 func (s *PoolTestSuite) TestUpdatePoolByIDErrUnauthorized() {
 	_, err := s.Runner.UpdatePoolByID(context.Background(), "dummy-pool-id", s.Fixtures.UpdatePoolParams)
 
@@ -246,6 +303,46 @@ func (s *PoolTestSuite) TestTestUpdatePoolByIDMinIdleGreaterThanMax() {
 	s.Require().Equal(runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners"), err)
 }
 
+func (s *PoolTestSuite) TestTestUpdatePoolByIDScheduleMinIdleGreaterThanMax() {
+	s.Fixtures.UpdatePoolParams.ScalingSchedules = []params.PoolScalingSchedule{
+		{
+			Name:           "weekend",
+			Days:           "sat,sun",
+			MinIdleRunners: *s.Fixtures.UpdatePoolParams.MaxRunners + 1,
+		},
+	}
+
+	_, err := s.Runner.UpdatePoolByID(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, s.Fixtures.UpdatePoolParams)
+
+	s.Require().NotNil(err)
+	s.Require().Equal(runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners in scaling schedule %q", "weekend"), err)
+}
+
+func (s *PoolTestSuite) TestTestUpdatePoolByIDInvalidSchedule() {
+	s.Fixtures.UpdatePoolParams.ScalingSchedules = []params.PoolScalingSchedule{
+		{
+			Name:  "nights",
+			Start: "22:00",
+		},
+	}
+
+	_, err := s.Runner.UpdatePoolByID(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, s.Fixtures.UpdatePoolParams)
+
+	s.Require().NotNil(err)
+	s.Require().Equal(runnerErrors.NewBadRequestError("scaling schedule %q must set both start and end, or neither", "nights"), err)
+}
+
+func (s *PoolTestSuite) TestTestUpdatePoolByIDInvalidScaleDownPolicy() {
+	s.Fixtures.UpdatePoolParams.ScaleDownPolicy = &params.PoolScaleDownPolicy{
+		Factor: 2,
+	}
+
+	_, err := s.Runner.UpdatePoolByID(s.Fixtures.AdminContext, s.Fixtures.Pools[0].ID, s.Fixtures.UpdatePoolParams)
+
+	s.Require().NotNil(err)
+	s.Require().Equal(runnerErrors.NewBadRequestError("scale down factor must be between 0 and 1"), err)
+}
+
 func TestPoolTestSuite(t *testing.T) {
 	suite.Run(t, new(PoolTestSuite))
 }
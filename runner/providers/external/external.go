@@ -321,6 +321,9 @@ func (e *external) AsParams() params.Provider {
 		Name:         e.cfg.Name,
 		Description:  e.cfg.Description,
 		ProviderType: e.cfg.ProviderType,
+
+		MaxConcurrentCreates: e.cfg.MaxConcurrentCreates,
+		CreatesPerMinute:     e.cfg.CreatesPerMinute,
 	}
 }
 
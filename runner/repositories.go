@@ -34,6 +34,9 @@ func (r *Runner) CreateRepository(ctx context.Context, param params.CreateRepoPa
 		return repo, runnerErrors.ErrUnauthorized
 	}
 
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
 	if err := param.Validate(); err != nil {
 		return params.Repository{}, errors.Wrap(err, "validating params")
 	}
@@ -85,7 +88,7 @@ func (r *Runner) CreateRepository(ctx context.Context, param params.CreateRepoPa
 }
 
 func (r *Runner) ListRepositories(ctx context.Context) ([]params.Repository, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return nil, runnerErrors.ErrUnauthorized
 	}
 
@@ -111,7 +114,7 @@ func (r *Runner) ListRepositories(ctx context.Context) ([]params.Repository, err
 }
 
 func (r *Runner) GetRepositoryByID(ctx context.Context, repoID string) (params.Repository, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.Repository{}, runnerErrors.ErrUnauthorized
 	}
 
@@ -134,6 +137,9 @@ func (r *Runner) DeleteRepository(ctx context.Context, repoID string, keepWebhoo
 		return runnerErrors.ErrUnauthorized
 	}
 
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
 	repo, err := r.store.GetRepositoryByID(ctx, repoID)
 	if err != nil {
 		return errors.Wrap(err, "fetching repo")
@@ -192,11 +198,23 @@ func (r *Runner) UpdateRepository(ctx context.Context, repoID string, param para
 	defer r.mux.Unlock()
 
 	switch param.PoolBalancerType {
-	case params.PoolBalancerTypeRoundRobin, params.PoolBalancerTypePack, params.PoolBalancerTypeNone:
+	case params.PoolBalancerTypeRoundRobin, params.PoolBalancerTypePack, params.PoolBalancerTypeWeighted, params.PoolBalancerTypeCapacity, params.PoolBalancerTypeNone:
 	default:
 		return params.Repository{}, runnerErrors.NewBadRequestError("invalid pool balancer type: %s", param.PoolBalancerType)
 	}
 
+	if param.JobBackfill != nil {
+		if err := param.JobBackfill.Validate(params.GithubEntityTypeRepository); err != nil {
+			return params.Repository{}, err
+		}
+	}
+
+	if param.FairShare != nil {
+		if err := param.FairShare.Validate(); err != nil {
+			return params.Repository{}, err
+		}
+	}
+
 	slog.InfoContext(ctx, "updating repository", "repo_id", repoID, "param", param)
 	repo, err := r.store.UpdateRepository(ctx, repoID, param)
 	if err != nil {
@@ -240,7 +258,7 @@ func (r *Runner) CreateRepoPool(ctx context.Context, repoID string, param params
 }
 
 func (r *Runner) GetRepoPoolByID(ctx context.Context, repoID, poolID string) (params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.Pool{}, runnerErrors.ErrUnauthorized
 	}
 
@@ -288,7 +306,7 @@ func (r *Runner) DeleteRepoPool(ctx context.Context, repoID, poolID string) erro
 }
 
 func (r *Runner) ListRepoPools(ctx context.Context, repoID string) ([]params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return []params.Pool{}, runnerErrors.ErrUnauthorized
 	}
 	entity := params.GithubEntity{
@@ -303,7 +321,7 @@ func (r *Runner) ListRepoPools(ctx context.Context, repoID string) ([]params.Poo
 }
 
 func (r *Runner) ListPoolInstances(ctx context.Context, poolID string) ([]params.Instance, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return nil, runnerErrors.ErrUnauthorized
 	}
 
@@ -315,8 +333,8 @@ func (r *Runner) ListPoolInstances(ctx context.Context, poolID string) ([]params
 }
 
 func (r *Runner) UpdateRepoPool(ctx context.Context, repoID, poolID string, param params.UpdatePoolParams) (params.Pool, error) {
-	if !auth.IsAdmin(ctx) {
-		return params.Pool{}, runnerErrors.ErrUnauthorized
+	if err := checkPoolUpdateRole(ctx, param); err != nil {
+		return params.Pool{}, err
 	}
 
 	entity := params.GithubEntity{
@@ -328,18 +346,8 @@ func (r *Runner) UpdateRepoPool(ctx context.Context, repoID, poolID string, para
 		return params.Pool{}, errors.Wrap(err, "fetching pool")
 	}
 
-	maxRunners := pool.MaxRunners
-	minIdleRunners := pool.MinIdleRunners
-
-	if param.MaxRunners != nil {
-		maxRunners = *param.MaxRunners
-	}
-	if param.MinIdleRunners != nil {
-		minIdleRunners = *param.MinIdleRunners
-	}
-
-	if minIdleRunners > maxRunners {
-		return params.Pool{}, runnerErrors.NewBadRequestError("min_idle_runners cannot be larger than max_runners")
+	if err := validatePoolUpdate(pool, param); err != nil {
+		return params.Pool{}, err
 	}
 
 	newPool, err := r.store.UpdateEntityPool(ctx, entity, poolID, param)
@@ -350,7 +358,7 @@ func (r *Runner) UpdateRepoPool(ctx context.Context, repoID, poolID string, para
 }
 
 func (r *Runner) ListRepoInstances(ctx context.Context, repoID string) ([]params.Instance, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return nil, runnerErrors.ErrUnauthorized
 	}
 	entity := params.GithubEntity{
@@ -424,7 +432,7 @@ func (r *Runner) UninstallRepoWebhook(ctx context.Context, repoID string) error
 }
 
 func (r *Runner) GetRepoWebhookInfo(ctx context.Context, repoID string) (params.HookInfo, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.HookInfo{}, runnerErrors.ErrUnauthorized
 	}
 
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/auth"
 	"github.com/cloudbase/garm/database"
 	dbCommon "github.com/cloudbase/garm/database/common"
 	"github.com/cloudbase/garm/database/watcher"
@@ -250,6 +251,28 @@ func (s *RepoTestSuite) TestCreateRepositoryStartPoolMgrFailed() {
 	s.Require().Equal(fmt.Sprintf("starting repo pool manager: %s", s.Fixtures.ErrMock.Error()), err.Error())
 }
 
+func (s *RepoTestSuite) TestCreateRepositoryRecordsAuditEntry() {
+	s.Fixtures.PoolMgrMock.On("Start").Return(nil)
+	s.Fixtures.PoolMgrCtrlMock.On("CreateRepoPoolManager", s.Fixtures.AdminContext, mock.AnythingOfType("params.Repository"), s.Fixtures.Providers, s.Fixtures.Store).Return(s.Fixtures.PoolMgrMock, nil)
+
+	repo, err := s.Runner.CreateRepository(s.Fixtures.AdminContext, s.Fixtures.CreateRepoParams)
+	s.Require().Nil(err)
+
+	entries, _, err := s.Runner.ListAuditEntries(s.Fixtures.AdminContext, params.AuditFilter{EntityID: repo.ID})
+
+	s.Require().Nil(err)
+	s.Require().Len(entries, 1)
+	s.Require().Equal(params.AuditActionCreate, entries[0].Action)
+	s.Require().Equal(auth.UserID(s.Fixtures.AdminContext), entries[0].UserID)
+	s.Require().Contains(entries[0].Changes, params.AuditChange{Field: "webhook_secret", Before: "<redacted>", After: "<redacted>"})
+}
+
+func (s *RepoTestSuite) TestListAuditEntriesErrUnauthorized() {
+	_, _, err := s.Runner.ListAuditEntries(context.Background(), params.AuditFilter{})
+
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
 func (s *RepoTestSuite) TestListRepositories() {
 	s.Fixtures.PoolMgrCtrlMock.On("GetRepoPoolManager", mock.AnythingOfType("params.Repository")).Return(s.Fixtures.PoolMgrMock, nil)
 	s.Fixtures.PoolMgrMock.On("Status").Return(params.PoolManagerStatus{IsRunning: true}, nil)
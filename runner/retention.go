@@ -0,0 +1,64 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// purgeFunc removes the entries recorded before olderThan and returns the number of
+// entries it removed.
+type purgeFunc func(ctx context.Context, olderThan time.Time) (int64, error)
+
+// purgeOlderThan removes the entries older than the given retention. When running in
+// high availability mode, only the leader purges entries.
+func (r *Runner) purgeOlderThan(name string, purge purgeFunc, retention time.Duration) error {
+	if r.leader != nil && !r.leader.IsLeader() {
+		return nil
+	}
+
+	olderThan := time.Now().Add(-retention)
+	removed, err := purge(r.ctx, olderThan)
+	if err != nil {
+		return errors.Wrapf(err, "purging %s", name)
+	}
+	if removed > 0 {
+		slog.DebugContext(r.ctx, "purged old entries", "name", name, "removed", removed, "older_than", olderThan)
+	}
+	return nil
+}
+
+// runRetention purges the entries older than the given retention every interval, until
+// the runner is stopped.
+func (r *Runner) runRetention(name string, interval time.Duration, purge purgeFunc, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.purgeOlderThan(name, purge, retention); err != nil {
+				slog.With(slog.Any("error", err)).ErrorContext(
+					r.ctx, "failed to purge old entries", "name", name)
+			}
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
@@ -42,8 +42,10 @@ import (
 	dbCommon "github.com/cloudbase/garm/database/common"
 	"github.com/cloudbase/garm/params"
 	"github.com/cloudbase/garm/runner/common"
+	"github.com/cloudbase/garm/runner/notifications"
 	"github.com/cloudbase/garm/runner/pool"
 	"github.com/cloudbase/garm/runner/providers"
+	"github.com/cloudbase/garm/util/appdefaults"
 )
 
 func NewRunner(ctx context.Context, cfg config.Config, db dbCommon.Store) (*Runner, error) {
@@ -78,6 +80,17 @@ func NewRunner(ctx context.Context, cfg config.Config, db dbCommon.Store) (*Runn
 		providers:       providers,
 	}
 
+	if cfg.HighAvailability.Enable {
+		leader, err := newLeaderElector(ctx, cfg.HighAvailability, db)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating leader elector")
+		}
+		runner.leader = leader
+		poolManagerCtrl.leader = leader
+	}
+
+	runner.notifications = notifications.NewDispatcher(ctx, cfg.Notifications, db, poolManagerCtrl.leader)
+
 	if err := runner.loadReposOrgsAndEnterprises(); err != nil {
 		return nil, errors.Wrap(err, "loading pool managers")
 	}
@@ -90,6 +103,8 @@ type poolManagerCtrl struct {
 
 	config config.Config
 	store  dbCommon.Store
+	// leader is nil if high availability is not enabled.
+	leader common.LeaderElector
 
 	repositories  map[string]common.PoolManager
 	organizations map[string]common.PoolManager
@@ -109,7 +124,7 @@ func (p *poolManagerCtrl) CreateRepoPoolManager(ctx context.Context, repo params
 	if err != nil {
 		return nil, errors.Wrap(err, "creating instance token getter")
 	}
-	poolManager, err := pool.NewEntityPoolManager(ctx, entity, instanceTokenGetter, providers, store)
+	poolManager, err := pool.NewEntityPoolManager(ctx, entity, instanceTokenGetter, providers, store, p.leader)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating repo pool manager")
 	}
@@ -155,7 +170,7 @@ func (p *poolManagerCtrl) CreateOrgPoolManager(ctx context.Context, org params.O
 	if err != nil {
 		return nil, errors.Wrap(err, "creating instance token getter")
 	}
-	poolManager, err := pool.NewEntityPoolManager(ctx, entity, instanceTokenGetter, providers, store)
+	poolManager, err := pool.NewEntityPoolManager(ctx, entity, instanceTokenGetter, providers, store, p.leader)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating org pool manager")
 	}
@@ -201,7 +216,7 @@ func (p *poolManagerCtrl) CreateEnterprisePoolManager(ctx context.Context, enter
 	if err != nil {
 		return nil, errors.Wrap(err, "creating instance token getter")
 	}
-	poolManager, err := pool.NewEntityPoolManager(ctx, entity, instanceTokenGetter, providers, store)
+	poolManager, err := pool.NewEntityPoolManager(ctx, entity, instanceTokenGetter, providers, store, p.leader)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating enterprise pool manager")
 	}
@@ -244,6 +259,12 @@ type Runner struct {
 	poolManagerCtrl PoolManagerController
 
 	providers map[string]common.Provider
+
+	// leader is nil if high availability is not enabled.
+	leader *leaderElector
+
+	// notifications sends notification events to the configured sinks.
+	notifications *notifications.Dispatcher
 }
 
 // UpdateController will update the controller settings.
@@ -256,7 +277,7 @@ func (r *Runner) UpdateController(ctx context.Context, param params.UpdateContro
 		return params.ControllerInfo{}, errors.Wrap(err, "validating controller update params")
 	}
 
-	info, err := r.store.UpdateController(param)
+	info, err := r.store.UpdateController(ctx, param)
 	if err != nil {
 		return params.ControllerInfo{}, errors.Wrap(err, "updating controller info")
 	}
@@ -266,7 +287,7 @@ func (r *Runner) UpdateController(ctx context.Context, param params.UpdateContro
 // GetControllerInfo returns the controller id and the hostname.
 // This data might be used in metrics and logging.
 func (r *Runner) GetControllerInfo(ctx context.Context) (params.ControllerInfo, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.ControllerInfo{}, runnerErrors.ErrUnauthorized
 	}
 	// It is unlikely that fetching the hostname will encounter an error on a standard
@@ -305,11 +326,15 @@ func (r *Runner) GetControllerInfo(ctx context.Context) (params.ControllerInfo,
 	// object. As a single controller will be made up of multiple nodes, we will need to model
 	// that aspect of GARM.
 	info.Hostname = hostname
+	if r.leader != nil {
+		info.NodeID = r.leader.NodeID()
+		info.LeaderNodeID = r.leader.LeaderNodeID()
+	}
 	return info, nil
 }
 
 func (r *Runner) ListProviders(ctx context.Context) ([]params.Provider, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return nil, runnerErrors.ErrUnauthorized
 	}
 	ret := []params.Provider{}
@@ -379,6 +404,21 @@ func (r *Runner) Start() error {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
+	if r.leader != nil {
+		// Pool managers on all controllers are started, but only the leader
+		// runs the loops that reconcile pools.
+		r.leader.Start()
+		go r.runPoolManagerSync()
+	}
+	go r.runRetention("job history", appdefaults.JobHistoryPurgeInterval, r.store.PurgeJobHistory, r.config.Database.GetJobHistoryRetention())
+	go r.runRetention("audit log", appdefaults.AuditLogPurgeInterval, r.store.PurgeAuditEntries, r.config.Database.GetAuditLogRetention())
+
+	if r.notifications != nil {
+		if err := r.notifications.Start(); err != nil {
+			return errors.Wrap(err, "starting notification dispatcher")
+		}
+	}
+
 	repositories, err := r.poolManagerCtrl.GetRepoPoolManagers()
 	if err != nil {
 		return errors.Wrap(err, "fetch repo pool managers")
@@ -497,6 +537,14 @@ func (r *Runner) Stop() error {
 	if err := r.waitForErrorGroupOrTimeout(g); err != nil {
 		return fmt.Errorf("failed to stop pool managers: %w", err)
 	}
+
+	if r.notifications != nil {
+		r.notifications.Stop()
+	}
+
+	if r.leader != nil {
+		r.leader.Stop()
+	}
 	return nil
 }
 
@@ -552,6 +600,10 @@ func (r *Runner) Wait() error {
 	}
 
 	wg.Wait()
+
+	if r.leader != nil {
+		r.leader.Stop()
+	}
 	return nil
 }
 
@@ -675,7 +727,7 @@ func (r *Runner) appendTagsToCreatePoolParams(param params.CreatePoolParams) (pa
 }
 
 func (r *Runner) GetInstance(ctx context.Context, instanceName string) (params.Instance, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return params.Instance{}, runnerErrors.ErrUnauthorized
 	}
 
@@ -687,7 +739,7 @@ func (r *Runner) GetInstance(ctx context.Context, instanceName string) (params.I
 }
 
 func (r *Runner) ListAllInstances(ctx context.Context) ([]params.Instance, error) {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
 		return nil, runnerErrors.ErrUnauthorized
 	}
 
@@ -698,6 +750,20 @@ func (r *Runner) ListAllInstances(ctx context.Context) ([]params.Instance, error
 	return instances, nil
 }
 
+// ListInstances returns a page of instances matching the filter and the cursor
+// of the next page.
+func (r *Runner) ListInstances(ctx context.Context, filter params.InstanceFilter) ([]params.Instance, string, error) {
+	if !auth.HasRole(ctx, params.UserRoleViewer) {
+		return nil, "", runnerErrors.ErrUnauthorized
+	}
+
+	instances, next, err := r.store.ListInstances(ctx, filter)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "fetching instances")
+	}
+	return instances, next, nil
+}
+
 func (r *Runner) AddInstanceStatusMessage(ctx context.Context, param params.InstanceUpdateMessage) error {
 	instanceName := auth.InstanceName(ctx)
 	if instanceName == "" {
@@ -796,7 +862,7 @@ func (r *Runner) getPoolManagerFromInstance(ctx context.Context, instance params
 // that may occur, and attempt to remove the runner from GitHub and then the database, regardless of provider
 // errors.
 func (r *Runner) DeleteRunner(ctx context.Context, instanceName string, forceDelete, bypassGithubUnauthorized bool) error {
-	if !auth.IsAdmin(ctx) {
+	if !auth.HasRole(ctx, params.UserRoleOperator) {
 		return runnerErrors.ErrUnauthorized
 	}
 
@@ -826,5 +892,25 @@ func (r *Runner) DeleteRunner(ctx context.Context, instanceName string, forceDel
 	if err := poolMgr.DeleteRunner(instance, forceDelete, bypassGithubUnauthorized); err != nil {
 		return errors.Wrap(err, "removing runner")
 	}
+
+	// The pool manager marks the runner for deletion using its own context, so that
+	// change is recorded with the controller as the actor. The request is recorded
+	// here, along with the user that made it.
+	status := commonParams.InstancePendingDelete
+	if forceDelete {
+		status = commonParams.InstancePendingForceDelete
+	}
+	entry := params.AuditEntry{
+		UserID:     auth.UserID(ctx),
+		Action:     params.AuditActionDelete,
+		EntityType: string(dbCommon.InstanceEntityType),
+		EntityID:   instance.ID,
+		EntityName: instance.Name,
+		Changes:    []params.AuditChange{{Field: "status", Before: string(instance.Status), After: string(status)}},
+		SourceIP:   auth.SourceIP(ctx),
+	}
+	if _, err := r.store.RecordAuditEntry(ctx, entry); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(ctx, "failed to record audit entry", "runner_name", instance.Name)
+	}
 	return nil
 }
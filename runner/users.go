@@ -0,0 +1,165 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm-provider-common/util"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/params"
+)
+
+// ensureOtherAdmin returns an error if the user is the only enabled admin. Removing
+// the last admin would leave nobody able to manage the controller.
+func (r *Runner) ensureOtherAdmin(ctx context.Context, user params.User) error {
+	if !user.IsAdmin || !user.Enabled {
+		return nil
+	}
+
+	users, err := r.store.ListUsers(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetching users")
+	}
+	for _, other := range users {
+		if other.ID != user.ID && other.IsAdmin && other.Enabled {
+			return nil
+		}
+	}
+	return runnerErrors.NewBadRequestError("%s is the only enabled admin user", user.Username)
+}
+
+func (r *Runner) ListUsers(ctx context.Context) ([]params.User, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, runnerErrors.ErrUnauthorized
+	}
+
+	users, err := r.store.ListUsers(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching users")
+	}
+	return users, nil
+}
+
+func (r *Runner) GetUser(ctx context.Context, user string) (params.User, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.User{}, runnerErrors.ErrUnauthorized
+	}
+
+	ret, err := r.store.GetUser(ctx, user)
+	if err != nil {
+		return params.User{}, errors.Wrap(err, "fetching user")
+	}
+	return ret, nil
+}
+
+func (r *Runner) CreateUser(ctx context.Context, param params.NewUserParams) (params.User, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.User{}, runnerErrors.ErrUnauthorized
+	}
+
+	if err := auth.ValidateNewUser(param); err != nil {
+		return params.User{}, errors.Wrap(err, "validating params")
+	}
+	if param.Role == "" {
+		param.Role = params.UserRoleViewer
+	}
+	if !param.Role.IsValid() {
+		return params.User{}, runnerErrors.NewBadRequestError("invalid role: %q", param.Role)
+	}
+
+	hashed, err := util.PaswsordToBcrypt(param.Password)
+	if err != nil {
+		return params.User{}, errors.Wrap(err, "creating user")
+	}
+	param.Password = hashed
+	param.IsAdmin = false
+	param.Enabled = true
+
+	user, err := r.store.CreateUser(ctx, param)
+	if err != nil {
+		return params.User{}, errors.Wrap(err, "creating user")
+	}
+	return user, nil
+}
+
+func (r *Runner) UpdateUser(ctx context.Context, user string, param params.UpdateUserParams) (params.User, error) {
+	if !auth.IsAdmin(ctx) {
+		return params.User{}, runnerErrors.ErrUnauthorized
+	}
+
+	current, err := r.store.GetUser(ctx, user)
+	if err != nil {
+		return params.User{}, errors.Wrap(err, "fetching user")
+	}
+
+	if param.Role != nil && !param.Role.IsValid() {
+		return params.User{}, runnerErrors.NewBadRequestError("invalid role: %q", *param.Role)
+	}
+
+	demoted := param.Role != nil && *param.Role != params.UserRoleAdmin
+	disabled := param.Enabled != nil && !*param.Enabled
+	if demoted || disabled {
+		if err := r.ensureOtherAdmin(ctx, current); err != nil {
+			return params.User{}, err
+		}
+	}
+
+	if param.Password != "" {
+		if err := auth.ValidatePassword(param.Password); err != nil {
+			return params.User{}, errors.Wrap(err, "validating params")
+		}
+		hashed, err := util.PaswsordToBcrypt(param.Password)
+		if err != nil {
+			return params.User{}, errors.Wrap(err, "updating user")
+		}
+		param.Password = hashed
+	}
+
+	updated, err := r.store.UpdateUser(ctx, user, param)
+	if err != nil {
+		return params.User{}, errors.Wrap(err, "updating user")
+	}
+	return updated, nil
+}
+
+func (r *Runner) DeleteUser(ctx context.Context, user string) error {
+	if !auth.IsAdmin(ctx) {
+		return runnerErrors.ErrUnauthorized
+	}
+
+	current, err := r.store.GetUser(ctx, user)
+	if err != nil {
+		if errors.Is(err, runnerErrors.ErrNotFound) {
+			return nil
+		}
+		return errors.Wrap(err, "fetching user")
+	}
+
+	if current.ID == auth.UserID(ctx) {
+		return runnerErrors.NewBadRequestError("you can not delete your own user")
+	}
+	if err := r.ensureOtherAdmin(ctx, current); err != nil {
+		return err
+	}
+
+	if err := r.store.DeleteUser(ctx, user); err != nil {
+		return errors.Wrap(err, "deleting user")
+	}
+	return nil
+}
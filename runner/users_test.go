@@ -0,0 +1,211 @@
+// Copyright 2024 Cloudbase Solutions SRL
+//
+//    Licensed under the Apache License, Version 2.0 (the "License"); you may
+//    not use this file except in compliance with the License. You may obtain
+//    a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+//    Unless required by applicable law or agreed to in writing, software
+//    distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//    WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//    License for the specific language governing permissions and limitations
+//    under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	runnerErrors "github.com/cloudbase/garm-provider-common/errors"
+	"github.com/cloudbase/garm/auth"
+	"github.com/cloudbase/garm/database"
+	dbCommon "github.com/cloudbase/garm/database/common"
+	garmTesting "github.com/cloudbase/garm/internal/testing"
+	"github.com/cloudbase/garm/params"
+)
+
+type UserTestSuite struct {
+	suite.Suite
+	Runner   *Runner
+	Store    dbCommon.Store
+	adminCtx context.Context
+	admin    params.User
+}
+
+func (s *UserTestSuite) SetupTest() {
+	db, err := database.NewDatabase(context.Background(), garmTesting.GetTestSqliteDBConfig(s.T()))
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create db connection: %s", err))
+	}
+	s.Store = db
+	s.adminCtx = garmTesting.ImpersonateAdminContext(context.Background(), db, s.T())
+	s.admin, err = db.GetAdminUser(s.adminCtx)
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to get admin user: %s", err))
+	}
+
+	s.Runner = &Runner{
+		ctx:   s.adminCtx,
+		store: db,
+	}
+}
+
+func (s *UserTestSuite) userContext(role params.UserRole) context.Context {
+	user, err := s.Runner.CreateUser(s.adminCtx, params.NewUserParams{
+		Email:    fmt.Sprintf("test-%s@example.com", role),
+		Username: fmt.Sprintf("test%s", role),
+		Password: "vWm9#pqRt2!xLz7Kd",
+		Role:     role,
+	})
+	if err != nil {
+		s.FailNow(fmt.Sprintf("failed to create user: %s", err))
+	}
+	return auth.PopulateContext(context.Background(), user)
+}
+
+func (s *UserTestSuite) TestCreateUser() {
+	user, err := s.Runner.CreateUser(s.adminCtx, params.NewUserParams{
+		Email:    "viewer@example.com",
+		Username: "viewer",
+		Password: "vWm9#pqRt2!xLz7Kd",
+	})
+
+	s.Require().Nil(err)
+	s.Require().Equal(params.UserRoleViewer, user.Role)
+	s.Require().True(user.Enabled)
+	s.Require().NotEqual("vWm9#pqRt2!xLz7Kd", user.Password)
+}
+
+func (s *UserTestSuite) TestCreateUserInvalidRole() {
+	_, err := s.Runner.CreateUser(s.adminCtx, params.NewUserParams{
+		Email:    "viewer@example.com",
+		Username: "viewer",
+		Password: "vWm9#pqRt2!xLz7Kd",
+		Role:     params.UserRole("superuser"),
+	})
+
+	s.Require().Equal(runnerErrors.NewBadRequestError("invalid role: %q", "superuser"), err)
+}
+
+func (s *UserTestSuite) TestCreateUserErrUnauthorized() {
+	ctx := s.userContext(params.UserRoleOperator)
+
+	_, err := s.Runner.CreateUser(ctx, params.NewUserParams{})
+
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func (s *UserTestSuite) TestListUsers() {
+	s.userContext(params.UserRoleViewer)
+
+	users, err := s.Runner.ListUsers(s.adminCtx)
+
+	s.Require().Nil(err)
+	s.Require().Len(users, 2)
+}
+
+func (s *UserTestSuite) TestUpdateUserRole() {
+	s.userContext(params.UserRoleViewer)
+	role := params.UserRoleAdmin
+
+	user, err := s.Runner.UpdateUser(s.adminCtx, "testviewer", params.UpdateUserParams{Role: &role})
+
+	s.Require().Nil(err)
+	s.Require().Equal(params.UserRoleAdmin, user.Role)
+	s.Require().True(user.IsAdmin)
+}
+
+func (s *UserTestSuite) TestUpdateUserDemoteLastAdmin() {
+	role := params.UserRoleViewer
+
+	_, err := s.Runner.UpdateUser(s.adminCtx, s.admin.Username, params.UpdateUserParams{Role: &role})
+
+	s.Require().Equal(runnerErrors.NewBadRequestError("%s is the only enabled admin user", s.admin.Username), err)
+}
+
+func (s *UserTestSuite) TestUpdateUserDisableLastAdmin() {
+	enabled := false
+
+	_, err := s.Runner.UpdateUser(s.adminCtx, s.admin.Username, params.UpdateUserParams{Enabled: &enabled})
+
+	s.Require().Equal(runnerErrors.NewBadRequestError("%s is the only enabled admin user", s.admin.Username), err)
+}
+
+func (s *UserTestSuite) TestUpdateUserDemoteAdminWithOtherAdmin() {
+	s.userContext(params.UserRoleAdmin)
+	role := params.UserRoleOperator
+
+	user, err := s.Runner.UpdateUser(s.adminCtx, s.admin.Username, params.UpdateUserParams{Role: &role})
+
+	s.Require().Nil(err)
+	s.Require().Equal(params.UserRoleOperator, user.Role)
+	s.Require().False(user.IsAdmin)
+}
+
+func (s *UserTestSuite) TestDeleteUser() {
+	s.userContext(params.UserRoleViewer)
+
+	err := s.Runner.DeleteUser(s.adminCtx, "testviewer")
+
+	s.Require().Nil(err)
+	_, err = s.Store.GetUser(s.adminCtx, "testviewer")
+	s.Require().ErrorIs(err, runnerErrors.ErrNotFound)
+}
+
+func (s *UserTestSuite) TestDeleteUserSelf() {
+	err := s.Runner.DeleteUser(s.adminCtx, s.admin.Username)
+
+	s.Require().Equal(runnerErrors.NewBadRequestError("you can not delete your own user"), err)
+}
+
+func (s *UserTestSuite) TestDeleteUserLastAdmin() {
+	ctx := auth.PopulateContext(context.Background(), params.User{ID: "other-admin", IsAdmin: true, Role: params.UserRoleAdmin, Enabled: true})
+
+	err := s.Runner.DeleteUser(ctx, s.admin.Username)
+
+	s.Require().Equal(runnerErrors.NewBadRequestError("%s is the only enabled admin user", s.admin.Username), err)
+}
+
+func (s *UserTestSuite) TestCheckPoolUpdateRoleOperatorScaling() {
+	ctx := s.userContext(params.UserRoleOperator)
+	maxRunners := uint(10)
+	enabled := false
+
+	err := checkPoolUpdateRole(ctx, params.UpdatePoolParams{MaxRunners: &maxRunners, Enabled: &enabled})
+
+	s.Require().Nil(err)
+}
+
+func (s *UserTestSuite) TestCheckPoolUpdateRoleOperatorOtherSettings() {
+	ctx := s.userContext(params.UserRoleOperator)
+
+	err := checkPoolUpdateRole(ctx, params.UpdatePoolParams{Image: "ubuntu:24.04"})
+
+	s.Require().Equal(runnerErrors.NewBadRequestError("operators can only change max_runners, min_idle_runners and enabled"), err)
+}
+
+func (s *UserTestSuite) TestCheckPoolUpdateRoleViewer() {
+	ctx := s.userContext(params.UserRoleViewer)
+	maxRunners := uint(10)
+
+	err := checkPoolUpdateRole(ctx, params.UpdatePoolParams{MaxRunners: &maxRunners})
+
+	s.Require().Equal(runnerErrors.ErrUnauthorized, err)
+}
+
+func (s *UserTestSuite) TestViewerCanListRepositories() {
+	ctx := s.userContext(params.UserRoleViewer)
+
+	_, err := s.Runner.ListRepositories(ctx)
+
+	s.Require().Nil(err)
+}
+
+func TestUserTestSuite(t *testing.T) {
+	suite.Run(t, new(UserTestSuite))
+}
@@ -30,4 +30,48 @@ const (
 
 	// metrics data update interval
 	DefaultMetricsUpdateInterval = 60 * time.Second
+
+	// DefaultScaleDownFactor is the default fraction of surplus idle runners that
+	// are removed from a pool in one scale down iteration.
+	DefaultScaleDownFactor = 0.5
+
+	// DefaultScaleDownIdleGracePeriod is the default amount of time, in minutes, a runner
+	// needs to be idle before it is considered for scale down.
+	DefaultScaleDownIdleGracePeriod = 2
+
+	// DefaultStaleJobTimeout is the amount of time after which a queued job that was not
+	// picked up by any runner is considered stale and removed on scale down.
+	DefaultStaleJobTimeout = 10 * time.Minute
+
+	// DefaultHALeaseDuration is the default amount of time the leader holds the
+	// controller lease after its last heartbeat.
+	DefaultHALeaseDuration = 30 * time.Second
+
+	// DefaultHAHeartbeatInterval is the default interval at which the leader renews
+	// the controller lease.
+	DefaultHAHeartbeatInterval = 10 * time.Second
+
+	// DefaultJobHistoryRetention is the default amount of time job history entries
+	// are kept after they were last updated.
+	DefaultJobHistoryRetention = 30 * 24 * time.Hour
+
+	// JobHistoryPurgeInterval is the interval at which expired job history entries
+	// are removed.
+	JobHistoryPurgeInterval = time.Hour
+
+	// DefaultAuditLogRetention is the default amount of time audit log entries
+	// are kept after they were recorded.
+	DefaultAuditLogRetention = 90 * 24 * time.Hour
+
+	// AuditLogPurgeInterval is the interval at which expired audit log entries
+	// are removed.
+	AuditLogPurgeInterval = time.Hour
+
+	// DefaultNotificationDedupWindow is the default amount of time during which
+	// repeated notifications about the same problem are not sent again to a sink.
+	DefaultNotificationDedupWindow = 10 * time.Minute
+
+	// DefaultNotificationMaxAttempts is the default number of times GARM attempts
+	// to deliver a notification to a sink before giving up.
+	DefaultNotificationMaxAttempts = 5
 )
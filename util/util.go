@@ -224,6 +224,41 @@ func (g *githubClient) ListEntityRunnerApplicationDownloads(ctx context.Context)
 	return ret, response, err
 }
 
+func (g *githubClient) ListEntityRepositories(ctx context.Context, opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	var ret []*github.Repository
+	var response *github.Response
+	var err error
+
+	metrics.GithubOperationCount.WithLabelValues(
+		"ListEntityRepositories", // label: operation
+		g.entity.LabelScope(),    // label: scope
+	).Inc()
+	defer func() {
+		if err != nil {
+			metrics.GithubOperationFailedCount.WithLabelValues(
+				"ListEntityRepositories", // label: operation
+				g.entity.LabelScope(),    // label: scope
+			).Inc()
+		}
+	}()
+
+	switch g.entity.EntityType {
+	case params.GithubEntityTypeOrganization:
+		listOpts := &github.RepositoryListByOrgOptions{
+			Sort:      "pushed",
+			Direction: "desc",
+		}
+		if opts != nil {
+			listOpts.ListOptions = *opts
+		}
+		ret, response, err = g.repo.ListByOrg(ctx, g.entity.Owner, listOpts)
+	default:
+		return nil, nil, fmt.Errorf("listing repositories is not supported for %s", g.entity.EntityType)
+	}
+
+	return ret, response, err
+}
+
 func (g *githubClient) RemoveEntityRunner(ctx context.Context, runnerID int64) (*github.Response, error) {
 	var response *github.Response
 	var err error
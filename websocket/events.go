@@ -0,0 +1,112 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/database/watcher"
+)
+
+// NewEventClient returns a client that streams the database change events
+// matching the supplied filters to a websocket connection. Secrets are removed
+// from all events.
+func NewEventClient(ctx context.Context, conn *websocket.Conn, filters ...dbCommon.PayloadFilterFunc) (*EventClient, error) {
+	clientID := uuid.New().String()
+	consumer, err := watcher.RegisterConsumer(ctx, fmt.Sprintf("events-%s", clientID), filters...)
+	if err != nil {
+		return nil, errors.Wrap(err, "registering consumer")
+	}
+	return &EventClient{
+		id:       clientID,
+		conn:     conn,
+		consumer: consumer,
+		ctx:      ctx,
+		done:     make(chan struct{}),
+	}, nil
+}
+
+type EventClient struct {
+	id       string
+	conn     *websocket.Conn
+	consumer dbCommon.Consumer
+	ctx      context.Context
+
+	// done is closed when the peer goes away.
+	done chan struct{}
+}
+
+// Run streams events until the peer closes the connection, the context is
+// canceled or the watcher is stopped. The connection is closed on return.
+func (c *EventClient) Run() {
+	defer func() {
+		c.consumer.Close()
+		c.conn.Close()
+	}()
+
+	go c.clientReader()
+
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-c.ctx.Done():
+			return
+		case payload, ok := <-c.consumer.Watch():
+			if !ok {
+				// The watcher closed the consumer.
+				if err := c.write(websocket.CloseMessage, []byte{}); err != nil {
+					slog.With(slog.Any("error", err)).ErrorContext(c.ctx, "failed to write message")
+				}
+				return
+			}
+			message, err := json.Marshal(watcher.StripSecrets(payload))
+			if err != nil {
+				slog.With(slog.Any("error", err)).ErrorContext(c.ctx, "failed to marshal event", "client_id", c.id)
+				continue
+			}
+			if err := c.write(websocket.TextMessage, message); err != nil {
+				slog.With(slog.Any("error", err)).ErrorContext(c.ctx, "error sending event", "client_id", c.id)
+				return
+			}
+		case <-ticker.C:
+			if err := c.write(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *EventClient) write(messageType int, data []byte) error {
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(c.ctx, "failed to set write deadline")
+	}
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// clientReader reads and discards messages from the peer, to process control
+// messages and to detect when the connection is closed.
+func (c *EventClient) clientReader() {
+	defer close(c.done)
+	c.conn.SetReadLimit(maxMessageSize)
+	if err := c.conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
+		slog.With(slog.Any("error", err)).ErrorContext(c.ctx, "failed to set read deadline")
+	}
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
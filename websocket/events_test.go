@@ -0,0 +1,79 @@
+//go:build testing
+
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	dbCommon "github.com/cloudbase/garm/database/common"
+	"github.com/cloudbase/garm/database/watcher"
+	"github.com/cloudbase/garm/params"
+)
+
+func TestEventClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher.InitWatcher(ctx)
+	defer watcher.GetWatcher().Close()
+
+	producer, err := watcher.RegisterProducer(ctx, "test-producer")
+	require.NoError(t, err)
+
+	registered := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("failed to upgrade connection: %s", err)
+			return
+		}
+		client, err := NewEventClient(r.Context(), conn, watcher.WithEntityTypeFilter(dbCommon.RepositoryEntityType))
+		if err != nil {
+			t.Errorf("failed to create event client: %s", err)
+			return
+		}
+		close(registered)
+		client.Run()
+	}))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	<-registered
+
+	require.NoError(t, producer.Notify(dbCommon.ChangePayload{
+		EntityType: dbCommon.PoolEntityType,
+		Operation:  dbCommon.CreateOperation,
+		Payload:    params.Pool{ID: "pool-id"},
+	}))
+	require.NoError(t, producer.Notify(dbCommon.ChangePayload{
+		EntityType: dbCommon.RepositoryEntityType,
+		Operation:  dbCommon.UpdateOperation,
+		Payload:    params.Repository{ID: "repo-id", Name: "repo", WebhookSecret: "super-secret"},
+	}))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+	require.NotContains(t, string(message), "super-secret")
+
+	var event struct {
+		EntityType string            `json:"entity_type"`
+		Operation  string            `json:"operation"`
+		Payload    params.Repository `json:"payload"`
+	}
+	require.NoError(t, json.Unmarshal(message, &event))
+	require.Equal(t, "repository", event.EntityType)
+	require.Equal(t, "update", event.Operation)
+	require.Equal(t, "repo-id", event.Payload.ID)
+}